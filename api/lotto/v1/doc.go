@@ -0,0 +1,6 @@
+// Package lottov1 holds the gRPC service definition of weekly-lotto and the
+// code generated from it. Clients dial `weekly-lotto serve --grpc-addr` with
+// NewWeeklyLottoClient.
+package lottov1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative lotto.proto
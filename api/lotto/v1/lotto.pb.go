@@ -0,0 +1,1640 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: lotto.proto
+
+// The weekly-lotto service, served by `weekly-lotto serve --grpc-addr`. It
+// mirrors the operations of the HTTP API (/api/*).
+
+package lottov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetWinningRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Round to look up; 0 for the latest draw.
+	Round         int32 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWinningRequest) Reset() {
+	*x = GetWinningRequest{}
+	mi := &file_lotto_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWinningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWinningRequest) ProtoMessage() {}
+
+func (x *GetWinningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWinningRequest.ProtoReflect.Descriptor instead.
+func (*GetWinningRequest) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetWinningRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+type WinningNumbers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Round         int32                  `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	DrawDate      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=draw_date,json=drawDate,proto3" json:"draw_date,omitempty"`
+	Numbers       []int32                `protobuf:"varint,3,rep,packed,name=numbers,proto3" json:"numbers,omitempty"`
+	Bonus         int32                  `protobuf:"varint,4,opt,name=bonus,proto3" json:"bonus,omitempty"`
+	Prizes        []*Prize               `protobuf:"bytes,5,rep,name=prizes,proto3" json:"prizes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WinningNumbers) Reset() {
+	*x = WinningNumbers{}
+	mi := &file_lotto_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WinningNumbers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WinningNumbers) ProtoMessage() {}
+
+func (x *WinningNumbers) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WinningNumbers.ProtoReflect.Descriptor instead.
+func (*WinningNumbers) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WinningNumbers) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *WinningNumbers) GetDrawDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DrawDate
+	}
+	return nil
+}
+
+func (x *WinningNumbers) GetNumbers() []int32 {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *WinningNumbers) GetBonus() int32 {
+	if x != nil {
+		return x.Bonus
+	}
+	return 0
+}
+
+func (x *WinningNumbers) GetPrizes() []*Prize {
+	if x != nil {
+		return x.Prizes
+	}
+	return nil
+}
+
+type Prize struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Rank            int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	TotalAmount     int64                  `protobuf:"varint,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	WinnerCount     int32                  `protobuf:"varint,3,opt,name=winner_count,json=winnerCount,proto3" json:"winner_count,omitempty"`
+	AmountPerWinner int64                  `protobuf:"varint,4,opt,name=amount_per_winner,json=amountPerWinner,proto3" json:"amount_per_winner,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Prize) Reset() {
+	*x = Prize{}
+	mi := &file_lotto_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Prize) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Prize) ProtoMessage() {}
+
+func (x *Prize) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Prize.ProtoReflect.Descriptor instead.
+func (*Prize) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Prize) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Prize) GetTotalAmount() int64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *Prize) GetWinnerCount() int32 {
+	if x != nil {
+		return x.WinnerCount
+	}
+	return 0
+}
+
+func (x *Prize) GetAmountPerWinner() int64 {
+	if x != nil {
+		return x.AmountPerWinner
+	}
+	return 0
+}
+
+type GetLatestResultsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Account to list the tickets of; empty for every account.
+	Account       string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestResultsRequest) Reset() {
+	*x = GetLatestResultsRequest{}
+	mi := &file_lotto_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestResultsRequest) ProtoMessage() {}
+
+func (x *GetLatestResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestResultsRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestResultsRequest) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLatestResultsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+type LatestResults struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Winning       *WinningNumbers        `protobuf:"bytes,1,opt,name=winning,proto3" json:"winning,omitempty"`
+	Tickets       []*Ticket              `protobuf:"bytes,2,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatestResults) Reset() {
+	*x = LatestResults{}
+	mi := &file_lotto_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatestResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatestResults) ProtoMessage() {}
+
+func (x *LatestResults) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatestResults.ProtoReflect.Descriptor instead.
+func (*LatestResults) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LatestResults) GetWinning() *WinningNumbers {
+	if x != nil {
+		return x.Winning
+	}
+	return nil
+}
+
+func (x *LatestResults) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+// Ticket is a recorded ticket with its result.
+type Ticket struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Account     string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Round       int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	OrderNo     string                 `protobuf:"bytes,3,opt,name=order_no,json=orderNo,proto3" json:"order_no,omitempty"`
+	Slot        string                 `protobuf:"bytes,4,opt,name=slot,proto3" json:"slot,omitempty"`
+	Mode        string                 `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	Numbers     []int32                `protobuf:"varint,6,rep,packed,name=numbers,proto3" json:"numbers,omitempty"`
+	Amount      int64                  `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	PurchasedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=purchased_at,json=purchasedAt,proto3" json:"purchased_at,omitempty"`
+	// Result in words (e.g. "5등", "낙첨", "미추첨").
+	Result string `protobuf:"bytes,9,opt,name=result,proto3" json:"result,omitempty"`
+	// Rank won, 0 when the ticket did not win or is not drawn yet.
+	Rank          int32 `protobuf:"varint,10,opt,name=rank,proto3" json:"rank,omitempty"`
+	Prize         int64 `protobuf:"varint,11,opt,name=prize,proto3" json:"prize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_lotto_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticket) ProtoMessage() {}
+
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Ticket) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Ticket) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Ticket) GetOrderNo() string {
+	if x != nil {
+		return x.OrderNo
+	}
+	return ""
+}
+
+func (x *Ticket) GetSlot() string {
+	if x != nil {
+		return x.Slot
+	}
+	return ""
+}
+
+func (x *Ticket) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Ticket) GetNumbers() []int32 {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *Ticket) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Ticket) GetPurchasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurchasedAt
+	}
+	return nil
+}
+
+func (x *Ticket) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *Ticket) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Ticket) GetPrize() int64 {
+	if x != nil {
+		return x.Prize
+	}
+	return 0
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_lotto_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{6}
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      []*Balance             `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_lotto_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBalanceResponse) GetBalances() []*Balance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+type Balance struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Account        string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Deposit        int64                  `protobuf:"varint,2,opt,name=deposit,proto3" json:"deposit,omitempty"`
+	UnclaimedPrize int64                  `protobuf:"varint,3,opt,name=unclaimed_prize,json=unclaimedPrize,proto3" json:"unclaimed_prize,omitempty"`
+	Round          int32                  `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	RoundTickets   int32                  `protobuf:"varint,5,opt,name=round_tickets,json=roundTickets,proto3" json:"round_tickets,omitempty"`
+	CheckedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_lotto_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Balance) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *Balance) GetDeposit() int64 {
+	if x != nil {
+		return x.Deposit
+	}
+	return 0
+}
+
+func (x *Balance) GetUnclaimedPrize() int64 {
+	if x != nil {
+		return x.UnclaimedPrize
+	}
+	return 0
+}
+
+func (x *Balance) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Balance) GetRoundTickets() int32 {
+	if x != nil {
+		return x.RoundTickets
+	}
+	return 0
+}
+
+func (x *Balance) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+type ListPurchasesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Account   string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Round     int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	FromRound int32                  `protobuf:"varint,3,opt,name=from_round,json=fromRound,proto3" json:"from_round,omitempty"`
+	ToRound   int32                  `protobuf:"varint,4,opt,name=to_round,json=toRound,proto3" json:"to_round,omitempty"`
+	// Purchase dates (YYYY-MM-DD, inclusive).
+	Since string `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"`
+	Until string `protobuf:"bytes,6,opt,name=until,proto3" json:"until,omitempty"`
+	// Ranks to keep (e.g. "1,2,3", "win", "none").
+	Rank          string `protobuf:"bytes,7,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPurchasesRequest) Reset() {
+	*x = ListPurchasesRequest{}
+	mi := &file_lotto_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurchasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurchasesRequest) ProtoMessage() {}
+
+func (x *ListPurchasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurchasesRequest.ProtoReflect.Descriptor instead.
+func (*ListPurchasesRequest) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListPurchasesRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListPurchasesRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *ListPurchasesRequest) GetFromRound() int32 {
+	if x != nil {
+		return x.FromRound
+	}
+	return 0
+}
+
+func (x *ListPurchasesRequest) GetToRound() int32 {
+	if x != nil {
+		return x.ToRound
+	}
+	return 0
+}
+
+func (x *ListPurchasesRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ListPurchasesRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *ListPurchasesRequest) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+type ListPurchasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickets       []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPurchasesResponse) Reset() {
+	*x = ListPurchasesResponse{}
+	mi := &file_lotto_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPurchasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPurchasesResponse) ProtoMessage() {}
+
+func (x *ListPurchasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPurchasesResponse.ProtoReflect.Descriptor instead.
+func (*ListPurchasesResponse) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListPurchasesResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	FromRound     int32                  `protobuf:"varint,2,opt,name=from_round,json=fromRound,proto3" json:"from_round,omitempty"`
+	ToRound       int32                  `protobuf:"varint,3,opt,name=to_round,json=toRound,proto3" json:"to_round,omitempty"`
+	Since         string                 `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	Until         string                 `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_lotto_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetStatsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *GetStatsRequest) GetFromRound() int32 {
+	if x != nil {
+		return x.FromRound
+	}
+	return 0
+}
+
+func (x *GetStatsRequest) GetToRound() int32 {
+	if x != nil {
+		return x.ToRound
+	}
+	return 0
+}
+
+func (x *GetStatsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *GetStatsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+type Stats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Tickets        int32                  `protobuf:"varint,1,opt,name=tickets,proto3" json:"tickets,omitempty"`
+	DrawnTickets   int32                  `protobuf:"varint,2,opt,name=drawn_tickets,json=drawnTickets,proto3" json:"drawn_tickets,omitempty"`
+	PendingTickets int32                  `protobuf:"varint,3,opt,name=pending_tickets,json=pendingTickets,proto3" json:"pending_tickets,omitempty"`
+	Spent          int64                  `protobuf:"varint,4,opt,name=spent,proto3" json:"spent,omitempty"`
+	DrawnSpent     int64                  `protobuf:"varint,5,opt,name=drawn_spent,json=drawnSpent,proto3" json:"drawn_spent,omitempty"`
+	Winnings       int64                  `protobuf:"varint,6,opt,name=winnings,proto3" json:"winnings,omitempty"`
+	Roi            float64                `protobuf:"fixed64,7,opt,name=roi,proto3" json:"roi,omitempty"`
+	// Tickets by rank ("1" to "5", "none" for no win).
+	Ranks         map[string]int32 `protobuf:"bytes,8,rep,name=ranks,proto3" json:"ranks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Numbers       []*NumberCount   `protobuf:"bytes,9,rep,name=numbers,proto3" json:"numbers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_lotto_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Stats) GetTickets() int32 {
+	if x != nil {
+		return x.Tickets
+	}
+	return 0
+}
+
+func (x *Stats) GetDrawnTickets() int32 {
+	if x != nil {
+		return x.DrawnTickets
+	}
+	return 0
+}
+
+func (x *Stats) GetPendingTickets() int32 {
+	if x != nil {
+		return x.PendingTickets
+	}
+	return 0
+}
+
+func (x *Stats) GetSpent() int64 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
+func (x *Stats) GetDrawnSpent() int64 {
+	if x != nil {
+		return x.DrawnSpent
+	}
+	return 0
+}
+
+func (x *Stats) GetWinnings() int64 {
+	if x != nil {
+		return x.Winnings
+	}
+	return 0
+}
+
+func (x *Stats) GetRoi() float64 {
+	if x != nil {
+		return x.Roi
+	}
+	return 0
+}
+
+func (x *Stats) GetRanks() map[string]int32 {
+	if x != nil {
+		return x.Ranks
+	}
+	return nil
+}
+
+func (x *Stats) GetNumbers() []*NumberCount {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+type NumberCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NumberCount) Reset() {
+	*x = NumberCount{}
+	mi := &file_lotto_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NumberCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumberCount) ProtoMessage() {}
+
+func (x *NumberCount) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumberCount.ProtoReflect.Descriptor instead.
+func (*NumberCount) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NumberCount) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *NumberCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type BuyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Preview the purchase without buying.
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyRequest) Reset() {
+	*x = BuyRequest{}
+	mi := &file_lotto_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyRequest) ProtoMessage() {}
+
+func (x *BuyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyRequest.ProtoReflect.Descriptor instead.
+func (*BuyRequest) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BuyRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BuyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BuyResult           `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyResponse) Reset() {
+	*x = BuyResponse{}
+	mi := &file_lotto_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyResponse) ProtoMessage() {}
+
+func (x *BuyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyResponse.ProtoReflect.Descriptor instead.
+func (*BuyResponse) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BuyResponse) GetResults() []*BuyResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type BuyResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Account string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// "purchased", "preview", "skipped" or "failed".
+	Status        string          `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Round         int32           `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Amount        int64           `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Tickets       []*ResultTicket `protobuf:"bytes,5,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Reason        string          `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Error         string          `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyResult) Reset() {
+	*x = BuyResult{}
+	mi := &file_lotto_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyResult) ProtoMessage() {}
+
+func (x *BuyResult) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyResult.ProtoReflect.Descriptor instead.
+func (*BuyResult) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BuyResult) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *BuyResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BuyResult) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BuyResult) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BuyResult) GetTickets() []*ResultTicket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *BuyResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BuyResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ResultTicket is a ticket bought or checked by Buy or Check.
+type ResultTicket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slot          string                 `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Numbers       []int32                `protobuf:"varint,3,rep,packed,name=numbers,proto3" json:"numbers,omitempty"`
+	Result        string                 `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	Rank          int32                  `protobuf:"varint,5,opt,name=rank,proto3" json:"rank,omitempty"`
+	Prize         int64                  `protobuf:"varint,6,opt,name=prize,proto3" json:"prize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResultTicket) Reset() {
+	*x = ResultTicket{}
+	mi := &file_lotto_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResultTicket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultTicket) ProtoMessage() {}
+
+func (x *ResultTicket) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultTicket.ProtoReflect.Descriptor instead.
+func (*ResultTicket) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResultTicket) GetSlot() string {
+	if x != nil {
+		return x.Slot
+	}
+	return ""
+}
+
+func (x *ResultTicket) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ResultTicket) GetNumbers() []int32 {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *ResultTicket) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *ResultTicket) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *ResultTicket) GetPrize() int64 {
+	if x != nil {
+		return x.Prize
+	}
+	return 0
+}
+
+type CheckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_lotto_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{18}
+}
+
+type CheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CheckResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_lotto_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CheckResponse) GetResults() []*CheckResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CheckResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Round         int32                  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Numbers       []int32                `protobuf:"varint,3,rep,packed,name=numbers,proto3" json:"numbers,omitempty"`
+	Bonus         int32                  `protobuf:"varint,4,opt,name=bonus,proto3" json:"bonus,omitempty"`
+	Tickets       []*ResultTicket        `protobuf:"bytes,5,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Winnings      int64                  `protobuf:"varint,6,opt,name=winnings,proto3" json:"winnings,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResult) Reset() {
+	*x = CheckResult{}
+	mi := &file_lotto_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResult) ProtoMessage() {}
+
+func (x *CheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_lotto_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResult.ProtoReflect.Descriptor instead.
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return file_lotto_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CheckResult) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CheckResult) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *CheckResult) GetNumbers() []int32 {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *CheckResult) GetBonus() int32 {
+	if x != nil {
+		return x.Bonus
+	}
+	return 0
+}
+
+func (x *CheckResult) GetTickets() []*ResultTicket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *CheckResult) GetWinnings() int64 {
+	if x != nil {
+		return x.Winnings
+	}
+	return 0
+}
+
+func (x *CheckResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_lotto_proto protoreflect.FileDescriptor
+
+const file_lotto_proto_rawDesc = "" +
+	"\n" +
+	"\vlotto.proto\x12\x0eweeklylotto.v1\x1a\x1fgoogle/protobuf/timestamp.proto\")\n" +
+	"\x11GetWinningRequest\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\"\xbe\x01\n" +
+	"\x0eWinningNumbers\x12\x14\n" +
+	"\x05round\x18\x01 \x01(\x05R\x05round\x127\n" +
+	"\tdraw_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdrawDate\x12\x18\n" +
+	"\anumbers\x18\x03 \x03(\x05R\anumbers\x12\x14\n" +
+	"\x05bonus\x18\x04 \x01(\x05R\x05bonus\x12-\n" +
+	"\x06prizes\x18\x05 \x03(\v2\x15.weeklylotto.v1.PrizeR\x06prizes\"\x8d\x01\n" +
+	"\x05Prize\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x03R\vtotalAmount\x12!\n" +
+	"\fwinner_count\x18\x03 \x01(\x05R\vwinnerCount\x12*\n" +
+	"\x11amount_per_winner\x18\x04 \x01(\x03R\x0famountPerWinner\"3\n" +
+	"\x17GetLatestResultsRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\"{\n" +
+	"\rLatestResults\x128\n" +
+	"\awinning\x18\x01 \x01(\v2\x1e.weeklylotto.v1.WinningNumbersR\awinning\x120\n" +
+	"\atickets\x18\x02 \x03(\v2\x16.weeklylotto.v1.TicketR\atickets\"\xae\x02\n" +
+	"\x06Ticket\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x12\x19\n" +
+	"\border_no\x18\x03 \x01(\tR\aorderNo\x12\x12\n" +
+	"\x04slot\x18\x04 \x01(\tR\x04slot\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\x12\x18\n" +
+	"\anumbers\x18\x06 \x03(\x05R\anumbers\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x03R\x06amount\x12=\n" +
+	"\fpurchased_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vpurchasedAt\x12\x16\n" +
+	"\x06result\x18\t \x01(\tR\x06result\x12\x12\n" +
+	"\x04rank\x18\n" +
+	" \x01(\x05R\x04rank\x12\x14\n" +
+	"\x05prize\x18\v \x01(\x03R\x05prize\"\x13\n" +
+	"\x11GetBalanceRequest\"I\n" +
+	"\x12GetBalanceResponse\x123\n" +
+	"\bbalances\x18\x01 \x03(\v2\x17.weeklylotto.v1.BalanceR\bbalances\"\xdc\x01\n" +
+	"\aBalance\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x18\n" +
+	"\adeposit\x18\x02 \x01(\x03R\adeposit\x12'\n" +
+	"\x0funclaimed_prize\x18\x03 \x01(\x03R\x0eunclaimedPrize\x12\x14\n" +
+	"\x05round\x18\x04 \x01(\x05R\x05round\x12#\n" +
+	"\rround_tickets\x18\x05 \x01(\x05R\froundTickets\x129\n" +
+	"\n" +
+	"checked_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\"\xc0\x01\n" +
+	"\x14ListPurchasesRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x12\x1d\n" +
+	"\n" +
+	"from_round\x18\x03 \x01(\x05R\tfromRound\x12\x19\n" +
+	"\bto_round\x18\x04 \x01(\x05R\atoRound\x12\x14\n" +
+	"\x05since\x18\x05 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x06 \x01(\tR\x05until\x12\x12\n" +
+	"\x04rank\x18\a \x01(\tR\x04rank\"I\n" +
+	"\x15ListPurchasesResponse\x120\n" +
+	"\atickets\x18\x01 \x03(\v2\x16.weeklylotto.v1.TicketR\atickets\"\x91\x01\n" +
+	"\x0fGetStatsRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x1d\n" +
+	"\n" +
+	"from_round\x18\x02 \x01(\x05R\tfromRound\x12\x19\n" +
+	"\bto_round\x18\x03 \x01(\x05R\atoRound\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x05 \x01(\tR\x05until\"\xfd\x02\n" +
+	"\x05Stats\x12\x18\n" +
+	"\atickets\x18\x01 \x01(\x05R\atickets\x12#\n" +
+	"\rdrawn_tickets\x18\x02 \x01(\x05R\fdrawnTickets\x12'\n" +
+	"\x0fpending_tickets\x18\x03 \x01(\x05R\x0ependingTickets\x12\x14\n" +
+	"\x05spent\x18\x04 \x01(\x03R\x05spent\x12\x1f\n" +
+	"\vdrawn_spent\x18\x05 \x01(\x03R\n" +
+	"drawnSpent\x12\x1a\n" +
+	"\bwinnings\x18\x06 \x01(\x03R\bwinnings\x12\x10\n" +
+	"\x03roi\x18\a \x01(\x01R\x03roi\x126\n" +
+	"\x05ranks\x18\b \x03(\v2 .weeklylotto.v1.Stats.RanksEntryR\x05ranks\x125\n" +
+	"\anumbers\x18\t \x03(\v2\x1b.weeklylotto.v1.NumberCountR\anumbers\x1a8\n" +
+	"\n" +
+	"RanksEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\";\n" +
+	"\vNumberCount\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"%\n" +
+	"\n" +
+	"BuyRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"B\n" +
+	"\vBuyResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.weeklylotto.v1.BuyResultR\aresults\"\xd1\x01\n" +
+	"\tBuyResult\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05round\x18\x03 \x01(\x05R\x05round\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x126\n" +
+	"\atickets\x18\x05 \x03(\v2\x1c.weeklylotto.v1.ResultTicketR\atickets\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"\x92\x01\n" +
+	"\fResultTicket\x12\x12\n" +
+	"\x04slot\x18\x01 \x01(\tR\x04slot\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x18\n" +
+	"\anumbers\x18\x03 \x03(\x05R\anumbers\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\x12\x12\n" +
+	"\x04rank\x18\x05 \x01(\x05R\x04rank\x12\x14\n" +
+	"\x05prize\x18\x06 \x01(\x03R\x05prize\"\x0e\n" +
+	"\fCheckRequest\"F\n" +
+	"\rCheckResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.weeklylotto.v1.CheckResultR\aresults\"\xd7\x01\n" +
+	"\vCheckResult\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\x05R\x05round\x12\x18\n" +
+	"\anumbers\x18\x03 \x03(\x05R\anumbers\x12\x14\n" +
+	"\x05bonus\x18\x04 \x01(\x05R\x05bonus\x126\n" +
+	"\atickets\x18\x05 \x03(\v2\x1c.weeklylotto.v1.ResultTicketR\atickets\x12\x1a\n" +
+	"\bwinnings\x18\x06 \x01(\x03R\bwinnings\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error2\xb7\x04\n" +
+	"\vWeeklyLotto\x12O\n" +
+	"\n" +
+	"GetWinning\x12!.weeklylotto.v1.GetWinningRequest\x1a\x1e.weeklylotto.v1.WinningNumbers\x12Z\n" +
+	"\x10GetLatestResults\x12'.weeklylotto.v1.GetLatestResultsRequest\x1a\x1d.weeklylotto.v1.LatestResults\x12S\n" +
+	"\n" +
+	"GetBalance\x12!.weeklylotto.v1.GetBalanceRequest\x1a\".weeklylotto.v1.GetBalanceResponse\x12\\\n" +
+	"\rListPurchases\x12$.weeklylotto.v1.ListPurchasesRequest\x1a%.weeklylotto.v1.ListPurchasesResponse\x12B\n" +
+	"\bGetStats\x12\x1f.weeklylotto.v1.GetStatsRequest\x1a\x15.weeklylotto.v1.Stats\x12>\n" +
+	"\x03Buy\x12\x1a.weeklylotto.v1.BuyRequest\x1a\x1b.weeklylotto.v1.BuyResponse\x12D\n" +
+	"\x05Check\x12\x1c.weeklylotto.v1.CheckRequest\x1a\x1d.weeklylotto.v1.CheckResponseB#Z!weekly-lotto/api/lotto/v1;lottov1b\x06proto3"
+
+var (
+	file_lotto_proto_rawDescOnce sync.Once
+	file_lotto_proto_rawDescData []byte
+)
+
+func file_lotto_proto_rawDescGZIP() []byte {
+	file_lotto_proto_rawDescOnce.Do(func() {
+		file_lotto_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_lotto_proto_rawDesc), len(file_lotto_proto_rawDesc)))
+	})
+	return file_lotto_proto_rawDescData
+}
+
+var file_lotto_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_lotto_proto_goTypes = []any{
+	(*GetWinningRequest)(nil),       // 0: weeklylotto.v1.GetWinningRequest
+	(*WinningNumbers)(nil),          // 1: weeklylotto.v1.WinningNumbers
+	(*Prize)(nil),                   // 2: weeklylotto.v1.Prize
+	(*GetLatestResultsRequest)(nil), // 3: weeklylotto.v1.GetLatestResultsRequest
+	(*LatestResults)(nil),           // 4: weeklylotto.v1.LatestResults
+	(*Ticket)(nil),                  // 5: weeklylotto.v1.Ticket
+	(*GetBalanceRequest)(nil),       // 6: weeklylotto.v1.GetBalanceRequest
+	(*GetBalanceResponse)(nil),      // 7: weeklylotto.v1.GetBalanceResponse
+	(*Balance)(nil),                 // 8: weeklylotto.v1.Balance
+	(*ListPurchasesRequest)(nil),    // 9: weeklylotto.v1.ListPurchasesRequest
+	(*ListPurchasesResponse)(nil),   // 10: weeklylotto.v1.ListPurchasesResponse
+	(*GetStatsRequest)(nil),         // 11: weeklylotto.v1.GetStatsRequest
+	(*Stats)(nil),                   // 12: weeklylotto.v1.Stats
+	(*NumberCount)(nil),             // 13: weeklylotto.v1.NumberCount
+	(*BuyRequest)(nil),              // 14: weeklylotto.v1.BuyRequest
+	(*BuyResponse)(nil),             // 15: weeklylotto.v1.BuyResponse
+	(*BuyResult)(nil),               // 16: weeklylotto.v1.BuyResult
+	(*ResultTicket)(nil),            // 17: weeklylotto.v1.ResultTicket
+	(*CheckRequest)(nil),            // 18: weeklylotto.v1.CheckRequest
+	(*CheckResponse)(nil),           // 19: weeklylotto.v1.CheckResponse
+	(*CheckResult)(nil),             // 20: weeklylotto.v1.CheckResult
+	nil,                             // 21: weeklylotto.v1.Stats.RanksEntry
+	(*timestamppb.Timestamp)(nil),   // 22: google.protobuf.Timestamp
+}
+var file_lotto_proto_depIdxs = []int32{
+	22, // 0: weeklylotto.v1.WinningNumbers.draw_date:type_name -> google.protobuf.Timestamp
+	2,  // 1: weeklylotto.v1.WinningNumbers.prizes:type_name -> weeklylotto.v1.Prize
+	1,  // 2: weeklylotto.v1.LatestResults.winning:type_name -> weeklylotto.v1.WinningNumbers
+	5,  // 3: weeklylotto.v1.LatestResults.tickets:type_name -> weeklylotto.v1.Ticket
+	22, // 4: weeklylotto.v1.Ticket.purchased_at:type_name -> google.protobuf.Timestamp
+	8,  // 5: weeklylotto.v1.GetBalanceResponse.balances:type_name -> weeklylotto.v1.Balance
+	22, // 6: weeklylotto.v1.Balance.checked_at:type_name -> google.protobuf.Timestamp
+	5,  // 7: weeklylotto.v1.ListPurchasesResponse.tickets:type_name -> weeklylotto.v1.Ticket
+	21, // 8: weeklylotto.v1.Stats.ranks:type_name -> weeklylotto.v1.Stats.RanksEntry
+	13, // 9: weeklylotto.v1.Stats.numbers:type_name -> weeklylotto.v1.NumberCount
+	16, // 10: weeklylotto.v1.BuyResponse.results:type_name -> weeklylotto.v1.BuyResult
+	17, // 11: weeklylotto.v1.BuyResult.tickets:type_name -> weeklylotto.v1.ResultTicket
+	20, // 12: weeklylotto.v1.CheckResponse.results:type_name -> weeklylotto.v1.CheckResult
+	17, // 13: weeklylotto.v1.CheckResult.tickets:type_name -> weeklylotto.v1.ResultTicket
+	0,  // 14: weeklylotto.v1.WeeklyLotto.GetWinning:input_type -> weeklylotto.v1.GetWinningRequest
+	3,  // 15: weeklylotto.v1.WeeklyLotto.GetLatestResults:input_type -> weeklylotto.v1.GetLatestResultsRequest
+	6,  // 16: weeklylotto.v1.WeeklyLotto.GetBalance:input_type -> weeklylotto.v1.GetBalanceRequest
+	9,  // 17: weeklylotto.v1.WeeklyLotto.ListPurchases:input_type -> weeklylotto.v1.ListPurchasesRequest
+	11, // 18: weeklylotto.v1.WeeklyLotto.GetStats:input_type -> weeklylotto.v1.GetStatsRequest
+	14, // 19: weeklylotto.v1.WeeklyLotto.Buy:input_type -> weeklylotto.v1.BuyRequest
+	18, // 20: weeklylotto.v1.WeeklyLotto.Check:input_type -> weeklylotto.v1.CheckRequest
+	1,  // 21: weeklylotto.v1.WeeklyLotto.GetWinning:output_type -> weeklylotto.v1.WinningNumbers
+	4,  // 22: weeklylotto.v1.WeeklyLotto.GetLatestResults:output_type -> weeklylotto.v1.LatestResults
+	7,  // 23: weeklylotto.v1.WeeklyLotto.GetBalance:output_type -> weeklylotto.v1.GetBalanceResponse
+	10, // 24: weeklylotto.v1.WeeklyLotto.ListPurchases:output_type -> weeklylotto.v1.ListPurchasesResponse
+	12, // 25: weeklylotto.v1.WeeklyLotto.GetStats:output_type -> weeklylotto.v1.Stats
+	15, // 26: weeklylotto.v1.WeeklyLotto.Buy:output_type -> weeklylotto.v1.BuyResponse
+	19, // 27: weeklylotto.v1.WeeklyLotto.Check:output_type -> weeklylotto.v1.CheckResponse
+	21, // [21:28] is the sub-list for method output_type
+	14, // [14:21] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_lotto_proto_init() }
+func file_lotto_proto_init() {
+	if File_lotto_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_lotto_proto_rawDesc), len(file_lotto_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_lotto_proto_goTypes,
+		DependencyIndexes: file_lotto_proto_depIdxs,
+		MessageInfos:      file_lotto_proto_msgTypes,
+	}.Build()
+	File_lotto_proto = out.File
+	file_lotto_proto_goTypes = nil
+	file_lotto_proto_depIdxs = nil
+}
@@ -0,0 +1,372 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: lotto.proto
+
+// The weekly-lotto service, served by `weekly-lotto serve --grpc-addr`. It
+// mirrors the operations of the HTTP API (/api/*).
+
+package lottov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WeeklyLotto_GetWinning_FullMethodName       = "/weeklylotto.v1.WeeklyLotto/GetWinning"
+	WeeklyLotto_GetLatestResults_FullMethodName = "/weeklylotto.v1.WeeklyLotto/GetLatestResults"
+	WeeklyLotto_GetBalance_FullMethodName       = "/weeklylotto.v1.WeeklyLotto/GetBalance"
+	WeeklyLotto_ListPurchases_FullMethodName    = "/weeklylotto.v1.WeeklyLotto/ListPurchases"
+	WeeklyLotto_GetStats_FullMethodName         = "/weeklylotto.v1.WeeklyLotto/GetStats"
+	WeeklyLotto_Buy_FullMethodName              = "/weeklylotto.v1.WeeklyLotto/Buy"
+	WeeklyLotto_Check_FullMethodName            = "/weeklylotto.v1.WeeklyLotto/Check"
+)
+
+// WeeklyLottoClient is the client API for WeeklyLotto service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WeeklyLottoClient interface {
+	// GetWinning returns the draw of a round (GET /api/winning).
+	GetWinning(ctx context.Context, in *GetWinningRequest, opts ...grpc.CallOption) (*WinningNumbers, error)
+	// GetLatestResults returns the latest draw with the recorded tickets of
+	// its round (GET /api/results/latest).
+	GetLatestResults(ctx context.Context, in *GetLatestResultsRequest, opts ...grpc.CallOption) (*LatestResults, error)
+	// GetBalance returns the balance of every account (GET /api/balance).
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	// ListPurchases returns recorded purchases (GET /api/purchases).
+	ListPurchases(ctx context.Context, in *ListPurchasesRequest, opts ...grpc.CallOption) (*ListPurchasesResponse, error)
+	// GetStats summarizes recorded purchases (GET /api/stats).
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error)
+	// Buy buys the configured tickets for every account (POST /api/buy).
+	// Failed accounts carry their error in the result.
+	Buy(ctx context.Context, in *BuyRequest, opts ...grpc.CallOption) (*BuyResponse, error)
+	// Check checks the latest draw for every account (POST /api/check).
+	// Failed accounts carry their error in the result.
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+}
+
+type weeklyLottoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWeeklyLottoClient(cc grpc.ClientConnInterface) WeeklyLottoClient {
+	return &weeklyLottoClient{cc}
+}
+
+func (c *weeklyLottoClient) GetWinning(ctx context.Context, in *GetWinningRequest, opts ...grpc.CallOption) (*WinningNumbers, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WinningNumbers)
+	err := c.cc.Invoke(ctx, WeeklyLotto_GetWinning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weeklyLottoClient) GetLatestResults(ctx context.Context, in *GetLatestResultsRequest, opts ...grpc.CallOption) (*LatestResults, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LatestResults)
+	err := c.cc.Invoke(ctx, WeeklyLotto_GetLatestResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weeklyLottoClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, WeeklyLotto_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weeklyLottoClient) ListPurchases(ctx context.Context, in *ListPurchasesRequest, opts ...grpc.CallOption) (*ListPurchasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPurchasesResponse)
+	err := c.cc.Invoke(ctx, WeeklyLotto_ListPurchases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weeklyLottoClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*Stats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Stats)
+	err := c.cc.Invoke(ctx, WeeklyLotto_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weeklyLottoClient) Buy(ctx context.Context, in *BuyRequest, opts ...grpc.CallOption) (*BuyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuyResponse)
+	err := c.cc.Invoke(ctx, WeeklyLotto_Buy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weeklyLottoClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, WeeklyLotto_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WeeklyLottoServer is the server API for WeeklyLotto service.
+// All implementations must embed UnimplementedWeeklyLottoServer
+// for forward compatibility.
+type WeeklyLottoServer interface {
+	// GetWinning returns the draw of a round (GET /api/winning).
+	GetWinning(context.Context, *GetWinningRequest) (*WinningNumbers, error)
+	// GetLatestResults returns the latest draw with the recorded tickets of
+	// its round (GET /api/results/latest).
+	GetLatestResults(context.Context, *GetLatestResultsRequest) (*LatestResults, error)
+	// GetBalance returns the balance of every account (GET /api/balance).
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	// ListPurchases returns recorded purchases (GET /api/purchases).
+	ListPurchases(context.Context, *ListPurchasesRequest) (*ListPurchasesResponse, error)
+	// GetStats summarizes recorded purchases (GET /api/stats).
+	GetStats(context.Context, *GetStatsRequest) (*Stats, error)
+	// Buy buys the configured tickets for every account (POST /api/buy).
+	// Failed accounts carry their error in the result.
+	Buy(context.Context, *BuyRequest) (*BuyResponse, error)
+	// Check checks the latest draw for every account (POST /api/check).
+	// Failed accounts carry their error in the result.
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	mustEmbedUnimplementedWeeklyLottoServer()
+}
+
+// UnimplementedWeeklyLottoServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWeeklyLottoServer struct{}
+
+func (UnimplementedWeeklyLottoServer) GetWinning(context.Context, *GetWinningRequest) (*WinningNumbers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWinning not implemented")
+}
+func (UnimplementedWeeklyLottoServer) GetLatestResults(context.Context, *GetLatestResultsRequest) (*LatestResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatestResults not implemented")
+}
+func (UnimplementedWeeklyLottoServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedWeeklyLottoServer) ListPurchases(context.Context, *ListPurchasesRequest) (*ListPurchasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPurchases not implemented")
+}
+func (UnimplementedWeeklyLottoServer) GetStats(context.Context, *GetStatsRequest) (*Stats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedWeeklyLottoServer) Buy(context.Context, *BuyRequest) (*BuyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Buy not implemented")
+}
+func (UnimplementedWeeklyLottoServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedWeeklyLottoServer) mustEmbedUnimplementedWeeklyLottoServer() {}
+func (UnimplementedWeeklyLottoServer) testEmbeddedByValue()                     {}
+
+// UnsafeWeeklyLottoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WeeklyLottoServer will
+// result in compilation errors.
+type UnsafeWeeklyLottoServer interface {
+	mustEmbedUnimplementedWeeklyLottoServer()
+}
+
+func RegisterWeeklyLottoServer(s grpc.ServiceRegistrar, srv WeeklyLottoServer) {
+	// If the following call pancis, it indicates UnimplementedWeeklyLottoServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WeeklyLotto_ServiceDesc, srv)
+}
+
+func _WeeklyLotto_GetWinning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWinningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeeklyLottoServer).GetWinning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeeklyLotto_GetWinning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeeklyLottoServer).GetWinning(ctx, req.(*GetWinningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeeklyLotto_GetLatestResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLatestResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeeklyLottoServer).GetLatestResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeeklyLotto_GetLatestResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeeklyLottoServer).GetLatestResults(ctx, req.(*GetLatestResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeeklyLotto_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeeklyLottoServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeeklyLotto_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeeklyLottoServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeeklyLotto_ListPurchases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPurchasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeeklyLottoServer).ListPurchases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeeklyLotto_ListPurchases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeeklyLottoServer).ListPurchases(ctx, req.(*ListPurchasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeeklyLotto_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeeklyLottoServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeeklyLotto_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeeklyLottoServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeeklyLotto_Buy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeeklyLottoServer).Buy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeeklyLotto_Buy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeeklyLottoServer).Buy(ctx, req.(*BuyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeeklyLotto_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeeklyLottoServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeeklyLotto_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeeklyLottoServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WeeklyLotto_ServiceDesc is the grpc.ServiceDesc for WeeklyLotto service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WeeklyLotto_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "weeklylotto.v1.WeeklyLotto",
+	HandlerType: (*WeeklyLottoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWinning",
+			Handler:    _WeeklyLotto_GetWinning_Handler,
+		},
+		{
+			MethodName: "GetLatestResults",
+			Handler:    _WeeklyLotto_GetLatestResults_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _WeeklyLotto_GetBalance_Handler,
+		},
+		{
+			MethodName: "ListPurchases",
+			Handler:    _WeeklyLotto_ListPurchases_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _WeeklyLotto_GetStats_Handler,
+		},
+		{
+			MethodName: "Buy",
+			Handler:    _WeeklyLotto_Buy_Handler,
+		},
+		{
+			MethodName: "Check",
+			Handler:    _WeeklyLotto_Check_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "lotto.proto",
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"context"
+	"os"
+	"weekly-lotto/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(context.Background(), os.Args[1:]))
+}
@@ -0,0 +1,177 @@
+// Package audit keeps an append-only log of money-moving actions, separate
+// from the application log.
+//
+// Each entry is one JSON line that carries the SHA-256 hash of the previous
+// line, so editing, reordering or removing an earlier entry breaks the chain
+// and is reported by Verify. The chain cannot stop someone from rewriting
+// the whole file, so copy it off the host (e.g. with the store backup) when
+// that matters.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Actions.
+const (
+	ActionPurchase = "purchase"
+)
+
+// Results of an action. An attempt is written before the request is sent,
+// so a run that dies mid-purchase still leaves a trace.
+const (
+	ResultAttempt   = "attempt"
+	ResultPurchased = "purchased"
+	ResultFailed    = "failed"
+	ResultSkipped   = "skipped"
+)
+
+// Operators tell scheduled runs from ones started by hand.
+const (
+	OperatorScheduled = "scheduled"
+	OperatorManual    = "manual"
+)
+
+// Entry is one audited action.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	Result   string    `json:"result"`
+	Account  string    `json:"account"`
+	Operator string    `json:"operator"`
+	// Source is how the action was started: cli, schedule, api or
+	// github-actions.
+	Source  string `json:"source"`
+	Round   int    `json:"round,omitempty"`
+	Tickets int    `json:"tickets"`
+	Amount  int64  `json:"amount"`
+	Reason  string `json:"reason,omitempty"`
+
+	Prev string `json:"prev"`
+	Hash string `json:"hash,omitempty"`
+}
+
+// Log appends entries for one operator and source. A nil *Log records
+// nothing.
+type Log struct {
+	path     string
+	operator string
+	source   string
+}
+
+// Open returns a Log that appends to path, or nil when path is empty.
+func Open(path, operator, source string) *Log {
+	if path == "" {
+		return nil
+	}
+	return &Log{path: path, operator: operator, source: source}
+}
+
+// Record appends e, filling in its time, operator, source and chain hashes.
+func (l *Log) Record(e Entry) error {
+	if l == nil {
+		return nil
+	}
+	entries, err := Read(l.path)
+	if err != nil {
+		return err
+	}
+
+	e.Time = time.Now()
+	e.Operator = l.operator
+	e.Source = l.source
+	e.Prev = ""
+	if len(entries) > 0 {
+		e.Prev = entries[len(entries)-1].Hash
+	}
+	e.Hash = ""
+	e.Hash, err = hash(e)
+	if err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("감사 로그 열기 실패: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("감사 로그 기록 실패: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("감사 로그 기록 실패: %w", err)
+	}
+	return f.Close()
+}
+
+// Read returns every entry in the log at path, oldest first. A missing file
+// is an empty log.
+func Read(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("감사 로그 읽기 실패: %w", err)
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("감사 로그 %d번째 줄 손상: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Verify checks the hash chain of entries and returns an error naming the
+// first entry that was altered, inserted or follows a removed one.
+func Verify(entries []Entry) error {
+	prev := ""
+	for i, e := range entries {
+		if e.Prev != prev {
+			return fmt.Errorf("감사 로그 %d번째 항목(%s)의 이전 해시가 맞지 않습니다 - 앞의 항목이 삭제되었거나 순서가 바뀌었습니다", i+1, e.Time.Format(time.RFC3339))
+		}
+		recorded := e.Hash
+		e.Hash = ""
+		want, err := hash(e)
+		if err != nil {
+			return err
+		}
+		if recorded != want {
+			return fmt.Errorf("감사 로그 %d번째 항목(%s)의 해시가 맞지 않습니다 - 내용이 수정되었습니다", i+1, e.Time.Format(time.RFC3339))
+		}
+		prev = recorded
+	}
+	return nil
+}
+
+// hash returns the chain hash of e, which must have an empty Hash.
+func hash(e Entry) (string, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,167 @@
+// Package backup copies the local store to remote storage and back.
+//
+// A backup is a gzip-compressed snapshot of the store file uploaded under
+// the store's file name, replacing the previous one; enable object
+// versioning on the bucket or server to keep older copies. S3 and GCS are
+// reached with hand-signed AWS Signature V4 requests and WebDAV with plain
+// PUT/GET, so no cloud SDK is needed.
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/store"
+)
+
+// ErrNotFound is returned by Restore when no backup has been uploaded yet.
+var ErrNotFound = errors.New("백업을 찾을 수 없습니다")
+
+var httpClient = &http.Client{Timeout: 5 * time.Minute}
+
+// target stores backup objects under a remote location.
+type target interface {
+	put(ctx context.Context, name string, data []byte) error
+	// get returns ErrNotFound when name does not exist.
+	get(ctx context.Context, name string) ([]byte, error)
+}
+
+// Backup uploads snapshots of one store file to a remote location.
+type Backup struct {
+	target   target
+	location string
+	name     string
+}
+
+// New returns a Backup of the store at storePath, or nil when backups are
+// not configured.
+func New(cfg config.BackupConfig, storePath string) (*Backup, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("백업 URL 형식 오류: %w", err)
+	}
+
+	var t target
+	switch u.Scheme {
+	case "s3", "gs":
+		t, err = newS3(u, cfg)
+	case "http", "https":
+		t = newWebDAV(u, cfg)
+	default:
+		err = fmt.Errorf("지원하지 않는 백업 URL입니다: %s", cfg.URL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(storePath) + ".gz"
+	return &Backup{target: t, location: strings.TrimSuffix(cfg.URL, "/") + "/" + name, name: name}, nil
+}
+
+// Location is the URL of the backup object, for logs.
+func (b *Backup) Location() string {
+	return b.location
+}
+
+// Upload snapshots s and uploads it, returning the compressed size.
+func (b *Backup) Upload(ctx context.Context, s store.Store) (int, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := s.Snapshot(ctx, zw); err != nil {
+		return 0, fmt.Errorf("저장소 스냅샷 실패: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+	if err := b.target.put(ctx, b.name, buf.Bytes()); err != nil {
+		return 0, fmt.Errorf("백업 업로드 실패: %w", err)
+	}
+	return buf.Len(), nil
+}
+
+// Restore downloads the backup and atomically writes it to path, returning
+// the restored size. Leftover SQLite WAL files of the replaced database are
+// removed so they are not replayed onto the restored one.
+func (b *Backup) Restore(ctx context.Context, path string) (int, error) {
+	data, err := b.target.get(ctx, b.name)
+	if err != nil {
+		return 0, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return 0, fmt.Errorf("백업 압축 해제 실패: %w", err)
+	}
+	restored, err := io.ReadAll(zr)
+	if err != nil {
+		return 0, fmt.Errorf("백업 압축 해제 실패: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".restore-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(restored); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return 0, err
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, err
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return len(restored), nil
+}
+
+// objectPath joins the URL path prefix and name into an object key.
+func objectPath(prefix, name string) string {
+	return strings.TrimPrefix(path.Join(prefix, name), "/")
+}
+
+// readResponse returns the body of a successful response, ErrNotFound for
+// 404 and an error with the (truncated) body otherwise.
+func readResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		if len(body) > 512 {
+			body = body[:512]
+		}
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,110 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/sigv4"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+	gcsEndpoint     = "https://storage.googleapis.com"
+	gcsRegion       = "auto"
+)
+
+// s3Target talks to S3, an S3-compatible service or GCS through its XML
+// interoperability API, signing requests with AWS Signature V4.
+type s3Target struct {
+	endpoint *url.URL // nil: virtual-hosted AWS S3
+	bucket   string
+	prefix   string
+	region   string
+	creds    sigv4.Credentials
+}
+
+// newS3 signs with the configured keys or, for S3 without them, the AWS
+// environment variables, session token included.
+func newS3(u *url.URL, cfg config.BackupConfig) (*s3Target, error) {
+	t := &s3Target{
+		bucket: u.Host,
+		prefix: u.Path,
+		region: cfg.Region,
+		creds:  sigv4.Credentials{AccessKeyID: cfg.AccessKeyID, SecretAccessKey: cfg.SecretAccessKey},
+	}
+	if u.Scheme == "s3" && t.creds.AccessKeyID == "" && t.creds.SecretAccessKey == "" {
+		t.creds = sigv4.FromEnv()
+	}
+	if !t.creds.Complete() {
+		return nil, fmt.Errorf("S3 백업에는 backup.accessKeyId/secretAccessKey 또는 AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY가 필요합니다")
+	}
+
+	endpoint := cfg.Endpoint
+	if u.Scheme == "gs" {
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+		if t.region == "" {
+			t.region = gcsRegion
+		}
+	}
+	if t.region == "" {
+		t.region = defaultS3Region
+	}
+	if endpoint != "" {
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("백업 endpoint 형식 오류: %w", err)
+		}
+		t.endpoint = parsed
+	}
+	return t, nil
+}
+
+// objectURL addresses name path-style on a custom endpoint and
+// virtual-hosted style on AWS.
+func (t *s3Target) objectURL(name string) *url.URL {
+	key := objectPath(t.prefix, name)
+	if t.endpoint == nil {
+		return &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", t.bucket, t.region), Path: "/" + key}
+	}
+	u := *t.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + t.bucket + "/" + key
+	return &u
+}
+
+func (t *s3Target) put(ctx context.Context, name string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, t.objectURL(name).String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	sigv4.Sign(req, data, t.creds, t.region, "s3", time.Now())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_, err = readResponse(resp)
+	return err
+}
+
+func (t *s3Target) get(ctx context.Context, name string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.objectURL(name).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	sigv4.Sign(req, nil, t.creds, t.region, "s3", time.Now())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return readResponse(resp)
+}
@@ -0,0 +1,64 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+
+	"weekly-lotto/internal/config"
+)
+
+// webdavTarget stores backups in a WebDAV directory (Nextcloud, a NAS,
+// rclone serve webdav, ...) that must already exist.
+type webdavTarget struct {
+	base     url.URL
+	username string
+	password string
+}
+
+func newWebDAV(u *url.URL, cfg config.BackupConfig) *webdavTarget {
+	return &webdavTarget{base: *u, username: cfg.Username, password: cfg.Password}
+}
+
+func (t *webdavTarget) objectURL(name string) string {
+	u := t.base
+	u.Path = "/" + objectPath(u.Path, name)
+	return u.String()
+}
+
+func (t *webdavTarget) put(ctx context.Context, name string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, t.objectURL(name), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	t.authorize(req)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_, err = readResponse(resp)
+	return err
+}
+
+func (t *webdavTarget) get(ctx context.Context, name string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.objectURL(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	t.authorize(req)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return readResponse(resp)
+}
+
+func (t *webdavTarget) authorize(req *http.Request) {
+	if t.username != "" || t.password != "" {
+		req.SetBasicAuth(t.username, t.password)
+	}
+}
@@ -0,0 +1,89 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+)
+
+// Ledger reports how much an account has already spent.
+type Ledger interface {
+	Spent(ctx context.Context, account string, from, to time.Time) (int64, error)
+}
+
+// ExceededError is returned when a purchase would go over a spending cap.
+type ExceededError struct {
+	Account string
+	Period  string // "주간" 또는 "월간"
+	Cap     int64
+	Spent   int64
+	Amount  int64
+	From    time.Time
+	To      time.Time
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s 예산 한도 초과: 한도 %s원, 사용 %s원, 이번 구매 %s원",
+		e.Period,
+		utils.FormatAmount(e.Cap),
+		utils.FormatAmount(e.Spent),
+		utils.FormatAmount(e.Amount),
+	)
+}
+
+// Check verifies that spending amount now keeps account within every cap.
+// It returns *ExceededError when a cap would be exceeded.
+func Check(ctx context.Context, ledger Ledger, account string, cfg config.BudgetConfig, amount int64, now time.Time) error {
+	periods := []struct {
+		name     string
+		cap      int64
+		from, to time.Time
+	}{
+		{"주간", cfg.Weekly, weekStart(now), weekStart(now).AddDate(0, 0, 7)},
+		{"월간", cfg.Monthly, monthStart(now), monthStart(now).AddDate(0, 1, 0)},
+	}
+
+	for _, period := range periods {
+		if period.cap <= 0 {
+			continue
+		}
+
+		spent, err := ledger.Spent(ctx, account, period.from, period.to)
+		if err != nil {
+			return fmt.Errorf("%s 지출 조회 실패: %w", period.name, err)
+		}
+
+		if spent+amount > period.cap {
+			return &ExceededError{
+				Account: account,
+				Period:  period.name,
+				Cap:     period.cap,
+				Spent:   spent,
+				Amount:  amount,
+				From:    period.from,
+				To:      period.to,
+			}
+		}
+	}
+
+	return nil
+}
+
+// seoul is the timezone the lottery operates in.
+var seoul = domain.Seoul
+
+// weekStart returns the Sunday 00:00 KST that starts the draw week of t.
+func weekStart(t time.Time) time.Time {
+	day := domain.StartOfDay(t)
+	return day.AddDate(0, 0, -int(day.Weekday()))
+}
+
+// monthStart returns the first day of t's month at 00:00 KST.
+func monthStart(t time.Time) time.Time {
+	t = t.In(seoul)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, seoul)
+}
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/store"
+)
+
+var archiveCommand = &command{
+	name:    "archive",
+	usage:   "archive [--account NAME] [--kind KIND] [--round N | --from-round N --to-round N] [--out DIR [--raw]] [flags]",
+	summary: "저장소에 보관된 사이트 페이지 원본(store.archivePages)을 나열하거나 파일로 꺼냅니다",
+	run:     runArchive,
+}
+
+// pageKinds lists the kinds accepted by --kind.
+var pageKinds = []string{lottery.PageBuy, lottery.PageWinning, lottery.PageBuyList, lottery.PageBuyDetail}
+
+// archivedPage is the JSON form of an archived page.
+type archivedPage struct {
+	Account   string    `json:"account,omitempty"`
+	Kind      string    `json:"kind"`
+	Round     int       `json:"round"`
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Bytes     int       `json:"bytes"`
+	File      string    `json:"file,omitempty"`
+}
+
+func runArchive(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	account := fs.String("account", "", "이 계정의 페이지만 출력 (당첨 번호 페이지는 계정이 없음)")
+	kind := fs.String("kind", "", "페이지 종류: "+strings.Join(pageKinds, ", "))
+	round := fs.Int("round", 0, "이 회차의 페이지만 출력")
+	fromRound := fs.Int("from-round", 0, "이 회차부터 출력")
+	toRound := fs.Int("to-round", 0, "이 회차까지 출력")
+	out := fs.String("out", "", "페이지 원본을 이 디렉터리에 파일로 저장 (파서 재현용)")
+	raw := fs.Bool("raw", false, "--out 으로 저장할 때 아이디, 쿠키, 주문번호 등을 가리지 않고 원본 그대로 저장")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *kind != "" && !slices.Contains(pageKinds, *kind) {
+		return usageError(fs, fmt.Errorf("--kind 는 %s 중 하나여야 합니다: %s", strings.Join(pageKinds, ", "), *kind))
+	}
+	if *round > 0 {
+		if *fromRound > 0 || *toRound > 0 {
+			return usageError(fs, fmt.Errorf("--round 와 --from-round/--to-round 는 함께 쓸 수 없습니다"))
+		}
+		*fromRound, *toRound = *round, *round
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	ledger, err := openLedger(app, cfg)
+	if err != nil {
+		return err
+	}
+	defer ledger.Close()
+
+	pages, err := ledger.Pages(ctx, store.PageFilter{Account: *account, Kind: *kind, FromRound: *fromRound, ToRound: *toRound})
+	if err != nil {
+		return err
+	}
+
+	results := make([]archivedPage, 0, len(pages))
+	for _, page := range pages {
+		results = append(results, archivedPage{
+			Account:   page.Account,
+			Kind:      page.Kind,
+			Round:     page.Round,
+			URL:       page.URL,
+			FetchedAt: page.FetchedAt,
+			Bytes:     len(page.Body),
+		})
+	}
+
+	if *out != "" {
+		if err := os.MkdirAll(*out, 0o700); err != nil {
+			return err
+		}
+		for i, page := range pages {
+			path := filepath.Join(*out, pageFileName(page))
+			body := page.Body
+			if !*raw {
+				body = sanitize.Page(body)
+			}
+			if err := os.WriteFile(path, body, 0o600); err != nil {
+				return fmt.Errorf("페이지 저장 실패: %w", err)
+			}
+			results[i].File = path
+		}
+		logging.Infof("📂 페이지 %d개를 %s에 저장했습니다", len(pages), *out)
+	}
+
+	if app.jsonOutput() {
+		return writeJSON(results)
+	}
+	return writeArchiveTable(results)
+}
+
+func writeArchiveTable(pages []archivedPage) error {
+	if len(pages) == 0 {
+		fmt.Println("조건에 맞는 보관 페이지가 없습니다")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "조회 시각\t회차\t종류\t계정\t크기\t파일")
+	for _, page := range pages {
+		account, file := page.Account, page.File
+		if account == "" {
+			account = "-"
+		}
+		if file == "" {
+			file = "-"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\t%s\n",
+			page.FetchedAt.In(domain.Seoul).Format("2006-01-02 15:04:05"), page.Round, page.Kind, account, page.Bytes, file)
+	}
+	return w.Flush()
+}
+
+// pageFileName names an extracted page so files sort by round and time and
+// open with the right viewer.
+func pageFileName(page store.Page) string {
+	ext := ".html"
+	if page.Kind == lottery.PageBuy {
+		ext = ".json"
+	}
+	name := fmt.Sprintf("%04d-%s-%s", page.Round, page.Kind, page.FetchedAt.In(domain.Seoul).Format("20060102T150405.000"))
+	if page.Account != "" {
+		name += "-" + page.Account
+	}
+	return name + ext
+}
+
+// pageArchive collects the pages lottery clients record during a run until
+// they are saved to the store.
+type pageArchive struct {
+	mu    sync.Mutex
+	cfg   *config.Config
+	pages []store.Page
+}
+
+// recordPages makes client record the pages it fetches for account (empty
+// for guest sessions) when cfg enables store.archivePages.
+func (a *App) recordPages(cfg *config.Config, client *lottery.Client, account string) {
+	if cfg == nil || !cfg.Store.ArchivePages || !cfg.Store.Enabled() {
+		return
+	}
+	client.SetRecorder(func(page lottery.Page) {
+		a.archive.mu.Lock()
+		defer a.archive.mu.Unlock()
+		a.archive.cfg = cfg
+		a.archive.pages = append(a.archive.pages, store.Page{
+			Account:   account,
+			Kind:      page.Kind,
+			Round:     page.Round,
+			URL:       page.URL,
+			FetchedAt: page.FetchedAt,
+			Body:      page.Body,
+		})
+	})
+}
+
+// archived wraps login so the clients it returns record their pages.
+func (a *App) archived(cfg *config.Config, login loginFunc) loginFunc {
+	return func(ctx context.Context, account config.AccountConfig) (*lottery.Client, error) {
+		client, err := login(ctx, account)
+		if err == nil {
+			a.recordPages(cfg, client, account.Name)
+		}
+		return client, err
+	}
+}
+
+// savePages saves the recorded pages to the store. Failures are logged, not
+// returned, and the pages are kept for the next attempt, so a full disk or
+// a busy store never fails a purchase. Callers must have closed their own
+// store first.
+func (a *App) savePages(ctx context.Context) {
+	a.archive.mu.Lock()
+	cfg, pages := a.archive.cfg, a.archive.pages
+	a.archive.pages = nil
+	a.archive.mu.Unlock()
+	if len(pages) == 0 {
+		return
+	}
+
+	err := func() error {
+		ledger, err := a.OpenStore(cfg)
+		if err != nil {
+			return err
+		}
+		defer ledger.Close()
+		return ledger.SavePages(ctx, pages)
+	}()
+	if err != nil {
+		logging.Warnf("⚠️  페이지 원본 보관 실패: %v", err)
+		a.archive.mu.Lock()
+		a.archive.pages = append(pages, a.archive.pages...)
+		a.archive.mu.Unlock()
+		return
+	}
+	logging.Debugf("📦 페이지 원본 %d개를 보관했습니다", len(pages))
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"weekly-lotto/internal/audit"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
+)
+
+var auditCommand = &command{
+	name:    "audit",
+	usage:   "audit [--account 이름] [flags]",
+	summary: "구매 감사 로그를 출력하고 해시 체인으로 변조 여부를 검증합니다",
+	run:     runAudit,
+}
+
+func runAudit(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	account := fs.String("account", "", "계정 이름으로 필터링")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	if cfg.Audit.Path == "" {
+		return errors.New("감사 로그가 설정되어 있지 않습니다 - audit.path (LOTTO_AUDIT_PATH) 를 설정하세요")
+	}
+
+	entries, err := audit.Read(cfg.Audit.Path)
+	if err != nil {
+		return err
+	}
+	// 검증은 필터링 전 전체 체인으로
+	verifyErr := audit.Verify(entries)
+
+	if *account != "" {
+		filtered := entries[:0:0]
+		for _, entry := range entries {
+			if entry.Account == *account {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	if app.jsonOutput() {
+		if err := writeJSON(entries); err != nil {
+			return err
+		}
+	} else if err := writeAuditTable(entries); err != nil {
+		return err
+	}
+
+	if verifyErr != nil {
+		return verifyErr
+	}
+	logging.Infof("🔏 감사 로그 해시 체인 검증 완료")
+	return nil
+}
+
+func writeAuditTable(entries []audit.Entry) error {
+	if len(entries) == 0 {
+		fmt.Println("감사 로그가 비어 있습니다")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "시각\t계정\t작업\t결과\t실행\t회차\t장수\t금액\t사유")
+	for _, entry := range entries {
+		round := "-"
+		if entry.Round > 0 {
+			round = fmt.Sprint(entry.Round)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s (%s)\t%s\t%d\t%s원\t%s\n",
+			entry.Time.In(domain.Seoul).Format("2006-01-02 15:04:05"), entry.Account, entry.Action, entry.Result,
+			entry.Operator, entry.Source, round, entry.Tickets, utils.FormatAmount(entry.Amount), entry.Reason)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/parser"
+	"weekly-lotto/internal/store"
+)
+
+// backfillAttempts bounds how often a single round is requested before
+// backfill gives up; it can be resumed later.
+const backfillAttempts = 3
+
+var backfillCommand = &command{
+	name:    "backfill",
+	usage:   "backfill [--from 1] [--to N] [--delay 300ms] [--file FILE] [flags]",
+	summary: "1회차부터 최신 회차까지의 당첨 번호를 로컬 저장소에 내려받거나 파일에서 가져옵니다 (요청 간격 조절, 중단 후 이어받기 가능)",
+	run:     runBackfill,
+}
+
+func runBackfill(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	from := fs.Int("from", 1, "시작 회차")
+	to := fs.Int("to", 0, "끝 회차 (기본: 최신 회차)")
+	delay := fs.Duration("delay", 300*time.Millisecond, "사이트 요청 간격")
+	force := fs.Bool("force", false, "이미 저장된 회차도 다시 내려받기")
+	file := fs.String("file", "", "사이트 대신 파일에서 가져오기 (회차별 당첨번호 엑셀 다운로드 .xls 또는 export --data draws 의 .csv)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *from < 1 || *to < 0 || (*to > 0 && *to < *from) || *delay < 0 {
+		return usageError(fs, fmt.Errorf("회차 범위 또는 요청 간격이 올바르지 않습니다: %d~%d, %s", *from, *to, *delay))
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	draws := app.drawResults(ctx)
+	defer draws.close()
+	if draws.ledger == nil {
+		return fmt.Errorf("당첨 번호를 저장할 로컬 저장소가 설정되지 않았습니다 (store.path / LOTTO_STORE_PATH)")
+	}
+	if *file != "" {
+		return importDrawsFile(ctx, draws.ledger, *file, *from, *to)
+	}
+
+	if *to == 0 {
+		latest, err := draws.fetchLatest()
+		if err != nil {
+			return fmt.Errorf("최신 당첨 번호 조회 실패: %w", err)
+		}
+		*to = latest.Round
+	}
+
+	stored := make(map[int]bool)
+	if !*force {
+		existing, err := draws.ledger.Draws(ctx, *from, *to)
+		if err != nil {
+			return err
+		}
+		for _, draw := range existing {
+			stored[draw.Round] = true
+		}
+	}
+
+	total := *to - *from + 1 - len(stored)
+	if total == 0 {
+		logging.Infof("✅ %d~%d회 당첨 번호가 모두 저장되어 있습니다", *from, *to)
+		return nil
+	}
+	logging.Infof("📥 %d~%d회 중 %d개 회차를 내려받습니다", *from, *to, total)
+
+	fetched := 0
+	for round := *from; round <= *to; round++ {
+		if stored[round] {
+			continue
+		}
+		if err := backfillRound(ctx, draws, round, *delay); err != nil {
+			if ctx.Err() != nil {
+				logging.Infof("🛑 중단됨 - %d/%d개 저장 완료, 다시 실행하면 %d회부터 이어받습니다", fetched, total, round)
+				return nil
+			}
+			return fmt.Errorf("%d회 당첨 번호 조회 실패 (%d/%d개 저장 완료, 다시 실행하면 이어받습니다): %w", round, fetched, total, err)
+		}
+		fetched++
+		if fetched%50 == 0 {
+			logging.Infof("📥 %d/%d개 회차 저장", fetched, total)
+		}
+	}
+
+	logging.Infof("✅ %d개 회차 당첨 번호를 저장했습니다", fetched)
+	return nil
+}
+
+// importDrawsFile loads the draws in path that fall in [from, to] (to 0:
+// no upper bound) into ledger. Rounds already stored unchanged are skipped.
+func importDrawsFile(ctx context.Context, ledger store.Store, path string, from, to int) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("당첨 번호 파일 열기 실패: %w", err)
+	}
+	defer file.Close()
+
+	var all []*domain.WinningNumbers
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		all, err = store.ReadDrawsCSV(file)
+	} else {
+		all, err = parser.ParseDrawArchive(file)
+	}
+	if err != nil {
+		return fmt.Errorf("%s 읽기 실패: %w", path, err)
+	}
+
+	var selected []*domain.WinningNumbers
+	for _, draw := range all {
+		if draw.Round >= from && (to == 0 || draw.Round <= to) {
+			selected = append(selected, draw)
+		}
+	}
+
+	result, err := store.ImportDraws(ctx, ledger, selected)
+	if err != nil {
+		return err
+	}
+	logging.Infof("✅ %s에서 %d개 회차를 가져왔습니다 (추가 %d, 갱신 %d, 변경 없음 %d)",
+		path, len(selected), result.Inserted, result.Updated, result.Unchanged)
+	return nil
+}
+
+// backfillRound fetches round from the site after waiting delay, retrying
+// with a doubling delay. The draw is saved by drawResults as it arrives.
+func backfillRound(ctx context.Context, draws *drawResults, round int, delay time.Duration) error {
+	var err error
+	for attempt := 1; attempt <= backfillAttempts; attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if _, err = draws.fetch(round); err == nil {
+			return nil
+		}
+		if attempt < backfillAttempts {
+			logging.Warnf("⚠️  %d회 조회 실패 (%d/%d): %v", round, attempt, backfillAttempts, err)
+		}
+		delay *= 2
+	}
+	return err
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"weekly-lotto/internal/backup"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/logging"
+)
+
+var backupCommand = &command{
+	name:    "backup",
+	usage:   "backup [flags]",
+	summary: "로컬 저장소를 설정된 원격 위치(S3/GCS/WebDAV)에 지금 백업합니다 (평소에는 실행 후 자동 백업)",
+	run:     runBackup,
+}
+
+var restoreCommand = &command{
+	name:    "restore",
+	usage:   "restore [--force] [flags]",
+	summary: "원격 백업을 내려받아 로컬 저장소 파일을 복원합니다",
+	run:     runRestore,
+}
+
+// backupResult is the JSON form of a backup or restore.
+type backupResult struct {
+	Location string `json:"location"`
+	Path     string `json:"path"`
+	Bytes    int    `json:"bytes"`
+	Previous string `json:"previous,omitempty"`
+}
+
+func runBackup(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	b, err := newBackup(cfg)
+	if err != nil {
+		return err
+	}
+
+	size, err := app.uploadBackup(ctx, cfg, b)
+	if err != nil {
+		return err
+	}
+	logging.Infof("☁️  저장소를 %s에 백업했습니다 (%d바이트)", b.Location(), size)
+
+	if app.jsonOutput() {
+		return writeJSON(backupResult{Location: b.Location(), Path: cfg.Store.Path, Bytes: size})
+	}
+	return nil
+}
+
+func runRestore(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	force := fs.Bool("force", false, "저장소 파일이 이미 있어도 덮어쓰기 (기존 파일은 .bak으로 보관)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	b, err := newBackup(cfg)
+	if err != nil {
+		return err
+	}
+
+	result := backupResult{Location: b.Location(), Path: cfg.Store.Path}
+	if _, err := os.Stat(cfg.Store.Path); err == nil {
+		if !*force {
+			return fmt.Errorf("%s 파일이 이미 있습니다 - 덮어쓰려면 --force 를 지정하세요", cfg.Store.Path)
+		}
+		result.Previous = cfg.Store.Path + ".bak"
+		if err := copyFile(cfg.Store.Path, result.Previous); err != nil {
+			return fmt.Errorf("기존 저장소 보관 실패: %w", err)
+		}
+	}
+
+	size, err := b.Restore(ctx, cfg.Store.Path)
+	if errors.Is(err, backup.ErrNotFound) {
+		return fmt.Errorf("%s: %w", b.Location(), err)
+	}
+	if err != nil {
+		return fmt.Errorf("백업 복원 실패: %w", err)
+	}
+	result.Bytes = size
+	logging.Infof("♻️  %s에서 %s를 복원했습니다 (%d바이트)", b.Location(), cfg.Store.Path, size)
+	if result.Previous != "" {
+		logging.Infof("📦 기존 파일은 %s에 보관했습니다", result.Previous)
+	}
+
+	if app.jsonOutput() {
+		return writeJSON(result)
+	}
+	return nil
+}
+
+// newBackup returns the configured backup, failing when none is set up.
+func newBackup(cfg *config.Config) (*backup.Backup, error) {
+	b, err := backup.New(cfg.Backup, cfg.Store.Path)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, fmt.Errorf("백업 위치가 설정되지 않았습니다 (backup.url / LOTTO_BACKUP_URL)")
+	}
+	return b, nil
+}
+
+// uploadBackup snapshots the configured store and uploads it with b.
+func (a *App) uploadBackup(ctx context.Context, cfg *config.Config, b *backup.Backup) (int, error) {
+	ledger, err := openLedger(a, cfg)
+	if err != nil {
+		return 0, err
+	}
+	defer ledger.Close()
+	// 이 업로드가 곧 이번 실행의 백업이므로 실행 후 자동 백업은 생략
+	defer a.storeUsed.Store(false)
+
+	return b.Upload(ctx, ledger)
+}
+
+// backupStore uploads the store after a run that opened it, when backups
+// are configured. Failures are logged, not returned, so a broken backup
+// location never fails a purchase.
+func (a *App) backupStore(ctx context.Context) {
+	if !a.storeUsed.Swap(false) || a.cfg == nil || !a.cfg.Backup.Enabled() {
+		return
+	}
+	b, err := backup.New(a.cfg.Backup, a.cfg.Store.Path)
+	if err != nil {
+		logging.Warnf("⚠️  백업 설정 오류: %v", err)
+		return
+	}
+
+	size, err := a.uploadBackup(ctx, a.cfg, b)
+	if err != nil {
+		logging.Warnf("⚠️  저장소 백업 실패: %v", err)
+		return
+	}
+	logging.Debugf("☁️  저장소를 %s에 백업했습니다 (%d바이트)", b.Location(), size)
+}
+
+// copyFile copies src to dst, replacing dst.
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0o600)
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+)
+
+var balanceCommand = &command{
+	name:    "balance",
+	usage:   "balance [flags]",
+	summary: "계정별 예치금, 이번 회차 구매 장수, 미수령 당첨금을 출력합니다",
+	run:     runBalance,
+}
+
+func runBalance(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	notify := fs.Bool("notify", false, "조회 결과를 이메일로도 전송")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	emailSender := app.EmailSender(cfg)
+
+	var errs []error
+	snapshots := make([]*domain.BalanceSnapshot, 0, len(cfg.LotteryAccounts()))
+	for _, account := range cfg.LotteryAccounts() {
+		snapshot, err := balance(ctx, account)
+		if err != nil {
+			logging.Errorf("❌ [%s] %v", account.Name, err)
+			errs = append(errs, accountFailed(account.Name, err))
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+		if !app.jsonOutput() {
+			fmt.Print(snapshot.ToString())
+		}
+
+		if *notify {
+			if err := emailSender.ForAccount(account.Name).SendBalanceSnapshot(snapshot); err != nil {
+				logging.Errorf("❌ [%s] 잔액 이메일 전송 실패: %v", account.Name, err)
+				errs = append(errs, accountFailed(account.Name, err))
+				continue
+			}
+			logging.Infof("✉️  [%s] 잔액 이메일 전송 완료", account.Name)
+		}
+	}
+
+	publishBalances(ctx, cfg, snapshots)
+
+	if app.jsonOutput() {
+		if err := writeJSON(snapshots); err != nil {
+			return err
+		}
+	}
+
+	return accountsFailed("잔액 조회가 실패했습니다", errs)
+}
+
+// balance logs in and collects the balance snapshot of a single account.
+func balance(ctx context.Context, account config.AccountConfig) (*domain.BalanceSnapshot, error) {
+	client, err := lottery.NewClient(ctx, account.Username, account.Password)
+	if err != nil {
+		return nil, fmt.Errorf("로그인 실패: %w", err)
+	}
+
+	money, err := client.GetBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("예치금 조회 실패: %w", err)
+	}
+
+	round, err := client.GetCurrentRound(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("회차 조회 실패: %w", err)
+	}
+
+	// 판매 중인 회차의 구매분은 최근 일주일 내역에 모두 포함됨
+	purchases, err := client.GetRecentPurchases(ctx, purchaseHistoryDays)
+	if err != nil && !errors.Is(err, lottery.ErrNoPurchases) {
+		return nil, fmt.Errorf("구매 내역 조회 실패: %w", err)
+	}
+
+	roundTickets := 0
+	for _, purchase := range purchases {
+		if purchase.Round == round {
+			roundTickets += len(purchase.Tickets)
+		}
+	}
+
+	return &domain.BalanceSnapshot{
+		Account:        account.Name,
+		Deposit:        money.Deposit,
+		UnclaimedPrize: money.UnclaimedPrize,
+		Round:          round,
+		RoundTickets:   roundTickets,
+		CheckedAt:      domain.Now(),
+	}, nil
+}
@@ -0,0 +1,349 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/telegram"
+)
+
+const (
+	// botPollTimeout is how long a getUpdates long poll waits for updates.
+	botPollTimeout = 50 * time.Second
+	// botRetryDelay is the pause after a failed poll.
+	botRetryDelay = 5 * time.Second
+	// botConfirmTimeout is how long a /buy confirmation button stays valid.
+	botConfirmTimeout = 5 * time.Minute
+	// botHistoryTickets is how many tickets /history lists without a round.
+	botHistoryTickets = 10
+)
+
+var botCommand = &command{
+	name:        "bot",
+	usage:       "bot [flags]",
+	summary:     "텔레그램 봇으로 /buy, /check, /balance, /history 명령을 받아 처리합니다 (구매 전 확인 버튼)",
+	run:         runBot,
+	longRunning: true,
+}
+
+const botHelp = `🎰 weekly-lotto 봇
+/buy - 설정된 티켓 구매 (확인 버튼을 누르면 구매)
+/check - 최신 회차 당첨 확인
+/balance - 계정별 예치금과 이번 회차 구매 현황
+/history [회차] - 구매 내역 (기본: 최근 %d장)`
+
+// telegramBot answers the commands of the allowed chats with the serve
+// operations.
+type telegramBot struct {
+	bot *telegram.Bot
+	cfg *config.Config
+	ops *server
+
+	// pending holds the /buy confirmation awaiting a button press, by chat.
+	mu      sync.Mutex
+	pending map[int64]pendingBuy
+}
+
+// pendingBuy is a purchase shown to a chat but not confirmed yet.
+type pendingBuy struct {
+	id      string
+	expires time.Time
+}
+
+func runBot(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	if !cfg.Telegram.Enabled() {
+		return fmt.Errorf("%w: %s", errConfig, "telegram.token (LOTTO_TELEGRAM_TOKEN) 이 설정되지 않았습니다")
+	}
+	sheet, err := app.Sheet(cfg)
+	if err != nil {
+		return err
+	}
+
+	b := &telegramBot{
+		bot:     telegram.New(cfg.Telegram.Token),
+		cfg:     cfg,
+		ops:     &server{app: app, cfg: cfg, started: time.Now(), sheet: sheet, source: "telegram"},
+		pending: make(map[int64]pendingBuy),
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logging.Infof("🤖 텔레그램 봇 시작 (허용 채팅 %d개)", len(cfg.Telegram.ChatIDs))
+	var offset int64
+	for {
+		updates, err := b.bot.Updates(ctx, offset, botPollTimeout)
+		if ctx.Err() != nil {
+			logging.Infof("🛑 종료 신호 수신 - 봇을 종료합니다")
+			return nil
+		}
+		if err != nil {
+			logging.Warnf("⚠️  텔레그램 업데이트 조회 실패: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(botRetryDelay):
+			}
+			continue
+		}
+		// 명령은 하나씩 처리해 구매가 겹치지 않게 함
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			switch {
+			case update.Message != nil:
+				b.handleMessage(ctx, update.Message)
+			case update.CallbackQuery != nil:
+				b.handleButton(ctx, update.CallbackQuery)
+			}
+		}
+	}
+}
+
+func (b *telegramBot) handleMessage(ctx context.Context, message *telegram.Message) {
+	chatID := message.Chat.ID
+	if !b.cfg.Telegram.Allowed(chatID) {
+		logging.Warnf("⚠️  허용되지 않은 채팅의 메시지를 무시합니다 (채팅 ID %d)", chatID)
+		b.reply(ctx, chatID, fmt.Sprintf("이 채팅은 허용되지 않았습니다. telegram.chatIds 에 %d 를 추가하세요.", chatID))
+		return
+	}
+
+	fields := strings.Fields(message.Text)
+	if len(fields) == 0 {
+		return
+	}
+	// 그룹 채팅에서는 /buy@봇이름 형태로 옴
+	command, _, _ := strings.Cut(fields[0], "@")
+	logging.Infof("🤖 [%d] %s", chatID, command)
+	switch command {
+	case "/buy":
+		b.offerBuy(ctx, chatID)
+	case "/check":
+		b.reply(ctx, chatID, "🔍 당첨 확인 중...")
+		results, err := b.ops.checkAll(ctx)
+		if results == nil {
+			b.replyError(ctx, chatID, err)
+			return
+		}
+		b.reply(ctx, chatID, formatCheckResults(results))
+	case "/balance":
+		snapshots, err := b.ops.balances(ctx)
+		if err != nil {
+			b.replyError(ctx, chatID, err)
+			return
+		}
+		var text []string
+		for _, snapshot := range snapshots {
+			text = append(text, snapshot.ToString())
+		}
+		b.reply(ctx, chatID, strings.Join(text, "\n"))
+	case "/history":
+		b.history(ctx, chatID, fields[1:])
+	default:
+		b.reply(ctx, chatID, fmt.Sprintf(botHelp, botHistoryTickets))
+	}
+}
+
+// offerBuy shows what /buy would purchase with confirm and cancel buttons.
+func (b *telegramBot) offerBuy(ctx context.Context, chatID int64) {
+	id := newConfirmID()
+	b.mu.Lock()
+	b.pending[chatID] = pendingBuy{id: id, expires: time.Now().Add(botConfirmTimeout)}
+	b.mu.Unlock()
+
+	tickets := b.cfg.Purchase.Tickets
+	accounts := b.cfg.LotteryAccounts()
+	var text strings.Builder
+	round := domain.RoundOn(domain.NextSalesOpen(domain.Now()))
+	fmt.Fprintf(&text, "🛒 %d회 구매를 진행할까요?\n", round)
+	fmt.Fprintf(&text, "계정 %d개 × %d장 = %s원\n", len(accounts), len(tickets), utils.FormatAmount(int64(len(accounts)*len(tickets))*domain.TicketPrice))
+	for i, ticket := range tickets {
+		mode, _ := domain.ParseLotto645Mode(ticket.Mode)
+		fmt.Fprintf(&text, "%c. %s", 'A'+i, mode)
+		if len(ticket.Numbers) > 0 {
+			fmt.Fprintf(&text, " %s", utils.FormatNumbers(ticket.Numbers))
+		}
+		if ticket.Strategy != "" {
+			fmt.Fprintf(&text, " (%s)", ticket.Strategy)
+		}
+		text.WriteString("\n")
+	}
+	fmt.Fprintf(&text, "%s 안에 선택하세요.", botConfirmTimeout)
+
+	keyboard := telegram.Keyboard{{
+		{Text: "✅ 구매", Data: "buy:" + id},
+		{Text: "❌ 취소", Data: "cancel:" + id},
+	}}
+	if _, err := b.bot.Send(ctx, chatID, text.String(), keyboard); err != nil {
+		logging.Warnf("⚠️  텔레그램 메시지 전송 실패: %v", err)
+	}
+}
+
+// handleButton acts on a /buy confirmation button. Only the latest offer of
+// the chat is honored, once, and not after botConfirmTimeout.
+func (b *telegramBot) handleButton(ctx context.Context, query *telegram.CallbackQuery) {
+	if query.Message == nil {
+		return
+	}
+	chatID, messageID := query.Message.Chat.ID, query.Message.MessageID
+	if !b.cfg.Telegram.Allowed(chatID) {
+		b.answer(ctx, query.ID, "허용되지 않은 채팅입니다")
+		return
+	}
+
+	action, id, _ := strings.Cut(query.Data, ":")
+	b.mu.Lock()
+	offer, ok := b.pending[chatID]
+	valid := ok && offer.id == id && time.Now().Before(offer.expires)
+	if valid {
+		delete(b.pending, chatID)
+	}
+	b.mu.Unlock()
+	if !valid {
+		b.answer(ctx, query.ID, "만료된 요청입니다 - /buy 를 다시 보내세요")
+		b.edit(ctx, chatID, messageID, "⌛ 만료된 구매 요청입니다.")
+		return
+	}
+
+	if action != "buy" {
+		b.answer(ctx, query.ID, "취소했습니다")
+		b.edit(ctx, chatID, messageID, "❌ 구매를 취소했습니다.")
+		return
+	}
+	b.answer(ctx, query.ID, "구매를 시작합니다")
+	b.edit(ctx, chatID, messageID, "🛒 구매 중...")
+	logging.Infof("🤖 [%d] 구매 확인", chatID)
+	results, err := b.ops.buyAll(ctx, false)
+	if results == nil {
+		b.replyError(ctx, chatID, err)
+		return
+	}
+	b.reply(ctx, chatID, formatBuyResults(results))
+}
+
+// history lists the recorded tickets of the round in args, or the latest
+// ones without it.
+func (b *telegramBot) history(ctx context.Context, chatID int64, args []string) {
+	var query historyQuery
+	if len(args) > 0 {
+		round, err := strconv.Atoi(strings.TrimSuffix(args[0], "회"))
+		if err != nil || round <= 0 {
+			b.reply(ctx, chatID, "회차는 숫자로 입력하세요 (예: /history 1150)")
+			return
+		}
+		query.Round = round
+	}
+	entries, err := b.ops.purchases(ctx, query)
+	if err != nil {
+		b.replyError(ctx, chatID, err)
+		return
+	}
+	if len(entries) == 0 {
+		b.reply(ctx, chatID, "기록된 구매가 없습니다.")
+		return
+	}
+	if query.Round == 0 {
+		entries = entries[max(0, len(entries)-botHistoryTickets):]
+	}
+
+	var text strings.Builder
+	text.WriteString("🧾 구매 내역\n")
+	for _, entry := range entries {
+		fmt.Fprintf(&text, "%d회 [%s] %s %s: %s → %s", entry.Round, entry.Account, entry.Slot, entry.Mode, utils.FormatNumbers(entry.Numbers), entry.Result)
+		if entry.Prize > 0 {
+			fmt.Fprintf(&text, " (%s원)", utils.FormatAmount(entry.Prize))
+		}
+		text.WriteString("\n")
+	}
+	b.reply(ctx, chatID, text.String())
+}
+
+func formatBuyResults(results []*buyResult) string {
+	var text strings.Builder
+	for _, result := range results {
+		switch result.Status {
+		case buyPurchased:
+			fmt.Fprintf(&text, "✅ [%s] %d회 %d장 구매 (%s원)\n", result.Account, result.Round, len(result.Tickets), utils.FormatAmount(result.Amount))
+			for _, ticket := range result.Tickets {
+				fmt.Fprintf(&text, "  %s %s: %s\n", ticket.Slot, ticket.Mode, utils.FormatNumbers(ticket.Numbers))
+			}
+		case buySkipped:
+			fmt.Fprintf(&text, "⏭️ [%s] 구매 건너뜀: %s\n", result.Account, result.Reason)
+		default:
+			fmt.Fprintf(&text, "❌ [%s] 구매 실패: %s\n", result.Account, result.Error)
+		}
+	}
+	return text.String()
+}
+
+func formatCheckResults(results []*checkResult) string {
+	var text strings.Builder
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Fprintf(&text, "❌ [%s] 당첨 확인 실패: %s\n", result.Account, result.Error)
+			continue
+		}
+		fmt.Fprintf(&text, "🎯 [%s] %d회 당첨 번호 %s + %d\n", result.Account, result.Round, utils.FormatNumbers(result.Numbers), result.Bonus)
+		for _, ticket := range result.Tickets {
+			fmt.Fprintf(&text, "  %s: %s → %s\n", ticket.Slot, utils.FormatNumbers(ticket.Numbers), ticket.Result)
+		}
+		fmt.Fprintf(&text, "  당첨금 합계 %s원\n", utils.FormatAmount(result.Winnings))
+	}
+	return text.String()
+}
+
+func (b *telegramBot) reply(ctx context.Context, chatID int64, text string) {
+	if _, err := b.bot.Send(ctx, chatID, text, nil); err != nil {
+		logging.Warnf("⚠️  텔레그램 메시지 전송 실패: %v", err)
+	}
+}
+
+func (b *telegramBot) replyError(ctx context.Context, chatID int64, err error) {
+	logging.Errorf("❌ %v", err)
+	var invalid *requestError
+	if errors.As(err, &invalid) {
+		b.reply(ctx, chatID, "⚠️ "+sanitize.Error(err))
+		return
+	}
+	b.reply(ctx, chatID, "❌ "+sanitize.Error(err))
+}
+
+func (b *telegramBot) edit(ctx context.Context, chatID, messageID int64, text string) {
+	if err := b.bot.Edit(ctx, chatID, messageID, text); err != nil {
+		logging.Warnf("⚠️  텔레그램 메시지 수정 실패: %v", err)
+	}
+}
+
+func (b *telegramBot) answer(ctx context.Context, queryID, text string) {
+	if err := b.bot.Answer(ctx, queryID, text); err != nil {
+		logging.Warnf("⚠️  텔레그램 버튼 응답 실패: %v", err)
+	}
+}
+
+// newConfirmID returns a random id tying a confirmation button to its offer.
+func newConfirmID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
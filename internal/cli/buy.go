@@ -0,0 +1,480 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+	"weekly-lotto/internal/audit"
+	"weekly-lotto/internal/budget"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/generator"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/notify"
+	"weekly-lotto/internal/runlock"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/sheets"
+	"weekly-lotto/internal/store"
+	"weekly-lotto/internal/tracing"
+)
+
+var buyCommand = &command{
+	name:    "buy",
+	usage:   "buy [flags]",
+	summary: "설정된 계정별로 로또 6/45를 구매하고 결과를 이메일로 전송합니다",
+	run:     runBuy,
+}
+
+func runBuy(ctx context.Context, app *App, args []string) (err error) {
+	fs := app.flags()
+	dryRun := fs.Bool("dry-run", false, "로그인, 회차/예치금 조회, 구매 파라미터 생성까지만 하고 실제 구매 없이 미리보기 알림을 전송")
+	var manual []config.TicketConfig
+	fs.Func("numbers", "수동 구매 번호 6개 (쉼표로 구분, 반복 가능, 예: --numbers 3,7,12,24,33,41). 지정하면 설정된 구매 목록 대신 사용", func(value string) error {
+		ticket, err := parseManualTicket(value)
+		if err != nil {
+			return err
+		}
+		manual = append(manual, ticket)
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if len(manual) > config.MaxTicketsPerPurchase {
+		return usageError(fs, fmt.Errorf("--numbers 는 최대 %d번까지 지정할 수 있습니다: %d", config.MaxTicketsPerPurchase, len(manual)))
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	if len(manual) > 0 {
+		cfg.Purchase.Tickets = manual
+	}
+	started := time.Now()
+	var results []*buyResult
+	// 미리보기는 실제 구매 실행이 아니므로 핑하지 않음
+	if !*dryRun {
+		done := monitor(ctx, cfg.Healthchecks.Buy)
+		defer func() {
+			done(err)
+			pushRun(ctx, cfg.Pushgateway, "buy", started, err, buyMetrics(results))
+			publishBuy(ctx, cfg, results)
+			writeSite(ctx, app, cfg)
+		}()
+	}
+
+	emailSender := app.EmailSender(cfg)
+	sheet, err := app.Sheet(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Open purchase ledger (optional, required for budget limits)
+	ledger, err := app.OpenStore(cfg)
+	if err != nil {
+		return err
+	}
+	if ledger != nil {
+		defer ledger.Close()
+	}
+
+	operator, source := commandOperator()
+	trail := audit.Open(cfg.Audit.Path, operator, source)
+	if operator == audit.OperatorScheduled && !*dryRun {
+		if err := sleepJitter(ctx, cfg.Schedule.BuyJitterWindow()); err != nil {
+			return err
+		}
+	}
+
+	ctx, span := tracing.Start(ctx, Program+" buy")
+	var errs []error
+	results = make([]*buyResult, 0, len(cfg.LotteryAccounts()))
+	for _, account := range cfg.LotteryAccounts() {
+		result, err := buy(ctx, cfg, ledger, account, app.archived(cfg, login), emailSender.ForAccount(account.Name), sheet, trail, *dryRun)
+		if err != nil {
+			logging.Errorf("❌ [%s] %v", account.Name, err)
+			result.Error = sanitize.Error(err)
+			errs = append(errs, accountFailed(account.Name, err))
+		}
+		results = append(results, result)
+	}
+	failed := accountsFailed("구매가 실패했습니다", errs)
+	span.End(failed)
+	writeStepSummary(buyStepSummary(results, *dryRun))
+
+	if app.jsonOutput() {
+		if err := writeJSON(results); err != nil {
+			return err
+		}
+	}
+
+	return failed
+}
+
+// Buy outcomes reported in buyResult.Status.
+const (
+	buyFailed    = "failed"
+	buySkipped   = "skipped"
+	buyPreview   = "preview"
+	buyPurchased = "purchased"
+)
+
+// buyResult is the outcome of buying for a single account.
+type buyResult struct {
+	Account string         `json:"account"`
+	Status  string         `json:"status"`
+	Round   int            `json:"round,omitempty"`
+	Amount  int64          `json:"amount,omitempty"`
+	Tickets []ticketOutput `json:"tickets,omitempty"`
+	Reason  string         `json:"reason,omitempty"`
+	Error   string         `json:"error,omitempty"`
+
+	// submitted is set once the purchase request has been sent, after which
+	// a failure may still have bought tickets and must not be retried.
+	submitted bool
+	// deposit is the deposit left after the run, when it could be read.
+	deposit *int64
+}
+
+// ticketOutput is the JSON form of a purchased (or checked) ticket.
+type ticketOutput struct {
+	Slot    string `json:"slot"`
+	Mode    string `json:"mode"`
+	Numbers []int  `json:"numbers"`
+	Result  string `json:"result,omitempty"`
+	Rank    int    `json:"rank,omitempty"`
+	Prize   int64  `json:"prize,omitempty"`
+}
+
+func newTicketOutputs(tickets []lottery.PurchasedTicket) []ticketOutput {
+	outputs := make([]ticketOutput, 0, len(tickets))
+	for _, ticket := range tickets {
+		outputs = append(outputs, ticketOutput{Slot: ticket.Slot, Mode: ticket.Mode, Numbers: ticket.Numbers})
+	}
+	return outputs
+}
+
+// buy purchases the configured basket for a single account.
+// ledger and sheet may be nil when not configured. With dryRun, everything up
+// to the purchase request is performed and a preview is sent instead.
+// The lottery session is obtained from login.
+// The returned result is never nil, even when err is set.
+func buy(ctx context.Context, cfg *config.Config, ledger store.Store, account config.AccountConfig, login loginFunc, emailSender *notify.EmailSender, sheet *sheets.Sheet, trail *audit.Log, dryRun bool) (result *buyResult, err error) {
+	result = &buyResult{Account: account.Name, Status: buyFailed}
+	ctx, span := tracing.Start(ctx, "buy", tracing.String("account", account.Name), tracing.Bool("dry_run", dryRun))
+	defer func() {
+		span.SetAttr(tracing.String("status", result.Status))
+		if result.Round > 0 {
+			span.SetAttr(tracing.Int("round", result.Round))
+		}
+		span.End(err)
+	}()
+
+	// 1. Build tickets from the configured basket
+	tickets, err := buildTickets(cfg.Purchase)
+	if err != nil {
+		return result, fmt.Errorf("티켓 생성 실패: %w", err)
+	}
+	amount := domain.TicketPrice * int64(len(tickets))
+	result.Amount = amount
+	if !dryRun && account.ReadOnly {
+		return result, lottery.ErrReadOnly
+	}
+
+	// 2. Keep overlapping runs from buying the same round twice
+	if !dryRun {
+		unlock, err := lockPurchase(ctx, cfg.Lock, account, len(tickets), amount, trail, result)
+		if err != nil {
+			return result, err
+		}
+		if unlock == nil {
+			return result, nil
+		}
+		defer unlock()
+	}
+
+	// 3. Enforce spending caps before touching the lottery site
+	if cfg.Budget.Enabled() {
+		err := budget.Check(ctx, ledger, account.Name, cfg.Budget, amount, domain.Now())
+		var exceeded *budget.ExceededError
+		if errors.As(err, &exceeded) {
+			logging.Warnf("⚠️  [%s] %v - 구매를 건너뜁니다", account.Name, exceeded)
+			result.Status = buySkipped
+			result.Reason = exceeded.Error()
+			if dryRun {
+				return result, nil
+			}
+			entry := purchaseEntry(account, audit.ResultSkipped, len(tickets), amount)
+			entry.Reason = exceeded.Error()
+			if err := trail.Record(entry); err != nil {
+				logging.Warnf("⚠️  [%s] %v", account.Name, err)
+			}
+			if err := notifyStep(ctx, "budget", func() error { return emailSender.SendBudgetExceeded(exceeded) }); err != nil {
+				return result, fmt.Errorf("예산 초과 이메일 전송 실패: %w", err)
+			}
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("예산 확인 실패: %w", err)
+		}
+	}
+
+	// 4. Create lottery client (auto login)
+	_, step := tracing.Start(ctx, "login")
+	client, err := login(ctx, account)
+	step.End(err)
+	if err != nil {
+		return result, fmt.Errorf("로그인 실패: %w", err)
+	}
+
+	logging.Infof("✅ [%s] 로그인 성공", account.Name)
+	logging.Infof("📝 [%s] 로또 %d장 구매 준비", account.Name, len(tickets))
+
+	if dryRun {
+		return result, previewBuy(ctx, client, account, tickets, emailSender, result)
+	}
+
+	// 5. Make sure the deposit covers the purchase
+	_, step = tracing.Start(ctx, "balance")
+	balance, err := client.GetBalance(ctx)
+	step.End(err)
+	if err != nil {
+		// 예치금 페이지를 읽지 못해도 구매는 진행 (부족하면 구매 요청이 실패함)
+		logging.Warnf("⚠️  [%s] 예치금 확인 실패, 구매를 계속합니다: %v", account.Name, err)
+	} else if balance.Deposit < amount {
+		result.deposit = &balance.Deposit
+		return result, skipForTopUp(ctx, account, balance, len(tickets), amount, emailSender, trail, result)
+	}
+
+	// 6. Purchase tickets
+	// 시도를 남기지 못하면 구매하지 않음
+	if err := trail.Record(purchaseEntry(account, audit.ResultAttempt, len(tickets), amount)); err != nil {
+		return result, fmt.Errorf("구매 중단: %w", err)
+	}
+	result.submitted = true
+	_, step = tracing.Start(ctx, "purchase", tracing.Int("tickets", len(tickets)))
+	purchased, err := client.BuyLotto645(ctx, tickets)
+	step.End(err)
+	entry := purchaseEntry(account, audit.ResultPurchased, len(tickets), amount)
+	if err != nil {
+		entry.Result, entry.Reason = audit.ResultFailed, sanitize.Error(err)
+	} else if len(purchased) > 0 {
+		entry.Round = purchased[0].Round
+	}
+	if err := trail.Record(entry); err != nil {
+		logging.Warnf("⚠️  [%s] %v", account.Name, err)
+	}
+	if err != nil {
+		return result, fmt.Errorf("구매 실패: %w", err)
+	}
+
+	logging.Infof("✅ [%s] 로또 %d장 구매 완료", account.Name, len(tickets))
+	result.Status = buyPurchased
+	if balance != nil {
+		left := balance.Deposit - amount
+		result.deposit = &left
+	}
+	result.Tickets = newTicketOutputs(purchased)
+	if len(purchased) > 0 {
+		result.Round = purchased[0].Round
+	}
+
+	// 7. Record purchases in the ledger and the spreadsheet
+	// 구매는 이미 완료되었으므로 기록에 실패해도 알림은 계속 진행
+	_, step = tracing.Start(ctx, "record")
+	records := toLedgerPurchases(account.Name, purchased, domain.Now())
+	if ledger != nil {
+		if err := ledger.SavePurchases(ctx, records); err != nil {
+			logging.Warnf("⚠️  [%s] 구매 기록 저장 실패: %v", account.Name, err)
+		}
+	}
+	if err := sheet.RecordPurchases(ctx, records); err != nil {
+		logging.Warnf("⚠️  [%s] %v", account.Name, err)
+	}
+	step.End(nil)
+
+	// 8. sendEmail
+	if err := notifyStep(ctx, "buy", func() error { return emailSender.SendLotteryBuyMail(purchased) }); err != nil {
+		return result, fmt.Errorf("구매 결과 이메일 전송 실패: %w", err)
+	}
+	logging.Infof("✉️  [%s] 구매 결과 이메일 전송 완료", account.Name)
+
+	return result, nil
+}
+
+// lockPurchase takes the purchase lock of account for the current round and
+// returns the function releasing it. When another run holds the lock, the
+// purchase is recorded as skipped and a nil function is returned; any other
+// lock failure is an error, as buying without the lock could buy twice.
+func lockPurchase(ctx context.Context, cfg config.LockConfig, account config.AccountConfig, tickets int, amount int64, trail *audit.Log, result *buyResult) (func(), error) {
+	_, span := tracing.Start(ctx, "lock")
+	locker, err := runlock.New(cfg)
+	var lock runlock.Lock
+	if err == nil {
+		lock, err = locker.TryLock(ctx, fmt.Sprintf("buy-%s-%d", account.Name, domain.RoundOn(domain.Now())))
+	}
+	span.End(err)
+	if errors.Is(err, runlock.ErrHeld) {
+		logging.Warnf("⚠️  [%s] 같은 회차를 구매 중인 다른 실행이 있어 구매를 건너뜁니다", account.Name)
+		result.Status = buySkipped
+		result.Reason = "다른 실행이 같은 회차를 구매 중입니다"
+		entry := purchaseEntry(account, audit.ResultSkipped, tickets, amount)
+		entry.Reason = result.Reason
+		if err := trail.Record(entry); err != nil {
+			logging.Warnf("⚠️  [%s] %v", account.Name, err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("구매 중단: %w", err)
+	}
+
+	return func() {
+		// 요청이 취소되어도 잠금은 풀어야 함
+		if err := lock.Unlock(context.WithoutCancel(ctx)); err != nil {
+			logging.Warnf("⚠️  [%s] 구매 잠금 해제 실패: %v", account.Name, err)
+		}
+	}, nil
+}
+
+// skipForTopUp skips a purchase the deposit cannot cover: it records the
+// skip, sends the top-up alert with the deposit account and returns an
+// error wrapping lottery.ErrInsufficientBalance, so the run exits with its
+// own code instead of failing at the purchase request.
+func skipForTopUp(ctx context.Context, account config.AccountConfig, balance *lottery.Balance, tickets int, amount int64, emailSender *notify.EmailSender, trail *audit.Log, result *buyResult) error {
+	shortage := fmt.Errorf("%w: 예치금 %s원, 구매 예정 %s원", lottery.ErrInsufficientBalance, utils.FormatAmount(balance.Deposit), utils.FormatAmount(amount))
+	logging.Warnf("⚠️  [%s] %v - 구매를 건너뜁니다", account.Name, shortage)
+	result.Status = buySkipped
+	result.Reason = shortage.Error()
+
+	entry := purchaseEntry(account, audit.ResultSkipped, tickets, amount)
+	entry.Reason = shortage.Error()
+	if err := trail.Record(entry); err != nil {
+		logging.Warnf("⚠️  [%s] %v", account.Name, err)
+	}
+
+	alert := &domain.TopUpAlert{
+		Account:        account.Name,
+		Deposit:        balance.Deposit,
+		Required:       amount,
+		VirtualAccount: balance.VirtualAccount,
+		CheckedAt:      domain.Now(),
+	}
+	if err := notifyStep(ctx, "topup", func() error { return emailSender.SendTopUpAlert(alert) }); err != nil {
+		return fmt.Errorf("%w (충전 알림 이메일 전송 실패: %w)", shortage, err)
+	}
+	logging.Infof("✉️  [%s] 충전 알림 이메일 전송 완료", account.Name)
+	return shortage
+}
+
+// purchaseEntry returns an audit entry for a purchase by account.
+func purchaseEntry(account config.AccountConfig, result string, tickets int, amount int64) audit.Entry {
+	return audit.Entry{Action: audit.ActionPurchase, Result: result, Account: account.Name, Tickets: tickets, Amount: amount}
+}
+
+// commandOperator tells whether a command-line purchase was started by hand
+// or by a GitHub Actions cron trigger.
+func commandOperator() (operator, source string) {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return audit.OperatorManual, "cli"
+	}
+	if os.Getenv("GITHUB_EVENT_NAME") == "schedule" {
+		return audit.OperatorScheduled, "github-actions"
+	}
+	return audit.OperatorManual, "github-actions"
+}
+
+// previewBuy logs and notifies what would be purchased without buying.
+func previewBuy(ctx context.Context, client *lottery.Client, account config.AccountConfig, tickets []*domain.Lotto645Ticket, emailSender *notify.EmailSender, result *buyResult) error {
+	_, span := tracing.Start(ctx, "preview", tracing.Int("tickets", len(tickets)))
+	preview, err := client.PreviewLotto645(ctx, tickets)
+	span.End(err)
+	if err != nil {
+		return fmt.Errorf("구매 미리보기 실패: %w", err)
+	}
+
+	result.Status = buyPreview
+	result.Round = preview.Round
+	result.Tickets = newTicketOutputs(preview.Tickets)
+
+	logging.Infof("🧪 [%s] dry-run: %d회 로또 %d장 (%s원) 구매 예정 - 실제 구매는 하지 않습니다",
+		account.Name, preview.Round, len(preview.Tickets), utils.FormatAmount(preview.Amount))
+	for _, ticket := range preview.Tickets {
+		numbers := "구매 시 자동 선택"
+		if len(ticket.Numbers) > 0 {
+			numbers = utils.FormatNumbers(ticket.Numbers)
+		}
+		logging.Infof("   슬롯 %s (%s): %s", ticket.Slot, ticket.Mode, numbers)
+	}
+	logging.Debugf("   구매 파라미터: %s", preview.Param)
+
+	if preview.Deposit < preview.Amount {
+		logging.Warnf("⚠️  [%s] 예치금 %s원이 구매 금액 %s원보다 적어 실제 구매는 실패합니다",
+			account.Name, utils.FormatAmount(preview.Deposit), utils.FormatAmount(preview.Amount))
+	}
+
+	if err := notifyStep(ctx, "preview", func() error { return emailSender.SendPurchasePreview(preview) }); err != nil {
+		return fmt.Errorf("구매 미리보기 이메일 전송 실패: %w", err)
+	}
+	logging.Infof("✉️  [%s] 구매 미리보기 이메일 전송 완료", account.Name)
+
+	return nil
+}
+
+// buildTickets converts the configured basket into purchasable tickets.
+func buildTickets(purchase config.PurchaseConfig) ([]*domain.Lotto645Ticket, error) {
+	tickets := make([]*domain.Lotto645Ticket, 0, len(purchase.Tickets))
+	for i, spec := range purchase.Tickets {
+		mode, err := domain.ParseLotto645Mode(spec.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 티켓: %w", i+1, err)
+		}
+
+		ticket, err := generator.NewTicket(mode, spec.Numbers, spec.Strategy)
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 티켓: %w", i+1, err)
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
+}
+
+// parseManualTicket parses a "--numbers" value into a validated manual ticket.
+func parseManualTicket(value string) (config.TicketConfig, error) {
+	var numbers []int
+	for _, part := range splitComma(value) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return config.TicketConfig{}, fmt.Errorf("번호는 숫자여야 합니다: %q", part)
+		}
+		numbers = append(numbers, n)
+	}
+
+	if err := domain.ValidateNumbers(domain.ModeManual, numbers); err != nil {
+		return config.TicketConfig{}, err
+	}
+	return config.TicketConfig{Mode: "manual", Numbers: numbers}, nil
+}
+
+// toLedgerPurchases converts purchased tickets into ledger rows.
+func toLedgerPurchases(account string, purchased []lottery.PurchasedTicket, at time.Time) []store.Purchase {
+	rows := make([]store.Purchase, 0, len(purchased))
+	for _, ticket := range purchased {
+		rows = append(rows, store.Purchase{
+			Account:     account,
+			Round:       ticket.Round,
+			Slot:        ticket.Slot,
+			Mode:        ticket.Mode,
+			Numbers:     ticket.Numbers,
+			Amount:      domain.TicketPrice,
+			PurchasedAt: at,
+		})
+	}
+	return rows
+}
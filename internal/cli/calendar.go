@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/ical"
+	"weekly-lotto/internal/logging"
+)
+
+const (
+	// calendarDraws is how many upcoming draws the calendar lists by default.
+	calendarDraws = 8
+	// calendarMaxDraws caps the draws of a served calendar.
+	calendarMaxDraws = 104
+	// calendarDrawLength is how long a draw event lasts.
+	calendarDrawLength = 15 * time.Minute
+	// calendarRefresh is how often subscribers are asked to fetch again.
+	calendarRefresh = 12 * time.Hour
+)
+
+// calendarClaimAlarms remind of the deadline of prizes claimed in person.
+var calendarClaimAlarms = []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+var calendarCommand = &command{
+	name:    "calendar",
+	usage:   "calendar [--output FILE] [--draws N] [flags]",
+	summary: "다가오는 추첨 일정과 당첨 티켓의 지급 기한을 iCalendar(.ics)로 출력합니다",
+	run:     runCalendar,
+}
+
+func runCalendar(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	output := fs.String("output", "-", "저장할 파일 경로 (-: stdout)")
+	draws := fs.Int("draws", calendarDraws, "넣을 다가오는 추첨 수")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *draws < 0 {
+		return usageError(fs, fmt.Errorf("--draws 는 0 이상이어야 합니다: %d", *draws))
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	data, err := renderCalendar(ctx, app, cfg, *draws)
+	if err != nil {
+		return err
+	}
+	if *output == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := writeFileAtomic(*output, data, 0o644); err != nil {
+		return fmt.Errorf("%s 쓰기 실패: %w", *output, err)
+	}
+	logging.Infof("📅 캘린더를 %s에 저장했습니다", *output)
+	return nil
+}
+
+// handleCalendar serves the calendar feed, with the number of upcoming
+// draws in the draws query parameter.
+func (s *server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	draws := calendarDraws
+	if r.URL.Query().Has("draws") {
+		n, err := queryInt(r, "draws")
+		if err == nil && (n < 0 || n > calendarMaxDraws) {
+			err = fmt.Errorf("draws 는 0~%d 사이여야 합니다: %d", calendarMaxDraws, n)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		draws = n
+	}
+
+	data, err := renderCalendar(r.Context(), s.app, s.cfg, draws)
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="weekly-lotto.ics"`)
+	if _, err := w.Write(data); err != nil {
+		logging.Warnf("⚠️  응답 쓰기 실패: %v", err)
+	}
+}
+
+// renderCalendar encodes the calendar of the next draws, with the claim
+// deadlines of the winning tickets in the ledger when one is configured.
+func renderCalendar(ctx context.Context, app *App, cfg *config.Config, draws int) ([]byte, error) {
+	var entries []historyEntry
+	if cfg.Store.Enabled() {
+		var err error
+		entries, err = localHistory(ctx, app, cfg, &historyFilter{})
+		if err != nil {
+			return nil, err
+		}
+		defer app.savePages(ctx)
+		results := app.drawResults(ctx)
+		defer results.close()
+		resolveResults(results, entries)
+	}
+	now := domain.Now()
+	return buildCalendar(now, entries, draws).Marshal(now), nil
+}
+
+// buildCalendar lists the next draws, noting the tickets bought for them,
+// and the claim deadlines of the winning tickets of resolved entries that
+// have not passed. Prizes that must be claimed in person get reminders a
+// month and a week ahead; the smaller ones are credited to the deposit.
+func buildCalendar(now time.Time, entries []historyEntry, draws int) *ical.Calendar {
+	calendar := &ical.Calendar{Name: "weekly-lotto", RefreshInterval: calendarRefresh}
+
+	accounts := make(map[string]bool)
+	tickets := make(map[int]map[string]int)
+	for _, entry := range entries {
+		accounts[entry.Account] = true
+		if tickets[entry.Round] == nil {
+			tickets[entry.Round] = make(map[string]int)
+		}
+		tickets[entry.Round][entry.Account]++
+	}
+
+	next, _ := domain.NextDraw(now)
+	for round := next; round < next+draws; round++ {
+		drawn := domain.DrawTime(round)
+		description := fmt.Sprintf("온라인 판매 마감 %s (KST)", domain.SalesClose(round).In(domain.Seoul).Format("15:04"))
+		for _, account := range slices.Sorted(maps.Keys(tickets[round])) {
+			if len(accounts) > 1 {
+				description += fmt.Sprintf("\n[%s] 구매 %d장", account, tickets[round][account])
+			} else {
+				description += fmt.Sprintf("\n구매 %d장", tickets[round][account])
+			}
+		}
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         fmt.Sprintf("draw-%d@weekly-lotto", round),
+			Summary:     fmt.Sprintf("🎰 로또 %d회 추첨", round),
+			Description: description,
+			Start:       drawn,
+			End:         drawn.Add(calendarDrawLength),
+		})
+	}
+
+	for _, entry := range entries {
+		deadline := domain.ClaimDeadline(entry.Round)
+		if !entry.drawn || entry.rank == domain.RankNone || deadline.Before(domain.StartOfDay(now)) {
+			continue
+		}
+		method := domain.ClaimMethodFor(entry.rank, entry.Prize)
+		summary := fmt.Sprintf("🏆 %d회 %s 당첨금 지급 기한", entry.Round, entry.rank.String())
+		if len(accounts) > 1 {
+			summary += fmt.Sprintf(" [%s]", entry.Account)
+		}
+		numbers := make([]string, len(entry.Numbers))
+		for i, n := range entry.Numbers {
+			numbers[i] = fmt.Sprintf("%d", n)
+		}
+		event := ical.Event{
+			UID:     fmt.Sprintf("claim-%d-%s-%s@weekly-lotto", entry.Round, mqttID(entry.Account), mqttID(entry.Slot)),
+			Summary: summary,
+			Description: fmt.Sprintf("%s 슬롯 %s\n당첨금 %s원\n%s",
+				entry.Slot, strings.Join(numbers, " "), utils.FormatAmount(entry.Prize), method),
+			Start:  deadline,
+			AllDay: true,
+		}
+		if method != domain.ClaimAutoDeposit {
+			event.Alarms = calendarClaimAlarms
+		}
+		calendar.Events = append(calendar.Events, event)
+	}
+	return calendar
+}
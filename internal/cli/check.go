@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/notify"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/sheets"
+	"weekly-lotto/internal/store"
+	"weekly-lotto/internal/tracing"
+)
+
+const purchaseHistoryDays = 7
+
+// Polling intervals of check --wait, doubled after every attempt.
+const (
+	drawPollInitial = 30 * time.Second
+	drawPollMax     = 5 * time.Minute
+)
+
+var checkCommand = &command{
+	name:    "check",
+	usage:   "check [--wait 1h] [flags]",
+	summary: "최신 회차 당첨 여부를 계정별로 확인하고 결과를 이메일로 전송합니다",
+	run:     runCheck,
+}
+
+func runCheck(ctx context.Context, app *App, args []string) (err error) {
+	fs := app.flags()
+	wait := fs.Duration("wait", 0, "이번 주 회차 당첨 번호가 아직 발표되지 않았으면 발표될 때까지 이 시간 동안 간격을 늘려가며 다시 조회 (예: 1h, 0: 기다리지 않음)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *wait < 0 {
+		return usageError(fs, fmt.Errorf("--wait 는 0 이상이어야 합니다: %s", *wait))
+	}
+	app.deadline.extend(*wait)
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	started := time.Now()
+	var results []*checkResult
+	done := monitor(ctx, cfg.Healthchecks.Check)
+	defer func() {
+		done(err)
+		pushRun(ctx, cfg.Pushgateway, "check", started, err, checkMetrics(results))
+		publishCheck(ctx, cfg, results)
+		writeSite(ctx, app, cfg)
+	}()
+
+	emailSender := app.EmailSender(cfg)
+	sheet, err := app.Sheet(cfg)
+	if err != nil {
+		return err
+	}
+	ledger, err := app.OpenStore(cfg)
+	if err != nil {
+		return err
+	}
+	if ledger != nil {
+		defer ledger.Close()
+	}
+
+	ctx, span := tracing.Start(ctx, Program+" check")
+	var errs []error
+	results = make([]*checkResult, 0, len(cfg.LotteryAccounts()))
+	for _, account := range cfg.LotteryAccounts() {
+		result, err := check(ctx, ledger, account, app.archived(cfg, login), emailSender.ForAccount(account.Name), sheet, *wait)
+		if err != nil {
+			logging.Errorf("❌ [%s] %v", account.Name, err)
+			result.Error = sanitize.Error(err)
+			errs = append(errs, accountFailed(account.Name, err))
+		}
+		results = append(results, result)
+	}
+	failed := accountsFailed("당첨 확인이 실패했습니다", errs)
+	span.End(failed)
+	remindRotation(ctx, cfg, ledger, emailSender)
+	writeStepSummary(checkStepSummary(results))
+
+	if app.jsonOutput() {
+		if err := writeJSON(results); err != nil {
+			return err
+		}
+	}
+
+	return failed
+}
+
+// checkResult is the outcome of checking a single account.
+type checkResult struct {
+	Account  string         `json:"account"`
+	Round    int            `json:"round,omitempty"`
+	Numbers  []int          `json:"numbers,omitempty"`
+	Bonus    int            `json:"bonus,omitempty"`
+	Tickets  []ticketOutput `json:"tickets,omitempty"`
+	Winnings int64          `json:"winnings"`
+	Warnings []string       `json:"warnings,omitempty"`
+	Error    string         `json:"error,omitempty"`
+
+	// winning is the draw the tickets were checked against, for the prize
+	// table of the GitHub Actions job summary.
+	winning *domain.WinningNumbers
+}
+
+// check compares the latest draw with the purchases of a single account,
+// using the lottery session obtained from login. The purchases read from the
+// site are synced into ledger and results are appended to sheet when they
+// are not nil. With a positive wait, the draw of this week is awaited for up
+// to wait instead of checking the latest published one (see waitForDraw).
+// The returned result is never nil, even when err is set.
+func check(ctx context.Context, ledger store.Store, account config.AccountConfig, login loginFunc, emailSender *notify.EmailSender, sheet *sheets.Sheet, wait time.Duration) (result *checkResult, err error) {
+	result = &checkResult{Account: account.Name}
+	ctx, span := tracing.Start(ctx, "check", tracing.String("account", account.Name))
+	defer func() {
+		if result.Round > 0 {
+			span.SetAttr(tracing.Int("round", result.Round), tracing.Int("tickets", len(result.Tickets)))
+		}
+		span.End(err)
+	}()
+
+	// 1. Create lottery client (auto login)
+	_, step := tracing.Start(ctx, "login")
+	client, err := login(ctx, account)
+	step.End(err)
+	if err != nil {
+		return result, fmt.Errorf("로그인 실패: %w", err)
+	}
+	// 2. Load purchased numbers from lottery purchase history while the
+	// winning numbers are fetched; neither depends on the other.
+	var (
+		purchases   []lottery.PurchaseHistory
+		historyErr  error
+		historyDone = make(chan struct{})
+	)
+	go func() {
+		defer close(historyDone)
+		_, step := tracing.Start(ctx, "history", tracing.Int("days", purchaseHistoryDays))
+		purchases, historyErr = client.GetRecentPurchases(ctx, purchaseHistoryDays)
+		step.SetAttr(tracing.Int("purchases", len(purchases)))
+		step.End(historyErr)
+	}()
+
+	// 3. Get winning numbers
+	_, step = tracing.Start(ctx, "round")
+	var winning *domain.WinningNumbers
+	if wait > 0 {
+		winning, err = waitForDraw(ctx, client, domain.LatestDrawRound(domain.Now()), wait)
+	} else {
+		winning, err = client.GetWinningNumbers(ctx)
+	}
+	step.End(err)
+	<-historyDone
+	if err != nil {
+		return result, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+	result.Round = winning.Round
+	result.Numbers = winning.Numbers
+	result.Bonus = winning.BonusNumber
+	result.winning = winning
+
+	// 일부 구매의 상세 조회만 실패했으면 읽은 구매로 확인하고 메일에 알림
+	var partial *lottery.PartialPurchasesError
+	if errors.As(historyErr, &partial) {
+		logging.Warnf("⚠️  [%s] %v - 나머지 구매 내역으로 확인합니다", account.Name, partial)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("최근 %d일 구매 %d건 중 %d건의 상세 내역을 읽지 못해 결과에서 빠졌습니다. 동행복권 사이트에서 직접 확인해 주세요.", purchaseHistoryDays, partial.Total, len(partial.Failed)))
+		historyErr = nil
+	}
+	if historyErr != nil {
+		return result, fmt.Errorf("구매 내역 조회 실패: %w", historyErr)
+	}
+	if ledger != nil {
+		// 주문번호 기준으로 반영하므로 다시 확인해도 중복 기록되지 않음
+		if err := ledger.SavePurchases(ctx, sitePurchases(account.Name, purchases, domain.Now())); err != nil {
+			logging.Warnf("⚠️  [%s] 구매 내역 동기화 실패: %v", account.Name, err)
+		}
+	}
+
+	var purchased []lottery.PurchasedTicket
+	for _, purchase := range purchases {
+		if purchase.Round == winning.Round {
+			purchased = append(purchased, purchase.Tickets...)
+		}
+	}
+
+	if len(purchased) == 0 && partial != nil {
+		// 읽지 못한 구매에 이번 회차가 있었을 수 있으므로 구매 없음으로 보지 않음
+		return result, fmt.Errorf("%d회차 구매 내역 확인 실패: %w", winning.Round, partial)
+	}
+	if len(purchased) == 0 {
+		return result, fmt.Errorf("%d회차 %w (최근 %d일 조회)", winning.Round, lottery.ErrNoPurchases, purchaseHistoryDays)
+	}
+
+	// 4. Check each ticket and build summary
+	_, step = tracing.Start(ctx, "evaluate", tracing.Int("tickets", len(purchased)))
+	summary := domain.NewCheckSummary(winning)
+	summary.Warnings = result.Warnings
+	for _, ticket := range purchased {
+		rank, amount := prize(ticket.Numbers, winning)
+		summary.AddTicket(domain.NewTicketResult(ticket.Slot, ticket.Mode, ticket.Numbers, rank, amount))
+		result.Tickets = append(result.Tickets, ticketOutput{
+			Slot:    ticket.Slot,
+			Mode:    ticket.Mode,
+			Numbers: ticket.Numbers,
+			Result:  rank.String(),
+			Rank:    rank.Number(),
+			Prize:   amount,
+		})
+		result.Winnings += amount
+	}
+	step.End(nil)
+
+	if err := sheet.RecordResults(ctx, account.Name, summary, domain.Now()); err != nil {
+		logging.Warnf("⚠️  [%s] %v", account.Name, err)
+	}
+
+	if err := notifyStep(ctx, "check", func() error { return emailSender.SendLotteryCheckResultMail(summary) }); err != nil {
+		return result, fmt.Errorf("이메일 전송 실패: %w", err)
+	}
+	logging.Infof("✉️  [%s] 결과 이메일 전송 완료", account.Name)
+
+	return result, nil
+}
+
+// waitForDraw polls the winning numbers of round until they are published,
+// backing off from drawPollInitial to drawPollMax between attempts, and gives
+// up with lottery.ErrNotDrawn once wait has passed. Results appear some time
+// after the 20:35 KST draw, so a check started right after it would
+// otherwise compare the tickets with the previous round.
+func waitForDraw(ctx context.Context, client *lottery.Client, round int, wait time.Duration) (*domain.WinningNumbers, error) {
+	deadline := time.Now().Add(wait)
+	delay := drawPollInitial
+	for {
+		winning, err := client.GetWinningNumbersByRound(ctx, round)
+		if !errors.Is(err, lottery.ErrNotDrawn) {
+			return winning, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("%s 동안 기다렸지만 %w", wait, err)
+		}
+		delay = min(delay, remaining)
+		logging.Infof("⏳ %d회 당첨 번호가 아직 발표되지 않았습니다 - %s 후 다시 확인합니다", round, delay.Round(time.Second))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, drawPollMax)
+	}
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sanitize"
+)
+
+var claimCommand = &command{
+	name:    "claim",
+	usage:   "claim [--account NAME] [flags]",
+	summary: "지급 기한 내 당첨 티켓을 찾아 예치금 자동 지급분과 방문 수령이 필요한 당첨금을 구분해 출력합니다",
+	run:     runClaim,
+}
+
+// claimReport lists the winning tickets of an account that are still
+// within the claim period.
+type claimReport struct {
+	Account        string       `json:"account"`
+	UnclaimedPrize int64        `json:"unclaimedPrize"`
+	Credited       int64        `json:"credited"`
+	InPerson       int64        `json:"inPerson"`
+	Prizes         []claimEntry `json:"prizes"`
+	Error          string       `json:"error,omitempty"`
+}
+
+// claimEntry is a single winning ticket and how it is paid out.
+type claimEntry struct {
+	Round    int       `json:"round"`
+	Slot     string    `json:"slot"`
+	Numbers  []int     `json:"numbers"`
+	Rank     int       `json:"rank"`
+	Prize    int64     `json:"prize"`
+	Method   string    `json:"method"`
+	InPerson bool      `json:"inPerson"`
+	Deadline time.Time `json:"deadline"`
+
+	rank    domain.WinningRank
+	winning *domain.WinningNumbers
+}
+
+func runClaim(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	account := fs.String("account", "", "계정 이름으로 필터링")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	draws := app.drawResults(ctx)
+	defer draws.close()
+
+	var errs []error
+	reports := []*claimReport{}
+	for _, acc := range cfg.LotteryAccounts() {
+		if *account != "" && acc.Name != *account {
+			continue
+		}
+
+		report, err := claim(ctx, acc, draws)
+		if err != nil {
+			logging.Errorf("❌ [%s] %v", acc.Name, err)
+			report.Error = sanitize.Error(err)
+			errs = append(errs, accountFailed(acc.Name, err))
+		}
+		reports = append(reports, report)
+		if err == nil && !app.jsonOutput() {
+			if err := report.write(app.palette()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if app.jsonOutput() {
+		if err := writeJSON(reports); err != nil {
+			return err
+		}
+	}
+
+	return accountsFailed("당첨금 조회가 실패했습니다", errs)
+}
+
+// claim looks up the winning tickets of a single account drawn within the
+// claim period. The site credits prizes up to domain.OnlinePayoutLimit to
+// the deposit by itself and offers no endpoint to request payment, so the
+// larger ones are only reported as needing a bank visit.
+// The returned report is never nil, even when err is set.
+func claim(ctx context.Context, account config.AccountConfig, draws *drawResults) (*claimReport, error) {
+	report := &claimReport{Account: account.Name, Prizes: []claimEntry{}}
+
+	client, err := lottery.NewClient(ctx, account.Username, account.Password)
+	if err != nil {
+		return report, fmt.Errorf("로그인 실패: %w", err)
+	}
+
+	money, err := client.GetBalance(ctx)
+	if err != nil {
+		return report, fmt.Errorf("예치금 조회 실패: %w", err)
+	}
+	report.UnclaimedPrize = money.UnclaimedPrize
+
+	now := domain.Now()
+	histories, err := client.GetPurchases(ctx, now.AddDate(-1, 0, -7), now)
+	if err != nil && !errors.Is(err, lottery.ErrNoPurchases) {
+		return report, fmt.Errorf("구매 내역 조회 실패: %w", err)
+	}
+
+	var entries []historyEntry
+	for _, history := range histories {
+		for _, ticket := range history.Tickets {
+			entries = append(entries, historyEntry{
+				Account: account.Name,
+				Round:   history.Round,
+				Slot:    ticket.Slot,
+				Numbers: ticket.Numbers,
+			})
+		}
+	}
+	resolveResults(draws, entries)
+
+	for _, entry := range entries {
+		deadline := domain.ClaimDeadline(entry.Round)
+		if !entry.drawn || entry.rank == domain.RankNone || deadline.Before(now) {
+			continue
+		}
+
+		method := domain.ClaimMethodFor(entry.rank, entry.Prize)
+		inPerson := method != domain.ClaimAutoDeposit
+		if inPerson {
+			report.InPerson += entry.Prize
+		} else {
+			report.Credited += entry.Prize
+		}
+		report.Prizes = append(report.Prizes, claimEntry{
+			Round:    entry.Round,
+			Slot:     entry.Slot,
+			Numbers:  entry.Numbers,
+			Rank:     entry.Rank,
+			Prize:    entry.Prize,
+			Method:   method.String(),
+			InPerson: inPerson,
+			Deadline: deadline,
+			rank:     entry.rank,
+			winning:  entry.winning,
+		})
+	}
+
+	return report, nil
+}
+
+// write prints the report as a table on stdout.
+func (r *claimReport) write(colors palette) error {
+	fmt.Printf("🏆 [%s] 미수령 당첨금: %s원\n", r.Account, utils.FormatAmount(r.UnclaimedPrize))
+	if len(r.Prizes) == 0 {
+		fmt.Println("지급 기한 내 당첨 티켓이 없습니다")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "회차\t슬롯\t%s\t등수\t당첨금\t지급 방법\t지급 기한\n", colors.header("번호", domain.NumbersPerTicket))
+	for _, prize := range r.Prizes {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d등\t%s원\t%s\t%s\n",
+			prize.Round, prize.Slot, colors.balls(prize.Numbers, prize.winning, prize.rank), prize.Rank,
+			utils.FormatAmount(prize.Prize), prize.Method, prize.Deadline.Format("2006-01-02"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("- 예치금 자동 지급: %s원\n", utils.FormatAmount(r.Credited))
+	if r.InPerson > 0 {
+		fmt.Printf("- 방문 수령 필요: %s원 (신분증, 당첨 내역을 지참해 지급 기한 내 방문하세요)\n", utils.FormatAmount(r.InPerson))
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+// Package cli implements the weekly-lotto command-line interface.
+//
+// Every subcommand shares the same configuration loading (file < profile <
+// env < flags), logging and notification wiring through App.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/fault"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/lottery/cassette"
+	"weekly-lotto/internal/notify"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/sheets"
+	"weekly-lotto/internal/store"
+	"weekly-lotto/internal/tracing"
+)
+
+// Program is the name of the CLI binary.
+const Program = "weekly-lotto"
+
+// command is a single weekly-lotto subcommand.
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(ctx context.Context, app *App, args []string) error
+	// longRunning commands serve until stopped, so the run timeout does
+	// not apply to them.
+	longRunning bool
+}
+
+// commands lists the subcommands in the order they are shown in usage.
+var commands []*command
+
+func init() {
+	commands = []*command{
+		buyCommand,
+		checkCommand,
+		balanceCommand,
+		claimCommand,
+		historyCommand,
+		statsCommand,
+		reportCommand,
+		exportCommand,
+		siteCommand,
+		calendarCommand,
+		winningCommand,
+		simulateCommand,
+		numbersCommand,
+		backfillCommand,
+		pruneCommand,
+		backupCommand,
+		archiveCommand,
+		restoreCommand,
+		auditCommand,
+		failureCommand,
+		loginCommand,
+		serveCommand,
+		exporterCommand,
+		scheduleCommand,
+		botCommand,
+		mcpCommand,
+		doctorCommand,
+		notifyTestCommand,
+		mockSiteCommand,
+		configCommand,
+		selfUpdateCommand,
+	}
+}
+
+// errUsage signals that usage has already been printed for a bad invocation.
+var errUsage = errors.New("잘못된 사용법")
+
+// parseError converts a flag parsing error into errUsage; the flag package
+// has already printed the problem and the usage.
+func parseError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", errUsage, err)
+}
+
+// App carries state shared by every subcommand.
+type App struct {
+	overrides *config.Overrides
+	cfg       *config.Config
+	cmd       *command
+	format    string
+	noColor   bool
+	stderr    io.Writer
+	// cassette is the --record-http or --replay-http file, if any.
+	cassette string
+	// now is the LOTTO_NOW moment, until simulateNow applies it to the
+	// clock or Config drops it.
+	now time.Time
+	// ctx is the context of the running command, which notifications are
+	// sent with.
+	ctx context.Context
+	// deadline enforces the run timeout of one-shot commands.
+	deadline *runDeadline
+
+	// storeUsed is set once the store has been opened, so the run ends
+	// with a backup.
+	storeUsed atomic.Bool
+	// archive holds pages recorded by lottery clients until they are saved.
+	archive pageArchive
+}
+
+// Output formats accepted by --format.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// Run parses args (without the program name), dispatches to the matching
+// subcommand and returns the process exit code.
+func Run(ctx context.Context, args []string) int {
+	parent := ctx
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	app := &App{overrides: &config.Overrides{}, format: formatTable, stderr: os.Stderr, ctx: ctx, deadline: newRunDeadline(cancel)}
+
+	if format := os.Getenv("LOTTO_LOG_FORMAT"); format != "" {
+		if err := logging.SetFormat(format); err != nil {
+			fmt.Fprintf(app.stderr, "LOTTO_LOG_FORMAT: %v\n", err)
+			return ExitUsage
+		}
+	}
+	if level := os.Getenv("LOTTO_LOG_LEVEL"); level != "" {
+		if err := setLogLevel(level); err != nil {
+			fmt.Fprintf(app.stderr, "LOTTO_LOG_LEVEL: %v\n", err)
+			return ExitUsage
+		}
+	}
+
+	if now := os.Getenv("LOTTO_NOW"); now != "" {
+		t, err := domain.ParseClockTime(now)
+		if err != nil {
+			fmt.Fprintf(app.stderr, "LOTTO_NOW: %v\n", err)
+			return ExitUsage
+		}
+		app.now = t
+	}
+	if base := os.Getenv("LOTTO_DHLOTTERY_URL"); base != "" {
+		if err := lottery.SetBaseURL(base); err != nil {
+			fmt.Fprintf(app.stderr, "LOTTO_DHLOTTERY_URL: %v\n", err)
+			return ExitUsage
+		}
+		logging.Warnf("⚠️  LOTTO_DHLOTTERY_URL 설정됨 - 동행복권 사이트 대신 %s 로 요청합니다", base)
+		app.simulateNow()
+	}
+	if faults := os.Getenv("LOTTO_FAULTS"); faults != "" {
+		if err := fault.Configure(faults); err != nil {
+			fmt.Fprintf(app.stderr, "LOTTO_FAULTS: %v\n", err)
+			return ExitUsage
+		}
+		lottery.Use(fault.Middleware)
+		logging.Warnf("⚠️  LOTTO_FAULTS 설정됨 - 장애를 주입합니다: %s", faults)
+	}
+	if value := os.Getenv("LOTTO_TIMEOUT"); value != "" {
+		timeout, err := parseTimeout(value)
+		if err != nil {
+			fmt.Fprintf(app.stderr, "LOTTO_TIMEOUT: %v\n", err)
+			return ExitUsage
+		}
+		app.deadline.setTimeout(timeout)
+	}
+
+	global := flag.NewFlagSet(Program, flag.ContinueOnError)
+	global.SetOutput(app.stderr)
+	app.bindCommon(global)
+	global.Usage = app.usage
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	if global.NArg() == 0 {
+		app.usage()
+		return ExitUsage
+	}
+
+	name := global.Arg(0)
+	cmd := lookupCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(app.stderr, "알 수 없는 명령입니다: %s\n\n", name)
+		app.usage()
+		return ExitUsage
+	}
+
+	app.cmd = cmd
+	if cmd == mockSiteCommand {
+		app.simulateNow()
+	}
+	if !cmd.longRunning {
+		// 한 번 실행하는 명령은 제한 시간이 지나면 진행 중인 요청까지 취소
+		app.deadline.arm()
+	}
+	err := cmd.run(ctx, app, global.Args()[1:])
+	app.deadline.stop()
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	if errors.Is(err, errUsage) {
+		return ExitUsage
+	}
+	if cause := context.Cause(ctx); err != nil && errors.Is(cause, errRunTimeout) {
+		err = fmt.Errorf("%w: %w", cause, err)
+	}
+	// 제한 시간이 지났어도 마무리 작업은 진행
+	ctx, app.ctx = parent, parent
+	// 실패한 실행도 그 전까지 저장한 기록은 백업
+	app.savePages(ctx)
+	app.backupStore(ctx)
+	app.flushTraces(ctx)
+	if err != nil {
+		logging.Errorf("❌ %v", err)
+		app.reportFailures(ctx, cmd.name, err)
+		return exitCode(err)
+	}
+	return ExitOK
+}
+
+func lookupCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func (a *App) usage() {
+	fmt.Fprintf(a.stderr, "사용법: %s [flags] <명령> [명령 flags] [인자]\n\n명령:\n", Program)
+	for _, cmd := range commands {
+		fmt.Fprintf(a.stderr, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(a.stderr, "\n공통 flags (명령 뒤에도 사용 가능):\n")
+	global := flag.NewFlagSet(Program, flag.ContinueOnError)
+	global.SetOutput(a.stderr)
+	(&App{overrides: &config.Overrides{}}).bindCommon(global)
+	global.PrintDefaults()
+}
+
+// flags returns a flag set for the running subcommand with the config override
+// flags bound, so "weekly-lotto buy --tickets=3" works as well as
+// "weekly-lotto --tickets=3 buy".
+func (a *App) flags() *flag.FlagSet {
+	cmd := a.cmd
+	fs := flag.NewFlagSet(Program+" "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	a.bindCommon(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "사용법: %s %s\n\n%s\n\nflags:\n", Program, cmd.usage, cmd.summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// bindCommon registers the flags shared by every command on fs.
+func (a *App) bindCommon(fs *flag.FlagSet) {
+	a.overrides.Bind(fs)
+	fs.Func("format", "출력 형식 (table, json). json이면 결과를 stdout에 JSON으로 출력하고 로그는 stderr로 보냄", func(value string) error {
+		if value != formatTable && value != formatJSON {
+			return fmt.Errorf("알 수 없는 출력 형식입니다: %s (table, json)", value)
+		}
+		a.format = value
+		return nil
+	})
+	fs.Func("log-level", fmt.Sprintf("로그 레벨 (%s). 기본: info 또는 LOTTO_LOG_LEVEL", strings.Join(logging.Levels(), ", ")), setLogLevel)
+	fs.Func("log-format", fmt.Sprintf("로그 형식 (%s). json이면 Loki/CloudWatch 등에서 바로 수집할 수 있게 한 줄에 JSON 객체 하나로 출력. 기본: text 또는 LOTTO_LOG_FORMAT", strings.Join(logging.Formats(), ", ")), logging.SetFormat)
+	fs.BoolFunc("verbose", "디버그 로그까지 출력 (--log-level debug와 동일)", func(string) error {
+		return setLogLevel(logging.LevelDebug)
+	})
+	fs.BoolFunc("quiet", "경고와 오류만 출력 (--log-level warn과 동일)", func(string) error {
+		return setLogLevel(logging.LevelWarn)
+	})
+	fs.BoolFunc("trace-http", "동행복권 사이트로 보내는 모든 요청의 메서드, URL, 상태, 소요 시간과 요청/응답 본문 앞부분을 로그로 출력 (민감 정보는 가림)", func(value string) error {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		lottery.SetTraceHTTP(enabled)
+		return nil
+	})
+	fs.Func("record-http", "동행복권 사이트와 주고받은 요청/응답을 민감 정보를 가려 카세트 파일(JSON)에 녹화 (파일이 있으면 이어서 녹화)", func(path string) error {
+		return a.useCassette(path, true)
+	})
+	fs.Func("replay-http", "동행복권 사이트에 접속하지 않고 --record-http로 녹화한 카세트 파일의 응답을 재생", func(path string) error {
+		return a.useCassette(path, false)
+	})
+	fs.Func("timeout", fmt.Sprintf("명령 전체 제한 시간 - 지나면 진행 중인 사이트 요청과 이메일 전송을 취소하고 실패 (기본: %s 또는 LOTTO_TIMEOUT, 0: 제한 없음, serve/schedule 등 계속 실행하는 명령은 제외)", defaultRunTimeout), func(value string) error {
+		timeout, err := parseTimeout(value)
+		if err != nil {
+			return err
+		}
+		a.deadline.setTimeout(timeout)
+		return nil
+	})
+	fs.BoolVar(&a.noColor, "no-color", false, "번호를 색상 공으로 표시하지 않음 (NO_COLOR 환경 변수와 동일, 터미널이 아니면 자동으로 끔)")
+}
+
+// useCassette records the site interactions of the run into the cassette
+// at path, or replays them from it.
+func (a *App) useCassette(path string, record bool) error {
+	if a.cassette != "" {
+		return fmt.Errorf("--record-http 와 --replay-http 는 둘 중 하나만, 한 번만 쓸 수 있습니다")
+	}
+	a.cassette = path
+	if record {
+		c, err := cassette.Open(path)
+		if err != nil {
+			return err
+		}
+		lottery.Use(c.Record)
+		return nil
+	}
+	c, err := cassette.Load(path)
+	if err != nil {
+		return err
+	}
+	lottery.Use(c.Replay)
+	a.simulateNow()
+	return nil
+}
+
+// simulateNow starts the clock at LOTTO_NOW, if set. It is only called once
+// the run is known not to reach the real site - it talks to a mock site
+// (LOTTO_DHLOTTERY_URL), replays a cassette or is the mock site itself - so
+// a stray LOTTO_NOW cannot shift the round or the search windows of real
+// purchases.
+func (a *App) simulateNow() {
+	if a.now.IsZero() {
+		return
+	}
+	domain.SetClock(domain.StartingAt(a.now))
+	logging.Warnf("⚠️  LOTTO_NOW 설정됨 - 현재 시각을 %s 로 간주합니다", a.now.In(domain.Seoul).Format("2006-01-02 15:04:05 MST"))
+	a.now = time.Time{}
+}
+
+// setLogLevel applies a --log-level value.
+func setLogLevel(name string) error {
+	level, err := logging.ParseLevel(name)
+	if err != nil {
+		return err
+	}
+	logging.SetLevel(level)
+	return nil
+}
+
+// jsonOutput reports whether results should be printed as JSON.
+func (a *App) jsonOutput() bool {
+	return a.format == formatJSON
+}
+
+// Config loads and validates the configuration once per invocation.
+func (a *App) Config() (*config.Config, error) {
+	if a.cfg != nil {
+		return a.cfg, nil
+	}
+	// 명령 플래그까지 읽은 뒤에도 남아 있으면 실제 사이트와 통신하는 실행
+	if !a.now.IsZero() {
+		logging.Warnf("⚠️  LOTTO_NOW는 LOTTO_DHLOTTERY_URL, --replay-http 또는 mock-site와 함께 쓸 때만 적용됩니다 - 실제 시각을 사용합니다")
+		a.now = time.Time{}
+	}
+	cfg, err := config.LoadWith(a.overrides)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errConfig, err)
+	}
+	a.useConfig(cfg)
+	return cfg, nil
+}
+
+// useConfig makes cfg the configuration of the invocation and applies its
+// process-wide settings: the secrets to mask, the pinned keys and tracing.
+// The schedule daemon calls it again for every reload.
+func (a *App) useConfig(cfg *config.Config) {
+	a.cfg = cfg
+	sanitize.AddSecrets(cfg.Secrets()...)
+	pins, _ := cfg.TLS.PinHashes() // LoadWith에서 검증됨
+	lottery.SetPins(pins)
+	tracing.Configure(cfg.Tracing, Version)
+}
+
+// EmailSender returns the notification sender for cfg, whose deliveries
+// end with the running command. With LOTTO_FAULTS set, its deliveries fail
+// at the notify fault point.
+func (a *App) EmailSender(cfg *config.Config) *notify.EmailSender {
+	sender := notify.NewEmailSender(&cfg.Email, &cfg.Notifications)
+	if a.ctx != nil {
+		sender = sender.WithContext(a.ctx)
+	}
+	if fault.Enabled() {
+		sender = sender.WithTransport(fault.Transport(notify.NewSMTPTransport(&cfg.Email)))
+	}
+	return sender
+}
+
+// Sheet returns the Google Sheets sync of cfg, or nil when it is disabled.
+func (a *App) Sheet(cfg *config.Config) (*sheets.Sheet, error) {
+	sheet, err := sheets.New(cfg.Sheets)
+	if err != nil {
+		return nil, fmt.Errorf("Google Sheets 설정 오류: %w", err)
+	}
+	return sheet, nil
+}
+
+// OpenStore opens the purchase ledger. It returns a nil store when no store
+// path or DSN is configured; callers must close a non-nil store.
+func (a *App) OpenStore(cfg *config.Config) (store.Store, error) {
+	if !cfg.Store.Enabled() {
+		return nil, nil
+	}
+	key, err := cfg.Store.Key()
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := store.Open(cfg.Store.Driver, cfg.Store.Location(), key)
+	if err != nil {
+		return nil, fmt.Errorf("저장소 열기 실패: %w", err)
+	}
+	a.storeUsed.Store(true)
+	return ledger, nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/lottery"
+)
+
+func TestLottoNowOnlyMovesTheClockOffTheRealSite(t *testing.T) {
+	wall := time.Date(2026, 10, 14, 12, 0, 0, 0, domain.Seoul)
+	simulated := time.Date(2026, 10, 17, 19, 59, 0, 0, domain.Seoul)
+	numbers := []string{"numbers", "--count", "1", "--seed", "1"}
+
+	tests := []struct {
+		name    string
+		baseURL string
+		args    []string
+		want    time.Time
+	}{
+		{name: "real site", args: numbers, want: wall},
+		{name: "mock site", baseURL: "http://127.0.0.1:8645", args: numbers, want: simulated},
+		{name: "replayed cassette", args: append([]string{"--replay-http", "../lottery/testdata/check.json"}, numbers...), want: simulated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(domain.SetClock(domain.FixedClock(wall)))
+			t.Cleanup(func() { lottery.SetBaseURL("") })
+			t.Setenv("LOTTO_NOW", "2026-10-17 19:59")
+			t.Setenv("LOTTO_DHLOTTERY_URL", tt.baseURL)
+
+			if code := Run(context.Background(), tt.args); code != ExitOK {
+				t.Fatalf("Run(%v) = %d, want %d", tt.args, code, ExitOK)
+			}
+			if got := domain.Now(); got.Sub(tt.want).Abs() > time.Minute {
+				t.Errorf("clock after the run = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape codes used to draw lotto balls.
+const (
+	ansiReset = "\x1b[0m"
+
+	// Every ball code has the same byte length so tabwriter, which counts
+	// escape bytes as text, still lines up colored columns.
+	ballFormat    = "\x1b[30;%dm" // black number on the band color
+	missingFormat = "\x1b[22;%dm" // band-colored number without background
+)
+
+// ballBand is the background color code of n, following the dhlottery
+// color bands: 1~10 yellow, 11~20 blue, 21~30 red, 31~40 gray, 41~45 green.
+func ballBand(n int) int {
+	switch {
+	case n <= 10:
+		return 43
+	case n <= 20:
+		return 44
+	case n <= 30:
+		return 41
+	case n <= 40:
+		return 47
+	default:
+		return 42
+	}
+}
+
+// palette renders numbers for terminal output. A disabled palette prints
+// plain comma-separated numbers.
+type palette struct {
+	enabled bool
+}
+
+// palette reports how numbers are rendered on stdout: colored unless
+// --no-color or NO_COLOR is set, the output is JSON or stdout is not a terminal.
+func (a *App) palette() palette {
+	if a.noColor || os.Getenv("NO_COLOR") != "" || a.jsonOutput() {
+		return palette{}
+	}
+	return palette{enabled: term.IsTerminal(int(os.Stdout.Fd()))}
+}
+
+// ball renders a single number. Dimmed balls keep the band color on the
+// number only, so highlighted ones stand out.
+func (p palette) ball(n int, dimmed bool) string {
+	if !p.enabled {
+		return fmt.Sprintf("%d", n)
+	}
+	if dimmed {
+		return fmt.Sprintf(missingFormat, ballBand(n)-10) + fmt.Sprintf("%2d", n) + ansiReset
+	}
+	return fmt.Sprintf(ballFormat, ballBand(n)) + fmt.Sprintf("%2d", n) + ansiReset
+}
+
+// balls renders numbers as balls. When winning is set, numbers that do not
+// count toward rank are dimmed; the bonus number only counts for 2nd prize.
+func (p palette) balls(numbers []int, winning *domain.WinningNumbers, rank domain.WinningRank) string {
+	if !p.enabled {
+		return utils.FormatNumbers(numbers)
+	}
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		matched := winning == nil || slices.Contains(winning.Numbers, n) ||
+			(rank == domain.Rank2 && n == winning.BonusNumber)
+		parts[i] = p.ball(n, !matched)
+	}
+	return strings.Join(parts, " ")
+}
+
+// header pads a table title for a column of n colored balls with invisible
+// reset codes, so tabwriter sees the same escape bytes as in the rows.
+func (p palette) header(title string, n int) string {
+	if !p.enabled {
+		return title
+	}
+	ballEscapes := len(fmt.Sprintf(ballFormat, ballBand(1))) + len(ansiReset)
+	return title + strings.Repeat(ansiReset, n*ballEscapes/len(ansiReset))
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/logging"
+)
+
+var configCommand = &command{
+	name:    "config",
+	usage:   "config [flags] show|schema|init",
+	summary: "적용될 설정을 출력(show)하거나 설정 파일 JSON Schema를 출력(schema)하거나 대화형으로 설정 파일을 만듭니다(init)",
+	run:     runConfig,
+}
+
+func runConfig(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	output := fs.String("output", "weekly-lotto.json", "init: 저장할 설정 파일 경로")
+	force := fs.Bool("force", false, "init: 기존 파일 덮어쓰기")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	// "config init --output x.json" 처럼 하위 명령 뒤에 온 flag도 허용
+	action := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[min(1, fs.NArg()):]); err != nil {
+		return parseError(err)
+	}
+
+	switch action {
+	case "show":
+		return showConfig(app)
+	case "schema":
+		return printSchema()
+	case "init":
+		return initConfig(ctx, app, *output, *force)
+	default:
+		fs.Usage()
+		return errUsage
+	}
+}
+
+// printSchema prints the JSON Schema of the config file.
+func printSchema() error {
+	encoded, err := json.MarshalIndent(config.Schema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("스키마 출력 실패: %w", err)
+	}
+	fmt.Println(string(encoded))
+	return nil
+}
+
+// showConfig prints the merged effective configuration with secrets masked.
+func showConfig(app *App) error {
+	// 검증에 실패해도 진단을 위해 병합된 설정을 출력
+	cfg, validationErr := config.Inspect(app.overrides)
+
+	encoded, err := json.MarshalIndent(cfg.Redacted(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("설정 출력 실패: %w", err)
+	}
+	fmt.Println(string(encoded))
+
+	if cfg.Profile != "" {
+		logging.Infof("📂 적용된 프로필: %s", cfg.Profile)
+	}
+
+	if validationErr != nil {
+		return validationErr
+	}
+	logging.Infof("✅ 설정 검증 통과")
+	return nil
+}
@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/generator"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/notify"
+
+	"golang.org/x/term"
+)
+
+// prompter asks questions on the terminal for the config wizard. Its
+// context bounds the checks the steps make against the site.
+type prompter struct {
+	ctx context.Context
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask prints label and returns the trimmed answer, or def when it is empty.
+func (p *prompter) ask(label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("입력 읽기 실패: %w", err)
+	}
+	if answer := strings.TrimSpace(line); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// askSecret reads a value without echo when stdin is a terminal.
+func (p *prompter) askSecret(label string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return p.ask(label, "")
+	}
+	fmt.Fprintf(p.out, "%s: ", label)
+	secret, err := term.ReadPassword(fd)
+	fmt.Fprintln(p.out)
+	if err != nil {
+		return "", fmt.Errorf("입력 읽기 실패: %w", err)
+	}
+	return strings.TrimSpace(string(secret)), nil
+}
+
+// askInt asks until the answer is an integer in [min, max].
+func (p *prompter) askInt(label string, def, min, max int) (int, error) {
+	for {
+		answer, err := p.ask(label, strconv.Itoa(def))
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= min && n <= max {
+			return n, nil
+		}
+		fmt.Fprintf(p.out, "  %d~%d 사이의 숫자를 입력하세요\n", min, max)
+	}
+}
+
+// confirm asks a yes/no question.
+func (p *prompter) confirm(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	answer, err := p.ask(fmt.Sprintf("%s (%s)", label, hint), "")
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return def, nil
+	case "y", "yes", "예", "네":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// initConfig runs the interactive setup and writes the config file to path.
+func initConfig(ctx context.Context, app *App, path string, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s 파일이 이미 있습니다 (덮어쓰려면 --force)", path)
+	}
+
+	p := &prompter{ctx: ctx, in: bufio.NewReader(os.Stdin), out: app.stderr}
+	fmt.Fprintln(p.out, "🧙 weekly-lotto 설정 마법사 - 빈 값으로 Enter를 누르면 [기본값]을 사용합니다")
+
+	cfg := &config.Config{}
+	steps := []func(*prompter, *config.Config) error{
+		askCredential,
+		askEmail,
+		askTickets,
+		askBudget,
+	}
+	for _, step := range steps {
+		fmt.Fprintln(p.out)
+		if err := step(p, cfg); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("설정 인코딩 실패: %w", err)
+	}
+	// 비밀번호가 들어 있으므로 소유자만 읽을 수 있게 저장
+	if err := os.WriteFile(path, append(encoded, '\n'), 0o600); err != nil {
+		return fmt.Errorf("설정 파일 저장 실패: %w", err)
+	}
+	logging.Infof("📝 설정을 %s에 저장했습니다 (LOTTO_CONFIG=%s 또는 --config %s 로 사용)", path, path, path)
+
+	overrides := &config.Overrides{}
+	if err := overrides.Set("LOTTO_CONFIG=" + path); err != nil {
+		return err
+	}
+	if _, err := config.LoadWith(overrides); err != nil {
+		return fmt.Errorf("저장한 설정 검증 실패 (환경변수가 설정을 덮어썼을 수 있습니다): %w", err)
+	}
+	logging.Infof("✅ 설정 검증 통과")
+	return nil
+}
+
+func askCredential(p *prompter, cfg *config.Config) error {
+	fmt.Fprintln(p.out, "1/4 동행복권 계정")
+	for {
+		username, err := p.ask("아이디", cfg.Credential.Username)
+		if err != nil {
+			return err
+		}
+		password, err := p.askSecret("비밀번호")
+		if err != nil {
+			return err
+		}
+		cfg.Credential = config.CredentialConfig{Username: username, Password: password}
+
+		if _, err := lottery.NewClient(p.ctx, username, password); err != nil {
+			fmt.Fprintf(p.out, "❌ 로그인 실패: %v\n", err)
+			retry, err := p.confirm("다시 입력할까요?", true)
+			if err != nil {
+				return err
+			}
+			if retry {
+				continue
+			}
+			return nil
+		}
+		fmt.Fprintln(p.out, "✅ 로그인 성공")
+		return nil
+	}
+}
+
+func askEmail(p *prompter, cfg *config.Config) error {
+	fmt.Fprintln(p.out, "2/4 이메일 알림 (SMTP)")
+	for {
+		var err error
+		email := &cfg.Email
+		if email.SMTPHost, err = p.ask("SMTP 서버", orDefault(email.SMTPHost, "smtp.gmail.com")); err != nil {
+			return err
+		}
+		if email.SMTPPort, err = p.askInt("SMTP 포트 (587: STARTTLS, 465: TLS)", orDefaultInt(email.SMTPPort, 587), 1, 65535); err != nil {
+			return err
+		}
+		if email.Username, err = p.ask("SMTP 계정", email.Username); err != nil {
+			return err
+		}
+		if email.Password, err = p.askSecret("SMTP 비밀번호 (Gmail은 앱 비밀번호)"); err != nil {
+			return err
+		}
+		if email.From, err = p.ask("발신자 이메일", orDefault(email.From, email.Username)); err != nil {
+			return err
+		}
+		to, err := p.ask("수신자 이메일 (쉼표로 구분)", orDefault(strings.Join(email.To, ","), email.From))
+		if err != nil {
+			return err
+		}
+		email.To = splitComma(to)
+
+		sender := notify.NewEmailSender(email, &cfg.Notifications)
+		err = sender.Verify()
+		if err == nil {
+			fmt.Fprintln(p.out, "✅ SMTP 인증 성공")
+			var send bool
+			if send, err = p.confirm("테스트 메일을 보낼까요?", true); err != nil {
+				return err
+			}
+			if !send {
+				return nil
+			}
+			if err = sendSampleNotification(sender.WithTag(notifyTestTag), config.EventBuy); err == nil {
+				fmt.Fprintf(p.out, "✉️  테스트 메일을 보냈습니다: %s\n", strings.Join(email.To, ", "))
+				return nil
+			}
+		}
+
+		fmt.Fprintf(p.out, "❌ SMTP 확인 실패: %v\n", err)
+		retry, err := p.confirm("다시 입력할까요?", true)
+		if err != nil {
+			return err
+		}
+		if !retry {
+			return nil
+		}
+	}
+}
+
+func askTickets(p *prompter, cfg *config.Config) error {
+	fmt.Fprintln(p.out, "3/4 구매 설정")
+	count, err := p.askInt("회당 구매 장수", 1, 1, config.MaxTicketsPerPurchase)
+	if err != nil {
+		return err
+	}
+
+	strategies := append([]string{"site"}, generator.Names()...)
+	var strategy string
+	for {
+		if strategy, err = p.ask(fmt.Sprintf("번호 선택 (site: 사이트 자동, %s: 직접 생성)", strings.Join(generator.Names(), ", ")), "site"); err != nil {
+			return err
+		}
+		if _, err := generator.Lookup(strategy); err == nil || strategy == "site" {
+			break
+		}
+		fmt.Fprintf(p.out, "  %s 중 하나를 입력하세요\n", strings.Join(strategies, ", "))
+	}
+	if strategy == "site" {
+		strategy = ""
+	}
+
+	cfg.Purchase.Tickets = make([]config.TicketConfig, count)
+	for i := range cfg.Purchase.Tickets {
+		cfg.Purchase.Tickets[i] = config.TicketConfig{Mode: "auto", Strategy: strategy}
+	}
+	return nil
+}
+
+func askBudget(p *prompter, cfg *config.Config) error {
+	fmt.Fprintln(p.out, "4/4 예산 한도 (0: 사용 안 함)")
+	weekly, err := p.askInt("주간 한도 (원)", 0, 0, 1<<31-1)
+	if err != nil {
+		return err
+	}
+	monthly, err := p.askInt("월간 한도 (원)", 0, 0, 1<<31-1)
+	if err != nil {
+		return err
+	}
+	cfg.Budget = config.BudgetConfig{Weekly: int64(weekly), Monthly: int64(monthly)}
+
+	// 예산 한도는 구매 장부가 있어야 동작
+	def := ""
+	if cfg.Budget.Enabled() {
+		def = "weekly-lotto.db"
+	}
+	cfg.Store.Path, err = p.ask("구매 장부 SQLite 경로 (비우면 사용 안 함)", def)
+	return err
+}
+
+func orDefault(value, def string) string {
+	if value != "" {
+		return value
+	}
+	return def
+}
+
+func orDefaultInt(value, def int) int {
+	if value != 0 {
+		return value
+	}
+	return def
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/notify"
+)
+
+// Progress of an account within a daemon job, kept in jobState.Accounts.
+const (
+	accountRunning = "running"
+	accountDone    = "done"
+)
+
+// daemonState is what the schedule daemon needs to pick up where it left
+// off after a restart. Every change is written to the state file right
+// away, so a process killed without warning loses nothing either. Without
+// a path the state is only kept in memory.
+type daemonState struct {
+	path string
+
+	mu   sync.Mutex
+	data daemonStateData
+}
+
+type daemonStateData struct {
+	Jobs   map[string]*jobState `json:"jobs,omitempty"`
+	Outbox []notify.Message     `json:"outbox,omitempty"`
+}
+
+// jobState tracks one scheduled job. Started is set while a run is in
+// progress and cleared when it completes, so a set Started on startup means
+// the run was interrupted.
+type jobState struct {
+	Next     time.Time         `json:"next,omitzero"`
+	Started  time.Time         `json:"started,omitzero"`
+	Accounts map[string]string `json:"accounts,omitempty"`
+}
+
+// loadDaemonState reads the state file at path. A missing file is an empty
+// state.
+func loadDaemonState(path string) (*daemonState, error) {
+	s := &daemonState{path: path}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("데몬 상태 파일 읽기 실패: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.data); err != nil {
+		return nil, fmt.Errorf("데몬 상태 파일 형식 오류 (%s): %w", path, err)
+	}
+	return s, nil
+}
+
+// job returns the next run and the start of the interrupted run of the job
+// named key.
+func (s *daemonState) job(key string) (next, started time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job := s.data.Jobs[key]; job != nil {
+		return job.Next, job.Started
+	}
+	return time.Time{}, time.Time{}
+}
+
+// scheduled records when the job named key runs next.
+func (s *daemonState) scheduled(key string, next time.Time) {
+	s.update(func(data *daemonStateData) { data.jobState(key).Next = next })
+}
+
+// start marks a run of the job named key as in progress. A resumed run
+// keeps the progress of the interrupted one.
+func (s *daemonState) start(key string, resume bool) {
+	s.update(func(data *daemonStateData) {
+		job := data.jobState(key)
+		if resume && !job.Started.IsZero() {
+			return
+		}
+		job.Started = domain.Now()
+		job.Accounts = nil
+	})
+}
+
+// finish marks the run of the job named key as complete.
+func (s *daemonState) finish(key string, next time.Time) {
+	s.update(func(data *daemonStateData) {
+		job := data.jobState(key)
+		job.Next = next
+		job.Started = time.Time{}
+		job.Accounts = nil
+	})
+}
+
+// abandon drops the interrupted run of the job named key without running
+// it again.
+func (s *daemonState) abandon(key string) {
+	s.update(func(data *daemonStateData) {
+		job := data.jobState(key)
+		job.Started = time.Time{}
+		job.Accounts = nil
+	})
+}
+
+// account returns the progress of account in the current run of the job
+// named key, empty when it has not started.
+func (s *daemonState) account(key, account string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job := s.data.Jobs[key]; job != nil {
+		return job.Accounts[account]
+	}
+	return ""
+}
+
+// setAccount records the progress of account in the current run of the job
+// named key. An empty progress puts the account back to pending.
+func (s *daemonState) setAccount(key, account, progress string) {
+	s.update(func(data *daemonStateData) {
+		job := data.jobState(key)
+		if progress == "" {
+			delete(job.Accounts, account)
+			return
+		}
+		if job.Accounts == nil {
+			job.Accounts = make(map[string]string)
+		}
+		job.Accounts[account] = progress
+	})
+}
+
+// Enqueue implements notify.Outbox.
+func (s *daemonState) Enqueue(message notify.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Outbox = append(s.data.Outbox, message)
+	if err := s.save(); err != nil {
+		s.data.Outbox = s.data.Outbox[:len(s.data.Outbox)-1]
+		return err
+	}
+	return nil
+}
+
+// flushOutbox passes every queued notification to send, keeping those it
+// fails to send.
+func (s *daemonState) flushOutbox(send func(notify.Message) error) {
+	s.mu.Lock()
+	queued := s.data.Outbox
+	s.mu.Unlock()
+	if len(queued) == 0 {
+		return
+	}
+
+	sent := make(map[int]bool)
+	for i, message := range queued {
+		if err := send(message); err != nil {
+			continue
+		}
+		sent[i] = true
+	}
+	// 보내는 동안 새로 쌓인 알림은 그대로 둠
+	s.update(func(data *daemonStateData) {
+		var kept []notify.Message
+		for i, message := range data.Outbox {
+			if !sent[i] {
+				kept = append(kept, message)
+			}
+		}
+		data.Outbox = kept
+	})
+}
+
+// update applies change and writes the state file. A write failure is
+// logged rather than returned: the daemon keeps running on the in-memory
+// state.
+func (s *daemonState) update(change func(*daemonStateData)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(&s.data)
+	if err := s.save(); err != nil {
+		logging.Warnf("⚠️  데몬 상태 저장 실패: %v", err)
+	}
+}
+
+func (d *daemonStateData) jobState(key string) *jobState {
+	if d.Jobs == nil {
+		d.Jobs = make(map[string]*jobState)
+	}
+	job := d.Jobs[key]
+	if job == nil {
+		job = &jobState{}
+		d.Jobs[key] = job
+	}
+	return job
+}
+
+// save writes the state file atomically, so a kill mid-write leaves the
+// previous state in place. s.mu must be held.
+func (s *daemonState) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data, 0o600)
+}
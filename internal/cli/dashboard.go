@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/store"
+)
+
+// Dashboard limits: rounds in the spend/winnings chart and tickets listed.
+const (
+	dashboardChartRounds = 20
+	dashboardTickets     = 50
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// webFuncs are the template functions of the dashboard and the static site.
+var webFuncs = template.FuncMap{
+	"won":       utils.FormatAmount,
+	"ballClass": ballClass,
+	"kst":       func(t time.Time) string { return t.In(domain.Seoul).Format("2006-01-02 15:04") },
+	"unixMilli": func(t time.Time) int64 { return t.UnixMilli() },
+	"rankLabel": func(key string) string { return rankLabels[key] },
+	"style":     func() template.CSS { return template.CSS(webStyle()) },
+}
+
+// webStyle returns the stylesheet shared by the dashboard, which inlines
+// it, and the static site, which links it.
+func webStyle() []byte {
+	// 실행 파일에 포함된 파일이라 실패하지 않음
+	css, _ := webFiles.ReadFile("web/style.css")
+	return css
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard.html").Funcs(webFuncs).ParseFS(webFiles, "web/dashboard.html"))
+
+// rankLabels names the statsReport.Ranks keys on the dashboard.
+var rankLabels = map[string]string{"1": "1등", "2": "2등", "3": "3등", "4": "4등", "5": "5등", "none": "낙첨"}
+
+// dashboardPage is the data the dashboard template renders.
+type dashboardPage struct {
+	Now        time.Time
+	NextRound  int
+	NextDraw   time.Time
+	SalesClose time.Time
+	Winning    *domain.WinningNumbers
+	Stats      *statsReport
+	RankKeys   []string
+	Chart      []chartRound
+	ChartMax   int64
+	Tickets    []dashboardTicket
+	// Error explains why the purchases could not be shown.
+	Error string
+	// Live makes the page reload on the events of /api/events, which only
+	// serve has.
+	Live bool
+}
+
+// chartRound is one round in the spend/winnings chart.
+type chartRound struct {
+	Round    int
+	Spent    int64
+	Winnings int64
+	// Heights of the bars in percent of the tallest one.
+	SpentHeight    float64
+	WinningsHeight float64
+}
+
+// dashboardTicket is a ticket in the dashboard history table.
+type dashboardTicket struct {
+	historyEntry
+	Balls []dashboardBall
+}
+
+// dashboardBall is a picked number. Once the round is drawn a number is
+// either a miss or matched a winning (or, for 2nd place, the bonus) number.
+type dashboardBall struct {
+	Number int
+	Bonus  bool
+	Miss   bool
+}
+
+// dashboardHandler serves the dashboard page built from the ledger of cfg,
+// reloading itself on server events when live.
+func dashboardHandler(app *App, cfg *config.Config, live bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page := buildDashboard(r.Context(), app, cfg, r.URL.Query().Get("account"))
+		page.Live = live
+		var body bytes.Buffer
+		if err := dashboardTemplate.Execute(&body, page); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if _, err := body.WriteTo(w); err != nil {
+			logging.Warnf("⚠️  응답 쓰기 실패: %v", err)
+		}
+	}
+}
+
+// buildDashboard collects the dashboard data. A missing or unreadable ledger
+// is reported on the page rather than failing it, so the latest draw and the
+// countdown still show.
+func buildDashboard(ctx context.Context, app *App, cfg *config.Config, account string) *dashboardPage {
+	now := domain.Now()
+	page := &dashboardPage{Now: now}
+	page.NextRound, page.NextDraw = domain.NextDraw(now)
+	page.SalesClose = domain.SalesClose(page.NextRound)
+	for _, rank := range statsRanks {
+		page.RankKeys = append(page.RankKeys, rankKey(rank))
+	}
+
+	defer app.savePages(ctx)
+	draws := app.drawResults(ctx)
+	defer draws.close()
+	if winning, err := draws.latest(); err != nil {
+		logging.Warnf("⚠️  대시보드 당첨 번호 조회 실패: %v", err)
+	} else {
+		page.Winning = winning
+	}
+
+	entries, err := localHistory(ctx, app, cfg, &historyFilter{PurchaseFilter: store.PurchaseFilter{Account: account}})
+	if err != nil {
+		page.Error = sanitize.Error(err)
+		return page
+	}
+	resolveResults(draws, entries)
+	page.Stats = buildStats(entries)
+	page.Chart, page.ChartMax = chartRounds(entries, dashboardChartRounds)
+
+	slices.SortStableFunc(entries, func(a, b historyEntry) int { return b.Round - a.Round })
+	for _, entry := range entries[:min(len(entries), dashboardTickets)] {
+		page.Tickets = append(page.Tickets, newDashboardTicket(entry))
+	}
+	return page
+}
+
+// chartRounds totals the spend and winnings of the latest limit rounds
+// (every round when 0), oldest first, and returns them with the largest
+// total.
+func chartRounds(entries []historyEntry, limit int) ([]chartRound, int64) {
+	byRound := make(map[int]*chartRound)
+	for _, entry := range entries {
+		round := byRound[entry.Round]
+		if round == nil {
+			round = &chartRound{Round: entry.Round}
+			byRound[entry.Round] = round
+		}
+		round.Spent += entry.Amount
+		round.Winnings += entry.Prize
+	}
+
+	rounds := make([]chartRound, 0, len(byRound))
+	for _, round := range byRound {
+		rounds = append(rounds, *round)
+	}
+	slices.SortFunc(rounds, func(a, b chartRound) int { return a.Round - b.Round })
+	if limit > 0 {
+		rounds = rounds[max(0, len(rounds)-limit):]
+	}
+
+	var largest int64
+	for _, round := range rounds {
+		largest = max(largest, round.Spent, round.Winnings)
+	}
+	if largest > 0 {
+		for i := range rounds {
+			rounds[i].SpentHeight = float64(rounds[i].Spent) / float64(largest) * 100
+			rounds[i].WinningsHeight = float64(rounds[i].Winnings) / float64(largest) * 100
+		}
+	}
+	return rounds, largest
+}
+
+func newDashboardTicket(entry historyEntry) dashboardTicket {
+	ticket := dashboardTicket{historyEntry: entry}
+	for _, n := range entry.Numbers {
+		ball := dashboardBall{Number: n}
+		if entry.winning != nil {
+			ball.Bonus = entry.rank == domain.Rank2 && n == entry.winning.BonusNumber
+			ball.Miss = !ball.Bonus && !slices.Contains(entry.winning.Numbers, n)
+		}
+		ticket.Balls = append(ticket.Balls, ball)
+	}
+	return ticket
+}
+
+// ballClass returns the CSS class giving n the color of the official ball,
+// one per range of ten.
+func ballClass(n int) string {
+	return fmt.Sprintf("ball-%d", min((n-1)/10, 4))
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sanitize"
+)
+
+var doctorCommand = &command{
+	name:    "doctor",
+	usage:   "doctor [flags]",
+	summary: "설정, 동행복권 접속/로그인, 페이지 파서, SMTP 연결을 점검해 항목별 통과/실패를 출력합니다",
+	run:     runDoctor,
+}
+
+// Diagnostic statuses.
+const (
+	diagPass = "pass"
+	diagFail = "fail"
+	diagSkip = "skip"
+)
+
+// diagnostic is the outcome of a single doctor check.
+type diagnostic struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Detail     string `json:"detail,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// doctor runs checks in order and records their outcomes.
+type doctor struct {
+	results []diagnostic
+}
+
+// run times check and records it under name; it fails when check returns
+// an error and reports whether it passed.
+func (d *doctor) run(name string, check func() (string, error)) bool {
+	start := time.Now()
+	detail, err := check()
+	result := diagnostic{Name: name, Status: diagPass, Detail: detail, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = diagFail
+		result.Detail = sanitize.Error(err)
+	}
+	d.results = append(d.results, result)
+	return err == nil
+}
+
+func (d *doctor) skip(name, reason string) {
+	d.results = append(d.results, diagnostic{Name: name, Status: diagSkip, Detail: reason})
+}
+
+func (d *doctor) failed() int {
+	n := 0
+	for _, result := range d.results {
+		if result.Status == diagFail {
+			n++
+		}
+	}
+	return n
+}
+
+func runDoctor(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	d := &doctor{}
+
+	var cfg *config.Config
+	d.run("설정", func() (string, error) {
+		var err error
+		cfg, err = app.Config()
+		if err != nil {
+			return "", err
+		}
+		detail := fmt.Sprintf("계정 %d개", len(cfg.LotteryAccounts()))
+		if cfg.Profile != "" {
+			detail += fmt.Sprintf(", 프로필 %s", cfg.Profile)
+		}
+		if cfg.Purchase.ReadOnly {
+			detail += ", 읽기 전용"
+		}
+		return detail, nil
+	})
+
+	var guest *lottery.Client
+	reachable := d.run("동행복권 접속", func() (string, error) {
+		var err error
+		guest, err = lottery.NewGuestClient(ctx)
+		return "세션 초기화 성공", err
+	})
+
+	if reachable {
+		d.run("파서: 당첨 번호", func() (string, error) {
+			winning, err := guest.GetWinningNumbers(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d회 (%s 추첨)", winning.Round, winning.DrawDate.Format("2006-01-02")), nil
+		})
+	} else {
+		d.skip("파서: 당첨 번호", "사이트 접속 실패")
+	}
+
+	if cfg == nil {
+		d.skip("로그인", "설정 로드 실패")
+		d.skip("SMTP", "설정 로드 실패")
+	} else {
+		// 교체한 비밀번호는 로그인/인증에 성공했을 때만 확인된 것으로 기록
+		verified := make(map[string]bool)
+		for _, account := range cfg.LotteryAccounts() {
+			verified["lottery:"+account.Name] = diagnoseAccount(ctx, d, account, reachable)
+		}
+		verified["smtp"] = d.run("SMTP", func() (string, error) {
+			if err := app.EmailSender(cfg).Verify(); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s:%d 인증 성공", cfg.Email.SMTPHost, cfg.Email.SMTPPort), nil
+		})
+		if cfg.Store.Enabled() {
+			d.run("저장소", func() (string, error) {
+				ledger, err := app.OpenStore(cfg)
+				if err != nil {
+					return "", err
+				}
+				return cfg.Redacted().Store.Location(), ledger.Close()
+			})
+		}
+		if cfg.Rotation.Enabled() {
+			diagnoseRotation(ctx, d, app, cfg, verified)
+		}
+	}
+
+	if app.jsonOutput() {
+		if err := writeJSON(d.results); err != nil {
+			return err
+		}
+	} else if err := writeDiagnostics(d.results); err != nil {
+		return err
+	}
+
+	if n := d.failed(); n > 0 {
+		return fmt.Errorf("%d개 진단 항목이 실패했습니다", n)
+	}
+	return nil
+}
+
+// diagnoseAccount logs in with account and parses its private pages. It
+// reports whether the login succeeded.
+func diagnoseAccount(ctx context.Context, d *doctor, account config.AccountConfig, reachable bool) bool {
+	prefix := fmt.Sprintf("[%s] ", account.Name)
+	if !reachable {
+		d.skip(prefix+"로그인", "사이트 접속 실패")
+		return false
+	}
+
+	var client *lottery.Client
+	if !d.run(prefix+"로그인", func() (string, error) {
+		var err error
+		client, err = lottery.NewClient(ctx, account.Username, account.Password)
+		return account.Username, err
+	}) {
+		return false
+	}
+
+	d.run(prefix+"파서: 현재 회차", func() (string, error) {
+		round, err := client.GetCurrentRound(ctx)
+		return fmt.Sprintf("%d회", round), err
+	})
+	d.run(prefix+"파서: 예치금", func() (string, error) {
+		balance, err := client.GetBalance(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("예치금 %s원, 미수령 %s원", utils.FormatAmount(balance.Deposit), utils.FormatAmount(balance.UnclaimedPrize)), nil
+	})
+	d.run(prefix+"파서: 구매 내역", func() (string, error) {
+		purchases, err := client.GetRecentPurchases(ctx, purchaseHistoryDays)
+		if errors.Is(err, lottery.ErrNoPurchases) {
+			return fmt.Sprintf("최근 %d일 구매 없음", purchaseHistoryDays), nil
+		}
+		return fmt.Sprintf("최근 %d일 %d건", purchaseHistoryDays, len(purchases)), err
+	})
+	return true
+}
+
+// diagnoseRotation reports how long each password has been in use. A
+// password changed since it was recorded starts a new age only when the
+// login or SMTP check above succeeded with it, so doctor is how a rotation
+// is confirmed.
+func diagnoseRotation(ctx context.Context, d *doctor, app *App, cfg *config.Config, verified map[string]bool) {
+	ledger, err := app.OpenStore(cfg)
+	if err != nil {
+		d.skip("비밀번호 사용 기간", "저장소 열기 실패")
+		return
+	}
+	defer ledger.Close()
+
+	var checked []trackedCredential
+	for _, credential := range trackedCredentials(cfg) {
+		if verified[credential.name] {
+			checked = append(checked, credential)
+		} else {
+			d.skip("비밀번호 사용 기간: "+credential.label, "로그인/인증 실패")
+		}
+	}
+
+	now := domain.Now()
+	maxDays := cfg.Rotation.MaxAgeDays
+	var ages []credentialAge
+	if !d.run("비밀번호 사용 기간 기록", func() (string, error) {
+		ages, err = syncCredentials(ctx, ledger, checked, now)
+		return fmt.Sprintf("%d개, 교체 주기 %d일", len(checked), maxDays), err
+	}) {
+		return
+	}
+	for _, age := range ages {
+		d.run("비밀번호 사용 기간: "+age.label, func() (string, error) {
+			if age.changed {
+				return "새 비밀번호 확인 - 오늘부터 사용 기간을 셉니다", nil
+			}
+			days := domain.StaleCredential{Since: age.record.Since}.Days(now)
+			if days >= maxDays {
+				return "", fmt.Errorf("%d일째 사용 중 - %d일이 지나 교체가 필요합니다", days, maxDays)
+			}
+			return fmt.Sprintf("%d일째 사용 중 (%s부터)", days, age.record.Since.In(domain.Seoul).Format("2006-01-02")), nil
+		})
+	}
+}
+
+func writeDiagnostics(results []diagnostic) error {
+	icons := map[string]string{diagPass: "✅", diagFail: "❌", diagSkip: "⏭️"}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "상태\t항목\t소요\t내용")
+	for _, result := range results {
+		elapsed := "-"
+		if result.Status != diagSkip {
+			elapsed = fmt.Sprintf("%dms", result.DurationMs)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", icons[result.Status], result.Name, elapsed, strings.ReplaceAll(result.Detail, "\n", " "))
+	}
+	return w.Flush()
+}
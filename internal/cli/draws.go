@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/store"
+)
+
+// drawResults fetches winning numbers per round and caches them for the
+// rest of the invocation. The guest session is created on first use. When a
+// store is attached, rounds saved by backfill are read from it first and
+// rounds fetched from the site are saved to it.
+type drawResults struct {
+	ctx    context.Context
+	client *lottery.Client
+	ledger store.Store
+	rounds map[int]*domain.WinningNumbers
+	// archive records the pages of the guest session, when set.
+	archive func(client *lottery.Client)
+}
+
+func newDrawResults() *drawResults {
+	return &drawResults{ctx: context.Background(), rounds: make(map[int]*domain.WinningNumbers)}
+}
+
+// drawResults returns a lookup backed by the configured store, if any. The
+// store path is read even when the rest of the configuration is incomplete,
+// so public commands such as winning can use backfilled draws.
+// Callers must call close.
+func (a *App) drawResults(ctx context.Context) *drawResults {
+	draws := newDrawResults()
+	draws.ctx = ctx
+
+	cfg := a.cfg
+	if cfg == nil {
+		cfg, _ = config.Inspect(a.overrides)
+	}
+	draws.archive = func(client *lottery.Client) { a.recordPages(cfg, client, "") }
+	ledger, err := a.OpenStore(cfg)
+	if err != nil {
+		logging.Warnf("⚠️  %v - 당첨 번호를 사이트에서만 조회합니다", err)
+		return draws
+	}
+	draws.ledger = ledger
+	return draws
+}
+
+// close releases the attached store.
+func (d *drawResults) close() {
+	if d.ledger != nil {
+		d.ledger.Close()
+	}
+}
+
+// get returns the winning numbers of round, or lottery.ErrNotDrawn (wrapped)
+// when the draw has not been published yet.
+func (d *drawResults) get(round int) (*domain.WinningNumbers, error) {
+	if winning, ok := d.rounds[round]; ok {
+		return winning, nil
+	}
+
+	if d.ledger != nil {
+		stored, err := d.ledger.Draws(d.ctx, round, round)
+		if err != nil {
+			logging.Warnf("⚠️  %d회 저장된 당첨 번호 조회 실패: %v", round, err)
+		} else if len(stored) == 1 {
+			logging.Debugf("🔍 %d회 당첨 번호: 저장소", round)
+			d.rounds[round] = stored[0]
+			return stored[0], nil
+		}
+	}
+
+	return d.fetch(round)
+}
+
+// fetch reads round from the site, bypassing the store.
+func (d *drawResults) fetch(round int) (*domain.WinningNumbers, error) {
+	if err := d.connect(); err != nil {
+		return nil, err
+	}
+
+	logging.Debugf("🔍 %d회 당첨 번호: 사이트 조회", round)
+	winning, err := d.client.GetWinningNumbersByRound(d.ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	d.remember(winning)
+	return winning, nil
+}
+
+// latest returns the most recently published winning numbers. When the site
+// cannot be reached, the newest stored draw is used instead.
+func (d *drawResults) latest() (*domain.WinningNumbers, error) {
+	winning, err := d.fetchLatest()
+	if err == nil {
+		return winning, nil
+	}
+	if d.ledger != nil {
+		stored, storeErr := d.ledger.Draws(d.ctx, 0, 0)
+		if storeErr == nil && len(stored) > 0 {
+			newest := stored[len(stored)-1]
+			logging.Warnf("⚠️  최신 당첨 번호 조회 실패: %v - 저장된 %d회를 사용합니다", err, newest.Round)
+			d.rounds[newest.Round] = newest
+			return newest, nil
+		}
+	}
+	return nil, err
+}
+
+func (d *drawResults) fetchLatest() (*domain.WinningNumbers, error) {
+	if err := d.connect(); err != nil {
+		return nil, err
+	}
+
+	winning, err := d.client.GetWinningNumbers(d.ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.remember(winning)
+	return winning, nil
+}
+
+func (d *drawResults) connect() error {
+	if d.client != nil {
+		return nil
+	}
+	client, err := lottery.NewGuestClient(d.ctx)
+	if err != nil {
+		return fmt.Errorf("당첨 번호 조회 세션 생성 실패: %w", err)
+	}
+	if d.archive != nil {
+		d.archive(client)
+	}
+	d.client = client
+	return nil
+}
+
+// remember caches a draw fetched from the site and, once validated, saves it
+// to the store.
+func (d *drawResults) remember(winning *domain.WinningNumbers) {
+	d.rounds[winning.Round] = winning
+	if d.ledger == nil {
+		return
+	}
+	if _, err := store.ImportDraws(d.ctx, d.ledger, []*domain.WinningNumbers{winning}); err != nil {
+		logging.Warnf("⚠️  %d회 당첨 번호 저장 실패: %v", winning.Round, err)
+	}
+}
+
+// prize returns the rank and per-winner prize of numbers in the given draw.
+func prize(numbers []int, winning *domain.WinningNumbers) (domain.WinningRank, int64) {
+	rank := domain.CheckWinning(numbers, winning)
+	if rank == domain.RankNone {
+		return rank, 0
+	}
+	if info, ok := winning.Prizes[rank]; ok {
+		return rank, info.AmountPerWinner
+	}
+	return rank, 0
+}
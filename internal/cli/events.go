@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+)
+
+// Event types sent on GET /api/events.
+const (
+	eventPurchase = "purchase"
+	eventResult   = "result"
+	eventCheck    = "check"
+)
+
+const (
+	// eventBuffer is how many events a slow client may fall behind before
+	// events are dropped for it.
+	eventBuffer = 16
+	// eventHeartbeat is how often an idle stream gets a comment, so
+	// proxies do not close it.
+	eventHeartbeat = 25 * time.Second
+	// resultPollInterval is how often the numbers of a draw are looked up
+	// after it is held.
+	resultPollInterval = time.Minute
+	// resultWatchWindow is how long after a draw its numbers are waited for.
+	resultWatchWindow = 2 * time.Hour
+)
+
+// serverEvent is one event of the live stream.
+type serverEvent struct {
+	ID   int64     `json:"id"`
+	Type string    `json:"type"`
+	At   time.Time `json:"at"`
+	Data any       `json:"data"`
+}
+
+// eventHub fans server events out to the connected streams. A nil hub
+// drops events, for servers without a stream (the Telegram bot).
+type eventHub struct {
+	mu          sync.Mutex
+	nextID      int64
+	subscribers map[chan serverEvent]struct{}
+	closed      bool
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[chan serverEvent]struct{})}
+}
+
+// subscribe returns a channel receiving the events published from now on,
+// closed by the returned cancel function or when the hub closes.
+func (h *eventHub) subscribe() (<-chan serverEvent, func()) {
+	events := make(chan serverEvent, eventBuffer)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(events)
+		return events, func() {}
+	}
+	h.subscribers[events] = struct{}{}
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[events]; ok {
+			delete(h.subscribers, events)
+			close(events)
+		}
+	}
+}
+
+// publish sends an event of eventType with data to every subscriber.
+func (h *eventHub) publish(eventType string, data any) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextID++
+	event := serverEvent{ID: h.nextID, Type: eventType, At: domain.Now(), Data: data}
+	for events := range h.subscribers {
+		select {
+		case events <- event:
+		default:
+			// 느린 클라이언트 때문에 구매/확인이 멈추지 않도록 버림
+			logging.Warnf("⚠️  이벤트 스트림 클라이언트가 밀려 %s 이벤트를 버립니다", eventType)
+		}
+	}
+}
+
+// close ends every stream, so shutdown does not wait for them.
+func (h *eventHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for events := range h.subscribers {
+		delete(h.subscribers, events)
+		close(events)
+	}
+}
+
+// handleEvents streams server events as server-sent events until the
+// client goes away.
+func (s *server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	events, cancel := s.events.subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// nginx 등이 응답을 모아 두지 않도록
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	controller := http.NewResponseController(w)
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logging.Warnf("⚠️  %s 이벤트 변환 실패: %v", event.Type, err)
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// watchResults publishes a result event with the numbers of every draw
+// once they are out, polling from the draw time for up to
+// resultWatchWindow, until ctx is done.
+func (s *server) watchResults(ctx context.Context) {
+	for {
+		round, drawn := domain.NextDraw(domain.Now())
+		if !sleepUntil(ctx, drawn) {
+			return
+		}
+		deadline := drawn.Add(resultWatchWindow)
+		for {
+			report, err := s.winning(ctx, round)
+			if err == nil {
+				logging.Infof("📣 %d회 당첨 번호 발표 - 이벤트를 보냅니다", round)
+				s.events.publish(eventResult, report)
+				break
+			}
+			if !errors.Is(err, lottery.ErrNotDrawn) {
+				logging.Warnf("⚠️  %d회 당첨 번호 확인 실패: %v", round, err)
+			}
+			if !domain.Now().Add(resultPollInterval).Before(deadline) {
+				logging.Warnf("⚠️  %d회 당첨 번호가 추첨 후 %s 안에 발표되지 않아 기다리지 않습니다", round, resultWatchWindow)
+				break
+			}
+			if !sleepUntil(ctx, domain.Now().Add(resultPollInterval)) {
+				return
+			}
+		}
+	}
+}
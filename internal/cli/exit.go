@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/notify"
+)
+
+// Exit codes returned by Run, so wrappers (GitHub Actions, cron) can branch
+// on the failure class.
+const (
+	ExitOK           = 0
+	ExitFailure      = 1 // 분류되지 않은 오류
+	ExitUsage        = 2 // 잘못된 명령/플래그
+	ExitConfig       = 3 // 설정 로드/검증 실패
+	ExitLogin        = 4 // 로그인 실패 (아이디/비밀번호)
+	ExitMaintenance  = 5 // 동행복권 시스템 점검
+	ExitNoPurchases  = 6 // 확인할 구매 내역 없음
+	ExitNotification = 7 // 알림 전송 실패
+	ExitLowBalance   = 8 // 예치금 부족으로 구매 건너뜀
+)
+
+// errConfig marks every error returned by App.Config, including those that
+// are not a *config.ValidationError (e.g. an unreadable config file).
+var errConfig = errors.New("설정 로드 실패")
+
+// exitClasses maps failure classes to exit codes, in priority order when an
+// error wraps several of them.
+var exitClasses = []struct {
+	code  int
+	match func(error) bool
+}{
+	{ExitConfig, func(err error) bool {
+		var validation *config.ValidationError
+		return errors.As(err, &validation) || errors.Is(err, errConfig)
+	}},
+	{ExitMaintenance, func(err error) bool { return errors.Is(err, lottery.ErrMaintenance) }},
+	{ExitLogin, func(err error) bool { return errors.Is(err, lottery.ErrLoginFailed) }},
+	{ExitNoPurchases, func(err error) bool { return errors.Is(err, lottery.ErrNoPurchases) }},
+	{ExitLowBalance, func(err error) bool { return errors.Is(err, lottery.ErrInsufficientBalance) }},
+	{ExitNotification, func(err error) bool { return errors.Is(err, notify.ErrDelivery) }},
+}
+
+// exitCode classifies err into one of the Exit* codes.
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	for _, class := range exitClasses {
+		if class.match(err) {
+			return class.code
+		}
+	}
+	return ExitFailure
+}
+
+// accountsError reports that some accounts failed while keeping every
+// per-account error reachable through errors.Is / errors.As.
+type accountsError struct {
+	message string
+	errs    []error
+}
+
+func (e *accountsError) Error() string   { return e.message }
+func (e *accountsError) Unwrap() []error { return e.errs }
+
+// accountsFailed returns nil when errs is empty, or an accountsError whose
+// message reads "N개 계정의 <what>".
+func accountsFailed(what string, errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &accountsError{message: fmt.Sprintf("%d개 계정의 %s", len(errs), what), errs: errs}
+}
+
+// accountError tags a per-account error with the account it belongs to,
+// for failure reports. Its message is the wrapped error's.
+type accountError struct {
+	account string
+	err     error
+}
+
+func (e *accountError) Error() string { return e.err.Error() }
+func (e *accountError) Unwrap() error { return e.err }
+
+// accountFailed wraps err with the name of the account it belongs to.
+func accountFailed(account string, err error) error {
+	return &accountError{account: account, err: err}
+}
@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/store"
+)
+
+var exportCommand = &command{
+	name:    "export",
+	usage:   "export [--data results] [--output FILE] [--type csv|json] [flags]",
+	summary: "로컬 구매 장부의 구매 내역, 당첨 결과, 가계부 항목을 CSV/JSON 파일로 내보냅니다",
+	run:     runExport,
+}
+
+// Datasets accepted by --data.
+const (
+	exportPurchases = "purchases"
+	exportResults   = "results"
+	exportLedger    = "ledger"
+	exportDraws     = "draws"
+	exportAll       = "all"
+)
+
+// ledgerColumns is the CSV schema of the ledger dataset.
+var ledgerColumns = []string{"date", "account", "round", "kind", "description", "amount", "balance"}
+
+// ledgerEntry is a bookkeeping line: money spent on an order or a prize won.
+type ledgerEntry struct {
+	Date        time.Time `json:"date"`
+	Account     string    `json:"account"`
+	Round       int       `json:"round"`
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Amount      int64     `json:"amount"`
+	Balance     int64     `json:"balance"`
+}
+
+func runExport(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	data := fs.String("data", exportResults, "내보낼 데이터 (purchases: 구매 내역, results: 당첨 결과 포함, ledger: 지출/당첨금 가계부, draws: 저장된 당첨 번호, all: 구매/결과/당첨 번호 CSV를 --output 디렉터리에 저장)")
+	output := fs.String("output", "-", "저장할 파일 경로 (-: stdout, --data all은 디렉터리)")
+	fileType := fs.String("type", "", "파일 형식 (csv, json). 기본값: 파일 확장자가 .json이면 json, 아니면 csv")
+	account := fs.String("account", "", "계정 이름으로 필터링")
+	fromRound := fs.Int("from-round", 0, "시작 회차 (포함)")
+	toRound := fs.Int("to-round", 0, "끝 회차 (포함)")
+	since := fs.String("since", "", "시작 구매일 (YYYY-MM-DD, 포함)")
+	until := fs.String("until", "", "끝 구매일 (YYYY-MM-DD, 포함)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	if *fileType == "" {
+		*fileType = "csv"
+		if strings.EqualFold(filepath.Ext(*output), ".json") {
+			*fileType = "json"
+		}
+	}
+	if *fileType != "csv" && *fileType != "json" {
+		return usageError(fs, fmt.Errorf("알 수 없는 파일 형식입니다: %s (csv, json)", *fileType))
+	}
+	switch *data {
+	case exportPurchases, exportResults, exportLedger, exportDraws:
+	case exportAll:
+		if *output == "-" || *fileType != "csv" {
+			return usageError(fs, fmt.Errorf("--data all 은 CSV 파일을 저장할 --output 디렉터리가 필요합니다"))
+		}
+	default:
+		return usageError(fs, fmt.Errorf("알 수 없는 데이터입니다: %s (purchases, results, ledger, draws, all)", *data))
+	}
+
+	filter, err := parseHistoryFilter(*account, 0, *fromRound, *toRound, *since, *until, "")
+	if err != nil {
+		return usageError(fs, err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	switch *data {
+	case exportAll:
+		return exportAllCSV(ctx, app, cfg, *output, filter.PurchaseFilter)
+	case exportDraws:
+		return exportDrawsFile(ctx, app, cfg, *output, *fileType, filter)
+	}
+
+	entries, err := localHistory(ctx, app, cfg, filter)
+	if err != nil {
+		return err
+	}
+	if *data != exportPurchases {
+		draws := app.drawResults(ctx)
+		defer draws.close()
+		resolveResults(draws, entries)
+	}
+
+	switch *data {
+	case exportLedger:
+		ledger := buildLedger(entries)
+		return exportFile(*output, *fileType, *data, len(ledger), ledger, ledgerColumns, ledgerCSV(ledger))
+	case exportPurchases:
+		return exportFile(*output, *fileType, *data, len(entries), entries, store.PurchaseColumns, purchasesCSV(entries))
+	default:
+		return exportFile(*output, *fileType, *data, len(entries), entries, store.ResultColumns, resultsCSV(entries))
+	}
+}
+
+// exportDrawsFile exports the draws kept in the store within the round filter.
+func exportDrawsFile(ctx context.Context, app *App, cfg *config.Config, output, fileType string, filter *historyFilter) error {
+	ledger, err := openLedger(app, cfg)
+	if err != nil {
+		return err
+	}
+	defer ledger.Close()
+
+	draws, err := ledger.Draws(ctx, filter.FromRound, filter.ToRound)
+	if err != nil {
+		return err
+	}
+	reports := make([]*winningReport, 0, len(draws))
+	for _, draw := range draws {
+		reports = append(reports, newWinningReport(draw))
+	}
+	return exportFile(output, fileType, exportDraws, len(draws), reports, store.DrawColumns, drawsCSV(draws))
+}
+
+// exportAllCSV writes every CSV dataset of the store into dir. Results are
+// resolved only against stored draws; run backfill first to fill gaps.
+func exportAllCSV(ctx context.Context, app *App, cfg *config.Config, dir string, filter store.PurchaseFilter) error {
+	ledger, err := openLedger(app, cfg)
+	if err != nil {
+		return err
+	}
+	defer ledger.Close()
+
+	counts, err := store.ExportCSV(ctx, ledger, dir, filter)
+	if err != nil {
+		return fmt.Errorf("내보내기 실패: %w", err)
+	}
+	for _, name := range []string{store.PurchasesFile, store.ResultsFile, store.DrawsFile} {
+		logging.Infof("📝 %d건을 %s에 저장했습니다", counts[name], filepath.Join(dir, name))
+	}
+	return nil
+}
+
+// exportFile writes one dataset to output (or stdout for "-").
+func exportFile(output, fileType, data string, count int, v any, columns []string, records [][]string) error {
+	var w io.Writer = os.Stdout
+	if output != "-" {
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("파일 생성 실패: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if err := writeExport(w, fileType, v, columns, records); err != nil {
+		return fmt.Errorf("내보내기 실패: %w", err)
+	}
+	if output != "-" {
+		logging.Infof("📝 %s %d건을 %s에 저장했습니다", data, count, output)
+	}
+	return nil
+}
+
+// writeExport writes v as JSON or records as CSV depending on fileType.
+func writeExport(w io.Writer, fileType string, v any, columns []string, records [][]string) error {
+	if fileType == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	return store.WriteCSV(w, columns, records)
+}
+
+// buildLedger turns resolved entries into one spending line per order and
+// one income line per winning ticket, dated by purchase and draw, with a
+// running balance per account.
+func buildLedger(entries []historyEntry) []ledgerEntry {
+	var ledger []ledgerEntry
+	orders := make(map[string]int)
+	for _, entry := range entries {
+		date := domain.DrawDate(entry.Round)
+		if entry.PurchasedAt != nil {
+			date = *entry.PurchasedAt
+		}
+
+		key := fmt.Sprintf("%s|%d|%s|%d", entry.Account, entry.Round, entry.OrderNo, date.Unix())
+		if i, ok := orders[key]; ok {
+			ledger[i].Amount -= entry.Amount
+		} else {
+			orders[key] = len(ledger)
+			ledger = append(ledger, ledgerEntry{
+				Date:    date,
+				Account: entry.Account,
+				Round:   entry.Round,
+				Kind:    "purchase",
+				Amount:  -entry.Amount,
+			})
+		}
+
+		if entry.Prize > 0 {
+			ledger = append(ledger, ledgerEntry{
+				Date:        domain.DrawDate(entry.Round),
+				Account:     entry.Account,
+				Round:       entry.Round,
+				Kind:        "prize",
+				Description: fmt.Sprintf("%d회 %s %s 당첨", entry.Round, entry.Slot, entry.Result),
+				Amount:      entry.Prize,
+			})
+		}
+	}
+
+	sort.SliceStable(ledger, func(i, j int) bool {
+		return ledger[i].Date.Before(ledger[j].Date)
+	})
+
+	balances := make(map[string]int64)
+	for i := range ledger {
+		entry := &ledger[i]
+		if entry.Kind == "purchase" {
+			entry.Description = fmt.Sprintf("%d회 %d장 구매", entry.Round, -entry.Amount/domain.TicketPrice)
+		}
+		balances[entry.Account] += entry.Amount
+		entry.Balance = balances[entry.Account]
+	}
+	return ledger
+}
+
+func purchasesCSV(entries []historyEntry) [][]string {
+	records := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, store.PurchaseRecord(entry.purchase()))
+	}
+	return records
+}
+
+func resultsCSV(entries []historyEntry) [][]string {
+	records := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, store.ResultRecord(store.Result{
+			Purchase: entry.purchase(),
+			Status:   entry.Result,
+			Rank:     entry.Rank,
+			Prize:    entry.Prize,
+		}))
+	}
+	return records
+}
+
+func drawsCSV(draws []*domain.WinningNumbers) [][]string {
+	records := make([][]string, 0, len(draws))
+	for _, draw := range draws {
+		records = append(records, store.DrawRecord(draw))
+	}
+	return records
+}
+
+func ledgerCSV(ledger []ledgerEntry) [][]string {
+	records := make([][]string, 0, len(ledger))
+	for _, entry := range ledger {
+		records = append(records, []string{
+			store.CSVTime(entry.Date), entry.Account, strconv.Itoa(entry.Round), entry.Kind, entry.Description,
+			strconv.FormatInt(entry.Amount, 10), strconv.FormatInt(entry.Balance, 10),
+		})
+	}
+	return records
+}
+
+// purchase converts the entry back into its ledger form.
+func (e historyEntry) purchase() store.Purchase {
+	p := store.Purchase{
+		Account: e.Account,
+		Round:   e.Round,
+		OrderNo: e.OrderNo,
+		Slot:    e.Slot,
+		Mode:    e.Mode,
+		Numbers: e.Numbers,
+		Amount:  e.Amount,
+	}
+	if e.PurchasedAt != nil {
+		p.PurchasedAt = *e.PurchasedAt
+	}
+	return p
+}
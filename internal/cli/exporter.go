@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/pushgateway"
+	"weekly-lotto/internal/sanitize"
+)
+
+var exporterCommand = &command{
+	name:        "exporter",
+	usage:       "exporter [--addr HOST:PORT] [--balance-interval 6h] [flags]",
+	summary:     "누적 지출/당첨금, 예치금, 최근 등수, 참여 회차 수를 Prometheus 지표로 제공합니다 (Grafana용)",
+	run:         runExporter,
+	longRunning: true,
+}
+
+// portfolioExporter serves the lifetime totals of the ledger and the
+// deposits of the accounts as Prometheus gauges. The totals are read from
+// the ledger on every scrape; the deposits need a login, so they are
+// looked up every interval and the latest ones served in between.
+type portfolioExporter struct {
+	app      *App
+	cfg      *config.Config
+	interval time.Duration
+
+	mu       sync.Mutex
+	balances map[string]depositSample
+}
+
+// depositSample is the deposit of an account when it was last looked up.
+type depositSample struct {
+	deposit int64
+	at      time.Time
+}
+
+func runExporter(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	addr := fs.String("addr", "127.0.0.1:9808", "listen 주소")
+	interval := fs.Duration("balance-interval", 6*time.Hour, "예치금을 다시 조회하는 간격 (조회마다 로그인하므로 너무 짧게 두지 마세요, 0: 조회 안 함)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *interval < 0 {
+		return usageError(fs, fmt.Errorf("--balance-interval 은 0 이상이어야 합니다: %s", *interval))
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	if !cfg.Store.Enabled() {
+		return fmt.Errorf("누적 지표를 계산하려면 store.path (LOTTO_STORE_PATH) 또는 store.dsn (LOTTO_STORE_DSN) 이 필요합니다")
+	}
+
+	recordUpstream()
+	e := &portfolioExporter{app: app, cfg: cfg, interval: *interval, balances: make(map[string]depositSample)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", e.handleMetrics)
+	httpServer := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *interval > 0 {
+		go e.refreshBalances(ctx)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logging.Infof("📈 Prometheus exporter 시작: http://%s/metrics", *addr)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("exporter 실행 실패: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("exporter 종료 실패: %w", err)
+	}
+	return nil
+}
+
+// refreshBalances looks up the deposit of every account now and then every
+// interval until ctx is done. A failed lookup keeps the previous deposit,
+// whose age shows in weekly_lotto_balance_updated_timestamp_seconds.
+func (e *portfolioExporter) refreshBalances(ctx context.Context) {
+	for {
+		for _, account := range e.cfg.LotteryAccounts() {
+			deposit, err := lookupDeposit(ctx, account)
+			if err != nil {
+				logging.Warnf("⚠️  [%s] %v", account.Name, err)
+				continue
+			}
+			e.mu.Lock()
+			e.balances[account.Name] = depositSample{deposit: deposit, at: time.Now()}
+			e.mu.Unlock()
+		}
+		if !sleepUntil(ctx, domain.Now().Add(e.interval)) {
+			return
+		}
+	}
+}
+
+// lookupDeposit logs into account and reads its deposit.
+func lookupDeposit(ctx context.Context, account config.AccountConfig) (int64, error) {
+	client, err := lottery.NewClient(ctx, account.Username, account.Password)
+	if err != nil {
+		return 0, fmt.Errorf("로그인 실패: %w", err)
+	}
+	money, err := client.GetBalance(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("예치금 조회 실패: %w", err)
+	}
+	return money.Deposit, nil
+}
+
+func (e *portfolioExporter) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	entries, err := localHistory(ctx, e.app, e.cfg, &historyFilter{})
+	if err != nil {
+		http.Error(w, sanitize.Error(err), http.StatusInternalServerError)
+		return
+	}
+	defer e.app.savePages(ctx)
+	draws := e.app.drawResults(ctx)
+	defer draws.close()
+	resolveResults(draws, entries)
+
+	e.mu.Lock()
+	balances := make(map[string]depositSample, len(e.balances))
+	for name, sample := range e.balances {
+		balances[name] = sample
+	}
+	e.mu.Unlock()
+
+	var accounts []string
+	for _, account := range e.cfg.LotteryAccounts() {
+		accounts = append(accounts, account.Name)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(pushgateway.Encode(portfolioMetrics(domain.Now(), accounts, entries, balances)))
+	upstream.write(w)
+}
+
+// portfolioMetrics returns the gauges of every configured account and
+// every account in the resolved entries, sorted by account.
+func portfolioMetrics(now time.Time, accounts []string, entries []historyEntry, balances map[string]depositSample) []pushgateway.Metric {
+	byAccount := make(map[string][]historyEntry)
+	for _, name := range accounts {
+		byAccount[name] = nil
+	}
+	for _, entry := range entries {
+		byAccount[entry.Account] = append(byAccount[entry.Account], entry)
+	}
+	names := make([]string, 0, len(byAccount))
+	for name := range byAccount {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	_, next := domain.NextDraw(now)
+	metrics := []pushgateway.Metric{
+		{Name: "weekly_lotto_next_draw_timestamp_seconds", Help: "Unix time of the next draw.", Value: float64(next.Unix())},
+	}
+	for _, name := range names {
+		labels := map[string]string{"account": name}
+		report := buildStats(byAccount[name])
+		rounds := make(map[int]bool)
+		lastRound, lastRank := 0, 0
+		for _, entry := range byAccount[name] {
+			rounds[entry.Round] = true
+			if !entry.drawn || entry.Round < lastRound {
+				continue
+			}
+			if entry.Round > lastRound {
+				lastRound, lastRank = entry.Round, 0
+			}
+			if entry.Rank > 0 && (lastRank == 0 || entry.Rank < lastRank) {
+				lastRank = entry.Rank
+			}
+		}
+
+		metrics = append(metrics,
+			pushgateway.Metric{Name: "weekly_lotto_lifetime_spend_won", Help: "Amount spent on all recorded tickets in KRW.", Labels: labels, Value: float64(report.Spent)},
+			pushgateway.Metric{Name: "weekly_lotto_lifetime_winnings_won", Help: "Winnings of all drawn recorded tickets in KRW.", Labels: labels, Value: float64(report.Winnings)},
+			pushgateway.Metric{Name: "weekly_lotto_roi_percent", Help: "Return on the tickets already drawn in percent.", Labels: labels, Value: report.ROI},
+			pushgateway.Metric{Name: "weekly_lotto_rounds_played", Help: "Rounds with at least one recorded ticket.", Labels: labels, Value: float64(len(rounds))},
+			pushgateway.Metric{Name: "weekly_lotto_tickets_played", Help: "Recorded tickets.", Labels: labels, Value: float64(report.Tickets)},
+			pushgateway.Metric{Name: "weekly_lotto_tickets_pending", Help: "Recorded tickets not drawn or not checked yet.", Labels: labels, Value: float64(report.PendingTickets)},
+			pushgateway.Metric{Name: "weekly_lotto_last_rank", Help: "Best rank in the latest drawn round played (0: no win).", Labels: labels, Value: float64(lastRank)},
+			pushgateway.Metric{Name: "weekly_lotto_last_round", Help: "Latest drawn round played.", Labels: labels, Value: float64(lastRound)},
+		)
+		for _, rank := range statsRanks {
+			if rank == domain.RankNone {
+				continue
+			}
+			metrics = append(metrics, pushgateway.Metric{
+				Name:   "weekly_lotto_wins",
+				Help:   "Winning tickets by rank.",
+				Labels: map[string]string{"account": name, "rank": rankKey(rank)},
+				Value:  float64(report.Ranks[rankKey(rank)]),
+			})
+		}
+		if sample, ok := balances[name]; ok {
+			metrics = append(metrics,
+				pushgateway.Metric{Name: "weekly_lotto_current_balance_won", Help: "Deposit of the account in KRW when last looked up.", Labels: labels, Value: float64(sample.deposit)},
+				pushgateway.Metric{Name: "weekly_lotto_balance_updated_timestamp_seconds", Help: "Unix time the deposit was last looked up.", Labels: labels, Value: float64(sample.at.Unix())},
+			)
+		}
+	}
+	return metrics
+}
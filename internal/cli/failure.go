@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"weekly-lotto/internal/logging"
+)
+
+var failureCommand = &command{
+	name:    "failure",
+	usage:   "failure [flags] <작업명> <에러메시지>",
+	summary: "작업 실패 알림 이메일을 전송합니다",
+	run:     runFailure,
+}
+
+func runFailure(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return errUsage
+	}
+
+	operation := fs.Arg(0)
+	errorMsg := fs.Arg(1)
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	// Send failure notification email
+	if err := app.EmailSender(cfg).SendFailureNotification(operation, errorMsg); err != nil {
+		return fmt.Errorf("실패 알림 이메일 전송 실패: %w", err)
+	}
+
+	logging.Infof("✉️  [%s] 실패 알림 이메일 전송 완료", operation)
+	return nil
+}
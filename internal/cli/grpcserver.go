@@ -0,0 +1,329 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+	lottov1 "weekly-lotto/api/lotto/v1"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sanitize"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcService exposes the server operations as the lottov1.WeeklyLotto
+// gRPC service.
+type grpcService struct {
+	lottov1.UnimplementedWeeklyLottoServer
+	s *server
+}
+
+// newGRPCServer returns a gRPC server for s that checks the serve tokens
+// and logs every call.
+func newGRPCServer(s *server) *grpc.Server {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(logCalls, tokenInterceptor(s.cfg.Serve)))
+	lottov1.RegisterWeeklyLottoServer(grpcServer, &grpcService{s: s})
+	return grpcServer
+}
+
+// serveGRPC serves the gRPC service on addr until ctx is done.
+func serveGRPC(ctx context.Context, grpcServer *grpc.Server, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		logging.Infof("📡 gRPC 서버 시작: %s", addr)
+		serveErr <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		grpcServer.Stop()
+	}
+	return nil
+}
+
+// logCalls logs every call like the HTTP request log.
+func logCalls(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	started := time.Now()
+	resp, err := handler(ctx, req)
+	logging.Infof("📡 %s → %s (%s)", info.FullMethod, status.Code(err), time.Since(started).Round(time.Millisecond))
+	return resp, err
+}
+
+// grpcScopes are the token scopes of the methods that need more than
+// config.ScopeRead, as for their HTTP counterparts.
+var grpcScopes = map[string]string{
+	lottov1.WeeklyLotto_Buy_FullMethodName:   config.ScopePurchase,
+	lottov1.WeeklyLotto_Check_FullMethodName: config.ScopePurchase,
+}
+
+// tokenInterceptor requires a configured token allowed the scope of the
+// method as a bearer token in the authorization metadata, like requireScope
+// does for HTTP. Without any token configured read calls are let through
+// and the methods of grpcScopes are refused, like requireConfiguredScope.
+func tokenInterceptor(serve config.ServeConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !serve.AuthEnabled() {
+			if _, ok := grpcScopes[info.FullMethod]; ok {
+				return nil, status.Error(codes.PermissionDenied, "serve.token (LOTTO_SERVE_TOKEN) 또는 serve.tokens 를 설정해야 사용할 수 있습니다")
+			}
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		var given string
+		if values := md.Get("authorization"); len(values) > 0 {
+			given, _ = strings.CutPrefix(values[0], "Bearer ")
+		}
+		granted := serve.Scope(given)
+		if granted == "" {
+			return nil, status.Error(codes.Unauthenticated, "인증 토큰이 없거나 올바르지 않습니다")
+		}
+		scope, ok := grpcScopes[info.FullMethod]
+		if !ok {
+			scope = config.ScopeRead
+		}
+		if !config.ScopeAllows(granted, scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "이 토큰(%s)으로는 할 수 없는 요청입니다 (%s 권한 필요)", granted, scope)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// grpcError converts an operation error into a gRPC status, following the
+// same failure classes as httpStatus.
+func grpcError(err error) error {
+	var invalid *requestError
+	if errors.As(err, &invalid) {
+		return status.Error(codes.InvalidArgument, sanitize.Error(err))
+	}
+	code := codes.Internal
+	switch exitCode(err) {
+	case ExitMaintenance, ExitLogin, ExitNotification:
+		code = codes.Unavailable
+	case ExitNoPurchases:
+		code = codes.NotFound
+	case ExitLowBalance:
+		code = codes.FailedPrecondition
+	default:
+		if errors.Is(err, lottery.ErrNotDrawn) {
+			code = codes.NotFound
+		}
+	}
+	return status.Error(code, sanitize.Error(err))
+}
+
+func (g *grpcService) GetWinning(ctx context.Context, req *lottov1.GetWinningRequest) (*lottov1.WinningNumbers, error) {
+	report, err := g.s.winning(ctx, int(req.GetRound()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return winningMessage(report), nil
+}
+
+func (g *grpcService) GetLatestResults(ctx context.Context, req *lottov1.GetLatestResultsRequest) (*lottov1.LatestResults, error) {
+	results, err := g.s.latestResults(ctx, req.GetAccount())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &lottov1.LatestResults{Winning: winningMessage(results.Winning), Tickets: ticketMessages(results.Tickets)}, nil
+}
+
+func (g *grpcService) GetBalance(ctx context.Context, req *lottov1.GetBalanceRequest) (*lottov1.GetBalanceResponse, error) {
+	snapshots, err := g.s.balances(ctx)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &lottov1.GetBalanceResponse{}
+	for _, snapshot := range snapshots {
+		resp.Balances = append(resp.Balances, balanceMessage(snapshot))
+	}
+	return resp, nil
+}
+
+func (g *grpcService) ListPurchases(ctx context.Context, req *lottov1.ListPurchasesRequest) (*lottov1.ListPurchasesResponse, error) {
+	entries, err := g.s.purchases(ctx, historyQuery{
+		Account:   req.GetAccount(),
+		Round:     int(req.GetRound()),
+		FromRound: int(req.GetFromRound()),
+		ToRound:   int(req.GetToRound()),
+		Since:     req.GetSince(),
+		Until:     req.GetUntil(),
+		Rank:      req.GetRank(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &lottov1.ListPurchasesResponse{Tickets: ticketMessages(entries)}, nil
+}
+
+func (g *grpcService) GetStats(ctx context.Context, req *lottov1.GetStatsRequest) (*lottov1.Stats, error) {
+	report, err := g.s.stats(ctx, historyQuery{
+		Account:   req.GetAccount(),
+		FromRound: int(req.GetFromRound()),
+		ToRound:   int(req.GetToRound()),
+		Since:     req.GetSince(),
+		Until:     req.GetUntil(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return statsMessage(report), nil
+}
+
+func (g *grpcService) Buy(ctx context.Context, req *lottov1.BuyRequest) (*lottov1.BuyResponse, error) {
+	results, err := g.s.buyAll(ctx, req.GetDryRun())
+	if results == nil {
+		return nil, grpcError(err)
+	}
+	resp := &lottov1.BuyResponse{}
+	for _, result := range results {
+		resp.Results = append(resp.Results, &lottov1.BuyResult{
+			Account: result.Account,
+			Status:  result.Status,
+			Round:   int32(result.Round),
+			Amount:  result.Amount,
+			Tickets: resultTicketMessages(result.Tickets),
+			Reason:  result.Reason,
+			Error:   result.Error,
+		})
+	}
+	return resp, nil
+}
+
+func (g *grpcService) Check(ctx context.Context, req *lottov1.CheckRequest) (*lottov1.CheckResponse, error) {
+	results, err := g.s.checkAll(ctx)
+	if results == nil {
+		return nil, grpcError(err)
+	}
+	resp := &lottov1.CheckResponse{}
+	for _, result := range results {
+		resp.Results = append(resp.Results, &lottov1.CheckResult{
+			Account:  result.Account,
+			Round:    int32(result.Round),
+			Numbers:  int32s(result.Numbers),
+			Bonus:    int32(result.Bonus),
+			Tickets:  resultTicketMessages(result.Tickets),
+			Winnings: result.Winnings,
+			Error:    result.Error,
+		})
+	}
+	return resp, nil
+}
+
+func winningMessage(report *winningReport) *lottov1.WinningNumbers {
+	message := &lottov1.WinningNumbers{
+		Round:    int32(report.Round),
+		DrawDate: timestamppb.New(report.DrawDate),
+		Numbers:  int32s(report.Numbers),
+		Bonus:    int32(report.Bonus),
+	}
+	for _, prize := range report.Prizes {
+		message.Prizes = append(message.Prizes, &lottov1.Prize{
+			Rank:            int32(prize.Rank),
+			TotalAmount:     prize.TotalAmount,
+			WinnerCount:     int32(prize.WinnerCount),
+			AmountPerWinner: prize.AmountPerWinner,
+		})
+	}
+	return message
+}
+
+func ticketMessages(entries []historyEntry) []*lottov1.Ticket {
+	messages := make([]*lottov1.Ticket, 0, len(entries))
+	for _, entry := range entries {
+		message := &lottov1.Ticket{
+			Account: entry.Account,
+			Round:   int32(entry.Round),
+			OrderNo: entry.OrderNo,
+			Slot:    entry.Slot,
+			Mode:    entry.Mode,
+			Numbers: int32s(entry.Numbers),
+			Amount:  entry.Amount,
+			Result:  entry.Result,
+			Rank:    int32(entry.Rank),
+			Prize:   entry.Prize,
+		}
+		if entry.PurchasedAt != nil {
+			message.PurchasedAt = timestamppb.New(*entry.PurchasedAt)
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func resultTicketMessages(tickets []ticketOutput) []*lottov1.ResultTicket {
+	messages := make([]*lottov1.ResultTicket, 0, len(tickets))
+	for _, ticket := range tickets {
+		messages = append(messages, &lottov1.ResultTicket{
+			Slot:    ticket.Slot,
+			Mode:    ticket.Mode,
+			Numbers: int32s(ticket.Numbers),
+			Result:  ticket.Result,
+			Rank:    int32(ticket.Rank),
+			Prize:   ticket.Prize,
+		})
+	}
+	return messages
+}
+
+func balanceMessage(snapshot *domain.BalanceSnapshot) *lottov1.Balance {
+	return &lottov1.Balance{
+		Account:        snapshot.Account,
+		Deposit:        snapshot.Deposit,
+		UnclaimedPrize: snapshot.UnclaimedPrize,
+		Round:          int32(snapshot.Round),
+		RoundTickets:   int32(snapshot.RoundTickets),
+		CheckedAt:      timestamppb.New(snapshot.CheckedAt),
+	}
+}
+
+func statsMessage(report *statsReport) *lottov1.Stats {
+	message := &lottov1.Stats{
+		Tickets:        int32(report.Tickets),
+		DrawnTickets:   int32(report.DrawnTickets),
+		PendingTickets: int32(report.PendingTickets),
+		Spent:          report.Spent,
+		DrawnSpent:     report.DrawnSpent,
+		Winnings:       report.Winnings,
+		Roi:            report.ROI,
+		Ranks:          make(map[string]int32, len(report.Ranks)),
+	}
+	for rank, count := range report.Ranks {
+		message.Ranks[rank] = int32(count)
+	}
+	for _, number := range report.Numbers {
+		message.Numbers = append(message.Numbers, &lottov1.NumberCount{Number: int32(number.Number), Count: int32(number.Count)})
+	}
+	return message
+}
+
+func int32s(numbers []int) []int32 {
+	converted := make([]int32, len(numbers))
+	for i, n := range numbers {
+		converted[i] = int32(n)
+	}
+	return converted
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	lottov1 "weekly-lotto/api/lotto/v1"
+	"weekly-lotto/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenInterceptorWithoutTokens(t *testing.T) {
+	intercept := tokenInterceptor(config.ServeConfig{})
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	tests := []struct {
+		method string
+		want   codes.Code
+	}{
+		{lottov1.WeeklyLotto_Buy_FullMethodName, codes.PermissionDenied},
+		{lottov1.WeeklyLotto_Check_FullMethodName, codes.PermissionDenied},
+		{lottov1.WeeklyLotto_GetWinning_FullMethodName, codes.OK},
+	}
+	for _, tt := range tests {
+		_, err := intercept(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s without tokens = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
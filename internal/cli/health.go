@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/store"
+)
+
+// daemonHealth records the outcome of scheduled jobs for the health
+// endpoints of the schedule daemon.
+type daemonHealth struct {
+	started time.Time
+
+	mu   sync.Mutex
+	runs map[string]*runStatus
+	// storeErr is the result of the latest store check, reused while a job
+	// holds the daemon lock.
+	storeErr     error
+	storeChecked time.Time
+}
+
+// runStatus is the latest outcome of one scheduled operation.
+type runStatus struct {
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+	LastFailure *time.Time `json:"lastFailure,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+func newDaemonHealth() *daemonHealth {
+	return &daemonHealth{started: time.Now(), runs: make(map[string]*runStatus)}
+}
+
+// record stores the outcome of operation.
+func (h *daemonHealth) record(operation string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status, ok := h.runs[operation]
+	if !ok {
+		status = &runStatus{}
+		h.runs[operation] = status
+	}
+	now := time.Now()
+	if err != nil {
+		status.LastFailure = &now
+		status.LastError = sanitize.Error(err)
+		return
+	}
+	status.LastSuccess = &now
+}
+
+// serveHealth serves /healthz, /readyz, the lottery site request metrics
+// on /metrics and the dashboard on / on addr until ctx is done.
+func (d *daemon) serveHealth(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", d.handleHealth)
+	mux.HandleFunc("GET /readyz", d.handleReady)
+	mux.HandleFunc("GET /metrics", d.handleMetrics)
+	// 다시 읽은 설정의 토큰과 저장소를 쓰도록 요청마다 구성
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		cfg := d.watcher.Current()
+		requireScope(cfg.Serve, config.ScopeRead, dashboardHandler(d.app, cfg, false))(w, r)
+	})
+	httpServer := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logging.Infof("🩺 헬스 체크 서버 시작: http://%s/healthz, /readyz, /metrics (대시보드: /)", addr)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("헬스 체크 서버 실행 실패: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return httpServer.Shutdown(shutdownCtx)
+}
+
+// handleHealth reports that the daemon process is alive.
+func (d *daemon) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, map[string]any{
+		"status":        "ok",
+		"uptimeSeconds": int64(time.Since(d.health.started).Seconds()),
+	})
+}
+
+// handleMetrics reports the lottery site request metrics in the Prometheus
+// text format.
+func (d *daemon) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	upstream.write(w)
+}
+
+// readiness is the /readyz response.
+type readiness struct {
+	Status   string                  `json:"status"`
+	Problems []string                `json:"problems,omitempty"`
+	Runs     map[string]*runStatus   `json:"runs"`
+	Sessions map[string]sessionCheck `json:"sessions"`
+	Store    *storeCheck             `json:"store,omitempty"`
+}
+
+type storeCheck struct {
+	Reachable bool      `json:"reachable"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// handleReady reports 503 while the store is unreachable or a lottery
+// session could not be kept alive, along with the last run of every job.
+func (d *daemon) handleReady(w http.ResponseWriter, r *http.Request) {
+	ready := readiness{Status: "ready", Sessions: d.sessions.status()}
+
+	accounts := make([]string, 0, len(ready.Sessions))
+	for account := range ready.Sessions {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	for _, account := range accounts {
+		if check := ready.Sessions[account]; !check.Valid {
+			ready.Problems = append(ready.Problems, fmt.Sprintf("[%s] 로그인 세션 없음: %s", account, check.Error))
+		}
+	}
+
+	if d.watcher.Current().Store.Enabled() {
+		checkedAt, err := d.checkStore()
+		ready.Store = &storeCheck{Reachable: err == nil, CheckedAt: checkedAt}
+		if err != nil {
+			ready.Store.Error = sanitize.Error(err)
+			ready.Problems = append(ready.Problems, fmt.Sprintf("저장소 연결 실패: %v", err))
+		}
+	}
+
+	d.health.mu.Lock()
+	ready.Runs = make(map[string]*runStatus, len(d.health.runs))
+	for operation, status := range d.health.runs {
+		copied := *status
+		ready.Runs[operation] = &copied
+	}
+	d.health.mu.Unlock()
+
+	status := http.StatusOK
+	if len(ready.Problems) > 0 {
+		ready.Status = "not ready"
+		status = http.StatusServiceUnavailable
+	}
+	writeResponse(w, status, ready)
+}
+
+// checkStore opens and closes the store to see that it is reachable. While
+// a job is running (and may hold the store open) the previous result is
+// returned instead.
+func (d *daemon) checkStore() (time.Time, error) {
+	if d.mu.TryLock() {
+		err := d.openStore()
+		d.mu.Unlock()
+
+		d.health.mu.Lock()
+		d.health.storeErr, d.health.storeChecked = err, time.Now()
+		d.health.mu.Unlock()
+	}
+
+	d.health.mu.Lock()
+	defer d.health.mu.Unlock()
+	return d.health.storeChecked, d.health.storeErr
+}
+
+// openStore opens the store without marking it used, so a probe does not
+// trigger a backup.
+func (d *daemon) openStore() error {
+	key, err := d.cfg.Store.Key()
+	if err != nil {
+		return err
+	}
+	ledger, err := store.Open(d.cfg.Store.Driver, d.cfg.Store.Location(), key)
+	if err != nil {
+		return err
+	}
+	return ledger.Close()
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"weekly-lotto/internal/healthcheck"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+)
+
+// monitor sends the start ping of a run to the healthchecks URL and returns
+// a function that pings the run's outcome. With an empty URL both do
+// nothing. Ping failures are logged, not returned, so an unreachable
+// monitor never fails a purchase.
+func monitor(ctx context.Context, url string) func(err error) {
+	if url == "" {
+		return func(error) {}
+	}
+	if err := healthcheck.Start(ctx, url); err != nil {
+		logging.Warnf("⚠️  healthchecks 시작 핑 실패: %v", err)
+	}
+	return func(err error) {
+		// 중단 신호로 실행이 끝나는 경우에도 결과는 전송
+		ctx := context.WithoutCancel(ctx)
+		var pingErr error
+		// 확인할 구매가 없는 것은 실행 자체는 정상적으로 끝난 것
+		if err == nil || errors.Is(err, lottery.ErrNoPurchases) {
+			pingErr = healthcheck.Success(ctx, url)
+		} else {
+			pingErr = healthcheck.Fail(ctx, url, err.Error())
+		}
+		if pingErr != nil {
+			logging.Warnf("⚠️  healthchecks 결과 핑 실패: %v", pingErr)
+		}
+	}
+}
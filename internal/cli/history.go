@@ -0,0 +1,407 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/store"
+)
+
+// defaultOnlineHistoryDays bounds online lookups when no range is given.
+const defaultOnlineHistoryDays = 30
+
+var historyCommand = &command{
+	name:    "history",
+	usage:   "history [--source local|online] [--sync] [flags]",
+	summary: "저장된(또는 온라인) 구매 내역을 회차/날짜/등수로 필터링해 출력합니다",
+	run:     runHistory,
+}
+
+// historyEntry is a single ticket in the history output.
+type historyEntry struct {
+	Account     string     `json:"account"`
+	Round       int        `json:"round"`
+	OrderNo     string     `json:"orderNo,omitempty"`
+	Slot        string     `json:"slot"`
+	Mode        string     `json:"mode"`
+	Numbers     []int      `json:"numbers"`
+	Amount      int64      `json:"amount"`
+	PurchasedAt *time.Time `json:"purchasedAt,omitempty"`
+	Result      string     `json:"result"`
+	Rank        int        `json:"rank,omitempty"`
+	Prize       int64      `json:"prize"`
+
+	drawn   bool
+	rank    domain.WinningRank
+	winning *domain.WinningNumbers
+}
+
+// historyFilter holds the parsed history flags.
+type historyFilter struct {
+	store.PurchaseFilter
+	ranks map[domain.WinningRank]bool
+}
+
+func runHistory(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	source := fs.String("source", "", "조회 대상 (local: 로컬 구매 장부, online: 동행복권 사이트). 기본값: 저장소가 설정되어 있으면 local")
+	account := fs.String("account", "", "계정 이름으로 필터링")
+	round := fs.Int("round", 0, "특정 회차만 조회")
+	fromRound := fs.Int("from-round", 0, "시작 회차 (포함)")
+	toRound := fs.Int("to-round", 0, "끝 회차 (포함)")
+	since := fs.String("since", "", "시작 구매일 (YYYY-MM-DD, 포함)")
+	until := fs.String("until", "", "끝 구매일 (YYYY-MM-DD, 포함)")
+	rank := fs.String("rank", "", "등수로 필터링 (예: 1,2,3 / win: 당첨 전체 / none: 낙첨)")
+	sync := fs.Bool("sync", false, "사이트에서 조회한 구매 내역을 로컬 구매 장부에 반영 (--source online, 주문번호 기준으로 중복 없이)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	filter, err := parseHistoryFilter(*account, *round, *fromRound, *toRound, *since, *until, *rank)
+	if err != nil {
+		return usageError(fs, err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	if *source == "" {
+		*source = "online"
+		if cfg.Store.Enabled() && !*sync {
+			*source = "local"
+		}
+	}
+	if *sync && *source != "online" {
+		return usageError(fs, fmt.Errorf("--sync 는 --source online 에서만 쓸 수 있습니다"))
+	}
+
+	var entries []historyEntry
+	switch *source {
+	case "local":
+		entries, err = localHistory(ctx, app, cfg, filter)
+	case "online":
+		var ledger store.Store
+		if *sync {
+			if ledger, err = openLedger(app, cfg); err != nil {
+				return err
+			}
+		}
+		entries, err = onlineHistory(ctx, app, cfg, filter, ledger)
+		// 당첨 번호 조회가 같은 저장소를 다시 열기 전에 닫음
+		if ledger != nil {
+			ledger.Close()
+		}
+	default:
+		return usageError(fs, fmt.Errorf("알 수 없는 조회 대상입니다: %s", *source))
+	}
+	if err != nil {
+		return err
+	}
+
+	draws := app.drawResults(ctx)
+	defer draws.close()
+	resolveResults(draws, entries)
+	entries = filter.matchRanks(entries)
+
+	if app.jsonOutput() {
+		return writeJSON(entries)
+	}
+	return writeHistoryTable(entries, app.palette())
+}
+
+// usageError prints the usage of fs after reporting err.
+func usageError(fs *flag.FlagSet, err error) error {
+	fmt.Fprintf(fs.Output(), "%v\n\n", err)
+	fs.Usage()
+	return errUsage
+}
+
+func parseHistoryFilter(account string, round, fromRound, toRound int, since, until, rank string) (*historyFilter, error) {
+	filter := &historyFilter{PurchaseFilter: store.PurchaseFilter{
+		Account:   account,
+		FromRound: fromRound,
+		ToRound:   toRound,
+	}}
+
+	if round > 0 {
+		filter.FromRound, filter.ToRound = round, round
+	}
+
+	if since != "" {
+		from, err := time.ParseInLocation("2006-01-02", since, domain.Seoul)
+		if err != nil {
+			return nil, fmt.Errorf("--since 날짜 형식 오류: %w", err)
+		}
+		filter.From = from
+	}
+	if until != "" {
+		to, err := time.ParseInLocation("2006-01-02", until, domain.Seoul)
+		if err != nil {
+			return nil, fmt.Errorf("--until 날짜 형식 오류: %w", err)
+		}
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	if rank != "" {
+		filter.ranks = make(map[domain.WinningRank]bool)
+		for _, part := range splitComma(rank) {
+			switch part {
+			case "win":
+				for _, r := range []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5} {
+					filter.ranks[r] = true
+				}
+			case "none":
+				filter.ranks[domain.RankNone] = true
+			default:
+				n, err := strconv.Atoi(part)
+				if err != nil || n < 1 || n > 5 {
+					return nil, fmt.Errorf("--rank 값은 1~5, win, none 중 하나여야 합니다: %q", part)
+				}
+				filter.ranks[domain.Rank1-domain.WinningRank(n-1)] = true
+			}
+		}
+	}
+
+	return filter, nil
+}
+
+// matchRanks keeps entries whose rank is selected. Without a rank filter
+// every entry is kept; with one, undrawn tickets are dropped.
+func (f *historyFilter) matchRanks(entries []historyEntry) []historyEntry {
+	if f.ranks == nil {
+		return entries
+	}
+	matched := entries[:0]
+	for _, entry := range entries {
+		if entry.drawn && f.ranks[entry.rank] {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// openLedger opens the configured ledger for commands that cannot work
+// without one. Callers must close the store.
+func openLedger(app *App, cfg *config.Config) (store.Store, error) {
+	ledger, err := app.OpenStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ledger == nil {
+		return nil, fmt.Errorf("로컬 구매 장부가 설정되지 않았습니다 (store.path / LOTTO_STORE_PATH)")
+	}
+	return ledger, nil
+}
+
+// localHistory reads purchases from the configured ledger.
+func localHistory(ctx context.Context, app *App, cfg *config.Config, filter *historyFilter) ([]historyEntry, error) {
+	ledger, err := openLedger(app, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer ledger.Close()
+	return ledgerHistory(ctx, ledger, filter.PurchaseFilter)
+}
+
+// ledgerHistory reads the purchases matching filter from ledger.
+func ledgerHistory(ctx context.Context, ledger store.Store, filter store.PurchaseFilter) ([]historyEntry, error) {
+	purchases, err := ledger.Purchases(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]historyEntry, 0, len(purchases))
+	for _, p := range purchases {
+		purchasedAt := p.PurchasedAt.In(domain.Seoul)
+		entries = append(entries, historyEntry{
+			Account:     p.Account,
+			Round:       p.Round,
+			OrderNo:     p.OrderNo,
+			Slot:        p.Slot,
+			Mode:        p.Mode,
+			Numbers:     p.Numbers,
+			Amount:      p.Amount,
+			PurchasedAt: &purchasedAt,
+		})
+	}
+	return entries, nil
+}
+
+// onlineHistory logs into each account and reads its purchase history from
+// the lottery site. The site only searches by date, so round bounds are
+// converted to the sale weeks of those rounds. Every purchase read is synced
+// into ledger when it is not nil.
+func onlineHistory(ctx context.Context, app *App, cfg *config.Config, filter *historyFilter, ledger store.Store) ([]historyEntry, error) {
+	start, end := filter.From, filter.To.AddDate(0, 0, -1)
+	if filter.To.IsZero() {
+		end = domain.Now()
+		if filter.ToRound > 0 {
+			end = domain.DrawDate(filter.ToRound)
+		}
+	}
+	if start.IsZero() {
+		start = end.AddDate(0, 0, -defaultOnlineHistoryDays)
+		if filter.FromRound > 0 {
+			start = domain.DrawDate(filter.FromRound).AddDate(0, 0, -7)
+		}
+	}
+
+	entries := []historyEntry{}
+	for _, account := range cfg.LotteryAccounts() {
+		if filter.Account != "" && account.Name != filter.Account {
+			continue
+		}
+
+		client, err := lottery.NewClient(ctx, account.Username, account.Password)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] 로그인 실패: %w", account.Name, err)
+		}
+		app.recordPages(cfg, client, account.Name)
+
+		histories, err := client.GetPurchases(ctx, start, end)
+		if errors.Is(err, lottery.ErrNoPurchases) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("[%s] %w", account.Name, err)
+		}
+		if ledger != nil {
+			if err := ledger.SavePurchases(ctx, sitePurchases(account.Name, histories, domain.Now())); err != nil {
+				return nil, fmt.Errorf("[%s] 구매 내역 동기화 실패: %w", account.Name, err)
+			}
+			logging.Infof("🔄 [%s] 구매 내역 %d건을 장부에 반영했습니다", account.Name, len(histories))
+		}
+
+		for _, history := range histories {
+			if (filter.FromRound > 0 && history.Round < filter.FromRound) ||
+				(filter.ToRound > 0 && history.Round > filter.ToRound) {
+				continue
+			}
+			for _, ticket := range history.Tickets {
+				entries = append(entries, historyEntry{
+					Account: account.Name,
+					Round:   history.Round,
+					OrderNo: history.OrderNo,
+					Slot:    ticket.Slot,
+					Mode:    ticket.Mode,
+					Numbers: ticket.Numbers,
+					Amount:  domain.TicketPrice,
+				})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// sitePurchases converts purchases read from the site into ledger rows. The
+// site does not tell when a ticket was bought, so tickets not recorded yet
+// are dated by the draw date of their round (or now, before the draw).
+func sitePurchases(account string, histories []lottery.PurchaseHistory, now time.Time) []store.Purchase {
+	var purchases []store.Purchase
+	for _, history := range histories {
+		purchasedAt := domain.DrawDate(history.Round)
+		if purchasedAt.After(now) {
+			purchasedAt = now
+		}
+		for _, ticket := range history.Tickets {
+			purchases = append(purchases, store.Purchase{
+				Account:     account,
+				Round:       history.Round,
+				OrderNo:     history.OrderNo,
+				Slot:        ticket.Slot,
+				Mode:        ticket.Mode,
+				Numbers:     ticket.Numbers,
+				Amount:      domain.TicketPrice,
+				PurchasedAt: purchasedAt,
+			})
+		}
+	}
+	return purchases
+}
+
+// resolveResults fills in the rank and prize of every drawn entry.
+func resolveResults(draws *drawResults, entries []historyEntry) {
+	failed := make(map[int]bool)
+	now := domain.Now()
+
+	for i := range entries {
+		entry := &entries[i]
+		entry.Result = "미추첨"
+		if domain.DrawDate(entry.Round).After(now) {
+			continue
+		}
+		if failed[entry.Round] {
+			entry.Result = "확인 불가"
+			continue
+		}
+
+		winning, err := draws.get(entry.Round)
+		if errors.Is(err, lottery.ErrNotDrawn) {
+			continue
+		}
+		if err != nil {
+			logging.Warnf("⚠️  %d회 당첨 번호 조회 실패: %v", entry.Round, err)
+			failed[entry.Round] = true
+			entry.Result = "확인 불가"
+			continue
+		}
+
+		entry.drawn = true
+		entry.winning = winning
+		entry.rank, entry.Prize = prize(entry.Numbers, winning)
+		entry.Rank = entry.rank.Number()
+		entry.Result = entry.rank.String()
+	}
+}
+
+func writeHistoryTable(entries []historyEntry, colors palette) error {
+	if len(entries) == 0 {
+		fmt.Println("조건에 맞는 구매 내역이 없습니다")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "계정\t회차\t구매일\t슬롯\t모드\t%s\t결과\t당첨금\n", colors.header("번호", domain.NumbersPerTicket))
+	for _, entry := range entries {
+		purchasedAt := "-"
+		if entry.PurchasedAt != nil {
+			purchasedAt = entry.PurchasedAt.In(domain.Seoul).Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s원\n",
+			entry.Account, entry.Round, purchasedAt, entry.Slot, entry.Mode,
+			colors.balls(entry.Numbers, entry.winning, entry.rank), entry.Result, utils.FormatAmount(entry.Prize))
+	}
+	return w.Flush()
+}
+
+// writeJSON prints v as indented JSON on stdout.
+func writeJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// splitComma splits a comma-separated flag value, dropping blanks.
+func splitComma(s string) []string {
+	var parts []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
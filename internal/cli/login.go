@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/parser"
+	"weekly-lotto/internal/sanitize"
+)
+
+var loginCommand = &command{
+	name:    "login",
+	usage:   "login [--account NAME] [flags]",
+	summary: "세션 생성과 로그인만 수행해 계정별 성공/실패 원인(비밀번호 오류, 잠김, 휴면, 점검)을 출력합니다",
+	run:     runLogin,
+}
+
+// loginResult is the outcome of a login check.
+type loginResult struct {
+	Account string `json:"account"`
+	OK      bool   `json:"ok"`
+	Reason  string `json:"reason,omitempty"`
+	Hint    string `json:"hint,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func runLogin(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	account := fs.String("account", "", "확인할 계정 이름 (기본: 전체)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	results := []loginResult{}
+	for _, acc := range cfg.LotteryAccounts() {
+		if *account != "" && acc.Name != *account {
+			continue
+		}
+
+		result := checkLogin(ctx, acc)
+		if result.OK {
+			logging.Infof("✅ [%s] 로그인 성공", acc.Name)
+		} else {
+			logging.Errorf("❌ [%s] %s - %s", acc.Name, result.Reason, result.Hint)
+			errs = append(errs, accountFailed(acc.Name, result.err))
+		}
+		results = append(results, result.loginResult)
+	}
+
+	if app.jsonOutput() {
+		if err := writeJSON(results); err != nil {
+			return err
+		}
+	}
+
+	return accountsFailed("로그인이 실패했습니다", errs)
+}
+
+type loginCheck struct {
+	loginResult
+	err error
+}
+
+// checkLogin initializes a session and logs in without touching anything
+// else, classifying a failure into a reason and a hint.
+func checkLogin(ctx context.Context, account config.AccountConfig) loginCheck {
+	check := loginCheck{loginResult: loginResult{Account: account.Name}}
+
+	_, err := login(ctx, account)
+	if err == nil {
+		check.OK = true
+		return check
+	}
+	check.err = err
+	check.Error = sanitize.Error(err)
+
+	var loginErr *parser.LoginError
+	switch {
+	case errors.Is(err, lottery.ErrMaintenance):
+		check.Reason = "사이트 점검 중"
+		check.Hint = "점검이 끝난 뒤 다시 시도하세요"
+	case errors.As(err, &loginErr):
+		check.Reason = loginErr.Reason.String()
+		switch loginErr.Reason {
+		case parser.LoginLocked:
+			check.Hint = "동행복권 사이트에서 본인 인증 후 잠금을 해제하세요"
+		case parser.LoginDormant:
+			check.Hint = "동행복권 사이트에서 휴면 해제 후 다시 시도하세요"
+		default:
+			check.Hint = fmt.Sprintf("%s 계정의 아이디/비밀번호 설정을 확인하세요", account.Name)
+		}
+	default:
+		check.Reason = "접속 실패"
+		check.Hint = check.Error
+	}
+	return check
+}
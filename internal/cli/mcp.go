@@ -0,0 +1,307 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/mcp"
+	"weekly-lotto/internal/sanitize"
+)
+
+const (
+	// mcpConfirmTimeout is how long an emailed buy_tickets confirmation code
+	// stays valid.
+	mcpConfirmTimeout = 5 * time.Minute
+	// mcpHistoryLimit is how many tickets get_history returns by default.
+	mcpHistoryLimit = 50
+)
+
+var mcpCommand = &command{
+	name:        "mcp",
+	usage:       "mcp [flags]",
+	summary:     "LLM 도우미가 당첨 번호, 구매 내역 조회와 당첨 확인, 확인을 거친 구매를 할 수 있는 MCP 서버를 stdio로 실행합니다",
+	run:         runMCP,
+	longRunning: true,
+}
+
+const mcpInstructions = `동행복권 로또 6/45 자동 구매 도구 weekly-lotto입니다. 당첨 번호와 구매 내역은 자유롭게 조회해도 되지만, buy_tickets는 실제 돈이 나가므로 사용자가 구매를 요청했을 때만 호출하세요. 구매는 사용자가 직접 승인해야 합니다: 클라이언트가 지원하면 사용자에게 바로 확인 창을 띄우고, 지원하지 않으면 사용자 이메일로 확인 코드를 보내므로 구매 계획을 보여 주고 사용자가 알려 준 코드를 confirmation에 넣어 다시 호출하세요.`
+
+// mcpTools serves the serve operations as MCP tools. Purchases are approved
+// by the user out of the model's reach: through an elicitation shown by the
+// client, or else with a code emailed to the user that the model only learns
+// when the user tells it.
+type mcpTools struct {
+	cfg *config.Config
+	ops *server
+
+	// pending is the latest emailed confirmation code, honored once.
+	pending pendingBuy
+}
+
+// mcpBuyPlan is what buy_tickets would buy, returned while the emailed
+// confirmation code is awaited.
+type mcpBuyPlan struct {
+	Round     int                   `json:"round"`
+	Accounts  []string              `json:"accounts"`
+	Tickets   []config.TicketConfig `json:"tickets"`
+	Amount    int64                 `json:"amount"`
+	ExpiresAt time.Time             `json:"expiresAt"`
+	Message   string                `json:"message"`
+}
+
+// mcpConfirmSchema is the form of the purchase elicitation.
+var mcpConfirmSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"confirm": map[string]any{"type": "boolean", "title": "구매", "description": "위 내용대로 실제로 구매합니다"},
+	},
+	"required": []string{"confirm"},
+}
+
+func runMCP(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	sheet, err := app.Sheet(cfg)
+	if err != nil {
+		return err
+	}
+
+	// stdout은 프로토콜 전용이므로 다른 출력이 섞이지 않게 stderr로 돌림
+	protocol := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = protocol }()
+
+	t := &mcpTools{
+		cfg: cfg,
+		ops: &server{app: app, cfg: cfg, started: time.Now(), sheet: sheet, source: "mcp"},
+	}
+	server := &mcp.Server{Name: Program, Version: Version, Instructions: mcpInstructions, Tools: t.tools()}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	logging.Infof("🤖 MCP 서버 시작 (stdio)")
+	return server.Serve(ctx, os.Stdin, protocol)
+}
+
+func (t *mcpTools) tools() []mcp.Tool {
+	round := map[string]any{"type": "integer", "minimum": 1, "description": "회차"}
+	tools := []mcp.Tool{
+		{
+			Name:        "get_winning_numbers",
+			Description: "회차의 당첨 번호, 보너스 번호, 등수별 당첨금을 조회합니다. round를 생략하면 최신 회차입니다.",
+			InputSchema: objectSchema(map[string]any{"round": round}),
+			Annotations: &mcp.ToolAnnotations{Title: "당첨 번호 조회", ReadOnlyHint: true, IdempotentHint: true, OpenWorldHint: true},
+			Call:        t.winningNumbers,
+		},
+		{
+			Name:        "get_history",
+			Description: fmt.Sprintf("로컬 구매 장부의 구매 내역과 티켓별 당첨 결과를 조회합니다. 조건에 맞는 티켓 중 가장 최근 limit장(기본 %d장)을 오래된 순으로 돌려주고, total은 전체 장수입니다.", mcpHistoryLimit),
+			InputSchema: objectSchema(map[string]any{
+				"account":    map[string]any{"type": "string", "description": "계정 이름"},
+				"round":      round,
+				"from_round": map[string]any{"type": "integer", "minimum": 1, "description": "시작 회차 (포함)"},
+				"to_round":   map[string]any{"type": "integer", "minimum": 1, "description": "끝 회차 (포함)"},
+				"since":      map[string]any{"type": "string", "description": "시작 구매일 YYYY-MM-DD (포함)"},
+				"until":      map[string]any{"type": "string", "description": "끝 구매일 YYYY-MM-DD (포함)"},
+				"rank":       map[string]any{"type": "string", "description": "등수 필터 (예: 1,2,3 또는 win, none)"},
+				"limit":      map[string]any{"type": "integer", "minimum": 1, "description": "최대 티켓 수"},
+			}),
+			Annotations: &mcp.ToolAnnotations{Title: "구매 내역 조회", ReadOnlyHint: true, IdempotentHint: true, OpenWorldHint: true},
+			Call:        t.history,
+		},
+		{
+			Name:        "check_results",
+			Description: "모든 계정의 최신 회차 당첨 여부를 사이트에서 확인하고, 설정에 따라 결과 알림을 보냅니다.",
+			InputSchema: objectSchema(map[string]any{}),
+			Annotations: &mcp.ToolAnnotations{Title: "당첨 확인", IdempotentHint: true, OpenWorldHint: true},
+			Call:        t.checkResults,
+		},
+		{
+			Name: "buy_tickets",
+			Description: "설정된 티켓을 모든 계정으로 구매합니다. 실제 돈이 나가므로 사용자가 직접 승인해야 합니다: " +
+				"클라이언트가 elicitation을 지원하면 호출 중에 사용자에게 확인 창을 띄우고, 지원하지 않으면 아무것도 사지 않고 " +
+				"구매 계획을 돌려주며 사용자 이메일로 확인 코드를 보냅니다. 그때는 계획을 사용자에게 보여 주고, 사용자가 알려 준 코드를 " +
+				fmt.Sprintf("confirmation에 넣어 다시 호출하세요. 코드는 한 번만, %s 동안 쓸 수 있습니다. dry_run이면 확인 없이 구매 가능 여부만 미리 봅니다.", mcpConfirmTimeout),
+			InputSchema: objectSchema(map[string]any{
+				"confirmation": map[string]any{"type": "string", "description": "사용자가 이메일로 받아 알려 준 확인 코드"},
+				"dry_run":      map[string]any{"type": "boolean", "description": "사이트에 로그인해 구매 가능 여부만 확인하고 구매하지 않음"},
+			}),
+			Annotations: &mcp.ToolAnnotations{Title: "로또 구매", DestructiveHint: true, OpenWorldHint: true},
+			Call:        t.buyTickets,
+		},
+	}
+	for i, tool := range tools {
+		// 모델에게 가는 오류 메시지에서도 민감 정보를 가림
+		tools[i].Call = func(ctx context.Context, arguments json.RawMessage) (any, error) {
+			value, err := tool.Call(ctx, arguments)
+			if err != nil {
+				return nil, errors.New(sanitize.Error(err))
+			}
+			return value, nil
+		}
+	}
+	return tools
+}
+
+// objectSchema is the JSON Schema of an arguments object with properties.
+func objectSchema(properties map[string]any) map[string]any {
+	return map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+}
+
+func (t *mcpTools) winningNumbers(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Round int `json:"round"`
+	}
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	return t.ops.winning(ctx, args.Round)
+}
+
+func (t *mcpTools) history(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Account   string `json:"account"`
+		Round     int    `json:"round"`
+		FromRound int    `json:"from_round"`
+		ToRound   int    `json:"to_round"`
+		Since     string `json:"since"`
+		Until     string `json:"until"`
+		Rank      string `json:"rank"`
+		Limit     int    `json:"limit"`
+	}
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+	if args.Limit <= 0 {
+		args.Limit = mcpHistoryLimit
+	}
+	entries, err := t.ops.purchases(ctx, historyQuery{
+		Account: args.Account, Round: args.Round, FromRound: args.FromRound, ToRound: args.ToRound,
+		Since: args.Since, Until: args.Until, Rank: args.Rank,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 오래된 것부터 오므로 최신 구매를 남김
+	return map[string]any{"total": len(entries), "tickets": entries[max(0, len(entries)-args.Limit):]}, nil
+}
+
+func (t *mcpTools) checkResults(ctx context.Context, arguments json.RawMessage) (any, error) {
+	if err := mcp.DecodeArguments(arguments, &struct{}{}); err != nil {
+		return nil, err
+	}
+	results, err := t.ops.checkAll(ctx)
+	if results == nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (t *mcpTools) buyTickets(ctx context.Context, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Confirmation string `json:"confirmation"`
+		DryRun       bool   `json:"dry_run"`
+	}
+	if err := mcp.DecodeArguments(arguments, &args); err != nil {
+		return nil, err
+	}
+
+	if args.DryRun {
+		return t.buy(ctx, true)
+	}
+	if args.Confirmation == "" {
+		plan := t.plan()
+		answer, err := mcp.Elicit(ctx, plan.prompt(), mcpConfirmSchema)
+		switch {
+		case errors.Is(err, mcp.ErrElicitationUnsupported):
+			return t.mailConfirmation(plan)
+		case err != nil:
+			return nil, err
+		case !answer.Accepted("confirm"):
+			logging.Infof("🤖 MCP 구매를 사용자가 승인하지 않음 (%s)", answer.Action)
+			return "사용자가 구매를 승인하지 않아 아무것도 구매하지 않았습니다.", nil
+		}
+		logging.Infof("🤖 MCP 구매를 사용자가 승인함 - 구매를 진행합니다")
+		return t.buy(ctx, false)
+	}
+
+	pending := t.pending
+	t.pending = pendingBuy{}
+	switch {
+	case pending.id == "" || args.Confirmation != pending.id:
+		return nil, errors.New("확인 코드가 올바르지 않거나 이미 사용되었습니다 - confirmation 없이 다시 호출하면 새 코드를 사용자 이메일로 보냅니다")
+	case time.Now().After(pending.expires):
+		return nil, fmt.Errorf("확인 코드가 만료되었습니다 (%s) - confirmation 없이 다시 호출하면 새 코드를 사용자 이메일로 보냅니다", mcpConfirmTimeout)
+	}
+	logging.Infof("🤖 MCP 구매 확인 코드 확인됨 - 구매를 진행합니다")
+	return t.buy(ctx, false)
+}
+
+// plan returns what buy_tickets would buy.
+func (t *mcpTools) plan() *mcpBuyPlan {
+	plan := &mcpBuyPlan{
+		Round:   domain.RoundOn(domain.NextSalesOpen(domain.Now())),
+		Tickets: t.cfg.Purchase.Tickets,
+	}
+	for _, account := range t.cfg.LotteryAccounts() {
+		plan.Accounts = append(plan.Accounts, account.Name)
+	}
+	plan.Amount = int64(len(plan.Accounts)*len(plan.Tickets)) * domain.TicketPrice
+	return plan
+}
+
+// prompt is the question of the purchase elicitation.
+func (p *mcpBuyPlan) prompt() string {
+	return fmt.Sprintf("도우미가 %d회 로또를 계정 %d개(%s)로 %d장씩, 모두 %s원어치 구매하려고 합니다. 구매할까요?",
+		p.Round, len(p.Accounts), strings.Join(p.Accounts, ", "), len(p.Tickets), utils.FormatAmount(p.Amount))
+}
+
+// mailConfirmation emails a new confirmation code to the user, replacing
+// the previous one, and returns plan without it: the model learns the code
+// only when the user tells it.
+func (t *mcpTools) mailConfirmation(plan *mcpBuyPlan) (*mcpBuyPlan, error) {
+	pending := pendingBuy{id: newConfirmCode(), expires: time.Now().Add(mcpConfirmTimeout)}
+	if err := t.ops.app.EmailSender(t.cfg).SendPurchaseConfirmation(pending.id, plan.Accounts, plan.Round, plan.Amount, pending.expires); err != nil {
+		return nil, fmt.Errorf("구매 확인 코드를 보내지 못해 구매할 수 없습니다 - elicitation을 지원하는 클라이언트를 쓰거나 이메일 설정을 확인하세요: %w", err)
+	}
+	t.pending = pending
+	logging.Infof("🤖 MCP 구매 확인 코드를 이메일로 보냄 (%s까지 유효)", pending.expires.Format("15:04"))
+
+	plan.ExpiresAt = pending.expires
+	plan.Message = "아직 아무것도 구매하지 않았습니다. 구매 확인 코드를 사용자 이메일로 보냈습니다. 이 계획을 사용자에게 보여 주고, 사용자가 구매에 동의하며 코드를 알려 주면 그 코드를 confirmation에 넣어 buy_tickets를 다시 호출하세요."
+	return plan, nil
+}
+
+// newConfirmCode returns a random six-digit code for the user to pass on.
+// A wrong code voids the pending one, so it cannot be guessed.
+func newConfirmCode() string {
+	n, _ := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	return fmt.Sprintf("%06d", n)
+}
+
+func (t *mcpTools) buy(ctx context.Context, dryRun bool) (any, error) {
+	results, err := t.ops.buyAll(ctx, dryRun)
+	if results == nil {
+		return nil, err
+	}
+	return results, nil
+}
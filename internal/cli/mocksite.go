@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery/lotterytest"
+)
+
+var mockSiteCommand = &command{
+	name:        "mock-site",
+	usage:       "mock-site [--addr HOST:PORT] [--deposit WON] [flags]",
+	summary:     "설정된 계정으로 로그인할 수 있는 모의 동행복권 사이트를 실행합니다 (LOTTO_DHLOTTERY_URL로 연결해 실제 계정 없이 구매/확인 흐름 테스트)",
+	run:         runMockSite,
+	longRunning: true,
+}
+
+func runMockSite(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	addr := fs.String("addr", "127.0.0.1:8645", "listen 주소")
+	deposit := fs.Int64("deposit", 50_000, "계정마다 처음 넣어 둘 예치금 (원)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *deposit < 0 {
+		return usageError(fs, fmt.Errorf("--deposit 은 0 이상이어야 합니다: %d", *deposit))
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	var accounts []lotterytest.Account
+	for _, account := range cfg.LotteryAccounts() {
+		accounts = append(accounts, lotterytest.Account{Username: account.Username, Password: account.Password, Deposit: *deposit})
+	}
+	site := lotterytest.NewSite(domain.Now(), accounts...)
+	httpServer := &http.Server{Addr: *addr, Handler: site, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logging.Infof("🧪 모의 동행복권 사이트 시작: http://%s (계정 %d개, 예치금 %d원)", *addr, len(accounts), *deposit)
+		logging.Infof("   LOTTO_DHLOTTERY_URL=http://%s 로 다른 명령을 실행하면 이 사이트로 요청합니다", *addr)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("모의 사이트 실행 실패: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("모의 사이트 종료 실패: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/mqtt"
+)
+
+// Topics published under mqtt.topicPrefix. Account topics are nested under
+// the account id, e.g. weekly-lotto/default/deposit.
+const (
+	mqttTopicNextDraw = "next_draw"
+	mqttTopicDeposit  = "deposit"
+	mqttTopicResult   = "result"
+	mqttTopicWin      = "win"
+)
+
+// mqttResult is the retained payload of an account's result topic.
+type mqttResult struct {
+	Round int `json:"round"`
+	// Rank is the best rank of the round, 0 without a win.
+	Rank     int   `json:"rank"`
+	Winnings int64 `json:"winnings"`
+}
+
+// mqttWin is the payload of the win event, in the shape Home Assistant
+// event entities expect.
+type mqttWin struct {
+	EventType string `json:"event_type"`
+	mqttResult
+}
+
+// publishBuy publishes the deposits left after a buy run.
+func publishBuy(ctx context.Context, cfg *config.Config, results []*buyResult) {
+	var messages []mqtt.Message
+	for _, result := range results {
+		if result.deposit != nil {
+			messages = append(messages, depositMessage(cfg.MQTT, result.Account, *result.deposit))
+		}
+	}
+	publishMQTT(ctx, cfg, messages)
+}
+
+// publishCheck publishes the result of every checked account, with a win
+// event for the accounts that won.
+func publishCheck(ctx context.Context, cfg *config.Config, results []*checkResult) {
+	var messages []mqtt.Message
+	for _, result := range results {
+		if result.Error != "" || result.Round == 0 {
+			continue
+		}
+		state := mqttResult{Round: result.Round, Winnings: result.Winnings}
+		for _, ticket := range result.Tickets {
+			if ticket.Rank > 0 && (state.Rank == 0 || ticket.Rank < state.Rank) {
+				state.Rank = ticket.Rank
+			}
+		}
+		messages = append(messages, mqtt.Message{
+			Topic:   accountTopic(cfg.MQTT, result.Account, mqttTopicResult),
+			Payload: mustJSON(state),
+			Retain:  true,
+		})
+		if state.Rank > 0 {
+			// 이벤트는 보관하지 않아 재접속한 구독자가 같은 당첨을 다시 받지 않음
+			messages = append(messages, mqtt.Message{
+				Topic:   accountTopic(cfg.MQTT, result.Account, mqttTopicWin),
+				Payload: mustJSON(mqttWin{EventType: "win", mqttResult: state}),
+			})
+		}
+	}
+	publishMQTT(ctx, cfg, messages)
+}
+
+// publishBalances publishes the deposits of balance snapshots.
+func publishBalances(ctx context.Context, cfg *config.Config, snapshots []*domain.BalanceSnapshot) {
+	var messages []mqtt.Message
+	for _, snapshot := range snapshots {
+		messages = append(messages, depositMessage(cfg.MQTT, snapshot.Account, snapshot.Deposit))
+	}
+	publishMQTT(ctx, cfg, messages)
+}
+
+// publishMQTT publishes messages along with the Home Assistant discovery
+// configs and the next draw time, when a broker is configured. Failures are
+// logged, not returned.
+func publishMQTT(ctx context.Context, cfg *config.Config, messages []mqtt.Message) {
+	if !cfg.MQTT.Enabled() {
+		return
+	}
+	_, next := domain.NextDraw(domain.Now())
+	all := append(discoveryMessages(cfg), mqtt.Message{
+		Topic:   cfg.MQTT.TopicPrefix + "/" + mqttTopicNextDraw,
+		Payload: []byte(next.Format(time.RFC3339)),
+		Retain:  true,
+	})
+	all = append(all, messages...)
+
+	// 같은 클라이언트 ID로 접속하면 브로커가 먼저 접속한 쪽을 끊으므로 실행마다 다르게
+	clientID := fmt.Sprintf("weekly-lotto-%d", os.Getpid())
+	// 중단 신호로 실행이 끝나는 경우에도 결과는 발행
+	if err := mqtt.Publish(context.WithoutCancel(ctx), cfg.MQTT.URL, cfg.MQTT.Username, cfg.MQTT.Password, clientID, all); err != nil {
+		logging.Warnf("⚠️  %v", err)
+		return
+	}
+	logging.Debugf("📡 MQTT 메시지 %d건 발행 완료", len(all))
+}
+
+// discoveryMessages returns the retained Home Assistant discovery configs:
+// a next draw sensor, and deposit, rank and winnings sensors and a win
+// event per account.
+func discoveryMessages(cfg *config.Config) []mqtt.Message {
+	m := cfg.MQTT
+	device := map[string]any{
+		"identifiers":  []string{"weekly_lotto"},
+		"name":         "Weekly Lotto",
+		"manufacturer": Program,
+	}
+	messages := []mqtt.Message{discoveryMessage(m, "sensor", "next_draw", map[string]any{
+		"name":         "다음 추첨",
+		"state_topic":  m.TopicPrefix + "/" + mqttTopicNextDraw,
+		"device_class": "timestamp",
+		"icon":         "mdi:clover",
+		"device":       device,
+	})}
+
+	for _, account := range cfg.LotteryAccounts() {
+		id := mqttID(account.Name)
+		device := map[string]any{
+			"identifiers":  []string{"weekly_lotto_" + id},
+			"name":         "Weekly Lotto " + account.Name,
+			"manufacturer": Program,
+			"via_device":   "weekly_lotto",
+		}
+		result := accountTopic(m, account.Name, mqttTopicResult)
+		messages = append(messages,
+			discoveryMessage(m, "sensor", id+"_deposit", map[string]any{
+				"name":                "예치금",
+				"state_topic":         accountTopic(m, account.Name, mqttTopicDeposit),
+				"device_class":        "monetary",
+				"unit_of_measurement": "KRW",
+				"device":              device,
+			}),
+			discoveryMessage(m, "sensor", id+"_rank", map[string]any{
+				"name":                  "최근 당첨 등수",
+				"state_topic":           result,
+				"value_template":        "{{ value_json.rank }}",
+				"json_attributes_topic": result,
+				"icon":                  "mdi:trophy",
+				"device":                device,
+			}),
+			discoveryMessage(m, "sensor", id+"_winnings", map[string]any{
+				"name":                "최근 당첨금",
+				"state_topic":         result,
+				"value_template":      "{{ value_json.winnings }}",
+				"device_class":        "monetary",
+				"unit_of_measurement": "KRW",
+				"device":              device,
+			}),
+			discoveryMessage(m, "event", id+"_win", map[string]any{
+				"name":        "당첨",
+				"state_topic": accountTopic(m, account.Name, mqttTopicWin),
+				"event_types": []string{"win"},
+				"icon":        "mdi:party-popper",
+				"device":      device,
+			}),
+		)
+	}
+	return messages
+}
+
+// discoveryMessage returns the retained discovery config of one entity.
+func discoveryMessage(m config.MQTTConfig, component, objectID string, entity map[string]any) mqtt.Message {
+	entity["unique_id"] = "weekly_lotto_" + objectID
+	entity["object_id"] = "weekly_lotto_" + objectID
+	return mqtt.Message{
+		Topic:   fmt.Sprintf("%s/%s/weekly_lotto/%s/config", m.DiscoveryPrefix, component, objectID),
+		Payload: mustJSON(entity),
+		Retain:  true,
+	}
+}
+
+func depositMessage(m config.MQTTConfig, account string, deposit int64) mqtt.Message {
+	return mqtt.Message{
+		Topic:   accountTopic(m, account, mqttTopicDeposit),
+		Payload: []byte(strconv.FormatInt(deposit, 10)),
+		Retain:  true,
+	}
+}
+
+func accountTopic(m config.MQTTConfig, account, name string) string {
+	return m.TopicPrefix + "/" + mqttID(account) + "/" + name
+}
+
+// mqttID turns an account name into a topic level and entity id. Names
+// with other characters than lowercase letters, digits, - and _ (e.g.
+// Korean names) get a checksum suffix so they stay distinct, such as
+// account_76cbbe4b for 엄마.
+func mqttID(name string) string {
+	id := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(name))
+	if id != name {
+		if id = strings.Trim(id, "_"); id == "" {
+			id = "account"
+		}
+		id = fmt.Sprintf("%s_%08x", id, crc32.ChecksumIEEE([]byte(name)))
+	}
+	return id
+}
+
+// mustJSON encodes payloads built from plain values, which cannot fail.
+func mustJSON(v any) []byte {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return payload
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"weekly-lotto/internal/budget"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/notify"
+	"weekly-lotto/internal/sanitize"
+)
+
+var notifyTestCommand = &command{
+	name:    "notify-test",
+	usage:   "notify-test [--event buy,check,failure] [--account NAME] [flags]",
+	summary: "가짜 데이터로 만든 구매/당첨/실패 등 알림을 설정된 모든 채널로 보내 알림 설정을 미리 확인합니다",
+	run:     runNotifyTest,
+}
+
+// notifyTestTag marks the subject of every test notification.
+const notifyTestTag = "[TEST]"
+
+// notifyTestResult is the outcome of sending one sample notification.
+type notifyTestResult struct {
+	Event string `json:"event"`
+	Sent  bool   `json:"sent"`
+	Error string `json:"error,omitempty"`
+}
+
+func runNotifyTest(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	events := fs.String("event", strings.Join(config.NotificationEvents, ","), "보낼 알림 이벤트 (쉼표로 구분)")
+	account := fs.String("account", "", "알림 라우팅에 사용할 계정 이름 (기본: 계정 구분 없음)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	selected := splitComma(*events)
+	for _, event := range selected {
+		if !slices.Contains(config.NotificationEvents, event) {
+			return usageError(fs, fmt.Errorf("알 수 없는 이벤트입니다: %s (%s)", event, strings.Join(config.NotificationEvents, ", ")))
+		}
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	sender := app.EmailSender(cfg).WithTag(notifyTestTag)
+	if *account != "" {
+		sender = sender.ForAccount(*account)
+	}
+
+	var errs []error
+	results := make([]notifyTestResult, 0, len(selected))
+	for _, event := range selected {
+		result := notifyTestResult{Event: event}
+		if err := sendSampleNotification(sender, event); err != nil {
+			logging.Errorf("❌ [%s] 테스트 알림 전송 실패: %v", event, err)
+			result.Error = sanitize.Error(err)
+			errs = append(errs, err)
+		} else {
+			logging.Infof("✉️  [%s] 테스트 알림 전송 완료", event)
+			result.Sent = true
+		}
+		results = append(results, result)
+	}
+
+	if app.jsonOutput() {
+		if err := writeJSON(results); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d개 테스트 알림 전송이 실패했습니다: %w", len(errs), errs[0])
+	}
+	return nil
+}
+
+// sendSampleNotification renders the notification of event with dummy data
+// and sends it through sender.
+func sendSampleNotification(sender *notify.EmailSender, event string) error {
+	now := domain.Now()
+	round := domain.RoundOn(now)
+
+	switch event {
+	case config.EventBuy:
+		return sender.SendLotteryBuyMail(sampleTickets(round))
+	case config.EventCheck:
+		winning := sampleWinning(round - 1)
+		summary := domain.NewCheckSummary(winning)
+		for _, ticket := range sampleTickets(round - 1) {
+			rank := domain.CheckWinning(ticket.Numbers, winning)
+			var amount int64
+			if info, ok := winning.Prizes[rank]; ok {
+				amount = info.AmountPerWinner
+			}
+			summary.AddTicket(domain.NewTicketResult(ticket.Slot, ticket.Mode, ticket.Numbers, rank, amount))
+		}
+		return sender.SendLotteryCheckResultMail(summary)
+	case config.EventFailure:
+		return sender.SendFailureNotification("알림 테스트", "notify-test 명령이 보낸 테스트 실패 알림입니다")
+	case config.EventBudget:
+		return sender.SendBudgetExceeded(&budget.ExceededError{
+			Account: config.DefaultAccountName,
+			Period:  "주간",
+			Cap:     5000,
+			Spent:   5000,
+			Amount:  1000,
+			From:    now.AddDate(0, 0, -int(now.Weekday())),
+			To:      now.AddDate(0, 0, 7-int(now.Weekday())),
+		})
+	case config.EventBalance:
+		return sender.SendBalanceSnapshot(&domain.BalanceSnapshot{
+			Account:        config.DefaultAccountName,
+			Deposit:        50000,
+			UnclaimedPrize: 5000,
+			Round:          round,
+			RoundTickets:   5,
+			CheckedAt:      now,
+		})
+	case config.EventTopUp:
+		return sender.SendTopUpAlert(&domain.TopUpAlert{
+			Account:        config.DefaultAccountName,
+			Deposit:        2000,
+			Required:       5000,
+			VirtualAccount: "농협 790-1234-5678-90",
+			CheckedAt:      now,
+		})
+	case config.EventRotation:
+		return sender.SendRotationReminder(&domain.RotationReminder{
+			MaxAgeDays: 90,
+			Credentials: []domain.StaleCredential{
+				{Label: fmt.Sprintf("동행복권 (%s)", config.DefaultAccountName), Since: now.AddDate(0, 0, -120)},
+				{Label: "SMTP", Since: now.AddDate(0, 0, -95)},
+			},
+			CheckedAt: now,
+		})
+	default:
+		return fmt.Errorf("알 수 없는 이벤트입니다: %s", event)
+	}
+}
+
+func sampleTickets(round int) []lottery.PurchasedTicket {
+	return []lottery.PurchasedTicket{
+		{Round: round, Slot: "A", Mode: "자동", Numbers: []int{3, 11, 19, 24, 33, 41}},
+		{Round: round, Slot: "B", Mode: "수동", Numbers: []int{1, 7, 13, 22, 30, 45}},
+		{Round: round, Slot: "C", Mode: "반자동", Numbers: []int{5, 12, 18, 27, 36, 44}},
+	}
+}
+
+func sampleWinning(round int) *domain.WinningNumbers {
+	return &domain.WinningNumbers{
+		Round:       round,
+		DrawDate:    domain.DrawDate(round),
+		Numbers:     []int{3, 11, 19, 24, 36, 44},
+		BonusNumber: 33,
+		Prizes: map[domain.WinningRank]*domain.PrizeInfo{
+			domain.Rank1: {Rank: domain.Rank1, TotalAmount: 2_400_000_000, WinnerCount: 12, AmountPerWinner: 200_000_000},
+			domain.Rank2: {Rank: domain.Rank2, TotalAmount: 400_000_000, WinnerCount: 70, AmountPerWinner: 5_714_285},
+			domain.Rank3: {Rank: domain.Rank3, TotalAmount: 400_000_000, WinnerCount: 2800, AmountPerWinner: 142_857},
+			domain.Rank4: {Rank: domain.Rank4, TotalAmount: 7_000_000_000, WinnerCount: 140000, AmountPerWinner: 50_000},
+			domain.Rank5: {Rank: domain.Rank5, TotalAmount: 11_500_000_000, WinnerCount: 2300000, AmountPerWinner: 5_000},
+		},
+	}
+}
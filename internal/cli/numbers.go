@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/generator"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/store"
+)
+
+var numbersCommand = &command{
+	name:    "numbers",
+	usage:   "numbers [--strategy balanced] [--count 5] [--seed N] [--output FILE] [flags]",
+	summary: "설정된 번호 생성 전략으로 번호만 만들어 출력합니다 (동행복권 접속/구매 없음, 판매점 구매용)",
+	run:     runNumbers,
+}
+
+// generatedTicket is a locally generated set of numbers.
+type generatedTicket struct {
+	Slot     string `json:"slot"`
+	Strategy string `json:"strategy"`
+	Numbers  []int  `json:"numbers"`
+}
+
+func runNumbers(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	strategyName := fs.String("strategy", "", fmt.Sprintf("번호 생성 전략 (%s). 지정하면 설정된 구매 목록 대신 이 전략으로 생성", strings.Join(generator.Names(), ", ")))
+	count := fs.Int("count", 0, fmt.Sprintf("생성할 장수 (1~%d). 지정하면 설정된 구매 목록 대신 사용", config.MaxTicketsPerPurchase))
+	output := fs.String("output", "", "CSV/JSON 파일로도 저장 (확장자가 .json이면 JSON)")
+	seed := fs.Uint64("seed", 0, "번호 생성 시드 - 같은 시드면 같은 번호 (테스트/시연용, 0: crypto/rand)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *strategyName != "" {
+		if _, err := generator.Lookup(*strategyName); err != nil {
+			return usageError(fs, err)
+		}
+	}
+	if *count < 0 || *count > config.MaxTicketsPerPurchase {
+		return usageError(fs, fmt.Errorf("--count 는 1~%d 사이여야 합니다: %d", config.MaxTicketsPerPurchase, *count))
+	}
+
+	// 전략이나 장수를 직접 지정하면 설정 파일 없이도 동작
+	var specs []config.TicketConfig
+	if *strategyName != "" || *count > 0 {
+		if *count == 0 {
+			*count = 1
+		}
+		for range *count {
+			specs = append(specs, config.TicketConfig{Mode: "auto", Strategy: *strategyName})
+		}
+	} else {
+		cfg, err := app.Config()
+		if err != nil {
+			return err
+		}
+		specs = cfg.Purchase.Tickets
+	}
+
+	var opts []generator.Option
+	if *seed != 0 {
+		opts = append(opts, generator.WithSeed(*seed))
+	}
+	tickets, err := generateNumbers(specs, opts...)
+	if err != nil {
+		return err
+	}
+
+	if *output != "" {
+		if err := saveNumbers(*output, tickets); err != nil {
+			return err
+		}
+		logging.Infof("📝 번호 %d장을 %s에 저장했습니다", len(tickets), *output)
+	}
+
+	if app.jsonOutput() {
+		return writeJSON(tickets)
+	}
+	colors := app.palette()
+	for _, ticket := range tickets {
+		fmt.Printf("%s  %s  (%s)\n", ticket.Slot, colors.balls(ticket.Numbers, nil, domain.RankNone), ticket.Strategy)
+	}
+	return nil
+}
+
+// generateNumbers fills every basket entry with six numbers. Entries that
+// would leave numbers to the lottery site (auto/semi-auto without a
+// strategy) are completed with the random strategy.
+func generateNumbers(specs []config.TicketConfig, opts ...generator.Option) ([]generatedTicket, error) {
+	tickets := make([]generatedTicket, 0, len(specs))
+	for i, spec := range specs {
+		mode, err := domain.ParseLotto645Mode(spec.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 티켓: %w", i+1, err)
+		}
+
+		strategyName := spec.Strategy
+		if strategyName == "" && mode != domain.ModeManual {
+			strategyName = "random"
+		}
+
+		ticket, err := generator.NewTicket(mode, spec.Numbers, strategyName, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 티켓: %w", i+1, err)
+		}
+
+		if strategyName == "" {
+			strategyName = "manual"
+		}
+		tickets = append(tickets, generatedTicket{
+			Slot:     string(rune('A' + i)),
+			Strategy: strategyName,
+			Numbers:  ticket.Numbers,
+		})
+	}
+	return tickets, nil
+}
+
+func saveNumbers(path string, tickets []generatedTicket) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("파일 생성 실패: %w", err)
+	}
+	defer file.Close()
+
+	fileType := "csv"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		fileType = "json"
+	}
+
+	records := make([][]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		records = append(records, []string{ticket.Slot, ticket.Strategy, store.CSVNumbers(ticket.Numbers)})
+	}
+	if err := writeExport(file, fileType, tickets, []string{"slot", "strategy", "numbers"}, records); err != nil {
+		return fmt.Errorf("번호 저장 실패: %w", err)
+	}
+	return file.Close()
+}
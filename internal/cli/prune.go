@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/store"
+)
+
+var pruneCommand = &command{
+	name:    "prune",
+	usage:   "prune [--days N] [--dry-run] [flags]",
+	summary: "보관 기간(store.retentionDays)이 지난 구매 기록과 보관 페이지를 로컬 저장소에서 삭제하고 파일 크기를 줄입니다",
+	run:     runPrune,
+}
+
+// pruneResult is the outcome of a prune run.
+type pruneResult struct {
+	Before  time.Time `json:"before"`
+	Deleted int64     `json:"deleted"`
+	Pages   int64     `json:"pages"`
+	DryRun  bool      `json:"dryRun"`
+}
+
+func runPrune(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	days := fs.Int("days", 0, "보관 일수 (기본: store.retentionDays)")
+	dryRun := fs.Bool("dry-run", false, "삭제하지 않고 삭제 대상 건수만 출력")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	if *days == 0 {
+		*days = cfg.Store.RetentionDays
+	}
+	if *days == 0 {
+		return usageError(fs, fmt.Errorf("보관 기간이 설정되지 않았습니다 (--days, store.retentionDays / LOTTO_STORE_RETENTION_DAYS)"))
+	}
+	if *days < config.MinRetentionDays {
+		return usageError(fs, fmt.Errorf("예산 한도와 지난달 리포트에 필요한 기록을 지키기 위해 --days 는 %d 이상이어야 합니다: %d", config.MinRetentionDays, *days))
+	}
+
+	ledger, err := openLedger(app, cfg)
+	if err != nil {
+		return err
+	}
+	defer ledger.Close()
+
+	now := domain.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, domain.Seoul)
+	result := &pruneResult{Before: today.AddDate(0, 0, -*days), DryRun: *dryRun}
+
+	if *dryRun {
+		expired, err := ledger.Purchases(ctx, store.PurchaseFilter{To: result.Before})
+		if err != nil {
+			return err
+		}
+		result.Deleted = int64(len(expired))
+		pages, err := ledger.Pages(ctx, store.PageFilter{})
+		if err != nil {
+			return err
+		}
+		for _, page := range pages {
+			if page.FetchedAt.Before(result.Before) {
+				result.Pages++
+			}
+		}
+		logging.Infof("🧪 dry-run: %s 이전 구매 기록 %d건과 보관 페이지 %d개가 삭제 대상입니다",
+			result.Before.Format("2006-01-02"), result.Deleted, result.Pages)
+	} else {
+		if result.Deleted, err = ledger.PrunePurchases(ctx, result.Before); err != nil {
+			return err
+		}
+		if result.Pages, err = ledger.PrunePages(ctx, result.Before); err != nil {
+			return err
+		}
+		logging.Infof("🧹 %s 이전 구매 기록 %d건과 보관 페이지 %d개를 삭제했습니다",
+			result.Before.Format("2006-01-02"), result.Deleted, result.Pages)
+	}
+
+	if app.jsonOutput() {
+		return writeJSON(result)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/pushgateway"
+)
+
+// pushRun pushes the summary of a finished buy or check run, the run-wide
+// gauges followed by the per-account ones in metrics, when a Pushgateway is
+// configured. Push failures are logged, not returned.
+func pushRun(ctx context.Context, cfg config.PushgatewayConfig, operation string, started time.Time, err error, metrics []pushgateway.Metric) {
+	if !cfg.Enabled() {
+		return
+	}
+	success := 0.0
+	// 확인할 구매가 없는 것은 실행 자체는 정상적으로 끝난 것
+	if err == nil || errors.Is(err, lottery.ErrNoPurchases) {
+		success = 1
+	}
+	run := []pushgateway.Metric{
+		{Name: "weekly_lotto_run_success", Help: "Whether the last run succeeded (1) or failed (0).", Value: success},
+		{Name: "weekly_lotto_run_duration_seconds", Help: "Duration of the last run.", Value: time.Since(started).Seconds()},
+		{Name: "weekly_lotto_run_finished_timestamp_seconds", Help: "Unix time the last run finished.", Value: float64(time.Now().Unix())},
+	}
+
+	// 중단 신호로 실행이 끝나는 경우에도 결과는 전송
+	if err := pushgateway.Push(context.WithoutCancel(ctx), cfg.URL, cfg.Job, operation, append(run, metrics...)); err != nil {
+		logging.Warnf("⚠️  %v", err)
+		return
+	}
+	logging.Debugf("📈 Pushgateway에 %s 실행 결과 전송 완료", operation)
+}
+
+// buyMetrics returns the per-account gauges of a buy run. Accounts that did
+// not buy report zero tickets.
+func buyMetrics(results []*buyResult) []pushgateway.Metric {
+	var metrics []pushgateway.Metric
+	for _, result := range results {
+		tickets, amount := 0, int64(0)
+		if result.Status == buyPurchased {
+			tickets, amount = len(result.Tickets), result.Amount
+		}
+		labels := map[string]string{"account": result.Account}
+		metrics = append(metrics,
+			pushgateway.Metric{Name: "weekly_lotto_tickets_bought", Help: "Tickets bought by the last buy run.", Labels: labels, Value: float64(tickets)},
+			pushgateway.Metric{Name: "weekly_lotto_purchase_amount_won", Help: "Amount spent by the last buy run in KRW.", Labels: labels, Value: float64(amount)},
+		)
+		if result.Round > 0 {
+			metrics = append(metrics, pushgateway.Metric{Name: "weekly_lotto_round", Help: "Round of the last run.", Labels: labels, Value: float64(result.Round)})
+		}
+	}
+	return metrics
+}
+
+// checkMetrics returns the per-account gauges of a check run. The best rank
+// is 0 when no ticket won.
+func checkMetrics(results []*checkResult) []pushgateway.Metric {
+	var metrics []pushgateway.Metric
+	for _, result := range results {
+		best := 0
+		for _, ticket := range result.Tickets {
+			if ticket.Rank > 0 && (best == 0 || ticket.Rank < best) {
+				best = ticket.Rank
+			}
+		}
+		labels := map[string]string{"account": result.Account}
+		metrics = append(metrics,
+			pushgateway.Metric{Name: "weekly_lotto_best_rank", Help: "Best rank achieved in the last check run (0: no win).", Labels: labels, Value: float64(best)},
+			pushgateway.Metric{Name: "weekly_lotto_winnings_won", Help: "Winnings found by the last check run in KRW.", Labels: labels, Value: float64(result.Winnings)},
+			pushgateway.Metric{Name: "weekly_lotto_tickets_checked", Help: "Tickets checked by the last check run.", Labels: labels, Value: float64(len(result.Tickets))},
+		)
+		if result.Round > 0 {
+			metrics = append(metrics, pushgateway.Metric{Name: "weekly_lotto_round", Help: "Round of the last run.", Labels: labels, Value: float64(result.Round)})
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/store"
+)
+
+var reportCommand = &command{
+	name:    "report",
+	usage:   "report [--month 2026-09 | --year 2026] [--notify] [flags]",
+	summary: "로컬 구매 장부로 월간/연간 지출, 당첨금, 수익률, 등수 리포트를 만듭니다 (기본: 지난달)",
+	run:     runReport,
+}
+
+func runReport(ctx context.Context, app *App, args []string) (err error) {
+	fs := app.flags()
+	month := fs.String("month", "", "리포트 월 (YYYY-MM, 기본: 지난달)")
+	year := fs.Int("year", 0, "연간 리포트 연도 (예: 2026)")
+	account := fs.String("account", "", "계정 이름으로 필터링")
+	notify := fs.Bool("notify", false, "리포트를 이메일로도 전송")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *month != "" && *year != 0 {
+		return usageError(fs, fmt.Errorf("--month 와 --year 는 함께 쓸 수 없습니다"))
+	}
+
+	var from time.Time
+	yearly := *year != 0
+	switch {
+	case yearly:
+		from = time.Date(*year, time.January, 1, 0, 0, 0, 0, domain.Seoul)
+	case *month != "":
+		parsed, err := time.ParseInLocation("2006-01", *month, domain.Seoul)
+		if err != nil {
+			return usageError(fs, fmt.Errorf("--month 형식 오류: %w", err))
+		}
+		from = parsed
+	default:
+		from = previousMonth(domain.Now())
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	// 이메일로 보내는 실행만 예약 작업으로 보고 핑
+	if *notify {
+		done := monitor(ctx, cfg.Healthchecks.Report)
+		defer func() { done(err) }()
+	}
+
+	report, err := buildReport(ctx, app, cfg, *account, from, yearly)
+	if err != nil {
+		return err
+	}
+
+	if app.jsonOutput() {
+		if err := writeJSON(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(report.ToString())
+	}
+
+	if *notify {
+		if err := app.EmailSender(cfg).SendReport(report); err != nil {
+			return fmt.Errorf("리포트 이메일 전송 실패: %w", err)
+		}
+		logging.Infof("✉️  %s 리포트 이메일 전송 완료", report.Title)
+	}
+	return nil
+}
+
+// previousMonth returns the first day of the month before now in KST.
+func previousMonth(now time.Time) time.Time {
+	now = now.In(domain.Seoul)
+	return time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, domain.Seoul)
+}
+
+// buildReport aggregates the purchases made in the month (or, if yearly,
+// the year) starting at from. Monthly reports break down by account, yearly
+// reports by month.
+func buildReport(ctx context.Context, app *App, cfg *config.Config, account string, from time.Time, yearly bool) (*domain.Report, error) {
+	report := &domain.Report{From: from, Rows: []domain.ReportRow{}}
+	if yearly {
+		report.Title = fmt.Sprintf("%d년", from.Year())
+		report.To = from.AddDate(1, 0, 0)
+	} else {
+		report.Title = fmt.Sprintf("%d년 %d월", from.Year(), from.Month())
+		report.To = from.AddDate(0, 1, 0)
+	}
+	report.Total.Label = report.Title
+
+	ledger, err := openLedger(app, cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Draws fetched while resolving are saved through the same store, so a
+	// JSON ledger is not rewritten from two stale copies.
+	draws := newDrawResults()
+	draws.ctx, draws.ledger = ctx, ledger
+	draws.archive = func(client *lottery.Client) { app.recordPages(cfg, client, "") }
+	defer draws.close()
+
+	months, err := monthlyRows(ctx, draws, report.From, report.To)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]int)
+	for _, month := range months {
+		label := month.start.Format("2006-01")
+		if yearly {
+			rows[label] = len(report.Rows)
+			report.Rows = append(report.Rows, domain.ReportRow{Label: label})
+		}
+		for _, total := range month.rows {
+			if account != "" && total.Label != account {
+				continue
+			}
+			report.Total.Merge(total)
+
+			if !yearly {
+				label = total.Label
+			}
+			i, ok := rows[label]
+			if !ok {
+				i = len(report.Rows)
+				rows[label] = i
+				report.Rows = append(report.Rows, domain.ReportRow{Label: label})
+			}
+			report.Rows[i].Merge(total)
+		}
+	}
+	return report, nil
+}
+
+// reportMonth holds the per-account totals of one month, labeled by account.
+type reportMonth struct {
+	start time.Time
+	rows  []domain.ReportRow
+}
+
+// monthlyRows returns the per-account totals of every month in [from, to).
+// Settled months are read from the aggregates materialized in the ledger;
+// the others are computed from the raw purchases and materialized once
+// their month is over and every ticket has been drawn, so later reports do
+// not rescan them.
+func monthlyRows(ctx context.Context, draws *drawResults, from, to time.Time) ([]reportMonth, error) {
+	ledger := draws.ledger
+	stored, err := ledger.Monthly(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	materialized := make(map[string]store.MonthlySummary, len(stored))
+	for _, month := range stored {
+		materialized[month.Month.Format("2006-01")] = month
+	}
+
+	now := domain.Now()
+	var months []reportMonth
+	for start := store.MonthStart(from); start.Before(to); start = start.AddDate(0, 1, 0) {
+		month := reportMonth{start: start}
+		if summary, ok := materialized[start.Format("2006-01")]; ok {
+			logging.Debugf("🔍 %s 월별 집계: 저장소", start.Format("2006-01"))
+			for _, total := range summary.Totals {
+				month.rows = append(month.rows, domain.ReportRow{
+					Label:      total.Account,
+					Tickets:    total.Tickets,
+					Spent:      total.Spent,
+					DrawnSpent: total.Spent,
+					Winnings:   total.Winnings,
+					Ranks:      total.Ranks,
+				})
+			}
+			months = append(months, month)
+			continue
+		}
+
+		end := start.AddDate(0, 1, 0)
+		entries, err := ledgerHistory(ctx, ledger, store.PurchaseFilter{From: start, To: end})
+		if err != nil {
+			return nil, err
+		}
+		resolveResults(draws, entries)
+
+		accounts := make(map[string]int)
+		settled := !end.After(now)
+		for _, entry := range entries {
+			i, ok := accounts[entry.Account]
+			if !ok {
+				i = len(month.rows)
+				accounts[entry.Account] = i
+				month.rows = append(month.rows, domain.ReportRow{Label: entry.Account})
+			}
+			month.rows[i].Add(entry.Amount, entry.drawn, entry.rank, entry.Prize)
+			settled = settled && entry.drawn
+		}
+		months = append(months, month)
+
+		if settled {
+			if err := ledger.SaveMonthly(ctx, start, monthlyTotals(month.rows)); err != nil {
+				logging.Warnf("⚠️  %s 월별 집계 저장 실패: %v", start.Format("2006-01"), err)
+			}
+		}
+	}
+	return months, nil
+}
+
+// monthlyTotals converts the rows of a settled month for the ledger.
+func monthlyTotals(rows []domain.ReportRow) []store.MonthlyTotal {
+	totals := make([]store.MonthlyTotal, 0, len(rows))
+	for _, row := range rows {
+		totals = append(totals, store.MonthlyTotal{
+			Account:  row.Label,
+			Tickets:  row.Tickets,
+			Spent:    row.Spent,
+			Winnings: row.Winnings,
+			Ranks:    row.Ranks,
+		})
+	}
+	return totals
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/notify"
+	"weekly-lotto/internal/store"
+)
+
+// rotationRemindInterval is how often the reminder is repeated while a
+// password is still not rotated.
+const rotationRemindInterval = 7 * 24 * time.Hour
+
+// trackedCredential is a password whose age is tracked for rotation
+// reminders.
+type trackedCredential struct {
+	name   string // key in the store
+	label  string
+	secret string
+}
+
+// trackedCredentials returns the passwords of cfg: the login of every
+// lottery account and the SMTP password.
+func trackedCredentials(cfg *config.Config) []trackedCredential {
+	var tracked []trackedCredential
+	for _, account := range cfg.LotteryAccounts() {
+		tracked = append(tracked, trackedCredential{name: "lottery:" + account.Name, label: fmt.Sprintf("동행복권 (%s)", account.Name), secret: account.Password})
+	}
+	if cfg.Email.Password != "" {
+		tracked = append(tracked, trackedCredential{name: "smtp", label: "SMTP", secret: cfg.Email.Password})
+	}
+	return tracked
+}
+
+// credentialAge is the stored record of a tracked password.
+type credentialAge struct {
+	trackedCredential
+	record store.Credential
+	// changed is set when the password was first seen by this sync.
+	changed bool
+}
+
+// syncCredentials brings the records of tracked in ledger up to date: a
+// password seen for the first time, or changed since it was recorded,
+// starts a new age at now.
+func syncCredentials(ctx context.Context, ledger store.CredentialStore, tracked []trackedCredential, now time.Time) ([]credentialAge, error) {
+	stored, err := ledger.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records := make(map[string]store.Credential, len(stored))
+	for _, record := range stored {
+		records[record.Name] = record
+	}
+
+	ages := make([]credentialAge, 0, len(tracked))
+	var changed []store.Credential
+	for _, credential := range tracked {
+		record, ok := records[credential.name]
+		age := credentialAge{trackedCredential: credential, record: record}
+		if !ok || !record.Matches(credential.secret) {
+			fingerprint, err := store.Fingerprint(credential.secret)
+			if err != nil {
+				return nil, err
+			}
+			age.record = store.Credential{Name: credential.name, Fingerprint: fingerprint, Since: now}
+			age.changed = true
+			changed = append(changed, age.record)
+		}
+		ages = append(ages, age)
+	}
+	if len(changed) > 0 {
+		if err := ledger.SaveCredentials(ctx, changed); err != nil {
+			return nil, err
+		}
+	}
+	return ages, nil
+}
+
+// remindRotation tracks the age of the passwords of cfg in ledger and asks
+// to rotate those older than rotation.maxAgeDays, at most once per
+// rotationRemindInterval. Failures are logged, not returned, so a reminder
+// never fails a check.
+func remindRotation(ctx context.Context, cfg *config.Config, ledger store.Store, sender *notify.EmailSender) {
+	if ledger == nil || !cfg.Rotation.Enabled() {
+		return
+	}
+	now := domain.Now()
+	ages, err := syncCredentials(ctx, ledger, trackedCredentials(cfg), now)
+	if err != nil {
+		logging.Warnf("⚠️  비밀번호 사용 기간 기록 실패: %v", err)
+		return
+	}
+
+	maxAge := time.Duration(cfg.Rotation.MaxAgeDays) * 24 * time.Hour
+	reminder := &domain.RotationReminder{MaxAgeDays: cfg.Rotation.MaxAgeDays, CheckedAt: now}
+	var reminded []store.Credential
+	for _, age := range ages {
+		if now.Sub(age.record.Since) < maxAge || now.Sub(age.record.RemindedAt) < rotationRemindInterval {
+			continue
+		}
+		reminder.Credentials = append(reminder.Credentials, domain.StaleCredential{Label: age.label, Since: age.record.Since})
+		age.record.RemindedAt = now
+		reminded = append(reminded, age.record)
+	}
+	if len(reminded) == 0 {
+		return
+	}
+
+	if err := notifyStep(ctx, config.EventRotation, func() error { return sender.SendRotationReminder(reminder) }); err != nil {
+		logging.Warnf("⚠️  비밀번호 교체 알림 전송 실패: %v", err)
+		return
+	}
+	logging.Infof("🔑 비밀번호 %d개의 교체 알림을 보냈습니다", len(reminded))
+	if err := ledger.SaveCredentials(ctx, reminded); err != nil {
+		logging.Warnf("⚠️  비밀번호 교체 알림 기록 실패: %v", err)
+	}
+}
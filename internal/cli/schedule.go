@@ -0,0 +1,572 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+	"weekly-lotto/internal/audit"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/notify"
+	"weekly-lotto/internal/sheets"
+	"weekly-lotto/internal/tracing"
+
+	"github.com/robfig/cron/v3"
+)
+
+var scheduleCommand = &command{
+	name:        "schedule",
+	usage:       "schedule [--keepalive 20m] [--addr 127.0.0.1:8081] [flags]",
+	summary:     "설정된 cron(KST)에 따라 구매와 당첨 확인을 직접 실행하는 데몬을 띄웁니다 (GitHub Actions 불필요)",
+	run:         runSchedule,
+	longRunning: true,
+}
+
+// scheduledJob is a daemon job and the cron spec it runs on.
+type scheduledJob struct {
+	name string
+	// key names the job in the daemon state. Jobs without a key are neither
+	// resumed nor made up for after a restart.
+	key  string
+	spec string
+	run  func(context.Context)
+	// catchUp reports whether a run missed at missed while the daemon was
+	// down is still worth making after now, and when to make it.
+	catchUp func(missed, now time.Time) (at time.Time, ok bool)
+}
+
+// Daemon state keys of the scheduled jobs, named after their actions.
+const (
+	jobBuy    = config.ActionBuy
+	jobCheck  = config.ActionCheck
+	jobReport = config.ActionReport
+	jobBackup = config.ActionBackup
+)
+
+// pendingRun is a run to make up for when the daemon starts.
+type pendingRun struct {
+	job    int
+	at     time.Time
+	resume bool
+}
+
+// daemon runs scheduled jobs one at a time with shared login sessions.
+type daemon struct {
+	app *App
+	// watcher reloads the config file. A reloaded configuration replaces
+	// cfg, sender and sheet between jobs, so a run never mixes two.
+	watcher  *config.Watcher
+	cfg      *config.Config
+	sessions *sessions
+	sender   *notify.EmailSender
+	sheet    *sheets.Sheet
+	health   *daemonHealth
+	state    *daemonState
+
+	// mu serializes jobs so a keep-alive never races a purchase.
+	mu sync.Mutex
+}
+
+func runSchedule(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	keepalive := fs.Duration("keepalive", 20*time.Minute, "로그인 세션 유지 주기 (0: 사용 안 함)")
+	addr := fs.String("addr", "", "/healthz, /readyz, /metrics 와 대시보드를 제공할 listen 주소 (예: 127.0.0.1:8081, 비어 있으면 사용 안 함)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+	if *keepalive < 0 {
+		return usageError(fs, fmt.Errorf("--keepalive 는 0 이상이어야 합니다: %s", *keepalive))
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	sheet, err := app.Sheet(cfg)
+	if err != nil {
+		return err
+	}
+	state, err := loadDaemonState(cfg.Schedule.State)
+	if err != nil {
+		return err
+	}
+	d := &daemon{app: app, cfg: cfg, sessions: newSessions(), sender: app.EmailSender(cfg).WithOutbox(state), sheet: sheet, health: newDaemonHealth(), state: state}
+	d.watcher = config.NewWatcher(cfg, app.overrides, config.DefaultWatchInterval)
+	d.watcher.OnReload(d.reload)
+	d.watcher.OnError(func(err error) { logging.Warnf("⚠️  %v", err) })
+
+	if cfg.Purchase.ReadOnly {
+		logging.Infof("🔒 읽기 전용 모드 - 예정된 구매는 건너뜁니다")
+	}
+	jobs := []scheduledJob{
+		{name: "구매", key: jobBuy, spec: cfg.Schedule.Buy, run: d.buy, catchUp: whileOnSale},
+		{name: "당첨 확인", key: jobCheck, spec: cfg.Schedule.Check, run: d.check, catchUp: always},
+	}
+	if cfg.Schedule.Report != "" {
+		jobs = append(jobs, scheduledJob{name: "월간 리포트", key: jobReport, spec: cfg.Schedule.Report, run: d.report, catchUp: sameMonth})
+	}
+	if cfg.Schedule.Backup != "" {
+		jobs = append(jobs, scheduledJob{name: "백업", key: jobBackup, spec: cfg.Schedule.Backup, run: d.backup, catchUp: always})
+	}
+	if *keepalive > 0 {
+		jobs = append(jobs, scheduledJob{name: "세션 유지", spec: fmt.Sprintf("@every %s", *keepalive), run: d.keepAlive})
+	}
+	// 새 다음 실행 시각을 기록하기 전에 놓친 실행을 찾음
+	pending := d.pendingRuns(jobs, domain.Now())
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go d.watcher.Run(ctx)
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				logging.Infof("🔄 SIGHUP 수신 - 설정 파일을 다시 읽습니다")
+				_ = d.watcher.Reload() // 실패는 OnError로 기록됨
+			}
+		}
+	}()
+
+	scheduler := cron.New(cron.WithLocation(domain.Seoul))
+	ids := make([]cron.EntryID, len(jobs))
+	next := make([]func() time.Time, len(jobs))
+	for i, job := range jobs {
+		next[i] = func() time.Time { return scheduler.Entry(ids[i]).Next }
+		if ids[i], err = scheduler.AddFunc(job.spec, func() { d.runJob(ctx, job, next[i], false) }); err != nil {
+			return fmt.Errorf("%s 스케줄 등록 실패 (%s): %w", job.name, job.spec, err)
+		}
+	}
+
+	healthErr := make(chan error, 1)
+	if *addr != "" {
+		recordUpstream()
+		go func() { healthErr <- d.serveHealth(ctx, *addr) }()
+	}
+
+	scheduler.Start()
+	logging.Infof("⏰ schedule 데몬 시작 (계정 %d개, 재시도 %d회, 간격 %s)", len(cfg.LotteryAccounts()), cfg.Schedule.Retries, cfg.Schedule.RetryInterval())
+	caughtUp := make(map[int]bool)
+	for _, run := range pending {
+		caughtUp[run.job] = true
+	}
+	for i, job := range jobs {
+		logging.Infof("🗓️  %s: %s (다음 실행 %s)", job.name, job.spec, next[i]().Format("2006-01-02 15:04 MST"))
+		// 따라잡기 전에 다시 꺼져도 놓친 실행을 잊지 않도록 그대로 둠
+		if job.key != "" && !caughtUp[i] {
+			state.scheduled(job.key, next[i]())
+		}
+	}
+	if *keepalive > 0 {
+		d.locked(ctx, d.keepAlive)
+	}
+	// 판매 시작을 기다리는 구매가 다른 작업을 막지 않도록 따로 실행
+	var catchUps sync.WaitGroup
+	catchUps.Go(func() {
+		for _, run := range pending {
+			if !sleepUntil(ctx, run.at) {
+				return
+			}
+			d.runJob(ctx, jobs[run.job], next[run.job], run.resume)
+		}
+	})
+
+	select {
+	case err = <-healthErr:
+		stop()
+	case <-ctx.Done():
+		logging.Infof("🛑 종료 신호 수신 - 실행 중인 작업이 끝나길 기다립니다")
+	}
+	<-scheduler.Stop().Done()
+	catchUps.Wait()
+	return err
+}
+
+// reload applies the configuration the watcher reloaded once the running
+// job, if any, is done. The jobs are registered with their cron specs at
+// startup, so changes to those (and to the state file) are only warned
+// about and take effect on restart; everything else applies from the next
+// job on.
+func (d *daemon) reload(_, _ *config.Config) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// 동시에 다시 읽은 경우에도 마지막 설정을 적용
+	cfg := d.watcher.Current()
+	if cfg == d.cfg {
+		return
+	}
+	for _, name := range restartOnly(d.cfg, cfg) {
+		logging.Warnf("⚠️  %s 변경은 재시작해야 적용됩니다 - 기존 일정으로 계속 실행합니다", name)
+	}
+
+	sheet, err := d.app.Sheet(cfg)
+	if err != nil {
+		logging.Warnf("⚠️  %v - 기존 Google Sheets 설정을 유지합니다", err)
+		sheet = d.sheet
+	}
+	// 바뀌거나 빠진 계정의 세션은 새 비밀번호로 다시 로그인
+	for _, account := range d.cfg.LotteryAccounts() {
+		if !slices.Contains(cfg.LotteryAccounts(), account) {
+			d.sessions.forget(account.Name)
+		}
+	}
+	d.app.useConfig(cfg)
+	d.cfg, d.sender, d.sheet = cfg, d.app.EmailSender(cfg).WithOutbox(d.state), sheet
+	logging.Infof("🔄 설정 파일이 바뀌어 새 설정을 적용했습니다 (계정 %d개)", len(cfg.LotteryAccounts()))
+}
+
+// restartOnly names the settings changed from old to updated that only take
+// effect when the daemon restarts.
+func restartOnly(old, updated *config.Config) []string {
+	settings := []struct{ name, old, updated string }{
+		{"schedule.buy", old.Schedule.Buy, updated.Schedule.Buy},
+		{"schedule.check", old.Schedule.Check, updated.Schedule.Check},
+		{"schedule.report", old.Schedule.Report, updated.Schedule.Report},
+		{"schedule.backup", old.Schedule.Backup, updated.Schedule.Backup},
+		{"schedule.state", old.Schedule.State, updated.Schedule.State},
+	}
+	var changed []string
+	for _, setting := range settings {
+		if setting.old != setting.updated {
+			changed = append(changed, setting.name)
+		}
+	}
+	return changed
+}
+
+// pendingRuns returns the runs to make up for on startup: runs the last
+// shutdown interrupted, which resume where they stopped, and runs missed
+// while the daemon was down that are still worth making.
+func (d *daemon) pendingRuns(jobs []scheduledJob, now time.Time) []pendingRun {
+	var pending []pendingRun
+	for i, job := range jobs {
+		if job.key == "" {
+			continue
+		}
+		next, started := d.state.job(job.key)
+		resume := !started.IsZero()
+		missed := next
+		if resume {
+			missed = started
+		}
+		if missed.IsZero() || !missed.Before(now) {
+			continue
+		}
+
+		when := missed.In(domain.Seoul).Format("2006-01-02 15:04 MST")
+		at, ok := job.catchUp(missed, now)
+		switch {
+		case !ok && resume:
+			logging.Warnf("⚠️  %s: %s에 시작해 중단된 실행은 이제 늦어 이어서 실행하지 않습니다", job.name, when)
+			d.state.abandon(job.key)
+		case !ok:
+			logging.Warnf("⚠️  %s: 중단된 동안 놓친 %s 실행은 이제 늦어 건너뜁니다", job.name, when)
+		case resume:
+			logging.Infof("🔁 %s: %s에 시작한 실행이 중단되어 %s 이어서 실행합니다", job.name, when, startsAt(at, now))
+			pending = append(pending, pendingRun{job: i, at: at, resume: true})
+		default:
+			logging.Infof("🔁 %s: 중단된 동안 %s 실행을 놓쳐 %s 실행합니다", job.name, when, startsAt(at, now))
+			pending = append(pending, pendingRun{job: i, at: at})
+		}
+	}
+	return pending
+}
+
+// startsAt describes when a made-up run starts.
+func startsAt(at, now time.Time) string {
+	if !at.After(now) {
+		return "지금"
+	}
+	return at.In(domain.Seoul).Format("2006-01-02 15:04 MST") + "에"
+}
+
+// whileOnSale makes up for a missed purchase only while sales for its round
+// are still open, waiting for the morning when the daemon starts during the
+// overnight break.
+func whileOnSale(missed, now time.Time) (time.Time, bool) {
+	at := domain.NextSalesOpen(now)
+	return at, at.Before(domain.SalesClose(domain.RoundOn(missed)))
+}
+
+// sameMonth makes up for a missed report only within the same month, as it
+// reports on the month before the run.
+func sameMonth(missed, now time.Time) (time.Time, bool) {
+	missed, now = missed.In(domain.Seoul), now.In(domain.Seoul)
+	return now, missed.Year() == now.Year() && missed.Month() == now.Month()
+}
+
+// always makes up for every missed run right away.
+func always(missed, now time.Time) (time.Time, bool) {
+	return now, true
+}
+
+// sleepUntil waits until the clock reaches at, reporting false when ctx is
+// done first.
+func sleepUntil(ctx context.Context, at time.Time) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(at.Sub(domain.Now())):
+		return true
+	}
+}
+
+// runJob runs a scheduled job, recording its progress in the daemon state.
+// A run cut short by shutdown is left in progress, so the next start
+// resumes it.
+func (d *daemon) runJob(ctx context.Context, job scheduledJob, next func() time.Time, resume bool) {
+	d.locked(ctx, func(ctx context.Context) {
+		if job.key == "" {
+			job.run(ctx)
+			return
+		}
+		d.state.start(job.key, resume)
+		job.run(ctx)
+		if ctx.Err() == nil {
+			d.state.finish(job.key, next())
+		}
+	})
+}
+
+// locked runs job while holding the daemon lock.
+func (d *daemon) locked(ctx context.Context, job func(context.Context)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+	job(ctx)
+	d.state.flushOutbox(d.sender.Resend)
+	d.app.savePages(ctx)
+	d.app.backupStore(ctx)
+	d.app.flushTraces(ctx)
+}
+
+func (d *daemon) buy(ctx context.Context) {
+	// 재시도마다 계정별 스팬이 하나씩 남음
+	if d.cfg.Purchase.ReadOnly {
+		logging.Infof("🔒 읽기 전용 모드 - 예정된 구매를 건너뜁니다")
+		return
+	}
+	ctx, span := tracing.Start(ctx, Program+" schedule buy")
+	defer span.End(nil)
+	done := monitor(ctx, d.cfg.Healthchecks.Buy)
+	if err := sleepJitter(ctx, d.cfg.Schedule.BuyJitterWindow()); err != nil {
+		done(err)
+		return
+	}
+
+	ledger, err := d.app.OpenStore(d.cfg)
+	if err != nil {
+		d.fail(config.AccountConfig{}, "로또 구매", err)
+		done(err)
+		return
+	}
+	// deferred first so it runs after the ledger is closed
+	defer writeSite(ctx, d.app, d.cfg)
+	if ledger != nil {
+		defer ledger.Close()
+	}
+
+	trail := audit.Open(d.cfg.Audit.Path, audit.OperatorScheduled, "schedule")
+	var results []*buyResult
+	// 구매 중에 중단된 계정은 구매 요청을 보냈을 수 있으므로 다시 구매하지 않음
+	errs := d.forEachAccount(ctx, jobBuy, "로또 구매", false, func(account config.AccountConfig) error {
+		if account.ReadOnly {
+			logging.Infof("🔒 [%s] 읽기 전용 계정 - 예정된 구매를 건너뜁니다", account.Name)
+			return nil
+		}
+		sender := d.sender.ForAccount(account.Name)
+		return d.retry(ctx, d.cfg.Schedule.RetryFor(config.ActionBuy), account, "로또 구매", func() (bool, error) {
+			result, err := buy(ctx, d.cfg, ledger, account, d.app.archived(d.cfg, d.sessions.login), sender, d.sheet, trail, false)
+			results = append(results, result)
+			// 구매 요청을 보낸 뒤의 실패는 중복 구매를 막기 위해 재시도하지 않음
+			return !result.submitted && !errors.Is(err, lottery.ErrLoginFailed) && !errors.Is(err, lottery.ErrInsufficientBalance) && !errors.Is(err, lottery.ErrReadOnly), err
+		})
+	})
+	publishBuy(ctx, d.cfg, results)
+	done(errors.Join(errs...))
+}
+
+func (d *daemon) check(ctx context.Context) {
+	// 재시도마다 계정별 스팬이 하나씩 남음
+	ctx, span := tracing.Start(ctx, Program+" schedule check")
+	defer span.End(nil)
+	done := monitor(ctx, d.cfg.Healthchecks.Check)
+
+	ledger, err := d.app.OpenStore(d.cfg)
+	if err != nil {
+		d.fail(config.AccountConfig{}, "당첨 확인", err)
+		done(err)
+		return
+	}
+	// deferred first so it runs after the ledger is closed
+	defer writeSite(ctx, d.app, d.cfg)
+	if ledger != nil {
+		defer ledger.Close()
+	}
+
+	var results []*checkResult
+	errs := d.forEachAccount(ctx, jobCheck, "당첨 확인", true, func(account config.AccountConfig) error {
+		sender := d.sender.ForAccount(account.Name)
+		return d.retry(ctx, d.cfg.Schedule.RetryFor(config.ActionCheck), account, "당첨 확인", func() (bool, error) {
+			result, err := check(ctx, ledger, account, d.app.archived(d.cfg, d.sessions.login), sender, d.sheet, 0)
+			if err == nil {
+				results = append(results, result)
+			}
+			return !errors.Is(err, lottery.ErrLoginFailed) && !errors.Is(err, lottery.ErrNoPurchases), err
+		})
+	})
+	remindRotation(ctx, d.cfg, ledger, d.sender)
+	publishCheck(ctx, d.cfg, results)
+	done(errors.Join(errs...))
+}
+
+func (d *daemon) report(ctx context.Context) {
+	done := monitor(ctx, d.cfg.Healthchecks.Report)
+	month := previousMonth(domain.Now())
+	done(d.retry(ctx, d.cfg.Schedule.RetryFor(config.ActionReport), config.AccountConfig{}, "월간 리포트", func() (bool, error) {
+		report, err := buildReport(ctx, d.app, d.cfg, "", month, false)
+		if err == nil {
+			err = d.sender.SendReport(report)
+		}
+		if err != nil {
+			return true, err
+		}
+		logging.Infof("✉️  %s 리포트 이메일 전송 완료", report.Title)
+		return false, nil
+	}))
+}
+
+func (d *daemon) backup(ctx context.Context) {
+	b, err := newBackup(d.cfg)
+	if err != nil {
+		d.fail(config.AccountConfig{}, "백업", err)
+		return
+	}
+	d.retry(ctx, d.cfg.Schedule.RetryFor(config.ActionBackup), config.AccountConfig{}, "백업", func() (bool, error) {
+		size, err := d.app.uploadBackup(ctx, d.cfg, b)
+		if err != nil {
+			return true, err
+		}
+		logging.Infof("☁️  저장소를 %s에 백업했습니다 (%d바이트)", b.Location(), size)
+		return false, nil
+	})
+}
+
+// forEachAccount runs op for every account the current run of the job
+// named key has not finished yet, recording the progress of each account in
+// the daemon state. On shutdown it stops before the next account, leaving
+// the rest to the resumed run. An account the last shutdown interrupted
+// while op was running is run again only with rerun; otherwise it is
+// reported as failed.
+func (d *daemon) forEachAccount(ctx context.Context, key, operation string, rerun bool, op func(config.AccountConfig) error) []error {
+	var errs []error
+	for _, account := range d.cfg.LotteryAccounts() {
+		if ctx.Err() != nil {
+			logging.Infof("🛑 [%s] 종료 중이라 %s 작업은 재시작 후 이어서 실행합니다", account.Name, operation)
+			continue
+		}
+		switch d.state.account(key, account.Name) {
+		case accountDone:
+			logging.Infof("⏭️  [%s] 중단 전에 %s 작업을 마쳐 건너뜁니다", account.Name, operation)
+			continue
+		case accountRunning:
+			if !rerun {
+				err := fmt.Errorf("재시작 전에 진행 중이던 %s 작업이 중단되어 다시 실행하지 않습니다 - 결과를 직접 확인하세요", operation)
+				d.fail(account, operation, err)
+				d.state.setAccount(key, account.Name, accountDone)
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		d.state.setAccount(key, account.Name, accountRunning)
+		err := op(account)
+		if errors.Is(err, context.Canceled) {
+			// 종료 신호로 재시도를 멈춘 계정은 재시작 후 다시 실행
+			d.state.setAccount(key, account.Name, "")
+		} else {
+			d.state.setAccount(key, account.Name, accountDone)
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func (d *daemon) keepAlive(ctx context.Context) {
+	d.sessions.keepAlive(ctx, d.cfg.LotteryAccounts())
+}
+
+// retry runs op until it succeeds, reports that another attempt is
+// pointless, or the retries of policy are used up. The session of account
+// is dropped after each failure in case it had expired. A final failure is
+// reported through the failure notification and returned.
+func (d *daemon) retry(ctx context.Context, policy config.Retry, account config.AccountConfig, operation string, op func() (retryable bool, err error)) error {
+	for attempt := 0; ; attempt++ {
+		retryable, err := op()
+		if err == nil {
+			d.health.record(operation, nil)
+			return nil
+		}
+		d.sessions.forget(account.Name)
+
+		if !retryable || attempt >= policy.Retries {
+			d.fail(account, operation, err)
+			return err
+		}
+
+		delay := policy.Wait(attempt)
+		logging.Warnf("⚠️  [%s] %s 실패 (%d/%d): %v - %s 후 재시도", account.Name, operation, attempt+1, policy.Retries+1, err, delay)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// sleepJitter waits a random duration below window before a scheduled
+// purchase, so purchases do not reach the site at the same second every
+// week. It returns early with the context's error when ctx is done.
+func sleepJitter(ctx context.Context, window time.Duration) error {
+	if window <= 0 {
+		return nil
+	}
+	delay := rand.N(window).Round(time.Second)
+	logging.Infof("🎲 구매 전 %s 대기 (최대 %s)", delay, window)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+func (d *daemon) fail(account config.AccountConfig, operation string, err error) {
+	logging.Errorf("❌ [%s] %s 실패: %v", account.Name, operation, err)
+	d.health.record(operation, err)
+	// 실패 알림보다 먼저 보고해 메일 전송이 실패해도 집계되도록
+	d.app.reportFailure(context.Background(), operation, account.Name, err)
+	// 예치금 부족은 충전 알림을 이미 보냄
+	if errors.Is(err, lottery.ErrInsufficientBalance) {
+		return
+	}
+	if notifyErr := d.sender.ForAccount(account.Name).SendFailureNotification(operation, err.Error()); notifyErr != nil {
+		logging.Errorf("❌ [%s] 실패 알림 전송 실패: %v", account.Name, notifyErr)
+	}
+}
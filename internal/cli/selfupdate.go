@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/update"
+)
+
+// Version is the release version, set at build time with
+// -ldflags "-X weekly-lotto/internal/cli.Version=v1.2.3".
+var Version = "dev"
+
+// UpdatePublicKey is the ed25519 key release checksums are signed with, set
+// at build time like Version. When empty, a key must be given with
+// --public-key or LOTTO_UPDATE_PUBLIC_KEY, or the signature check skipped
+// with --insecure.
+var UpdatePublicKey = ""
+
+var selfUpdateCommand = &command{
+	name:    "self-update",
+	usage:   "self-update [--check] [--version TAG] [--force] [--insecure] [flags]",
+	summary: "GitHub 최신 릴리스를 확인하고 체크섬과 서명을 검증한 뒤 실행 파일을 교체합니다",
+	run:     runSelfUpdate,
+}
+
+// selfUpdateResult is the outcome of a self-update run.
+type selfUpdateResult struct {
+	Current   string `json:"current"`
+	Latest    string `json:"latest"`
+	Available bool   `json:"available"`
+	Updated   bool   `json:"updated"`
+	URL       string `json:"url,omitempty"`
+}
+
+func runSelfUpdate(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	checkOnly := fs.Bool("check", false, "교체하지 않고 새 버전이 있는지만 확인")
+	tag := fs.String("version", "", "설치할 릴리스 태그 (기본: 최신 릴리스)")
+	force := fs.Bool("force", false, "현재 버전보다 새롭지 않아도 설치")
+	publicKey := fs.String("public-key", os.Getenv("LOTTO_UPDATE_PUBLIC_KEY"), "체크섬 서명 검증용 ed25519 공개 키 (PEM/base64 또는 파일 경로, LOTTO_UPDATE_PUBLIC_KEY)")
+	insecure := fs.Bool("insecure", false, "공개 키 없이 서명 검증을 건너뛰고 체크섬만 확인해 설치 (변조된 릴리스를 막지 못함)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	key, err := updateKey(*publicKey)
+	if err != nil {
+		return usageError(fs, err)
+	}
+	if key == nil && !*insecure && !*checkOnly {
+		return usageError(fs, fmt.Errorf("%w - --public-key 또는 LOTTO_UPDATE_PUBLIC_KEY로 릴리스 서명 공개 키를 지정하세요 (서명 없이 설치하려면 --insecure)", update.ErrNoPublicKey))
+	}
+
+	release, err := update.Latest(ctx, update.Repository, *tag)
+	if err != nil {
+		return err
+	}
+
+	result := &selfUpdateResult{
+		Current:   Version,
+		Latest:    release.Tag,
+		Available: release.Newer(Version),
+		URL:       release.URL,
+	}
+
+	switch {
+	case *checkOnly:
+		if result.Available {
+			logging.Infof("🆕 새 버전이 있습니다: %s → %s (%s)", Version, release.Tag, release.URL)
+		} else {
+			logging.Infof("✅ 최신 버전입니다: %s", Version)
+		}
+	case !result.Available && !*force && *tag == "":
+		logging.Infof("✅ 최신 버전입니다: %s", Version)
+	default:
+		if key == nil {
+			logging.Warnf("⚠️  --insecure: 서명을 검증하지 않고 체크섬만 확인합니다. 릴리스나 다운로드 경로가 변조되었다면 악성 실행 파일이 설치될 수 있습니다")
+		}
+		if err := installRelease(ctx, release, key); err != nil {
+			return err
+		}
+		result.Updated = true
+		logging.Infof("✅ %s → %s 업데이트 완료", Version, release.Tag)
+	}
+
+	if app.jsonOutput() {
+		return writeJSON(result)
+	}
+	return nil
+}
+
+// installRelease downloads and verifies the release binary, then replaces
+// the running executable with it. A nil key skips the signature check
+// (--insecure).
+func installRelease(ctx context.Context, release *update.Release, key ed25519.PublicKey) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("실행 파일 경로 확인 실패: %w", err)
+	}
+	if executable, err = filepath.EvalSymlinks(executable); err != nil {
+		return fmt.Errorf("실행 파일 경로 확인 실패: %w", err)
+	}
+
+	logging.Infof("📥 %s %s 다운로드 중", release.Tag, update.AssetName())
+	var binary []byte
+	if key != nil {
+		binary, err = update.Download(ctx, release, key)
+	} else {
+		binary, err = update.DownloadUnsigned(ctx, release)
+	}
+	if err != nil {
+		return err
+	}
+	return update.Replace(executable, binary)
+}
+
+// updateKey resolves the signing key from a flag value, a key file or the
+// key built into the binary.
+func updateKey(value string) (ed25519.PublicKey, error) {
+	if value == "" {
+		value = UpdatePublicKey
+	}
+	if value == "" {
+		return nil, nil
+	}
+	if contents, err := os.ReadFile(value); err == nil {
+		value = string(contents)
+	}
+	return update.ParsePublicKey(value)
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sentry"
+)
+
+// snippetLength caps the response excerpt attached to a failure report.
+const snippetLength = 500
+
+// reportFailures sends err to Sentry when it is configured, as one event per
+// failed account when err comes from a per-account loop. Reporting is
+// best effort: problems are logged and never change the run's outcome.
+func (a *App) reportFailures(ctx context.Context, operation string, err error) {
+	var failed *accountsError
+	if !errors.As(err, &failed) {
+		a.reportFailure(ctx, operation, "", err)
+		return
+	}
+	for _, err := range failed.errs {
+		account := ""
+		var tagged *accountError
+		if errors.As(err, &tagged) {
+			account = tagged.account
+		}
+		a.reportFailure(ctx, operation, account, err)
+	}
+}
+
+// reportFailure sends a single failure of operation for account (empty when
+// not account specific) to Sentry, with the round and a sanitized snippet
+// of the offending response when the error carries one.
+func (a *App) reportFailure(ctx context.Context, operation, account string, err error) {
+	if a.cfg == nil || !a.cfg.Sentry.Enabled() || errors.Is(err, errUsage) || errors.Is(err, lottery.ErrNoPurchases) || errors.Is(err, lottery.ErrInsufficientBalance) {
+		return
+	}
+	client, newErr := sentry.New(a.cfg.Sentry, Version)
+	if newErr != nil {
+		logging.Warnf("⚠️  Sentry 보고 실패: %v", newErr)
+		return
+	}
+
+	event := sentry.Event{Operation: operation, Account: account, Err: err}
+	var response *lottery.ResponseError
+	if errors.As(err, &response) {
+		event.Round = response.Round
+		event.Extra = map[string]string{
+			"page":     response.Kind,
+			"url":      response.URL,
+			"response": response.Snippet(snippetLength),
+		}
+	}
+
+	// 중단 신호로 실행이 끝나는 경우에도 보고는 전송
+	id, err := client.Capture(context.WithoutCancel(ctx), event)
+	if err != nil {
+		logging.Warnf("⚠️  Sentry 보고 실패: %v", err)
+		return
+	}
+	logging.Debugf("Sentry 이벤트 전송: %s", id)
+}
@@ -0,0 +1,669 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"weekly-lotto/internal/audit"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sanitize"
+	"weekly-lotto/internal/sheets"
+)
+
+// shutdownTimeout bounds how long in-flight requests may run after a stop signal.
+const shutdownTimeout = 30 * time.Second
+
+var serveCommand = &command{
+	name:        "serve",
+	usage:       "serve [--addr 127.0.0.1:8080] [--grpc-addr 127.0.0.1:9090] [flags]",
+	summary:     "구매/당첨 확인/잔액/내역/통계 조회를 HTTP API로 제공하는 상주 서버를 실행합니다 (/healthz, /metrics 포함)",
+	run:         runServe,
+	longRunning: true,
+}
+
+// server exposes the subcommand operations over HTTP. Operations that log
+// into the lottery site run one at a time.
+type server struct {
+	app     *App
+	cfg     *config.Config
+	started time.Time
+	metrics *serverMetrics
+	sheet   *sheets.Sheet
+	// source is the audit source of purchases made through the server.
+	source string
+	// events streams purchases, results and checks to GET /api/events.
+	events *eventHub
+
+	// mu serializes lottery operations so two purchases can never overlap.
+	mu       sync.Mutex
+	triggers triggers
+}
+
+func runServe(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	addr := fs.String("addr", "127.0.0.1:8080", "listen 주소 (외부에 노출할 때는 serve.token 을 설정하세요)")
+	grpcAddr := fs.String("grpc-addr", "", "gRPC 서비스 listen 주소 (예: 127.0.0.1:9090, 비어 있으면 사용 안 함)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	sheet, err := app.Sheet(cfg)
+	if err != nil {
+		return err
+	}
+	recordUpstream()
+	s := &server{app: app, cfg: cfg, started: time.Now(), metrics: newServerMetrics(), sheet: sheet, source: "api", events: newEventHub()}
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// 열려 있는 이벤트 스트림이 종료를 막지 않도록 먼저 닫음
+	httpServer.RegisterOnShutdown(s.events.close)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go s.watchResults(ctx)
+
+	serveErr := make(chan error, 1)
+	if !cfg.Serve.AuthEnabled() {
+		logging.Warnf("⚠️  serve.token (LOTTO_SERVE_TOKEN) 과 serve.tokens 가 없어 /api, gRPC 요청과 대시보드를 인증하지 않습니다 - localhost 밖에 노출하지 마세요")
+	}
+	go func() {
+		logging.Infof("🌐 HTTP 서버 시작: http://%s (대시보드: /)", *addr)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	grpcErr := make(chan error, 1)
+	if *grpcAddr != "" {
+		grpcServer := newGRPCServer(s)
+		go func() { grpcErr <- serveGRPC(ctx, grpcServer, *grpcAddr) }()
+	}
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("HTTP 서버 실행 실패: %w", err)
+	case err := <-grpcErr:
+		httpServer.Close()
+		return fmt.Errorf("gRPC 서버 실행 실패: %w", err)
+	case <-ctx.Done():
+	}
+
+	logging.Infof("🛑 종료 신호 수신 - 진행 중인 요청을 마무리합니다")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("HTTP 서버 종료 실패: %w", err)
+	}
+	if err := s.triggers.wait(shutdownCtx); err != nil {
+		return fmt.Errorf("트리거 작업 종료 대기 실패: %w", err)
+	}
+	if *grpcAddr != "" {
+		if err := <-grpcErr; err != nil {
+			return fmt.Errorf("gRPC 서버 종료 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("GET /{$}", s.authorized(config.ScopeRead, dashboardHandler(s.app, s.cfg, true)))
+	mux.HandleFunc("GET /api/winning", s.authorized(config.ScopeRead, s.handleWinning))
+	mux.HandleFunc("GET /api/results/latest", s.authorized(config.ScopeRead, s.handleLatestResults))
+	mux.HandleFunc("GET /api/balance", s.authorized(config.ScopeRead, s.handleBalance))
+	mux.HandleFunc("GET /api/history", s.authorized(config.ScopeRead, s.handleHistory))
+	mux.HandleFunc("GET /api/purchases", s.authorized(config.ScopeRead, s.handleHistory))
+	mux.HandleFunc("GET /api/stats", s.authorized(config.ScopeRead, s.handleStats))
+	mux.HandleFunc("GET /api/events", s.authorized(config.ScopeRead, s.handleEvents))
+	mux.HandleFunc("GET /calendar.ics", s.authorized(config.ScopeRead, s.handleCalendar))
+	// 구매 권한 요청은 토큰을 설정하지 않으면 열리지 않음 (다른 사이트에서 보낸 POST로 구매되지 않게)
+	mux.HandleFunc("POST /api/buy", requireConfiguredScope(s.cfg.Serve, config.ScopePurchase, s.handleBuy))
+	mux.HandleFunc("POST /api/check", requireConfiguredScope(s.cfg.Serve, config.ScopePurchase, s.handleCheck))
+	mux.HandleFunc("POST /trigger/{action}", requireConfiguredScope(s.cfg.Serve, config.ScopePurchase, s.handleTrigger))
+	mux.HandleFunc("GET /trigger/{action}", requireConfiguredScope(s.cfg.Serve, config.ScopeRead, s.handleTriggerStatus))
+	return s.metrics.instrument(mux)
+}
+
+// authorized requires a configured token allowed scope on requests to next.
+func (s *server) authorized(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return requireScope(s.cfg.Serve, scope, next)
+}
+
+// requireScope lets requests through to next only when they carry a
+// configured token allowed scope, either as a bearer token or, so a browser
+// can open the dashboard, as the password of HTTP basic authentication. An
+// unknown token gets 401, a token with too narrow a scope 403. Without any
+// token configured every request is let through.
+func requireScope(serve config.ServeConfig, scope string, next http.HandlerFunc) http.HandlerFunc {
+	if !serve.AuthEnabled() {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			_, given, _ = r.BasicAuth()
+		}
+		granted := serve.Scope(given)
+		if granted == "" {
+			w.Header().Add("WWW-Authenticate", `Bearer realm="weekly-lotto"`)
+			w.Header().Add("WWW-Authenticate", `Basic realm="weekly-lotto", charset="UTF-8"`)
+			writeError(w, http.StatusUnauthorized, errors.New("인증 토큰이 없거나 올바르지 않습니다"))
+			return
+		}
+		if !config.ScopeAllows(granted, scope) {
+			logging.Warnf("⚠️  %s 권한 토큰으로 %s 요청을 거부했습니다", granted, r.URL.Path)
+			writeError(w, http.StatusForbidden, fmt.Errorf("이 토큰(%s)으로는 할 수 없는 요청입니다 (%s 권한 필요)", granted, scope))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, map[string]any{
+		"status":        "ok",
+		"uptimeSeconds": int64(time.Since(s.started).Seconds()),
+	})
+}
+
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.metrics.write(w, s.started)
+	upstream.write(w)
+}
+
+func (s *server) handleWinning(w http.ResponseWriter, r *http.Request) {
+	round, err := queryInt(r, "round")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	report, err := s.winning(r.Context(), round)
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeResponse(w, http.StatusOK, report)
+}
+
+// latestResults is the latest draw with the recorded tickets of its round.
+type latestResults struct {
+	Winning *winningReport `json:"winning"`
+	Tickets []historyEntry `json:"tickets"`
+}
+
+func (s *server) handleLatestResults(w http.ResponseWriter, r *http.Request) {
+	results, err := s.latestResults(r.Context(), r.URL.Query().Get("account"))
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeResponse(w, http.StatusOK, results)
+}
+
+func (s *server) handleBalance(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := s.balances(r.Context())
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeResponse(w, http.StatusOK, snapshots)
+}
+
+func (s *server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	query, err := parseHistoryQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	entries, err := s.purchases(r.Context(), query)
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeResponse(w, http.StatusOK, entries)
+}
+
+func (s *server) handleStats(w http.ResponseWriter, r *http.Request) {
+	query, err := parseHistoryQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	report, err := s.stats(r.Context(), query)
+	if err != nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeResponse(w, http.StatusOK, report)
+}
+
+func (s *server) handleBuy(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := queryBool(r, "dry_run")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	results, err := s.buyAll(r.Context(), dryRun)
+	if results == nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeResponse(w, httpStatus(err), results)
+}
+
+func (s *server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	results, err := s.checkAll(r.Context())
+	if results == nil {
+		writeError(w, httpStatus(err), err)
+		return
+	}
+	writeResponse(w, httpStatus(err), results)
+}
+
+// historyQuery selects recorded purchases, as the history flags do.
+type historyQuery struct {
+	Account   string
+	Round     int
+	FromRound int
+	ToRound   int
+	Since     string
+	Until     string
+	Rank      string
+}
+
+func parseHistoryQuery(r *http.Request) (historyQuery, error) {
+	query := r.URL.Query()
+	var ints [3]int
+	for i, name := range []string{"round", "from_round", "to_round"} {
+		n, err := queryInt(r, name)
+		if err != nil {
+			return historyQuery{}, err
+		}
+		ints[i] = n
+	}
+	return historyQuery{
+		Account:   query.Get("account"),
+		Round:     ints[0],
+		FromRound: ints[1],
+		ToRound:   ints[2],
+		Since:     query.Get("since"),
+		Until:     query.Get("until"),
+		Rank:      query.Get("rank"),
+	}, nil
+}
+
+// requestError marks an error caused by the request itself rather than by
+// the operation.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+// The operations below back both the HTTP handlers and the gRPC service.
+// Their errors are classified by httpStatus and grpcCode.
+
+// winning returns the draw of round, or the latest one when round is 0.
+func (s *server) winning(ctx context.Context, round int) (*winningReport, error) {
+	defer s.app.savePages(ctx)
+	draws := s.app.drawResults(ctx)
+	defer draws.close()
+	var winning *domain.WinningNumbers
+	var err error
+	if round > 0 {
+		winning, err = draws.get(round)
+	} else {
+		winning, err = draws.latest()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+	return newWinningReport(winning), nil
+}
+
+// latestResults returns the latest draw with the recorded tickets of
+// account (every account when empty) for its round.
+func (s *server) latestResults(ctx context.Context, account string) (*latestResults, error) {
+	defer s.app.savePages(ctx)
+	draws := s.app.drawResults(ctx)
+	defer draws.close()
+	winning, err := draws.latest()
+	if err != nil {
+		return nil, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+
+	filter, err := parseHistoryFilter(account, winning.Round, 0, 0, "", "", "")
+	if err != nil {
+		return nil, &requestError{err}
+	}
+	entries, err := localHistory(ctx, s.app, s.cfg, filter)
+	if err != nil {
+		return nil, err
+	}
+	resolveResults(draws, entries)
+	if entries == nil {
+		entries = []historyEntry{}
+	}
+	return &latestResults{Winning: newWinningReport(winning), Tickets: entries}, nil
+}
+
+// balances returns the balance of every account.
+func (s *server) balances(ctx context.Context) ([]*domain.BalanceSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	snapshots := []*domain.BalanceSnapshot{}
+	for _, account := range s.cfg.LotteryAccounts() {
+		snapshot, err := balance(ctx, account)
+		if err != nil {
+			logging.Errorf("❌ [%s] %v", account.Name, err)
+			errs = append(errs, fmt.Errorf("[%s] %w", account.Name, err))
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	publishBalances(ctx, s.cfg, snapshots)
+	if err := accountsFailed("잔액 조회가 실패했습니다", errs); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// purchases returns the recorded purchases selected by query.
+func (s *server) purchases(ctx context.Context, query historyQuery) ([]historyEntry, error) {
+	filter, entries, err := s.resolvedHistory(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return filter.matchRanks(entries), nil
+}
+
+// stats summarizes the recorded purchases selected by query.
+func (s *server) stats(ctx context.Context, query historyQuery) (*statsReport, error) {
+	_, entries, err := s.resolvedHistory(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return buildStats(entries), nil
+}
+
+// resolvedHistory reads the recorded purchases selected by query and
+// resolves their results.
+func (s *server) resolvedHistory(ctx context.Context, query historyQuery) (*historyFilter, []historyEntry, error) {
+	filter, err := parseHistoryFilter(query.Account, query.Round, query.FromRound, query.ToRound, query.Since, query.Until, query.Rank)
+	if err != nil {
+		return nil, nil, &requestError{err}
+	}
+
+	entries, err := localHistory(ctx, s.app, s.cfg, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer s.app.savePages(ctx)
+	draws := s.app.drawResults(ctx)
+	defer draws.close()
+	resolveResults(draws, entries)
+	if entries == nil {
+		entries = []historyEntry{}
+	}
+	return filter, entries, nil
+}
+
+// buyAll buys for every account. Failed accounts carry their error in the
+// result; the returned error is the last of them and only classifies the
+// failure. Results are nil only when the ledger cannot be opened.
+func (s *server) buyAll(ctx context.Context, dryRun bool) ([]*buyResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// deferred first so they run after the ledger below is closed
+	defer s.app.flushTraces(ctx)
+	defer s.app.backupStore(ctx)
+	defer s.app.savePages(ctx)
+	if !dryRun {
+		defer writeSite(ctx, s.app, s.cfg)
+	}
+
+	ledger, err := s.app.OpenStore(s.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ledger != nil {
+		defer ledger.Close()
+	}
+
+	emailSender := s.app.EmailSender(s.cfg)
+	trail := audit.Open(s.cfg.Audit.Path, audit.OperatorManual, s.source)
+	var last error
+	results := make([]*buyResult, 0, len(s.cfg.LotteryAccounts()))
+	for _, account := range s.cfg.LotteryAccounts() {
+		result, err := buy(ctx, s.cfg, ledger, account, s.app.archived(s.cfg, login), emailSender.ForAccount(account.Name), s.sheet, trail, dryRun)
+		if err != nil {
+			logging.Errorf("❌ [%s] %v", account.Name, err)
+			result.Error = sanitize.Error(err)
+			last = err
+		}
+		results = append(results, result)
+		if result.Status == buyPurchased {
+			s.events.publish(eventPurchase, result)
+		}
+	}
+	publishBuy(ctx, s.cfg, results)
+	return results, last
+}
+
+// checkAll checks the latest draw for every account, reporting failures as
+// buyAll does.
+func (s *server) checkAll(ctx context.Context) ([]*checkResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// deferred first so they run after the ledger below is closed
+	defer s.app.flushTraces(ctx)
+	defer s.app.backupStore(ctx)
+	defer s.app.savePages(ctx)
+	defer writeSite(ctx, s.app, s.cfg)
+
+	ledger, err := s.app.OpenStore(s.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if ledger != nil {
+		defer ledger.Close()
+	}
+
+	emailSender := s.app.EmailSender(s.cfg)
+	var last error
+	results := make([]*checkResult, 0, len(s.cfg.LotteryAccounts()))
+	for _, account := range s.cfg.LotteryAccounts() {
+		result, err := check(ctx, ledger, account, s.app.archived(s.cfg, login), emailSender.ForAccount(account.Name), s.sheet, 0)
+		if err != nil {
+			logging.Errorf("❌ [%s] %v", account.Name, err)
+			result.Error = sanitize.Error(err)
+			last = err
+		}
+		results = append(results, result)
+	}
+	publishCheck(ctx, s.cfg, results)
+	s.events.publish(eventCheck, results)
+	return results, last
+}
+
+// httpStatus maps an operation error to a response status, following the
+// same failure classes as the exit codes.
+func httpStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var invalid *requestError
+	if errors.As(err, &invalid) {
+		return http.StatusBadRequest
+	}
+	switch exitCode(err) {
+	case ExitMaintenance:
+		return http.StatusServiceUnavailable
+	case ExitNoPurchases:
+		return http.StatusNotFound
+	case ExitLowBalance:
+		return http.StatusPaymentRequired
+	case ExitLogin, ExitNotification:
+		return http.StatusBadGateway
+	}
+	if errors.Is(err, lottery.ErrNotDrawn) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func writeResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		logging.Warnf("⚠️  응답 쓰기 실패: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeResponse(w, status, map[string]string{"error": sanitize.Error(err)})
+}
+
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s 값이 숫자가 아닙니다: %q", name, value)
+	}
+	return n, nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s 값이 true/false가 아닙니다: %q", name, value)
+	}
+	return b, nil
+}
+
+// serverMetrics counts requests by route and status in the Prometheus text
+// exposition format.
+type serverMetrics struct {
+	mu       sync.Mutex
+	requests map[requestKey]int64
+	seconds  map[string]float64
+}
+
+type requestKey struct {
+	route  string
+	status int
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{requests: make(map[requestKey]int64), seconds: make(map[string]float64)}
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, which
+// the event stream needs to flush.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+func (m *serverMetrics) instrument(next *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		_, route := next.Handler(r)
+		if route == "" {
+			route = "unmatched"
+		}
+		elapsed := time.Since(start)
+
+		m.mu.Lock()
+		m.requests[requestKey{route, recorder.status}]++
+		m.seconds[route] += elapsed.Seconds()
+		m.mu.Unlock()
+
+		if route != "GET /metrics" && route != "GET /healthz" {
+			logging.Infof("🌐 %s %s → %d (%s)", r.Method, r.URL.Path, recorder.status, elapsed.Round(time.Millisecond))
+		}
+	})
+}
+
+func (m *serverMetrics) write(w http.ResponseWriter, started time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP weekly_lotto_up_seconds Seconds since the server started.")
+	fmt.Fprintln(w, "# TYPE weekly_lotto_up_seconds gauge")
+	fmt.Fprintf(w, "weekly_lotto_up_seconds %.0f\n", time.Since(started).Seconds())
+
+	fmt.Fprintln(w, "# HELP weekly_lotto_http_requests_total HTTP requests by route and status.")
+	fmt.Fprintln(w, "# TYPE weekly_lotto_http_requests_total counter")
+	keys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, key := range keys {
+		fmt.Fprintf(w, "weekly_lotto_http_requests_total{route=%q,status=\"%d\"} %d\n", key.route, key.status, m.requests[key])
+	}
+
+	fmt.Fprintln(w, "# HELP weekly_lotto_http_request_seconds_total Time spent serving requests by route.")
+	fmt.Fprintln(w, "# TYPE weekly_lotto_http_request_seconds_total counter")
+	routes := make([]string, 0, len(m.seconds))
+	for route := range m.seconds {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	for _, route := range routes {
+		fmt.Fprintf(w, "weekly_lotto_http_request_seconds_total{route=%q} %.3f\n", route, m.seconds[route])
+	}
+}
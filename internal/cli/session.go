@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sanitize"
+)
+
+// loginFunc returns a logged-in lottery client for account.
+type loginFunc func(ctx context.Context, account config.AccountConfig) (*lottery.Client, error)
+
+// login logs into a fresh session for every call. The client refuses
+// purchases when the account is read-only.
+func login(ctx context.Context, account config.AccountConfig) (*lottery.Client, error) {
+	logging.Debugf("🔑 [%s] 로그인 시도", account.Name)
+	client, err := lottery.NewClient(ctx, account.Username, account.Password)
+	if err != nil {
+		return nil, err
+	}
+	client.SetReadOnly(account.ReadOnly)
+	return client, nil
+}
+
+// sessions keeps one logged-in client per account for long-running modes,
+// so scheduled runs do not pay for a login and expired sessions are noticed
+// before a purchase is due.
+type sessions struct {
+	mu      sync.Mutex
+	clients map[string]*lottery.Client
+	// checks holds the latest login or keep-alive outcome of each account,
+	// for readiness probes.
+	checks map[string]sessionCheck
+}
+
+// sessionCheck is the latest known state of an account's session.
+type sessionCheck struct {
+	Valid     bool      `json:"valid"`
+	CheckedAt time.Time `json:"checkedAt"`
+	Error     string    `json:"error,omitempty"`
+}
+
+func newSessions() *sessions {
+	return &sessions{clients: make(map[string]*lottery.Client), checks: make(map[string]sessionCheck)}
+}
+
+// login returns the cached client of account, logging in when there is none.
+// It satisfies loginFunc.
+func (s *sessions) login(ctx context.Context, account config.AccountConfig) (*lottery.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if client, ok := s.clients[account.Name]; ok {
+		logging.Debugf("🔑 [%s] 기존 세션 재사용", account.Name)
+		return client, nil
+	}
+	client, err := login(ctx, account)
+	s.checked(account.Name, err)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[account.Name] = client
+	return client, nil
+}
+
+// checked records the outcome of a login or keep-alive of account. The
+// caller must hold s.mu.
+func (s *sessions) checked(account string, err error) {
+	check := sessionCheck{Valid: err == nil, CheckedAt: domain.Now()}
+	if err != nil {
+		check.Error = sanitize.Error(err)
+	}
+	s.checks[account] = check
+}
+
+// status returns the latest session check of every account seen so far.
+func (s *sessions) status() map[string]sessionCheck {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := make(map[string]sessionCheck, len(s.checks))
+	for account, check := range s.checks {
+		status[account] = check
+	}
+	return status
+}
+
+// forget drops the cached client of account so the next call logs in again.
+func (s *sessions) forget(account string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clients, account)
+}
+
+// keepAlive touches every cached session. Sessions that no longer work are
+// dropped and logged into again.
+func (s *sessions) keepAlive(ctx context.Context, accounts []config.AccountConfig) {
+	for _, account := range accounts {
+		client, err := s.login(ctx, account)
+		if err == nil {
+			if _, err = client.GetBalance(ctx); err == nil {
+				s.mu.Lock()
+				s.checked(account.Name, nil)
+				s.mu.Unlock()
+				continue
+			}
+			s.forget(account.Name)
+			if _, err = s.login(ctx, account); err == nil {
+				logging.Infof("🔄 [%s] 세션 만료 - 다시 로그인했습니다", account.Name)
+				continue
+			}
+		}
+		err = fmt.Errorf("세션 유지 실패: %w", err)
+		s.mu.Lock()
+		s.checked(account.Name, err)
+		s.mu.Unlock()
+		logging.Warnf("⚠️  [%s] %v", account.Name, err)
+	}
+}
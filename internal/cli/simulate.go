@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/generator"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/simulate"
+)
+
+var simulateCommand = &command{
+	name:    "simulate",
+	usage:   "simulate [--strategy random] [--count 5] [--rounds 1000] [--seed N] [--backtest] [flags]",
+	summary: "번호 생성 전략을 N회차 동안 모의 실행(몬테카를로/과거 회차 백테스트)해 비용, 당첨금, 등수 분포를 출력합니다",
+	run:     runSimulate,
+}
+
+// simulateReport is the JSON form of a simulation result.
+type simulateReport struct {
+	Mode             string         `json:"mode"`
+	Strategy         string         `json:"strategy"`
+	Rounds           int            `json:"rounds"`
+	Tickets          int            `json:"tickets"`
+	Cost             int64          `json:"cost"`
+	Winnings         int64          `json:"winnings"`
+	CostPerRound     float64        `json:"costPerRound"`
+	WinningsPerRound float64        `json:"winningsPerRound"`
+	ROI              float64        `json:"roi"`
+	Ranks            map[string]int `json:"ranks"`
+}
+
+func runSimulate(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	strategyName := fs.String("strategy", "random", fmt.Sprintf("번호 생성 전략 (%s)", strings.Join(generator.Names(), ", ")))
+	count := fs.Int("count", config.MaxTicketsPerPurchase, "회차당 구매 장수")
+	rounds := fs.Int("rounds", 1000, "시뮬레이션 회차 수")
+	backtest := fs.Bool("backtest", false, "무작위 추첨 대신 최근 N회차 실제 당첨 번호로 백테스트 (회차마다 사이트 조회)")
+	seed := fs.Uint64("seed", 0, "몬테카를로 추첨과 번호 생성 시드 - 같은 시드면 같은 결과 (0: 매번 다름)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	var opts []generator.Option
+	if *seed != 0 {
+		opts = append(opts, generator.WithSeed(*seed))
+	}
+	strategy, err := generator.Lookup(*strategyName, opts...)
+	if err != nil {
+		return usageError(fs, err)
+	}
+	if *rounds < 1 || *count < 1 {
+		return usageError(fs, fmt.Errorf("--rounds 와 --count 는 1 이상이어야 합니다"))
+	}
+
+	var draws []*domain.WinningNumbers
+	mode := "montecarlo"
+	if *backtest {
+		mode = "backtest"
+		lookup := app.drawResults(ctx)
+		defer lookup.close()
+		if draws, err = recentDraws(lookup, *rounds); err != nil {
+			return err
+		}
+	} else {
+		if *seed == 0 {
+			*seed = uint64(time.Now().UnixNano())
+		}
+		draws = simulate.RandomDraws(*rounds, rand.New(rand.NewPCG(*seed, *seed)))
+	}
+
+	result, err := simulate.Run(strategy, *count, draws)
+	if err != nil {
+		return err
+	}
+
+	report := newSimulateReport(mode, result)
+	if app.jsonOutput() {
+		return writeJSON(report)
+	}
+	fmt.Print(report.ToString())
+	return nil
+}
+
+// recentDraws fetches the latest n published draws, newest first.
+func recentDraws(draws *drawResults, n int) ([]*domain.WinningNumbers, error) {
+	latest, err := draws.latest()
+	if err != nil {
+		return nil, fmt.Errorf("최신 당첨 번호 조회 실패: %w", err)
+	}
+	if n > latest.Round {
+		n = latest.Round
+	}
+
+	result := []*domain.WinningNumbers{latest}
+	for round := latest.Round - 1; round > latest.Round-n; round-- {
+		winning, err := draws.get(round)
+		if err != nil {
+			return nil, fmt.Errorf("%d회 당첨 번호 조회 실패: %w", round, err)
+		}
+		result = append(result, winning)
+		if len(result)%50 == 0 {
+			logging.Debugf("📥 당첨 번호 %d/%d회차 조회", len(result), n)
+		}
+	}
+	return result, nil
+}
+
+func newSimulateReport(mode string, result *simulate.Result) *simulateReport {
+	report := &simulateReport{
+		Mode:     mode,
+		Strategy: result.Strategy,
+		Rounds:   result.Rounds,
+		Tickets:  result.Tickets,
+		Cost:     result.Cost,
+		Winnings: result.Winnings,
+		ROI:      result.ROI(),
+		Ranks:    make(map[string]int),
+	}
+	if result.Rounds > 0 {
+		report.CostPerRound = float64(result.Cost) / float64(result.Rounds)
+		report.WinningsPerRound = float64(result.Winnings) / float64(result.Rounds)
+	}
+	for _, rank := range statsRanks {
+		report.Ranks[rankKey(rank)] = result.Ranks[rank]
+	}
+	return report
+}
+
+// ToString renders the report for terminal output.
+func (r *simulateReport) ToString() string {
+	modeName := "몬테카를로 (무작위 추첨, 1~3등 당첨금은 평균 추정치)"
+	if r.Mode == "backtest" {
+		modeName = "백테스트 (실제 당첨 번호)"
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🎲 %s 전략 시뮬레이션 - %s\n", r.Strategy, modeName))
+	builder.WriteString(fmt.Sprintf("- %d회차 x %d장 = %d장\n", r.Rounds, r.Tickets/max(r.Rounds, 1), r.Tickets))
+	builder.WriteString(fmt.Sprintf("- 총 비용: %s원 (회차당 %s원)\n", utils.FormatAmount(r.Cost), utils.FormatAmount(int64(r.CostPerRound))))
+	builder.WriteString(fmt.Sprintf("- 총 당첨금: %s원 (회차당 %s원)\n", utils.FormatAmount(r.Winnings), utils.FormatAmount(int64(r.WinningsPerRound))))
+	builder.WriteString(fmt.Sprintf("- 수익률: %.1f%%\n", r.ROI))
+
+	builder.WriteString("\n🏆 등수 분포\n")
+	for _, rank := range statsRanks {
+		hits := r.Ranks[rankKey(rank)]
+		builder.WriteString(fmt.Sprintf("- %s: %d장 (%.4f%%)\n", rank.String(), hits, float64(hits)/float64(max(r.Tickets, 1))*100))
+	}
+	return builder.String()
+}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+)
+
+var siteCommand = &command{
+	name:    "site",
+	usage:   "site [flags]",
+	summary: "전체 구매 내역, 통계, 차트를 정적 HTML로 생성합니다 (GitHub Pages, NAS 공유 폴더용)",
+	run:     runSite,
+}
+
+var siteTemplates = template.Must(template.New("site").Funcs(webFuncs).ParseFS(webFiles, "web/site/*.html"))
+
+// sitePage is the data of every page of the static site.
+type sitePage struct {
+	Now        time.Time
+	NextRound  int
+	NextDraw   time.Time
+	SalesClose time.Time
+	Winning    *domain.WinningNumbers
+	Stats      *statsReport
+	RankKeys   []string
+	Accounts   []siteAccount
+	Chart      []chartRound
+	ChartMax   int64
+	FirstRound int
+	LastRound  int
+	// Numbers are how often each number was picked, in number order.
+	Numbers []numberCount
+	Tickets []dashboardTicket
+}
+
+// siteAccount is the stats row of one account.
+type siteAccount struct {
+	Name  string
+	Stats *statsReport
+}
+
+func runSite(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	out := fs.String("out", "", "HTML을 쓸 디렉터리 (기본: site.dir)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+	dir := *out
+	if dir == "" {
+		dir = cfg.Site.Dir
+	}
+	if dir == "" {
+		return usageError(fs, fmt.Errorf("--out 또는 site.dir (LOTTO_SITE_DIR) 이 필요합니다"))
+	}
+
+	tickets, err := renderSite(ctx, app, cfg, dir)
+	if err != nil {
+		return err
+	}
+	logging.Infof("🌐 구매 %d장의 정적 HTML을 %s에 생성했습니다", tickets, dir)
+	return nil
+}
+
+// writeSite regenerates the static site after a buy or check run when
+// site.dir is configured. Failures are logged, not returned.
+func writeSite(ctx context.Context, app *App, cfg *config.Config) {
+	if !cfg.Site.Enabled() {
+		return
+	}
+	// 중단 신호로 실행이 끝나는 경우에도 결과는 반영
+	tickets, err := renderSite(context.WithoutCancel(ctx), app, cfg, cfg.Site.Dir)
+	if err != nil {
+		logging.Warnf("⚠️  정적 HTML 생성 실패: %v", err)
+		return
+	}
+	logging.Debugf("🌐 구매 %d장의 정적 HTML을 %s에 생성했습니다", tickets, cfg.Site.Dir)
+}
+
+// renderSite writes index.html (latest draw, stats and the chart of every
+// round), history.html (every ticket), history.json, calendar.ics and
+// style.css to dir, and returns the number of tickets. Pages link each
+// other relatively, so the directory can be served from any path.
+func renderSite(ctx context.Context, app *App, cfg *config.Config, dir string) (int, error) {
+	entries, err := localHistory(ctx, app, cfg, &historyFilter{})
+	if err != nil {
+		return 0, err
+	}
+
+	now := domain.Now()
+	page := &sitePage{Now: now}
+	page.NextRound, page.NextDraw = domain.NextDraw(now)
+	page.SalesClose = domain.SalesClose(page.NextRound)
+	for _, rank := range statsRanks {
+		page.RankKeys = append(page.RankKeys, rankKey(rank))
+	}
+
+	draws := app.drawResults(ctx)
+	defer draws.close()
+	if winning, err := draws.latest(); err != nil {
+		logging.Warnf("⚠️  정적 HTML 당첨 번호 조회 실패: %v", err)
+	} else {
+		page.Winning = winning
+	}
+	resolveResults(draws, entries)
+
+	page.Stats = buildStats(entries)
+	page.Numbers = slices.Clone(page.Stats.Numbers)
+	slices.SortFunc(page.Numbers, func(a, b numberCount) int { return a.Number - b.Number })
+	page.Chart, page.ChartMax = chartRounds(entries, 0)
+	if len(page.Chart) > 0 {
+		page.FirstRound, page.LastRound = page.Chart[0].Round, page.Chart[len(page.Chart)-1].Round
+	}
+
+	byAccount := make(map[string][]historyEntry)
+	for _, entry := range entries {
+		byAccount[entry.Account] = append(byAccount[entry.Account], entry)
+	}
+	for name, accountEntries := range byAccount {
+		page.Accounts = append(page.Accounts, siteAccount{Name: name, Stats: buildStats(accountEntries)})
+	}
+	slices.SortFunc(page.Accounts, func(a, b siteAccount) int { return cmp.Compare(a.Name, b.Name) })
+
+	slices.SortStableFunc(entries, func(a, b historyEntry) int { return b.Round - a.Round })
+	for _, entry := range entries {
+		page.Tickets = append(page.Tickets, newDashboardTicket(entry))
+	}
+
+	files := map[string][]byte{
+		"style.css":    webStyle(),
+		"calendar.ics": buildCalendar(now, entries, calendarDraws).Marshal(now),
+	}
+	for _, name := range []string{"index.html", "history.html"} {
+		var body bytes.Buffer
+		if err := siteTemplates.ExecuteTemplate(&body, name, page); err != nil {
+			return 0, err
+		}
+		files[name] = body.Bytes()
+	}
+	history, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	files["history.json"] = history
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, fmt.Errorf("정적 HTML 디렉터리 생성 실패: %w", err)
+	}
+	for name, data := range files {
+		if err := writeFileAtomic(filepath.Join(dir, name), data, 0o644); err != nil {
+			return 0, fmt.Errorf("%s 쓰기 실패: %w", name, err)
+		}
+	}
+	return len(entries), nil
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory, so readers never see a partly written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+)
+
+var statsCommand = &command{
+	name:    "stats",
+	usage:   "stats [flags]",
+	summary: "로컬 구매 장부로 번호별 선택 빈도, 등수 분포, 총 지출/당첨금, 수익률을 출력합니다",
+	run:     runStats,
+}
+
+// statsReport summarizes the purchases selected by the stats flags.
+type statsReport struct {
+	Tickets        int            `json:"tickets"`
+	DrawnTickets   int            `json:"drawnTickets"`
+	PendingTickets int            `json:"pendingTickets"`
+	Spent          int64          `json:"spent"`
+	DrawnSpent     int64          `json:"drawnSpent"`
+	Winnings       int64          `json:"winnings"`
+	ROI            float64        `json:"roi"`
+	Ranks          map[string]int `json:"ranks"`
+	Numbers        []numberCount  `json:"numbers"`
+}
+
+// numberCount is how often a number was picked.
+type numberCount struct {
+	Number int `json:"number"`
+	Count  int `json:"count"`
+}
+
+// statsRanks lists the rank keys of statsReport.Ranks in display order.
+var statsRanks = []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5, domain.RankNone}
+
+func runStats(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	account := fs.String("account", "", "계정 이름으로 필터링")
+	fromRound := fs.Int("from-round", 0, "시작 회차 (포함)")
+	toRound := fs.Int("to-round", 0, "끝 회차 (포함)")
+	since := fs.String("since", "", "시작 구매일 (YYYY-MM-DD, 포함)")
+	until := fs.String("until", "", "끝 구매일 (YYYY-MM-DD, 포함)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	filter, err := parseHistoryFilter(*account, 0, *fromRound, *toRound, *since, *until, "")
+	if err != nil {
+		return usageError(fs, err)
+	}
+
+	cfg, err := app.Config()
+	if err != nil {
+		return err
+	}
+
+	entries, err := localHistory(ctx, app, cfg, filter)
+	if err != nil {
+		return err
+	}
+	draws := app.drawResults(ctx)
+	defer draws.close()
+	resolveResults(draws, entries)
+
+	report := buildStats(entries)
+	if app.jsonOutput() {
+		return writeJSON(report)
+	}
+	fmt.Print(report.ToString())
+	return nil
+}
+
+// buildStats aggregates resolved history entries. ROI only counts tickets
+// whose draw has been checked, since pending tickets cannot have returns yet.
+func buildStats(entries []historyEntry) *statsReport {
+	report := &statsReport{Ranks: make(map[string]int)}
+	for _, rank := range statsRanks {
+		report.Ranks[rankKey(rank)] = 0
+	}
+
+	counts := make(map[int]int)
+	for _, entry := range entries {
+		report.Tickets++
+		report.Spent += entry.Amount
+		for _, n := range entry.Numbers {
+			counts[n]++
+		}
+
+		if !entry.drawn {
+			report.PendingTickets++
+			continue
+		}
+		report.DrawnTickets++
+		report.DrawnSpent += entry.Amount
+		report.Winnings += entry.Prize
+		report.Ranks[rankKey(entry.rank)]++
+	}
+
+	if report.DrawnSpent > 0 {
+		report.ROI = float64(report.Winnings-report.DrawnSpent) / float64(report.DrawnSpent) * 100
+	}
+
+	report.Numbers = make([]numberCount, 0, domain.MaxNumber)
+	for n := domain.MinNumber; n <= domain.MaxNumber; n++ {
+		report.Numbers = append(report.Numbers, numberCount{Number: n, Count: counts[n]})
+	}
+	sort.SliceStable(report.Numbers, func(i, j int) bool {
+		return report.Numbers[i].Count > report.Numbers[j].Count
+	})
+
+	return report
+}
+
+// rankKey is the JSON key of rank: "1"~"5", or "none".
+func rankKey(rank domain.WinningRank) string {
+	if rank == domain.RankNone {
+		return "none"
+	}
+	return fmt.Sprintf("%d", rank.Number())
+}
+
+// ToString renders the report for terminal output.
+func (r *statsReport) ToString() string {
+	var builder strings.Builder
+	builder.WriteString("📊 구매 통계\n")
+	builder.WriteString(fmt.Sprintf("- 구매: %d장 (추첨 완료 %d장, 미추첨/확인 불가 %d장)\n", r.Tickets, r.DrawnTickets, r.PendingTickets))
+	builder.WriteString(fmt.Sprintf("- 총 지출: %s원\n", utils.FormatAmount(r.Spent)))
+	builder.WriteString(fmt.Sprintf("- 총 당첨금: %s원\n", utils.FormatAmount(r.Winnings)))
+	builder.WriteString(fmt.Sprintf("- 수익률: %.1f%% (추첨 완료분 %s원 기준)\n", r.ROI, utils.FormatAmount(r.DrawnSpent)))
+
+	builder.WriteString("\n🏆 등수 분포\n")
+	for _, rank := range statsRanks {
+		builder.WriteString(fmt.Sprintf("- %s: %d장\n", rank.String(), r.Ranks[rankKey(rank)]))
+	}
+
+	builder.WriteString("\n🔢 번호별 선택 횟수 (많은 순)\n")
+	for i, number := range r.Numbers {
+		if i%9 != 0 {
+			builder.WriteString("  ")
+		}
+		builder.WriteString(fmt.Sprintf("%2d:%-3d", number.Number, number.Count))
+		if (i+1)%9 == 0 || i == len(r.Numbers)-1 {
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
+)
+
+// writeStepSummary appends markdown to the job summary of the GitHub Actions
+// step, so results show up on the workflow run page. Outside of GitHub
+// Actions (no GITHUB_STEP_SUMMARY) it does nothing.
+func writeStepSummary(markdown string) {
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err == nil {
+		_, err = f.WriteString(markdown)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		logging.Warnf("⚠️  GitHub Actions 작업 요약 기록 실패: %v", err)
+	}
+}
+
+// buyStepSummary renders the outcome of a buy run per account.
+func buyStepSummary(results []*buyResult, dryRun bool) string {
+	var b strings.Builder
+	if dryRun {
+		b.WriteString("## 🎫 로또 구매 미리보기\n\n")
+	} else {
+		b.WriteString("## 🎫 로또 구매\n\n")
+	}
+	for _, result := range results {
+		fmt.Fprintf(&b, "### %s\n\n", result.Account)
+		switch {
+		case result.Error != "":
+			fmt.Fprintf(&b, "❌ 실패: %s\n\n", result.Error)
+			continue
+		case result.Status == buySkipped:
+			fmt.Fprintf(&b, "⏭️ 건너뜀: %s\n\n", result.Reason)
+			continue
+		}
+		fmt.Fprintf(&b, "%d회 · %d장 · %s원\n\n", result.Round, len(result.Tickets), utils.FormatAmount(result.Amount))
+		if len(result.Tickets) == 0 {
+			continue
+		}
+		b.WriteString("| 슬롯 | 방식 | 번호 |\n|---|---|---|\n")
+		for _, ticket := range result.Tickets {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", ticket.Slot, ticket.Mode, utils.FormatNumbers(ticket.Numbers))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// checkStepSummary renders the winning numbers, every ticket with its
+// matching numbers in bold and the prize table of the round.
+func checkStepSummary(results []*checkResult) string {
+	var b strings.Builder
+	b.WriteString("## 🎰 당첨 확인\n\n")
+
+	var winning *domain.WinningNumbers
+	for _, result := range results {
+		if result.winning != nil {
+			winning = result.winning
+			break
+		}
+	}
+	if winning != nil {
+		fmt.Fprintf(&b, "**%d회** (%s 추첨) 당첨 번호: **%s** + 보너스 **%d**\n\n", winning.Round, winning.DrawDate.Format("2006-01-02"), utils.FormatNumbers(winning.Numbers), winning.BonusNumber)
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(&b, "### %s\n\n", result.Account)
+		if result.Error != "" {
+			fmt.Fprintf(&b, "❌ 실패: %s\n\n", result.Error)
+			continue
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(&b, "⚠️ %s\n\n", warning)
+		}
+		fmt.Fprintf(&b, "당첨금 합계: **%s원**\n\n", utils.FormatAmount(result.Winnings))
+		b.WriteString("| 슬롯 | 방식 | 번호 | 결과 | 당첨금 |\n|---|---|---|---|---|\n")
+		for _, ticket := range result.Tickets {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s원 |\n", ticket.Slot, ticket.Mode, markMatches(ticket.Numbers, result.Numbers, result.Bonus), ticket.Result, utils.FormatAmount(ticket.Prize))
+		}
+		b.WriteString("\n")
+	}
+
+	if winning != nil && len(winning.Prizes) > 0 {
+		b.WriteString("#### 등수별 당첨금\n\n| 등수 | 당첨자 수 | 1인당 당첨금 | 총 당첨금 |\n|---|---:|---:|---:|\n")
+		for _, rank := range []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5} {
+			if info, ok := winning.Prizes[rank]; ok {
+				fmt.Fprintf(&b, "| %s | %s명 | %s원 | %s원 |\n", rank, utils.FormatAmount(int64(info.WinnerCount)), utils.FormatAmount(info.AmountPerWinner), utils.FormatAmount(info.TotalAmount))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// markMatches formats numbers with the winning ones in bold and a matching
+// bonus number in italics.
+func markMatches(numbers, winning []int, bonus int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		switch {
+		case slices.Contains(winning, n):
+			parts[i] = "**" + strconv.Itoa(n) + "**"
+		case n == bonus:
+			parts[i] = "_" + strconv.Itoa(n) + "_"
+		default:
+			parts[i] = strconv.Itoa(n)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultRunTimeout bounds a one-shot command unless LOTTO_TIMEOUT or
+// --timeout says otherwise, well within the 6-hour limit of a GitHub
+// Actions job.
+const defaultRunTimeout = 30 * time.Minute
+
+// errRunTimeout is the cause of a run cancelled for outliving its timeout.
+var errRunTimeout = errors.New("실행 제한 시간 초과")
+
+// runDeadline cancels the context of a one-shot run once its timeout has
+// passed since the start. Flags after the command name can still change the
+// timeout while the command runs, so it is a timer rather than a context
+// deadline.
+type runDeadline struct {
+	mu      sync.Mutex
+	cancel  context.CancelCauseFunc
+	start   time.Time
+	timeout time.Duration
+	// extra is time a command adds for waits it was asked to make.
+	extra time.Duration
+	armed bool
+	timer *time.Timer
+}
+
+func newRunDeadline(cancel context.CancelCauseFunc) *runDeadline {
+	return &runDeadline{cancel: cancel, start: time.Now(), timeout: defaultRunTimeout}
+}
+
+// parseTimeout parses a LOTTO_TIMEOUT or --timeout value; 0 turns the
+// deadline off.
+func parseTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("0 이상의 시간이어야 합니다 (예: 30m, 0: 제한 없음): %s", value)
+	}
+	return d, nil
+}
+
+// setTimeout changes the timeout, measured from the start of the run.
+func (d *runDeadline) setTimeout(timeout time.Duration) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.timeout = timeout
+	d.reset()
+}
+
+// extend adds wait to the timeout, for commands told to wait that long.
+func (d *runDeadline) extend(wait time.Duration) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.extra += wait
+	d.reset()
+}
+
+// arm starts enforcing the timeout.
+func (d *runDeadline) arm() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.armed = true
+	d.reset()
+}
+
+// stop stops enforcing the timeout.
+func (d *runDeadline) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.armed = false
+	d.reset()
+}
+
+// reset replaces the timer after a change. It must be called with mu held.
+func (d *runDeadline) reset() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if !d.armed || d.timeout == 0 {
+		return
+	}
+	limit := d.timeout + d.extra
+	d.timer = time.AfterFunc(time.Until(d.start.Add(limit)), func() {
+		d.cancel(fmt.Errorf("%w (%s)", errRunTimeout, limit))
+	})
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"context"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/tracing"
+)
+
+// notifyStep sends a notification inside a "notify" span tagged with event.
+func notifyStep(ctx context.Context, event string, send func() error) error {
+	_, span := tracing.Start(ctx, "notify", tracing.String("event", event))
+	err := send()
+	span.End(err)
+	return err
+}
+
+// flushTraces exports the spans recorded during a run. Failures are logged,
+// not returned, so an unreachable collector never fails a purchase.
+func (a *App) flushTraces(ctx context.Context) {
+	// 중단 신호로 실행이 끝나는 경우에도 기록한 스팬은 전송
+	if err := tracing.Flush(context.WithoutCancel(ctx)); err != nil {
+		logging.Warnf("⚠️  %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/sanitize"
+)
+
+// Trigger run states reported in triggerRun.Status.
+const (
+	triggerRunning = "running"
+	triggerDone    = "done"
+	triggerFailed  = "failed"
+)
+
+// triggerRun is the latest run of a trigger action.
+type triggerRun struct {
+	Action     string     `json:"action"`
+	Status     string     `json:"status"`
+	DryRun     bool       `json:"dryRun,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	// Results are the per-account results of a finished run.
+	Results any `json:"results,omitempty"`
+}
+
+// triggers runs buy and check in the background for POST /trigger/{action},
+// so a caller with a short timeout (an iOS Shortcut, a webhook) gets an
+// answer right away. Results go out by email as for scheduled runs.
+type triggers struct {
+	mu   sync.Mutex
+	runs map[string]*triggerRun
+	// running counts background runs, so shutdown can wait for them.
+	running sync.WaitGroup
+}
+
+// triggerActions maps the trigger actions to their operations.
+var triggerActions = map[string]func(s *server, ctx context.Context, dryRun bool) (any, error){
+	"buy": func(s *server, ctx context.Context, dryRun bool) (any, error) {
+		return s.buyAll(ctx, dryRun)
+	},
+	"check": func(s *server, ctx context.Context, _ bool) (any, error) {
+		return s.checkAll(ctx)
+	},
+}
+
+// handleTrigger starts a run of the action in the path and answers 202
+// with it, or 409 with the run already in progress.
+func (s *server) handleTrigger(w http.ResponseWriter, r *http.Request) {
+	action := r.PathValue("action")
+	operation, ok := triggerActions[action]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("지원하지 않는 작업입니다 (buy, check)"))
+		return
+	}
+	dryRun, err := queryBool(r, "dry_run")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.triggers.mu.Lock()
+	defer s.triggers.mu.Unlock()
+	if run := s.triggers.runs[action]; run != nil && run.Status == triggerRunning {
+		writeResponse(w, http.StatusConflict, *run)
+		return
+	}
+	run := &triggerRun{Action: action, Status: triggerRunning, DryRun: dryRun && action == "buy", StartedAt: domain.Now()}
+	if s.triggers.runs == nil {
+		s.triggers.runs = make(map[string]*triggerRun)
+	}
+	s.triggers.runs[action] = run
+
+	logging.Infof("🔔 %s 트리거 수신 - 백그라운드에서 실행합니다", action)
+	// 요청이 끝나도 계속 실행하되, 종료 시에는 serve 가 기다림
+	ctx := context.WithoutCancel(r.Context())
+	s.triggers.running.Go(func() {
+		results, err := operation(s, ctx, run.DryRun)
+		finished := domain.Now()
+
+		s.triggers.mu.Lock()
+		defer s.triggers.mu.Unlock()
+		run.FinishedAt = &finished
+		run.Results = results
+		run.Status = triggerDone
+		if err != nil {
+			run.Status = triggerFailed
+			run.Error = sanitize.Error(err)
+		}
+	})
+	writeResponse(w, http.StatusAccepted, *run)
+}
+
+// handleTriggerStatus reports the latest run of the action in the path.
+func (s *server) handleTriggerStatus(w http.ResponseWriter, r *http.Request) {
+	action := r.PathValue("action")
+	if _, ok := triggerActions[action]; !ok {
+		writeError(w, http.StatusNotFound, errors.New("지원하지 않는 작업입니다 (buy, check)"))
+		return
+	}
+	s.triggers.mu.Lock()
+	defer s.triggers.mu.Unlock()
+	run := s.triggers.runs[action]
+	if run == nil {
+		writeError(w, http.StatusNotFound, errors.New("아직 실행한 적이 없습니다"))
+		return
+	}
+	writeResponse(w, http.StatusOK, *run)
+}
+
+// requireConfiguredScope is requireScope for endpoints that must never be
+// open: without a configured token they answer 403.
+func requireConfiguredScope(serve config.ServeConfig, scope string, next http.HandlerFunc) http.HandlerFunc {
+	if !serve.AuthEnabled() {
+		return func(w http.ResponseWriter, r *http.Request) {
+			writeError(w, http.StatusForbidden, errors.New("serve.token (LOTTO_SERVE_TOKEN) 또는 serve.tokens 를 설정해야 사용할 수 있습니다"))
+		}
+	}
+	return requireScope(serve, scope, next)
+}
+
+// wait waits for the background runs to finish, up to ctx.
+func (t *triggers) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"weekly-lotto/internal/lottery"
+)
+
+// upstreamBuckets are the upper bounds, in seconds, of the latency histogram
+// of lottery site requests.
+var upstreamBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// upstream records every request the lottery clients of this process send,
+// by endpoint, for the /metrics of serve and schedule. It is attached with
+// recordUpstream, before the first client is created.
+var upstream = &upstreamMetrics{endpoints: make(map[string]*endpointMetrics)}
+
+var upstreamOnce sync.Once
+
+// recordUpstream starts recording lottery site requests into upstream.
+func recordUpstream() {
+	upstreamOnce.Do(func() { lottery.Use(upstream.middleware) })
+}
+
+// upstreamMetrics holds the latency histogram and outcome counts of lottery
+// site requests per endpoint.
+type upstreamMetrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+}
+
+type endpointMetrics struct {
+	buckets []int64 // cumulative counts per upstreamBuckets bound
+	count   int64
+	seconds float64
+	// results counts requests by status code, or "error" when no response
+	// arrived (DNS, connection, timeout).
+	results map[string]int64
+}
+
+// endpoint names a request by host, path and the method query parameter the
+// site uses to route pages, e.g. "dhlottery.co.kr/gameResult.do?method=byWin".
+func endpoint(req *http.Request) string {
+	name := req.URL.Host + req.URL.Path
+	if method := req.URL.Query().Get("method"); method != "" {
+		name += "?method=" + method
+	}
+	return name
+}
+
+func (m *upstreamMetrics) middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		result := "error"
+		if err == nil {
+			result = strconv.Itoa(resp.StatusCode)
+		}
+		m.observe(endpoint(req), time.Since(start), result)
+		return resp, err
+	})
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func (m *upstreamMetrics) observe(name string, elapsed time.Duration, result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.endpoints[name]
+	if !ok {
+		e = &endpointMetrics{buckets: make([]int64, len(upstreamBuckets)), results: make(map[string]int64)}
+		m.endpoints[name] = e
+	}
+	seconds := elapsed.Seconds()
+	for i, bound := range upstreamBuckets {
+		if seconds <= bound {
+			e.buckets[i]++
+		}
+	}
+	e.count++
+	e.seconds += seconds
+	e.results[result]++
+}
+
+// write appends the upstream metrics in the Prometheus text exposition
+// format. Status codes of 5xx and "error" results are also counted as
+// errors, so an alert on the error rate needs no status regex.
+func (m *upstreamMetrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.endpoints))
+	for name := range m.endpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "# HELP weekly_lotto_upstream_request_duration_seconds Latency of lottery site requests by endpoint.")
+	fmt.Fprintln(w, "# TYPE weekly_lotto_upstream_request_duration_seconds histogram")
+	for _, name := range names {
+		e := m.endpoints[name]
+		for i, bound := range upstreamBuckets {
+			fmt.Fprintf(w, "weekly_lotto_upstream_request_duration_seconds_bucket{endpoint=%q,le=\"%g\"} %d\n", name, bound, e.buckets[i])
+		}
+		fmt.Fprintf(w, "weekly_lotto_upstream_request_duration_seconds_bucket{endpoint=%q,le=\"+Inf\"} %d\n", name, e.count)
+		fmt.Fprintf(w, "weekly_lotto_upstream_request_duration_seconds_sum{endpoint=%q} %.3f\n", name, e.seconds)
+		fmt.Fprintf(w, "weekly_lotto_upstream_request_duration_seconds_count{endpoint=%q} %d\n", name, e.count)
+	}
+
+	fmt.Fprintln(w, "# HELP weekly_lotto_upstream_requests_total Lottery site requests by endpoint and status (\"error\" when no response arrived).")
+	fmt.Fprintln(w, "# TYPE weekly_lotto_upstream_requests_total counter")
+	for _, name := range names {
+		e := m.endpoints[name]
+		results := make([]string, 0, len(e.results))
+		for result := range e.results {
+			results = append(results, result)
+		}
+		sort.Strings(results)
+		for _, result := range results {
+			fmt.Fprintf(w, "weekly_lotto_upstream_requests_total{endpoint=%q,status=%q} %d\n", name, result, e.results[result])
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP weekly_lotto_upstream_errors_total Lottery site requests that failed or returned a 5xx status, by endpoint.")
+	fmt.Fprintln(w, "# TYPE weekly_lotto_upstream_errors_total counter")
+	for _, name := range names {
+		var errs int64
+		for result, n := range m.endpoints[name].results {
+			if result == "error" || result[0] == '5' {
+				errs += n
+			}
+		}
+		fmt.Fprintf(w, "weekly_lotto_upstream_errors_total{endpoint=%q} %d\n", name, errs)
+	}
+}
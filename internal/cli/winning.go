@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"weekly-lotto/internal/domain"
+)
+
+var winningCommand = &command{
+	name:    "winning",
+	usage:   "winning [--round N] [flags]",
+	summary: "회차별 당첨 번호와 등수별 당첨금을 출력합니다 (기본: 최신 회차, 로그인 불필요)",
+	run:     runWinning,
+}
+
+// winningReport is the JSON form of a draw result.
+type winningReport struct {
+	Round    int           `json:"round"`
+	DrawDate time.Time     `json:"drawDate"`
+	Numbers  []int         `json:"numbers"`
+	Bonus    int           `json:"bonus"`
+	Prizes   []prizeReport `json:"prizes"`
+}
+
+type prizeReport struct {
+	Rank            int   `json:"rank"`
+	TotalAmount     int64 `json:"totalAmount"`
+	WinnerCount     int   `json:"winnerCount"`
+	AmountPerWinner int64 `json:"amountPerWinner"`
+}
+
+func runWinning(ctx context.Context, app *App, args []string) error {
+	fs := app.flags()
+	round := fs.Int("round", 0, "조회할 회차 (기본: 최신 회차)")
+	if err := fs.Parse(args); err != nil {
+		return parseError(err)
+	}
+
+	draws := app.drawResults(ctx)
+	defer draws.close()
+	var winning *domain.WinningNumbers
+	var err error
+	if *round > 0 {
+		winning, err = draws.get(*round)
+	} else {
+		winning, err = draws.latest()
+	}
+	if err != nil {
+		return fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+
+	if app.jsonOutput() {
+		return writeJSON(newWinningReport(winning))
+	}
+	fmt.Print(formatWinning(winning, app.palette()))
+	return nil
+}
+
+func newWinningReport(winning *domain.WinningNumbers) *winningReport {
+	report := &winningReport{
+		Round:    winning.Round,
+		DrawDate: winning.DrawDate,
+		Numbers:  winning.Numbers,
+		Bonus:    winning.BonusNumber,
+		Prizes:   []prizeReport{},
+	}
+	for _, rank := range []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5} {
+		info, ok := winning.Prizes[rank]
+		if !ok {
+			continue
+		}
+		report.Prizes = append(report.Prizes, prizeReport{
+			Rank:            rank.Number(),
+			TotalAmount:     info.TotalAmount,
+			WinnerCount:     info.WinnerCount,
+			AmountPerWinner: info.AmountPerWinner,
+		})
+	}
+	return report
+}
+
+// formatWinning renders a draw result for terminal output.
+func formatWinning(winning *domain.WinningNumbers, colors palette) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("🎰 %d회 (%s 추첨)\n", winning.Round, winning.DrawDate.Format("2006-01-02")))
+	builder.WriteString(fmt.Sprintf("당첨 번호: %s + %s\n\n", colors.balls(winning.Numbers, nil, domain.RankNone), colors.ball(winning.BonusNumber, false)))
+	for _, rank := range []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5} {
+		if info, ok := winning.Prizes[rank]; ok {
+			builder.WriteString(info.ToString())
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
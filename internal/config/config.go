@@ -1,101 +1,978 @@
 package config
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"weekly-lotto/internal/store"
 )
 
+// MaxTicketsPerPurchase is the number of slots (A~E) a single order can hold.
+const MaxTicketsPerPurchase = 5
+
 // Config bundles every configuration segment the application needs.
 type Config struct {
-	Credential CredentialConfig
-	Email      EmailConfig
+	Credential    CredentialConfig    `json:"credential"`
+	Accounts      []AccountConfig     `json:"accounts,omitempty"`
+	Email         EmailConfig         `json:"email"`
+	Notifications NotificationsConfig `json:"notifications"`
+	Purchase      PurchaseConfig      `json:"purchase"`
+	Budget        BudgetConfig        `json:"budget"`
+	Store         StoreConfig         `json:"store"`
+	Schedule      ScheduleConfig      `json:"schedule"`
+	Sheets        SheetsConfig        `json:"sheets"`
+	Backup        BackupConfig        `json:"backup"`
+	Sentry        SentryConfig        `json:"sentry"`
+	Tracing       TracingConfig       `json:"tracing"`
+	Healthchecks  HealthchecksConfig  `json:"healthchecks"`
+	Audit         AuditConfig         `json:"audit"`
+	Pushgateway   PushgatewayConfig   `json:"pushgateway"`
+	Lock          LockConfig          `json:"lock"`
+	Serve         ServeConfig         `json:"serve"`
+	Telegram      TelegramConfig      `json:"telegram"`
+	MQTT          MQTTConfig          `json:"mqtt"`
+	Site          SiteConfig          `json:"site"`
+	TLS           TLSConfig           `json:"tls"`
+	Rotation      RotationConfig      `json:"rotation"`
+
+	// Profiles holds named partial configs (e.g. "test", "prod") that are
+	// applied on top of the shared settings when selected via LOTTO_PROFILE.
+	Profiles map[string]json.RawMessage `json:"profiles,omitempty"`
+	// Profile is the name of the applied profile (empty when none).
+	Profile string `json:"-"`
+}
+
+// BudgetConfig caps spending per period in KRW (0 disables the cap).
+// The weekly period follows the draw cycle (Sunday 00:00 ~ Saturday KST).
+type BudgetConfig struct {
+	Weekly  int64 `json:"weekly,omitempty"`
+	Monthly int64 `json:"monthly,omitempty"`
+}
+
+// Enabled reports whether any spending cap is configured.
+func (b BudgetConfig) Enabled() bool {
+	return b.Weekly > 0 || b.Monthly > 0
+}
+
+// StoreConfig locates the purchase ledger. Driver selects the backend (see
+// store.Drivers) and is inferred from the path extension when empty. The
+// postgres driver connects to DSN instead of opening a file at Path.
+// RetentionDays bounds how long purchase records (and archived pages) are
+// kept by the prune command; 0 keeps everything. ArchivePages keeps a
+// compressed copy of every purchase confirmation, winning page and purchase
+// list fetched from the site. EncryptionKey encrypts ticket numbers, order
+// numbers and archived pages inside the store.
+type StoreConfig struct {
+	Driver        string `json:"driver,omitempty"`
+	Path          string `json:"path,omitempty"`
+	DSN           string `json:"dsn,omitempty"`
+	RetentionDays int    `json:"retentionDays,omitempty"`
+	ArchivePages  bool   `json:"archivePages,omitempty"`
+	EncryptionKey string `json:"encryptionKey,omitempty"`
+}
+
+// Enabled reports whether a store is configured.
+func (c StoreConfig) Enabled() bool {
+	return c.Path != "" || c.DSN != ""
+}
+
+// Key decodes EncryptionKey, a base64-encoded store.KeySize-byte key. It
+// returns nil when the store is not encrypted.
+func (c StoreConfig) Key() ([]byte, error) {
+	if c.EncryptionKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(c.EncryptionKey))
+	if err != nil || len(key) != store.KeySize {
+		return nil, fmt.Errorf("store.encryptionKey (LOTTO_STORE_ENCRYPTION_KEY) 는 base64로 인코딩한 %d바이트 키여야 합니다 (예: openssl rand -base64 %d)", store.KeySize, store.KeySize)
+	}
+	return key, nil
+}
+
+// Location is what store.Open expects for the configured driver: the DSN
+// for PostgreSQL, the file path otherwise.
+func (c StoreConfig) Location() string {
+	if c.DSN != "" {
+		return c.DSN
+	}
+	return c.Path
+}
+
+// MinRetentionDays keeps the records the monthly budget and the previous
+// month's report still need.
+const MinRetentionDays = 62
+
+// SheetsConfig appends purchases and check results to a Google Sheet.
+// Credentials is a service account JSON key, given inline, as a file path
+// or as a secret reference. The sync is disabled without SpreadsheetID.
+type SheetsConfig struct {
+	SpreadsheetID string `json:"spreadsheetId,omitempty"`
+	Credentials   string `json:"credentials,omitempty"`
+	PurchaseSheet string `json:"purchaseSheet,omitempty"`
+	ResultSheet   string `json:"resultSheet,omitempty"`
+}
+
+// Enabled reports whether a spreadsheet is configured.
+func (s SheetsConfig) Enabled() bool {
+	return s.SpreadsheetID != ""
+}
+
+// Default sheet (tab) names of the Google Sheets sync.
+const (
+	DefaultPurchaseSheet = "구매"
+	DefaultResultSheet   = "당첨 확인"
+)
+
+// BackupConfig uploads a snapshot of the store to remote storage after each
+// run. URL selects the backend: s3://bucket/prefix (Endpoint for MinIO, R2
+// and other S3-compatible services), gs://bucket/prefix (GCS with HMAC
+// interoperability keys) or an http(s) WebDAV directory with Username and
+// Password. Without AccessKeyID and SecretAccessKey, S3 requests are signed
+// with AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+// Backups are disabled without URL.
+type BackupConfig struct {
+	URL             string `json:"url,omitempty"`
+	Endpoint        string `json:"endpoint,omitempty"`
+	Region          string `json:"region,omitempty"`
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	Username        string `json:"username,omitempty"`
+	Password        string `json:"password,omitempty"`
+}
+
+// Enabled reports whether a backup location is configured.
+func (b BackupConfig) Enabled() bool {
+	return b.URL != ""
+}
+
+// SentryConfig reports failed runs to Sentry (or a compatible service such
+// as GlitchTip) in addition to the failure email. Reporting is disabled
+// without DSN.
+type SentryConfig struct {
+	DSN         string `json:"dsn,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// Enabled reports whether a Sentry DSN is configured.
+func (s SentryConfig) Enabled() bool {
+	return s.DSN != ""
+}
+
+// TracingConfig exports OpenTelemetry spans of each run to an OTLP/HTTP
+// collector (Jaeger, Tempo, Honeycomb, ...). Tracing is disabled without
+// Endpoint.
+type TracingConfig struct {
+	// Endpoint is the collector base URL; spans are posted to
+	// <Endpoint>/v1/traces.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Headers are sent with every export, e.g. an API key.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Enabled reports whether an OTLP endpoint is configured.
+func (t TracingConfig) Enabled() bool {
+	return t.Endpoint != ""
+}
+
+// HealthchecksConfig holds healthchecks.io (or compatible) check URLs that
+// are pinged when a purchase, winning check or monthly report starts and
+// when it succeeds or fails, so a cron run that never happened is noticed.
+// An empty URL disables pings for that operation.
+type HealthchecksConfig struct {
+	Buy    string `json:"buy,omitempty"`
+	Check  string `json:"check,omitempty"`
+	Report string `json:"report,omitempty"`
+}
+
+// AuditConfig enables the append-only audit log of purchase attempts. The
+// log is disabled without Path.
+type AuditConfig struct {
+	Path string `json:"path,omitempty"`
+}
+
+// PushgatewayConfig enables pushing a summary of every buy and check run to
+// a Prometheus Pushgateway, which scrapes cannot reach for short-lived jobs.
+// Pushing is disabled without URL.
+type PushgatewayConfig struct {
+	URL string `json:"url,omitempty"`
+	// Job is the job label of the pushed group.
+	Job string `json:"job,omitempty"`
+}
+
+// DefaultPushgatewayJob is the job label used when none is configured.
+const DefaultPushgatewayJob = "weekly_lotto"
+
+// Enabled reports whether a Pushgateway is configured.
+func (p PushgatewayConfig) Enabled() bool {
+	return p.URL != ""
+}
+
+// ServeConfig configures the serve HTTP API. With a token set, every /api
+// request must carry one as "Authorization: Bearer <token>", and the
+// dashboard (also served by schedule) asks for it as a basic auth password.
+type ServeConfig struct {
+	// Token is allowed everything (ScopePurchase).
+	Token string `json:"token,omitempty"`
+	// Tokens are further tokens with their own scope, e.g. a read-only
+	// token for a dashboard that must not be able to buy.
+	Tokens []ServeTokenConfig `json:"tokens,omitempty"`
+}
+
+// ServeTokenConfig is a named serve token limited to Scope.
+type ServeTokenConfig struct {
+	Name  string `json:"name"`
+	Token string `json:"token"`
+	// Scope is ScopeRead (the default) or ScopePurchase.
+	Scope string `json:"scope,omitempty"`
+}
+
+// Serve token scopes. ScopeRead allows the dashboard and the read-only
+// endpoints; ScopePurchase also allows buying, checking and triggers.
+const (
+	ScopeRead     = "read"
+	ScopePurchase = "purchase"
+)
+
+// ServeScopes lists the valid serve token scopes.
+var ServeScopes = []string{ScopeRead, ScopePurchase}
+
+// AuthEnabled reports whether any serve token is configured.
+func (s ServeConfig) AuthEnabled() bool {
+	return s.Token != "" || len(s.Tokens) > 0
+}
+
+// Scope returns the scope granted to token, or "" when it matches no
+// configured token. Every token is compared in constant time.
+func (s ServeConfig) Scope(token string) string {
+	scope := ""
+	if s.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) == 1 {
+		scope = ScopePurchase
+	}
+	for _, t := range s.Tokens {
+		if t.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(t.Token)) == 1 && scope == "" {
+			scope = t.Scope
+		}
+	}
+	return scope
+}
+
+// ScopeAllows reports whether a token granted scope may do what wanted
+// requires: ScopePurchase covers ScopeRead.
+func ScopeAllows(granted, wanted string) bool {
+	return granted == wanted || granted == ScopePurchase
+}
+
+// TelegramConfig configures the Telegram bot run by the bot command. Only
+// the chats listed in ChatIDs may use it, since it can buy tickets.
+type TelegramConfig struct {
+	Token   string  `json:"token,omitempty"`
+	ChatIDs []int64 `json:"chatIds,omitempty"`
+}
+
+// Enabled reports whether a bot token is configured.
+func (t TelegramConfig) Enabled() bool {
+	return t.Token != ""
+}
+
+// Allowed reports whether the bot may answer chatID.
+func (t TelegramConfig) Allowed(chatID int64) bool {
+	return slices.Contains(t.ChatIDs, chatID)
+}
+
+// MQTTConfig enables publishing balances, results and the next draw time
+// to an MQTT broker, with Home Assistant discovery so they appear as
+// entities without manual setup. Publishing is disabled without URL.
+type MQTTConfig struct {
+	// URL is mqtt://host[:port] (mqtts:// for TLS).
+	URL      string `json:"url,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// TopicPrefix is the root of the state topics.
+	TopicPrefix string `json:"topicPrefix,omitempty"`
+	// DiscoveryPrefix is the Home Assistant discovery prefix.
+	DiscoveryPrefix string `json:"discoveryPrefix,omitempty"`
+}
+
+// Defaults of the MQTT topic prefixes.
+const (
+	DefaultMQTTTopicPrefix     = "weekly-lotto"
+	DefaultMQTTDiscoveryPrefix = "homeassistant"
+)
+
+// Enabled reports whether an MQTT broker is configured.
+func (m MQTTConfig) Enabled() bool {
+	return m.URL != ""
+}
+
+// SiteConfig enables rendering the history, stats and charts into a static
+// HTML bundle after every buy and check run, for GitHub Pages or a file
+// share. The bundle is not written without Dir.
+type SiteConfig struct {
+	// Dir is the directory the bundle is written to.
+	Dir string `json:"dir,omitempty"`
+}
+
+// Enabled reports whether a site directory is configured.
+func (s SiteConfig) Enabled() bool {
+	return s.Dir != ""
+}
+
+// TLSConfig pins the public keys the lottery site may present. With Pins
+// set, connections to the site - which carry the login and the session -
+// are refused unless a certificate of the verified chain has one of the
+// pinned keys, so a proxy re-signing TLS with a CA trusted on the host (as
+// on some home and office networks) cannot read the credentials.
+type TLSConfig struct {
+	// Pins are base64-encoded SHA-256 hashes of certificates'
+	// SubjectPublicKeyInfo, optionally prefixed with "sha256/".
+	Pins []string `json:"pins,omitempty"`
+}
+
+// PinHashes decodes Pins. It returns nil when no key is pinned.
+func (t TLSConfig) PinHashes() ([][sha256.Size]byte, error) {
+	var hashes [][sha256.Size]byte
+	for _, pin := range t.Pins {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(strings.TrimSpace(pin), "sha256/"))
+		if err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("tls.pins (LOTTO_TLS_PINS) 는 공개키(SPKI) SHA-256 해시를 base64로 인코딩한 값이어야 합니다 (sha256/ 접두사 허용): %s", pin)
+		}
+		hashes = append(hashes, [sha256.Size]byte(decoded))
+	}
+	return hashes, nil
+}
+
+// RotationConfig reminds to rotate the lottery and SMTP passwords. The
+// store tracks how long each password has been in use, counted from the
+// first run that saw it, and check runs send a reminder once one is older
+// than MaxAgeDays - then weekly until it is changed. 0 disables reminders.
+type RotationConfig struct {
+	MaxAgeDays int `json:"maxAgeDays,omitempty"`
+}
+
+// Enabled reports whether rotation reminders are configured.
+func (r RotationConfig) Enabled() bool {
+	return r.MaxAgeDays > 0
+}
+
+// LockConfig selects where the purchase lock is held, so two runs buying
+// for the same account and round cannot overlap. Without URL the lock is a
+// file in the system temp directory, which only covers runs on one host.
+type LockConfig struct {
+	// URL is a lock directory (a path or file:///var/lock/weekly-lotto),
+	// redis://[:password@]host:port[/db] (rediss:// for TLS) or
+	// dynamodb://<table>?region=<region> for runners on several hosts.
+	URL string `json:"url,omitempty"`
+	// AccessKeyID and SecretAccessKey sign DynamoDB requests. They default
+	// to AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	// TTL bounds how long a Redis or DynamoDB lock outlives a run that died
+	// holding it.
+	TTL string `json:"ttl,omitempty"`
+}
+
+// DefaultLockTTL is the lock TTL used when none is configured.
+const DefaultLockTTL = "30m"
+
+// Expiry returns TTL as a duration. It must only be called on a validated
+// config.
+func (l LockConfig) Expiry() time.Duration {
+	d, _ := time.ParseDuration(l.TTL)
+	return d
+}
+
+// ScheduleConfig drives the schedule daemon. Buy, Check, Report and Backup
+// are standard 5-field cron specs (or descriptors such as "@every 1h")
+// evaluated in KST. An empty Report disables the monthly report and an
+// empty Backup the backup job (the store is still backed up after every
+// job that opened it). Retries and RetryDelay apply to every job unless
+// Retry overrides them.
+type ScheduleConfig struct {
+	Buy        string          `json:"buy,omitempty"`
+	Check      string          `json:"check,omitempty"`
+	Report     string          `json:"report,omitempty"`
+	Backup     string          `json:"backup,omitempty"`
+	Retries    int             `json:"retries,omitempty"`
+	RetryDelay string          `json:"retryDelay,omitempty"`
+	Retry      ScheduleRetries `json:"retry"`
+	// BuyJitter is the window of a random delay before every scheduled
+	// purchase (daemon jobs and GitHub Actions cron runs), e.g. "15m".
+	// Empty means no delay.
+	BuyJitter string `json:"buyJitter,omitempty"`
+	// State is the file where the daemon keeps the next run of each job,
+	// the progress of a running job and undelivered notifications, so a
+	// restart resumes them. Empty keeps them in memory only.
+	State string `json:"state,omitempty"`
+}
+
+// Scheduled actions of the daemon, as named in ScheduleRetries and in the
+// LOTTO_SCHEDULE_<ACTION>_* variables.
+const (
+	ActionBuy    = "buy"
+	ActionCheck  = "check"
+	ActionReport = "report"
+	ActionBackup = "backup"
+)
+
+// ScheduleActions lists the scheduled actions.
+var ScheduleActions = []string{ActionBuy, ActionCheck, ActionReport, ActionBackup}
+
+// ScheduleRetries holds the retry policy of each scheduled action.
+type ScheduleRetries struct {
+	Buy    RetryPolicy `json:"buy"`
+	Check  RetryPolicy `json:"check"`
+	Report RetryPolicy `json:"report"`
+	Backup RetryPolicy `json:"backup"`
+}
+
+// For returns the policy of action, nil for an unknown action.
+func (r *ScheduleRetries) For(action string) *RetryPolicy {
+	switch action {
+	case ActionBuy:
+		return &r.Buy
+	case ActionCheck:
+		return &r.Check
+	case ActionReport:
+		return &r.Report
+	case ActionBackup:
+		return &r.Backup
+	default:
+		return nil
+	}
+}
+
+// RetryPolicy overrides how one scheduled action is retried. Unset Retries
+// and RetryDelay fall back to schedule.retries and schedule.retryDelay.
+type RetryPolicy struct {
+	Retries    *int   `json:"retries,omitempty"`
+	RetryDelay string `json:"retryDelay,omitempty"`
+	// Backoff is BackoffConstant (the default) or BackoffExponential, which
+	// doubles the delay after every retry up to MaxRetryDelay.
+	Backoff       string `json:"backoff,omitempty"`
+	MaxRetryDelay string `json:"maxRetryDelay,omitempty"`
+}
+
+// Retry backoffs.
+const (
+	BackoffConstant    = "constant"
+	BackoffExponential = "exponential"
+)
+
+// Backoffs lists the supported retry backoffs.
+var Backoffs = []string{BackoffConstant, BackoffExponential}
+
+// Retry is the resolved retry policy of a scheduled action.
+type Retry struct {
+	Retries     int
+	Delay       time.Duration
+	Exponential bool
+	// MaxDelay caps an exponential delay; 0 leaves it uncapped.
+	MaxDelay time.Duration
 }
 
+// Wait returns the delay before retry number attempt, counting from 0.
+func (r Retry) Wait(attempt int) time.Duration {
+	if !r.Exponential {
+		return r.Delay
+	}
+	delay := r.Delay
+	for i := 0; i < attempt && (r.MaxDelay == 0 || delay < r.MaxDelay); i++ {
+		delay *= 2
+	}
+	if r.MaxDelay > 0 {
+		delay = min(delay, r.MaxDelay)
+	}
+	return delay
+}
+
+// RetryFor returns the retry policy of action. It must only be called on a
+// validated config.
+func (s ScheduleConfig) RetryFor(action string) Retry {
+	retry := Retry{Retries: s.Retries, Delay: s.RetryInterval()}
+	policy := s.Retry.For(action)
+	if policy == nil {
+		return retry
+	}
+	if policy.Retries != nil {
+		retry.Retries = *policy.Retries
+	}
+	if policy.RetryDelay != "" {
+		retry.Delay, _ = time.ParseDuration(policy.RetryDelay)
+	}
+	retry.Exponential = policy.Backoff == BackoffExponential
+	if policy.MaxRetryDelay != "" {
+		retry.MaxDelay, _ = time.ParseDuration(policy.MaxRetryDelay)
+	}
+	return retry
+}
+
+// Default schedule, matching the GitHub Actions workflows.
+const (
+	DefaultScheduleBuy        = "0 9 * * 1-5"
+	DefaultScheduleCheck      = "0 21 * * 6"
+	DefaultScheduleRetryDelay = "5m"
+)
+
+// RetryInterval returns RetryDelay as a duration. It must only be called on
+// a validated config.
+func (s ScheduleConfig) RetryInterval() time.Duration {
+	d, _ := time.ParseDuration(s.RetryDelay)
+	return d
+}
+
+// BuyJitterWindow returns BuyJitter as a duration, 0 when unset. It must
+// only be called on a validated config.
+func (s ScheduleConfig) BuyJitterWindow() time.Duration {
+	d, _ := time.ParseDuration(s.BuyJitter)
+	return d
+}
+
+// DefaultAccountName names the account built from the credential section.
+const DefaultAccountName = "default"
+
 // CredentialConfig keeps login credentials for the lottery site.
 type CredentialConfig struct {
-	Username string
-	Password string
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// AccountConfig is a named lottery account, used when several people
+// (e.g. family members) are managed from one installation. ReadOnly refuses
+// purchases with this account alone; PurchaseConfig.ReadOnly sets it for
+// every account.
+type AccountConfig struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	ReadOnly bool   `json:"readOnly,omitempty"`
+}
+
+// NotificationsConfig routes notification events to recipients.
+// Without routes every event is sent to EmailConfig.To.
+type NotificationsConfig struct {
+	Routes []RouteConfig `json:"routes,omitempty"`
+}
+
+// RouteConfig sends the listed events of the listed accounts to recipients.
+// Empty Events or Accounts (or "*") match everything. Mask hides the played
+// numbers from recipients less private than the owner, such as a shared
+// inbox: purchases show no numbers and results only the drawn ones.
+type RouteConfig struct {
+	Events   []string `json:"events,omitempty"`
+	Accounts []string `json:"accounts,omitempty"`
+	Channel  string   `json:"channel,omitempty"`
+	To       []string `json:"to"`
+	Mask     bool     `json:"mask,omitempty"`
+}
+
+// Notification event names used in RouteConfig.Events.
+const (
+	EventBuy      = "buy"
+	EventCheck    = "check"
+	EventFailure  = "failure"
+	EventBudget   = "budget"
+	EventBalance  = "balance"
+	EventReport   = "report"
+	EventTopUp    = "topup"
+	EventRotation = "rotation"
+)
+
+// NotificationEvents lists every event a route can subscribe to.
+var NotificationEvents = []string{EventBuy, EventCheck, EventFailure, EventBudget, EventBalance, EventReport, EventTopUp, EventRotation}
+
+// ChannelEmail is the only notification channel currently supported.
+const ChannelEmail = "email"
+
+// LotteryAccounts returns every account the commands should run for.
+// The credential section is used when no accounts are configured. With
+// purchase.readOnly every account is returned read-only.
+func (c *Config) LotteryAccounts() []AccountConfig {
+	accounts := c.Accounts
+	if len(accounts) == 0 {
+		accounts = []AccountConfig{{
+			Name:     DefaultAccountName,
+			Username: c.Credential.Username,
+			Password: c.Credential.Password,
+		}}
+	}
+	if !c.Purchase.ReadOnly {
+		return accounts
+	}
+	readOnly := make([]AccountConfig, len(accounts))
+	for i, account := range accounts {
+		account.ReadOnly = true
+		readOnly[i] = account
+	}
+	return readOnly
 }
 
 // EmailConfig holds SMTP configuration for notifications.
 type EmailConfig struct {
-	From     string
-	To       []string
-	SMTPHost string
-	SMTPPort int
-	Username string
-	Password string
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+	SMTPHost string   `json:"smtpHost"`
+	SMTPPort int      `json:"smtpPort"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+}
+
+// PurchaseConfig describes the weekly basket bought by cmd/buy. ReadOnly
+// turns purchasing off for every account, for a dashboard or a shared
+// machine that should only check results: the lottery clients of the
+// accounts then refuse every purchase request (see lottery.Client.SetReadOnly).
+type PurchaseConfig struct {
+	Tickets  []TicketConfig `json:"tickets"`
+	ReadOnly bool           `json:"readOnly,omitempty"`
 }
 
-// Load reads every configuration section from environment variables.
+// TicketConfig describes a single slot of the basket.
+//
+//   - mode "auto" without strategy: numbers are picked by the lottery site
+//   - mode "semi-auto": Numbers holds 1~5 fixed numbers, the rest is picked by the site
+//   - mode "manual": Numbers holds all 6 numbers
+//   - Strategy set: missing numbers are generated locally and bought as manual
+type TicketConfig struct {
+	Mode     string `json:"mode"`
+	Numbers  []int  `json:"numbers,omitempty"`
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// Load reads the optional JSON config file (LOTTO_CONFIG), overlays environment
+// variables and validates the result, reporting every problem at once.
 func Load() (*Config, error) {
-	credential, err := loadCredential()
-	if err != nil {
+	return LoadWith(nil)
+}
+
+// LoadWith is Load with command-line overrides applied on top of the
+// environment (see BindFlags).
+func LoadWith(overrides *Overrides) (*Config, error) {
+	cfg, problems := load(overrides)
+	if err := cfg.validate(problems); err != nil {
 		return nil, err
 	}
 
-	email, err := loadEmail()
-	if err != nil {
-		return nil, err
+	return cfg, nil
+}
+
+// Inspect loads the configuration like LoadWith but always returns it, together
+// with the validation error (if any), so broken setups can be diagnosed.
+func Inspect(overrides *Overrides) (*Config, error) {
+	cfg, problems := load(overrides)
+	return cfg, cfg.validate(problems)
+}
+
+func load(overrides *Overrides) (*Config, []string) {
+	var problems []string
+
+	e := newEnv(overrides, &problems)
+
+	cfg := &Config{}
+	if path := e.get("LOTTO_CONFIG"); path != "" {
+		err := loadFile(path, e.get("LOTTO_PROFILE"), cfg)
+		var invalid *ValidationError
+		if errors.As(err, &invalid) {
+			for _, problem := range invalid.Problems {
+				problems = append(problems, fmt.Sprintf("%s: %s", path, problem))
+			}
+		} else if err != nil {
+			problems = append(problems, fmt.Sprintf("LOTTO_CONFIG 설정 파일 로드 실패: %v", err))
+		}
+	} else if profile := e.get("LOTTO_PROFILE"); profile != "" {
+		problems = append(problems, fmt.Sprintf("LOTTO_PROFILE=%s 을 사용하려면 LOTTO_CONFIG 설정 파일이 필요합니다", profile))
 	}
 
-	return &Config{
-		Credential: *credential,
-		Email:      *email,
-	}, nil
+	cfg.applyEnv(e, &problems)
+	cfg.applyDefaults()
+	cfg.resolveSecrets(&problems)
+
+	return cfg, problems
 }
 
-func loadCredential() (*CredentialConfig, error) {
-	username := os.Getenv("LOTTO_USERNAME")
-	password := os.Getenv("LOTTO_PASSWORD")
+// loadFile decodes a JSON config file after validating it against Schema, so
+// unknown keys and wrong types are reported with their JSON path. When profile
+// is set, that entry of "profiles" is merged over the shared settings first:
+// objects are merged key by key, while lists (e.g. purchase.tickets) and
+// scalars are replaced.
+func loadFile(path, profile string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("JSON 파싱 실패: %w", err)
+	}
+	schema := Schema()
+	if problems := validateSchema(schema, schema, doc, ""); len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	if profile != "" {
+		if data, err = mergeProfile(data, profile); err != nil {
+			return err
+		}
+		cfg.Profile = profile
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(cfg); err != nil {
+		return fmt.Errorf("JSON 파싱 실패: %w", err)
+	}
+
+	return nil
+}
 
-	if username == "" {
-		return nil, fmt.Errorf("LOTTO_USERNAME 환경 변수가 설정되지 않았습니다")
+func mergeProfile(data []byte, name string) ([]byte, error) {
+	var base map[string]interface{}
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("JSON 파싱 실패: %w", err)
 	}
 
-	if password == "" {
-		return nil, fmt.Errorf("LOTTO_PASSWORD 환경 변수가 설정되지 않았습니다")
+	profiles, _ := base["profiles"].(map[string]interface{})
+	overlay, ok := profiles[name].(map[string]interface{})
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for profile := range profiles {
+			names = append(names, profile)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%q 프로필이 없습니다 (사용 가능: %s)", name, strings.Join(names, ", "))
+	}
+	if _, nested := overlay["profiles"]; nested {
+		return nil, fmt.Errorf("%s 프로필 안에 profiles 를 중첩할 수 없습니다", name)
 	}
 
-	return &CredentialConfig{
-		Username: username,
-		Password: password,
-	}, nil
+	return json.Marshal(mergeObjects(base, overlay))
 }
 
-func loadEmail() (*EmailConfig, error) {
-	from := os.Getenv("LOTTO_EMAIL_FROM")
-	toList := strings.Split(os.Getenv("LOTTO_EMAIL_TO"), ",")
-	host := os.Getenv("LOTTO_EMAIL_SMTP_HOST")
-	portStr := os.Getenv("LOTTO_EMAIL_SMTP_PORT")
-	username := os.Getenv("LOTTO_EMAIL_USERNAME")
-	password := os.Getenv("LOTTO_EMAIL_PASSWORD")
+// mergeObjects recursively merges overlay into base and returns base.
+func mergeObjects(base, overlay map[string]interface{}) map[string]interface{} {
+	for key, value := range overlay {
+		baseObj, baseIsObj := base[key].(map[string]interface{})
+		overlayObj, overlayIsObj := value.(map[string]interface{})
+		if baseIsObj && overlayIsObj {
+			base[key] = mergeObjects(baseObj, overlayObj)
+			continue
+		}
+		base[key] = value
+	}
+	return base
+}
+
+// applyEnv overrides file values with every non-empty environment variable.
+func (c *Config) applyEnv(e env, problems *[]string) {
+	overrideString(&c.Credential.Username, e.get("LOTTO_USERNAME"))
+	overrideString(&c.Credential.Password, e.get("LOTTO_PASSWORD"))
+
+	overrideString(&c.Email.From, e.get("LOTTO_EMAIL_FROM"))
+	if to := splitList(e.get("LOTTO_EMAIL_TO")); len(to) > 0 {
+		c.Email.To = to
+	}
+	overrideString(&c.Email.SMTPHost, e.get("LOTTO_EMAIL_SMTP_HOST"))
+	c.Email.SMTPPort = e.int("LOTTO_EMAIL_SMTP_PORT", c.Email.SMTPPort, problems)
+	overrideString(&c.Email.Username, e.get("LOTTO_EMAIL_USERNAME"))
+	overrideString(&c.Email.Password, e.get("LOTTO_EMAIL_PASSWORD"))
+
+	c.Budget.Weekly = int64(e.int("LOTTO_BUDGET_WEEKLY", int(c.Budget.Weekly), problems))
+	c.Budget.Monthly = int64(e.int("LOTTO_BUDGET_MONTHLY", int(c.Budget.Monthly), problems))
+	overrideString(&c.Store.Driver, e.get("LOTTO_STORE_DRIVER"))
+	overrideString(&c.Store.Path, e.get("LOTTO_STORE_PATH"))
+	overrideString(&c.Store.DSN, e.get("LOTTO_STORE_DSN"))
+	c.Store.RetentionDays = e.int("LOTTO_STORE_RETENTION_DAYS", c.Store.RetentionDays, problems)
+	c.Store.ArchivePages = e.bool("LOTTO_STORE_ARCHIVE_PAGES", c.Store.ArchivePages, problems)
+	overrideString(&c.Store.EncryptionKey, e.get("LOTTO_STORE_ENCRYPTION_KEY"))
 
-	recipients := make([]string, 0, len(toList))
-	for _, to := range toList {
-		to = strings.TrimSpace(to)
-		if to != "" {
-			recipients = append(recipients, to)
+	overrideString(&c.Schedule.Buy, e.get("LOTTO_SCHEDULE_BUY"))
+	overrideString(&c.Schedule.Check, e.get("LOTTO_SCHEDULE_CHECK"))
+	overrideString(&c.Schedule.Report, e.get("LOTTO_SCHEDULE_REPORT"))
+	overrideString(&c.Schedule.Backup, e.get("LOTTO_SCHEDULE_BACKUP"))
+	c.Schedule.Retries = e.int("LOTTO_SCHEDULE_RETRIES", c.Schedule.Retries, problems)
+	overrideString(&c.Schedule.RetryDelay, e.get("LOTTO_SCHEDULE_RETRY_DELAY"))
+	overrideString(&c.Schedule.BuyJitter, e.get("LOTTO_SCHEDULE_BUY_JITTER"))
+	overrideString(&c.Schedule.State, e.get("LOTTO_SCHEDULE_STATE"))
+	for _, action := range ScheduleActions {
+		prefix := "LOTTO_SCHEDULE_" + strings.ToUpper(action) + "_"
+		policy := c.Schedule.Retry.For(action)
+		if e.get(prefix+"RETRIES") != "" {
+			retries := e.int(prefix+"RETRIES", 0, problems)
+			policy.Retries = &retries
 		}
+		overrideString(&policy.RetryDelay, e.get(prefix+"RETRY_DELAY"))
+		overrideString(&policy.Backoff, e.get(prefix+"BACKOFF"))
+		overrideString(&policy.MaxRetryDelay, e.get(prefix+"MAX_RETRY_DELAY"))
 	}
 
-	if from == "" || len(recipients) == 0 || host == "" || portStr == "" || username == "" || password == "" {
-		return nil, fmt.Errorf("이메일 환경 변수가 누락되었습니다")
+	overrideString(&c.Sheets.SpreadsheetID, e.get("LOTTO_SHEETS_SPREADSHEET_ID"))
+	overrideString(&c.Sheets.Credentials, e.get("LOTTO_SHEETS_CREDENTIALS"))
+	overrideString(&c.Sheets.PurchaseSheet, e.get("LOTTO_SHEETS_PURCHASE_SHEET"))
+	overrideString(&c.Sheets.ResultSheet, e.get("LOTTO_SHEETS_RESULT_SHEET"))
+
+	overrideString(&c.Backup.URL, e.get("LOTTO_BACKUP_URL"))
+	overrideString(&c.Backup.Endpoint, e.get("LOTTO_BACKUP_ENDPOINT"))
+	overrideString(&c.Backup.Region, e.get("LOTTO_BACKUP_REGION"))
+	overrideString(&c.Backup.AccessKeyID, e.get("LOTTO_BACKUP_ACCESS_KEY_ID"))
+	overrideString(&c.Backup.SecretAccessKey, e.get("LOTTO_BACKUP_SECRET_ACCESS_KEY"))
+	overrideString(&c.Backup.Username, e.get("LOTTO_BACKUP_USERNAME"))
+	overrideString(&c.Backup.Password, e.get("LOTTO_BACKUP_PASSWORD"))
+	overrideString(&c.Sentry.DSN, e.get("LOTTO_SENTRY_DSN"))
+	overrideString(&c.Sentry.Environment, e.get("LOTTO_SENTRY_ENVIRONMENT"))
+	overrideString(&c.Tracing.Endpoint, e.get("LOTTO_TRACING_ENDPOINT"))
+	overrideString(&c.Audit.Path, e.get("LOTTO_AUDIT_PATH"))
+	overrideString(&c.Pushgateway.URL, e.get("LOTTO_PUSHGATEWAY_URL"))
+	overrideString(&c.Pushgateway.Job, e.get("LOTTO_PUSHGATEWAY_JOB"))
+	overrideString(&c.Lock.URL, e.get("LOTTO_LOCK_URL"))
+	overrideString(&c.Lock.AccessKeyID, e.get("LOTTO_LOCK_ACCESS_KEY_ID"))
+	overrideString(&c.Lock.SecretAccessKey, e.get("LOTTO_LOCK_SECRET_ACCESS_KEY"))
+	overrideString(&c.Lock.TTL, e.get("LOTTO_LOCK_TTL"))
+	overrideString(&c.Serve.Token, e.get("LOTTO_SERVE_TOKEN"))
+	if token := e.get("LOTTO_SERVE_READ_TOKEN"); token != "" {
+		c.Serve.Tokens = append(c.Serve.Tokens, ServeTokenConfig{Name: "LOTTO_SERVE_READ_TOKEN", Token: token, Scope: ScopeRead})
+	}
+	overrideString(&c.Telegram.Token, e.get("LOTTO_TELEGRAM_TOKEN"))
+	if ids := splitList(e.get("LOTTO_TELEGRAM_CHAT_IDS")); len(ids) > 0 {
+		c.Telegram.ChatIDs = nil
+		for _, id := range ids {
+			chatID, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				*problems = append(*problems, fmt.Sprintf("LOTTO_TELEGRAM_CHAT_IDS 값이 숫자가 아닙니다: %q", id))
+				continue
+			}
+			c.Telegram.ChatIDs = append(c.Telegram.ChatIDs, chatID)
+		}
+	}
+	overrideString(&c.MQTT.URL, e.get("LOTTO_MQTT_URL"))
+	overrideString(&c.MQTT.Username, e.get("LOTTO_MQTT_USERNAME"))
+	overrideString(&c.MQTT.Password, e.get("LOTTO_MQTT_PASSWORD"))
+	overrideString(&c.MQTT.TopicPrefix, e.get("LOTTO_MQTT_TOPIC_PREFIX"))
+	overrideString(&c.MQTT.DiscoveryPrefix, e.get("LOTTO_MQTT_DISCOVERY_PREFIX"))
+	overrideString(&c.Site.Dir, e.get("LOTTO_SITE_DIR"))
+	c.Rotation.MaxAgeDays = e.int("LOTTO_ROTATION_MAX_AGE_DAYS", c.Rotation.MaxAgeDays, problems)
+	if pins := splitList(e.get("LOTTO_TLS_PINS")); len(pins) > 0 {
+		c.TLS.Pins = pins
+	}
+	overrideString(&c.Healthchecks.Buy, e.get("LOTTO_HEALTHCHECKS_BUY"))
+	overrideString(&c.Healthchecks.Check, e.get("LOTTO_HEALTHCHECKS_CHECK"))
+	overrideString(&c.Healthchecks.Report, e.get("LOTTO_HEALTHCHECKS_REPORT"))
+	if headers := splitList(e.get("LOTTO_TRACING_HEADERS")); len(headers) > 0 {
+		c.Tracing.Headers = make(map[string]string, len(headers))
+		for _, header := range headers {
+			key, value, ok := strings.Cut(header, "=")
+			if !ok {
+				*problems = append(*problems, fmt.Sprintf("LOTTO_TRACING_HEADERS 형식 오류: 키=값 목록이어야 합니다: %s", header))
+				continue
+			}
+			c.Tracing.Headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
 	}
 
-	port, err := strconv.Atoi(portStr)
-	if err != nil {
-		return nil, fmt.Errorf("LOTTO_EMAIL_SMTP_PORT 파싱 실패: %w", err)
+	c.Purchase.ReadOnly = e.bool("LOTTO_READ_ONLY", c.Purchase.ReadOnly, problems)
+
+	// LOTTO_TICKET_COUNT / LOTTO_TICKET_MODE 는 동일한 티켓 N장으로 바구니를 대체
+	mode := e.get("LOTTO_TICKET_MODE")
+	count := e.int("LOTTO_TICKET_COUNT", 0, problems)
+	if count < 0 {
+		*problems = append(*problems, fmt.Sprintf("LOTTO_TICKET_COUNT 는 1~%d장이어야 합니다: %d", MaxTicketsPerPurchase, count))
+	} else if mode != "" || count != 0 {
+		if mode == "" {
+			mode = "auto"
+		}
+		if count == 0 {
+			count = max(len(c.Purchase.Tickets), 1)
+		}
+		c.Purchase.Tickets = uniformTickets(count, mode)
 	}
+}
 
-	return &EmailConfig{
-		From:     from,
-		To:       recipients,
-		SMTPHost: host,
-		SMTPPort: port,
-		Username: username,
-		Password: password,
-	}, nil
+// applyDefaults fills values that have a sensible default.
+func (c *Config) applyDefaults() {
+	for i := range c.Notifications.Routes {
+		if c.Notifications.Routes[i].Channel == "" {
+			c.Notifications.Routes[i].Channel = ChannelEmail
+		}
+	}
+	if c.Store.Driver == "" && c.Store.DSN != "" {
+		// key=value 형식 DSN은 스킴이 없으므로 PostgreSQL로 간주
+		c.Store.Driver = store.DriverFor(c.Store.DSN)
+		if !store.IsRemote(c.Store.Driver) {
+			c.Store.Driver = store.DriverPostgres
+		}
+	}
+	if c.Schedule.Buy == "" {
+		c.Schedule.Buy = DefaultScheduleBuy
+	}
+	if c.Schedule.Check == "" {
+		c.Schedule.Check = DefaultScheduleCheck
+	}
+	if c.Schedule.RetryDelay == "" {
+		c.Schedule.RetryDelay = DefaultScheduleRetryDelay
+	}
+	if c.Sheets.PurchaseSheet == "" {
+		c.Sheets.PurchaseSheet = DefaultPurchaseSheet
+	}
+	if c.Sheets.ResultSheet == "" {
+		c.Sheets.ResultSheet = DefaultResultSheet
+	}
+	if c.Lock.TTL == "" {
+		c.Lock.TTL = DefaultLockTTL
+	}
+	if c.Pushgateway.Enabled() && c.Pushgateway.Job == "" {
+		c.Pushgateway.Job = DefaultPushgatewayJob
+	}
+	for i := range c.Serve.Tokens {
+		if c.Serve.Tokens[i].Scope == "" {
+			c.Serve.Tokens[i].Scope = ScopeRead
+		}
+	}
+	if c.MQTT.Enabled() && c.MQTT.TopicPrefix == "" {
+		c.MQTT.TopicPrefix = DefaultMQTTTopicPrefix
+	}
+	if c.MQTT.Enabled() && c.MQTT.DiscoveryPrefix == "" {
+		c.MQTT.DiscoveryPrefix = DefaultMQTTDiscoveryPrefix
+	}
+	if len(c.Purchase.Tickets) == 0 {
+		c.Purchase.Tickets = uniformTickets(1, "auto")
+	}
+	for i := range c.Purchase.Tickets {
+		if c.Purchase.Tickets[i].Mode == "" {
+			c.Purchase.Tickets[i].Mode = "auto"
+		}
+	}
+}
+
+func uniformTickets(count int, mode string) []TicketConfig {
+	tickets := make([]TicketConfig, count)
+	for i := range tickets {
+		tickets[i] = TicketConfig{Mode: mode}
+	}
+	return tickets
+}
+
+func overrideString(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+)
+
+// loadCredentialsFile decrypts an age-encrypted dotenv file and returns its
+// KEY=VALUE pairs. The file is created with the age CLI, e.g.
+//
+//	age -p -o credentials.env.age credentials.env
+//
+// It is decrypted with LOTTO_CREDENTIALS_PASSPHRASE (scrypt recipient) or with
+// the identities in LOTTO_CREDENTIALS_IDENTITY (an age key file).
+func loadCredentialsFile(path string) (map[string]string, error) {
+	identities, err := credentialsIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decrypted, err := age.Decrypt(file, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("복호화 실패: %w", err)
+	}
+
+	plaintext, err := io.ReadAll(decrypted)
+	if err != nil {
+		return nil, fmt.Errorf("복호화 데이터 읽기 실패: %w", err)
+	}
+
+	return parseDotenv(plaintext)
+}
+
+func credentialsIdentities() ([]age.Identity, error) {
+	if passphrase := os.Getenv("LOTTO_CREDENTIALS_PASSPHRASE"); passphrase != "" {
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return []age.Identity{identity}, nil
+	}
+
+	if keyPath := os.Getenv("LOTTO_CREDENTIALS_IDENTITY"); keyPath != "" {
+		keyFile, err := os.Open(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		defer keyFile.Close()
+
+		return age.ParseIdentities(keyFile)
+	}
+
+	return nil, fmt.Errorf("LOTTO_CREDENTIALS_PASSPHRASE 또는 LOTTO_CREDENTIALS_IDENTITY 환경 변수가 필요합니다")
+}
+
+// parseDotenv parses KEY=VALUE lines, ignoring blanks and # comments.
+func parseDotenv(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%d번째 줄 형식이 올바르지 않습니다 (KEY=VALUE)", lineNo)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+
+	return values, scanner.Err()
+}
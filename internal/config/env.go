@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// env looks up configuration keys in flag overrides first, then the process
+// environment, and finally values decrypted from the credentials file
+// (LOTTO_CREDENTIALS_FILE).
+type env struct {
+	overrides *Overrides
+	fallback  map[string]string
+}
+
+func newEnv(overrides *Overrides, problems *[]string) env {
+	e := env{overrides: overrides}
+
+	path := e.get("LOTTO_CREDENTIALS_FILE")
+	if path == "" {
+		return e
+	}
+
+	values, err := loadCredentialsFile(path)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("LOTTO_CREDENTIALS_FILE 읽기 실패: %v", err))
+		return e
+	}
+
+	e.fallback = values
+	return e
+}
+
+func (e env) get(key string) string {
+	if value, ok := e.overrides.lookup(key); ok {
+		return value
+	}
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return e.fallback[key]
+}
+
+// int parses an integer value, recording a problem when the value is present
+// but malformed.
+func (e env) int(key string, fallback int, problems *[]string) int {
+	raw := strings.TrimSpace(e.get(key))
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s 값이 숫자가 아닙니다: %q", key, raw))
+		return fallback
+	}
+
+	return value
+}
+
+// bool parses a boolean value ("true", "1", "false", ...), recording a
+// problem when the value is present but malformed.
+func (e env) bool(key string, fallback bool, problems *[]string) bool {
+	raw := strings.TrimSpace(e.get(key))
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		*problems = append(*problems, fmt.Sprintf("%s 값이 true/false가 아닙니다: %q", key, raw))
+		return fallback
+	}
+
+	return value
+}
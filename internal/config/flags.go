@@ -0,0 +1,75 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// Overrides holds per-invocation values set via command-line flags.
+// Each value is keyed by the environment variable it overrides, so flags take
+// precedence over both the environment and the config file.
+type Overrides struct {
+	values map[string]string
+}
+
+// flagAliases maps friendly flag names to the environment variable they set.
+var flagAliases = []struct {
+	name  string
+	key   string
+	usage string
+}{
+	{"config", "LOTTO_CONFIG", "JSON 설정 파일 경로"},
+	{"profile", "LOTTO_PROFILE", "적용할 설정 프로필 이름"},
+	{"tickets", "LOTTO_TICKET_COUNT", "구매 장수 (1~5)"},
+	{"mode", "LOTTO_TICKET_MODE", "구매 모드 (auto, semi-auto, manual)"},
+	{"email-from", "LOTTO_EMAIL_FROM", "발신자 이메일"},
+	{"email-to", "LOTTO_EMAIL_TO", "수신자 이메일 (쉼표로 구분)"},
+	{"smtp-host", "LOTTO_EMAIL_SMTP_HOST", "SMTP 서버 주소"},
+	{"smtp-port", "LOTTO_EMAIL_SMTP_PORT", "SMTP 포트"},
+}
+
+// BindFlags registers override flags on fs. Besides the friendly aliases,
+// "--set KEY=VALUE" (repeatable) overrides any LOTTO_* environment variable.
+func BindFlags(fs *flag.FlagSet) *Overrides {
+	o := &Overrides{values: make(map[string]string)}
+	o.Bind(fs)
+	return o
+}
+
+// Bind registers the override flags on fs so that several flag sets (e.g. the
+// global flags and a subcommand's flags) can feed the same Overrides.
+func (o *Overrides) Bind(fs *flag.FlagSet) {
+	for _, alias := range flagAliases {
+		key := alias.key
+		fs.Func(alias.name, fmt.Sprintf("%s (%s 대체)", alias.usage, key), func(value string) error {
+			return o.Set(key + "=" + value)
+		})
+	}
+
+	fs.Func("set", "임의의 설정 값 대체 (KEY=VALUE, 반복 가능, 예: --set LOTTO_EMAIL_SMTP_PORT=465)", func(value string) error {
+		return o.Set(value)
+	})
+}
+
+// Set records a "KEY=VALUE" override.
+func (o *Overrides) Set(assignment string) error {
+	key, value, ok := strings.Cut(assignment, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("KEY=VALUE 형식이어야 합니다: %q", assignment)
+	}
+	if o.values == nil {
+		o.values = make(map[string]string)
+	}
+	o.values[key] = value
+	return nil
+}
+
+func (o *Overrides) lookup(key string) (string, bool) {
+	if o == nil {
+		return "", false
+	}
+	value, ok := o.values[key]
+	return value, ok
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redactedValue = "********"
+
+// Redacted returns a deep copy of the configuration with every password and
+// secret masked, suitable for printing.
+func (c *Config) Redacted() *Config {
+	clone := *c
+
+	clone.Credential.Password = redact(c.Credential.Password)
+	clone.Email.Password = redact(c.Email.Password)
+	clone.Backup.SecretAccessKey = redact(c.Backup.SecretAccessKey)
+	clone.Backup.Password = redact(c.Backup.Password)
+	clone.Store.DSN = redactDSN(c.Store.DSN)
+	clone.Store.EncryptionKey = redact(c.Store.EncryptionKey)
+	clone.Sentry.DSN = redact(c.Sentry.DSN)
+	clone.Lock.URL = redactDSN(c.Lock.URL)
+	clone.Lock.SecretAccessKey = redact(c.Lock.SecretAccessKey)
+	clone.Serve.Token = redact(c.Serve.Token)
+	clone.Serve.Tokens = make([]ServeTokenConfig, len(c.Serve.Tokens))
+	for i, token := range c.Serve.Tokens {
+		token.Token = redact(token.Token)
+		clone.Serve.Tokens[i] = token
+	}
+	if len(c.Serve.Tokens) == 0 {
+		clone.Serve.Tokens = nil
+	}
+	clone.Telegram.Token = redact(c.Telegram.Token)
+	clone.Telegram.ChatIDs = append([]int64(nil), c.Telegram.ChatIDs...)
+	clone.MQTT.Password = redact(c.MQTT.Password)
+	if c.Tracing.Headers != nil {
+		// 헤더에는 보통 API 키가 들어가므로 값은 모두 가림
+		clone.Tracing.Headers = make(map[string]string, len(c.Tracing.Headers))
+		for key, value := range c.Tracing.Headers {
+			clone.Tracing.Headers[key] = redact(value)
+		}
+	}
+	clone.Email.To = append([]string(nil), c.Email.To...)
+	// 파일 경로는 그대로 두고 직접 넣은 서비스 계정 키만 가림
+	if strings.HasPrefix(strings.TrimSpace(c.Sheets.Credentials), "{") {
+		clone.Sheets.Credentials = redactedValue
+	}
+
+	clone.Accounts = make([]AccountConfig, len(c.Accounts))
+	for i, account := range c.Accounts {
+		account.Password = redact(account.Password)
+		clone.Accounts[i] = account
+	}
+	if len(c.Accounts) == 0 {
+		clone.Accounts = nil
+	}
+
+	clone.Notifications.Routes = append([]RouteConfig(nil), c.Notifications.Routes...)
+	clone.Purchase.Tickets = append([]TicketConfig(nil), c.Purchase.Tickets...)
+	// 프로필 원문에는 비밀번호가 포함될 수 있으므로 출력하지 않음
+	clone.Profiles = nil
+
+	return &clone
+}
+
+// Secrets returns the secret values of the configuration - lottery and SMTP
+// usernames and passwords, keys and tokens - so they can be scrubbed from
+// logs and error messages.
+func (c *Config) Secrets() []string {
+	values := []string{
+		c.Credential.Username, c.Credential.Password,
+		c.Email.Username, c.Email.Password,
+		c.Backup.SecretAccessKey, c.Backup.Password,
+		c.Store.EncryptionKey,
+		c.Lock.SecretAccessKey,
+		c.Serve.Token,
+		c.Telegram.Token,
+		c.MQTT.Password,
+	}
+	for _, account := range c.Accounts {
+		values = append(values, account.Username, account.Password)
+	}
+	for _, token := range c.Serve.Tokens {
+		values = append(values, token.Token)
+	}
+	if u, err := url.Parse(c.Store.DSN); err == nil {
+		if password, ok := u.User.Password(); ok {
+			values = append(values, password)
+		}
+	}
+	if u, err := url.Parse(c.Lock.URL); err == nil {
+		if password, ok := u.User.Password(); ok {
+			values = append(values, password)
+		}
+	}
+	if u, err := url.Parse(c.Sentry.DSN); err == nil && u.User != nil {
+		values = append(values, u.User.Username())
+	}
+	for _, value := range c.Tracing.Headers {
+		values = append(values, value)
+	}
+	return values
+}
+
+// redact masks a secret while keeping whether it was set visible.
+func redact(secret string) string {
+	if strings.TrimSpace(secret) == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// dsnPassword matches the password of a key/value DSN such as
+// "host=db user=lotto password=secret".
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// redactDSN masks the password of a database DSN in either the URL or the
+// key/value form, leaving the host and database visible.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redactedValue)
+		}
+		query := u.Query()
+		if query.Has("password") {
+			query.Set("password", redactedValue)
+			u.RawQuery = query.Encode()
+		}
+		// URL 인코딩된 마스크를 읽기 쉽게 되돌림
+		return strings.ReplaceAll(u.String(), url.QueryEscape(redactedValue), redactedValue)
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}"+redactedValue)
+}
@@ -0,0 +1,344 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/generator"
+	"weekly-lotto/internal/store"
+)
+
+//go:generate sh -c "go run ../../cmd/weekly-lotto config schema > ../../config.schema.json"
+
+// SchemaID is the $id of the generated config schema.
+const SchemaID = "https://github.com/rlaxowns7916/weekly-lottoery/config.schema.json"
+
+// schemaDocs holds descriptions keyed by dotted field path; list items are
+// addressed with a "[]" suffix (e.g. "purchase.tickets[].mode").
+var schemaDocs = map[string]string{
+	"credential":                      "동행복권 로그인 정보 (accounts가 없을 때 default 계정)",
+	"credential.username":             "동행복권 아이디 (LOTTO_USERNAME)",
+	"credential.password":             "동행복권 비밀번호 또는 시크릿 참조 (LOTTO_PASSWORD)",
+	"accounts":                        "여러 계정을 관리할 때의 계정 목록",
+	"accounts[].name":                 "알림 라우팅에 쓰이는 계정 이름",
+	"accounts[].readOnly":             "이 계정만 읽기 전용 - 구매 요청을 막음 (purchase.readOnly는 모든 계정에 적용)",
+	"email":                           "SMTP 이메일 설정",
+	"email.from":                      "발신자 이메일 (LOTTO_EMAIL_FROM)",
+	"email.to":                        "기본 수신자 목록 (LOTTO_EMAIL_TO)",
+	"email.smtpHost":                  "SMTP 서버 주소 (LOTTO_EMAIL_SMTP_HOST)",
+	"email.smtpPort":                  "SMTP 포트, 465는 implicit TLS (LOTTO_EMAIL_SMTP_PORT)",
+	"notifications.routes":            "이벤트/계정별 알림 수신자 라우팅",
+	"notifications.routes[].events":   "구독할 이벤트 (비어 있으면 전체)",
+	"notifications.routes[].accounts": "대상 계정 (비어 있으면 전체)",
+	"notifications.routes[].mask":     "구매 번호를 가리고 보냄 - 구매 알림은 번호 없이, 당첨 결과는 맞은 번호만 (공유 메일함 등)",
+	"purchase.tickets":                "회당 구매할 티켓 목록 (최대 5장)",
+	"purchase.tickets[].mode":         "구매 모드",
+	"purchase.tickets[].numbers":      "수동/반자동 번호 또는 전략의 고정 번호",
+	"purchase.tickets[].strategy":     "로컬 번호 생성 전략",
+	"purchase.readOnly":               "읽기 전용 모드 - 구매 요청을 모두 막고 당첨 확인, 예치금/구매 내역 조회만 허용 (대시보드, 공용 PC용, LOTTO_READ_ONLY)",
+	"budget.weekly":                   "주간 지출 한도 (원, 0이면 미사용)",
+	"budget.monthly":                  "월간 지출 한도 (원, 0이면 미사용)",
+	"store.driver":                    "저장소 종류 (기본: dsn이 있으면 postgres, 경로 확장자가 .json/.jsonl/.ndjson이면 json, .bolt/.bbolt이면 bolt, 아니면 sqlite, LOTTO_STORE_DRIVER)",
+	"store.path":                      "구매 장부 파일 경로 (LOTTO_STORE_PATH)",
+	"store.dsn":                       "PostgreSQL 접속 문자열 (예: postgres://lotto:비밀번호@db:5432/lotto?sslmode=disable, 시크릿 참조 가능, LOTTO_STORE_DSN)",
+	"store.retentionDays":             "prune 명령이 구매 기록과 보관 페이지를 보관할 일수 (0이면 전체 보관, LOTTO_STORE_RETENTION_DAYS)",
+	"store.encryptionKey":             "저장소 안의 티켓 번호, 주문 번호, 보관 페이지를 암호화할 base64 32바이트 키 (openssl rand -base64 32, 시크릿 참조 가능, LOTTO_STORE_ENCRYPTION_KEY)",
+	"store.archivePages":              "구매 확인, 당첨 번호, 구매 내역 페이지 원본을 압축해 저장소에 보관 (LOTTO_STORE_ARCHIVE_PAGES)",
+	"schedule":                        "schedule 데몬 실행 주기 (KST)",
+	"schedule.buy":                    "구매 cron (기본 0 9 * * 1-5, LOTTO_SCHEDULE_BUY)",
+	"schedule.check":                  "당첨 확인 cron (기본 0 21 * * 6, LOTTO_SCHEDULE_CHECK)",
+	"schedule.report":                 "지난달 리포트 이메일 cron (예: 0 9 1 * *, 비어 있으면 사용 안 함, LOTTO_SCHEDULE_REPORT)",
+	"schedule.backup":                 "저장소 백업 cron (예: 0 4 * * *, backup.url 필요, 비어 있으면 작업 후 자동 백업만, LOTTO_SCHEDULE_BACKUP)",
+	"schedule.retries":                "실패 시 재시도 횟수 (기본 0, LOTTO_SCHEDULE_RETRIES)",
+	"schedule.retryDelay":             "재시도 간격 (기본 5m, LOTTO_SCHEDULE_RETRY_DELAY)",
+	"schedule.retry":                  "작업(buy, check, report, backup)별 재시도 정책, 지정하지 않은 값은 schedule.retries/retryDelay",
+	"schedule.buyJitter":              "예약 구매(schedule 데몬, GitHub Actions cron) 전에 0~이 값 사이에서 무작위로 기다림 (예: 15m, 비어 있으면 바로 구매, LOTTO_SCHEDULE_BUY_JITTER)",
+	"schedule.state":                  "데몬 상태 파일 경로: 작업별 다음 실행, 중단된 작업의 계정별 진행, 보내지 못한 알림을 남겨 재시작 후 이어서 처리 (예: schedule-state.json, 비어 있으면 메모리에만 보관, LOTTO_SCHEDULE_STATE)",
+	"sheets":                          "구매/당첨 확인 결과를 Google Sheets에 행으로 추가 (서비스 계정 사용)",
+	"sheets.spreadsheetId":            "스프레드시트 ID, URL의 /d/ 뒤 부분 (비어 있으면 사용 안 함, LOTTO_SHEETS_SPREADSHEET_ID)",
+	"sheets.credentials":              "서비스 계정 JSON 키 내용, 파일 경로 또는 시크릿 참조 (LOTTO_SHEETS_CREDENTIALS)",
+	"sheets.purchaseSheet":            "구매 기록 시트 이름 (기본 구매, LOTTO_SHEETS_PURCHASE_SHEET)",
+	"sheets.resultSheet":              "당첨 확인 결과 시트 이름 (기본 당첨 확인, LOTTO_SHEETS_RESULT_SHEET)",
+	"backup":                          "실행 후 저장소를 S3/GCS/WebDAV에 자동 백업 (restore 명령으로 복원)",
+	"backup.url":                      "백업 위치 s3://버킷/경로, gs://버킷/경로 또는 WebDAV https:// 디렉터리 (비어 있으면 사용 안 함, LOTTO_BACKUP_URL)",
+	"backup.endpoint":                 "S3 호환 서비스 주소 (예: MinIO, R2, LOTTO_BACKUP_ENDPOINT)",
+	"backup.region":                   "S3 리전 (기본 us-east-1, GCS는 auto, LOTTO_BACKUP_REGION)",
+	"backup.accessKeyId":              "S3 액세스 키 또는 GCS HMAC 키 ID (S3 기본: AWS_ACCESS_KEY_ID, LOTTO_BACKUP_ACCESS_KEY_ID)",
+	"backup.secretAccessKey":          "S3 시크릿 키 또는 GCS HMAC 시크릿, 시크릿 참조 가능 (S3 기본: AWS_SECRET_ACCESS_KEY와 AWS_SESSION_TOKEN, LOTTO_BACKUP_SECRET_ACCESS_KEY)",
+	"backup.username":                 "WebDAV 사용자 이름 (LOTTO_BACKUP_USERNAME)",
+	"backup.password":                 "WebDAV 비밀번호 또는 시크릿 참조 (LOTTO_BACKUP_PASSWORD)",
+	"sentry":                          "실패한 실행을 Sentry(또는 GlitchTip 등 호환 서비스)에 보고",
+	"sentry.dsn":                      "프로젝트 DSN https://<키>@<호스트>/<프로젝트 ID> (비어 있으면 사용 안 함, 시크릿 참조 가능, LOTTO_SENTRY_DSN)",
+	"sentry.environment":              "이벤트에 붙일 환경 이름 (예: production, LOTTO_SENTRY_ENVIRONMENT)",
+	"tracing":                         "실행 단계별 OpenTelemetry 스팬을 OTLP/HTTP 수집기로 전송",
+	"tracing.endpoint":                "OTLP/HTTP 수집기 주소, /v1/traces 앞부분 (예: http://localhost:4318, 비어 있으면 사용 안 함, LOTTO_TRACING_ENDPOINT)",
+	"tracing.headers":                 "전송 시 붙일 HTTP 헤더 (예: API 키, LOTTO_TRACING_HEADERS=키=값,키=값)",
+	"healthchecks":                    "작업 시작/성공/실패 시 healthchecks.io(또는 호환 서비스)에 핑을 보내 빠진 cron 실행을 감지",
+	"healthchecks.buy":                "구매 체크 URL (예: https://hc-ping.com/<UUID>, 비어 있으면 사용 안 함, LOTTO_HEALTHCHECKS_BUY)",
+	"healthchecks.check":              "당첨 확인 체크 URL (LOTTO_HEALTHCHECKS_CHECK)",
+	"healthchecks.report":             "월간 리포트 체크 URL (LOTTO_HEALTHCHECKS_REPORT)",
+	"audit":                           "구매 시도/결과를 기록하는 변경 방지(해시 체인) 감사 로그",
+	"audit.path":                      "감사 로그 파일 경로, JSON Lines (비어 있으면 사용 안 함, LOTTO_AUDIT_PATH)",
+	"pushgateway":                     "buy/check 실행 결과 요약을 Prometheus Pushgateway로 전송",
+	"pushgateway.url":                 "Pushgateway 주소 (예: http://localhost:9091, 비어 있으면 사용 안 함, LOTTO_PUSHGATEWAY_URL)",
+	"pushgateway.job":                 "job 레이블 (기본: weekly_lotto, LOTTO_PUSHGATEWAY_JOB)",
+	"lock":                            "같은 계정/회차 구매가 동시에 실행되지 않도록 잡는 잠금",
+	"lock.url":                        "잠금 위치: 디렉터리 경로(file://), redis://[:비밀번호@]<호스트>:<포트>[/<DB>], dynamodb://<테이블>?region=<리전>[&endpoint=<URL>] (비어 있으면 임시 디렉터리의 파일 잠금, 시크릿 참조 가능, LOTTO_LOCK_URL)",
+	"lock.accessKeyId":                "DynamoDB 액세스 키 ID (기본: AWS_ACCESS_KEY_ID, LOTTO_LOCK_ACCESS_KEY_ID)",
+	"lock.secretAccessKey":            "DynamoDB 시크릿 액세스 키 또는 시크릿 참조 (기본: AWS_SECRET_ACCESS_KEY, LOTTO_LOCK_SECRET_ACCESS_KEY)",
+	"lock.ttl":                        "Redis/DynamoDB 잠금이 중단된 실행 뒤에 남아 있는 최대 시간 (기본 30m, LOTTO_LOCK_TTL)",
+	"serve":                           "serve HTTP API 설정",
+	"serve.token":                     "/api 요청에 Authorization: Bearer 로, 대시보드에 기본 인증 비밀번호로 요구할 모든 권한(purchase)의 토큰 또는 시크릿 참조 (tokens도 없으면 인증 안 함, LOTTO_SERVE_TOKEN)",
+	"serve.tokens":                    "범위를 제한한 추가 토큰 목록 (예: 구매할 수 없는 대시보드용 토큰)",
+	"serve.tokens[].name":             "토큰 이름 (로그와 오류 메시지에 표시)",
+	"serve.tokens[].token":            "토큰 또는 시크릿 참조 (LOTTO_SERVE_READ_TOKEN 은 read 토큰 하나를 추가)",
+	"serve.tokens[].scope":            "read: 대시보드와 조회 API만 (기본), purchase: 구매/당첨 확인/트리거까지",
+	"telegram":                        "bot 명령으로 실행하는 텔레그램 봇 설정",
+	"telegram.token":                  "BotFather가 발급한 봇 토큰 또는 시크릿 참조 (LOTTO_TELEGRAM_TOKEN)",
+	"telegram.chatIds":                "봇 명령을 허용할 채팅 ID 목록 (LOTTO_TELEGRAM_CHAT_IDS, 쉼표로 구분)",
+	"mqtt":                            "잔액, 당첨 결과, 다음 추첨 시각을 MQTT로 발행 (Home Assistant 자동 등록)",
+	"mqtt.url":                        "브로커 주소 (예: mqtt://homeassistant.local:1883, TLS는 mqtts://, 비어 있으면 사용 안 함, LOTTO_MQTT_URL)",
+	"mqtt.username":                   "브로커 사용자 이름 (LOTTO_MQTT_USERNAME)",
+	"mqtt.password":                   "브로커 비밀번호 또는 시크릿 참조 (LOTTO_MQTT_PASSWORD)",
+	"mqtt.topicPrefix":                "상태 토픽 접두사 (기본: weekly-lotto, LOTTO_MQTT_TOPIC_PREFIX)",
+	"mqtt.discoveryPrefix":            "Home Assistant discovery 접두사 (기본: homeassistant, LOTTO_MQTT_DISCOVERY_PREFIX)",
+	"site":                            "buy/check 실행마다 구매 내역, 통계, 차트를 정적 HTML로 생성",
+	"site.dir":                        "HTML을 쓸 디렉터리 (예: ./docs, 비어 있으면 생성 안 함, LOTTO_SITE_DIR)",
+	"tls":                             "동행복권 사이트 인증서 공개키 고정 (로그인 정보를 가로채는 TLS 프록시 차단)",
+	"tls.pins":                        "허용할 공개키(SPKI) SHA-256 해시 목록, base64 (예: sha256/..., 비어 있으면 고정 안 함, LOTTO_TLS_PINS)",
+	"rotation":                        "동행복권/SMTP 비밀번호 교체 알림 (사용 기간은 저장소에 기록)",
+	"rotation.maxAgeDays":             "이 일수보다 오래 쓴 비밀번호는 check 실행 때 교체 알림을 보냄 (이후 매주, 0: 사용 안 함, LOTTO_ROTATION_MAX_AGE_DAYS)",
+	"profiles":                        "LOTTO_PROFILE로 선택하는 이름별 부분 설정",
+}
+
+// schemaConstraints adds enums and ranges keyed by dotted field path.
+func schemaConstraints() map[string]map[string]interface{} {
+	constraints := map[string]map[string]interface{}{
+		"email.smtpPort":                  {"minimum": 1, "maximum": 65535},
+		"notifications.routes[].events[]": {"enum": append([]string{"*"}, NotificationEvents...)},
+		"notifications.routes[].channel":  {"enum": []string{ChannelEmail}},
+		"purchase.tickets":                {"maxItems": MaxTicketsPerPurchase},
+		"purchase.tickets[].mode":         {"enum": []string{"auto", "semi-auto", "manual", "자동", "반자동", "수동"}},
+		"purchase.tickets[].numbers":      {"maxItems": domain.NumbersPerTicket},
+		"purchase.tickets[].numbers[]":    {"minimum": domain.MinNumber, "maximum": domain.MaxNumber},
+		"purchase.tickets[].strategy":     {"enum": generator.Names()},
+		"budget.weekly":                   {"minimum": 0},
+		"budget.monthly":                  {"minimum": 0},
+		"store.driver":                    {"enum": store.Drivers()},
+		"store.retentionDays":             {"minimum": 0},
+		"schedule.retries":                {"minimum": 0},
+		"serve.tokens[].scope":            {"enum": ServeScopes},
+	}
+	for _, action := range ScheduleActions {
+		constraints["schedule.retry."+action+".retries"] = map[string]interface{}{"minimum": 0}
+		constraints["schedule.retry."+action+".backoff"] = map[string]interface{}{"enum": Backoffs}
+	}
+	return constraints
+}
+
+func init() {
+	for _, action := range ScheduleActions {
+		path := "schedule.retry." + action
+		env := "LOTTO_SCHEDULE_" + strings.ToUpper(action)
+		schemaDocs[path+".retries"] = fmt.Sprintf("실패 시 재시도 횟수 (%s_RETRIES)", env)
+		schemaDocs[path+".retryDelay"] = fmt.Sprintf("첫 재시도 간격 (%s_RETRY_DELAY)", env)
+		schemaDocs[path+".backoff"] = fmt.Sprintf("constant: 같은 간격, exponential: 재시도마다 간격 두 배 (기본 constant, %s_BACKOFF)", env)
+		schemaDocs[path+".maxRetryDelay"] = fmt.Sprintf("exponential 재시도 간격 상한 (예: 1h, 비어 있으면 제한 없음, %s_MAX_RETRY_DELAY)", env)
+	}
+}
+
+// Schema returns the JSON Schema (draft 2020-12) of the config file,
+// generated from the Config struct.
+func Schema() map[string]interface{} {
+	schema := schemaFor(reflect.TypeOf(Config{}), "", schemaConstraints())
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = SchemaID
+	schema["title"] = "weekly-lotto config"
+	// 편집기가 스키마를 찾을 수 있도록 "$schema" 키 허용
+	schema["properties"].(map[string]interface{})["$schema"] = map[string]interface{}{"type": "string"}
+	return schema
+}
+
+func schemaFor(t reflect.Type, path string, constraints map[string]map[string]interface{}) map[string]interface{} {
+	var schema map[string]interface{}
+
+	switch {
+	case t == reflect.TypeOf(json.RawMessage{}):
+		// 프로필은 루트 스키마와 같은 형식의 부분 설정
+		schema = map[string]interface{}{"$ref": "#"}
+	case t.Kind() == reflect.Pointer:
+		// 선택 값은 가리키는 타입과 같은 스키마
+		return schemaFor(t.Elem(), path, constraints)
+	case t.Kind() == reflect.Struct:
+		properties := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := jsonName(field)
+			if name == "" {
+				continue
+			}
+			properties[name] = schemaFor(field.Type, joinPath(path, name), constraints)
+		}
+		schema = map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	case t.Kind() == reflect.Slice:
+		schema = map[string]interface{}{
+			"type":  "array",
+			"items": schemaFor(t.Elem(), path+"[]", constraints),
+		}
+	case t.Kind() == reflect.Map:
+		schema = map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": schemaFor(t.Elem(), path, constraints),
+		}
+	case t.Kind() == reflect.String:
+		schema = map[string]interface{}{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		schema = map[string]interface{}{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		schema = map[string]interface{}{"type": "integer"}
+	default:
+		schema = map[string]interface{}{}
+	}
+
+	if doc, ok := schemaDocs[path]; ok {
+		schema["description"] = doc
+	}
+	for key, value := range constraints[path] {
+		schema[key] = value
+	}
+	return schema
+}
+
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" || !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// validateSchema checks a decoded JSON document against schema and returns
+// one problem per violation, each prefixed with the JSON path.
+func validateSchema(root, schema map[string]interface{}, doc interface{}, path string) []string {
+	if ref, ok := schema["$ref"]; ok && ref == "#" {
+		schema = root
+	}
+
+	location := path
+	if location == "" {
+		location = "(루트)"
+	}
+
+	var problems []string
+	switch schema["type"] {
+	case "object":
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: 객체여야 합니다", location)}
+		}
+
+		properties, _ := schema["properties"].(map[string]interface{})
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := joinPath(path, key)
+			if propSchema, ok := properties[key].(map[string]interface{}); ok {
+				problems = append(problems, validateSchema(root, propSchema, obj[key], childPath)...)
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case map[string]interface{}:
+				problems = append(problems, validateSchema(root, extra, obj[key], childPath)...)
+			case bool:
+				if !extra {
+					problems = append(problems, fmt.Sprintf("%s: 알 수 없는 키입니다%s", childPath, suggestKey(key, properties)))
+				}
+			}
+		}
+	case "array":
+		items, ok := doc.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: 배열이어야 합니다", location)}
+		}
+		if maxItems, ok := toFloat(schema["maxItems"]); ok && float64(len(items)) > maxItems {
+			problems = append(problems, fmt.Sprintf("%s: 최대 %d개까지 가능합니다 (현재 %d개)", location, int(maxItems), len(items)))
+		}
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		for i, item := range items {
+			problems = append(problems, validateSchema(root, itemSchema, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		if _, ok := doc.(string); !ok {
+			return []string{fmt.Sprintf("%s: 문자열이어야 합니다", location)}
+		}
+	case "integer":
+		n, ok := doc.(float64)
+		if !ok || n != float64(int64(n)) {
+			return []string{fmt.Sprintf("%s: 정수여야 합니다", location)}
+		}
+		if min, ok := toFloat(schema["minimum"]); ok && n < min {
+			problems = append(problems, fmt.Sprintf("%s: %v 이상이어야 합니다 (현재 %v)", location, min, n))
+		}
+		if max, ok := toFloat(schema["maximum"]); ok && n > max {
+			problems = append(problems, fmt.Sprintf("%s: %v 이하여야 합니다 (현재 %v)", location, max, n))
+		}
+	case "boolean":
+		if _, ok := doc.(bool); !ok {
+			return []string{fmt.Sprintf("%s: true 또는 false 여야 합니다", location)}
+		}
+	}
+
+	if enum, ok := schema["enum"].([]string); ok {
+		if value, isString := doc.(string); isString && !containsString(enum, value) {
+			problems = append(problems, fmt.Sprintf("%s: %q 는 허용되지 않는 값입니다 (%s)", location, value, strings.Join(enum, ", ")))
+		}
+	}
+
+	return problems
+}
+
+// suggestKey points at a known key that differs only by case, a common typo.
+func suggestKey(key string, properties map[string]interface{}) string {
+	for known := range properties {
+		if strings.EqualFold(known, key) {
+			return fmt.Sprintf(" (%q 을(를) 의미했나요?)", known)
+		}
+	}
+	return ""
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"weekly-lotto/internal/secrets"
+)
+
+const secretResolveTimeout = 15 * time.Second
+
+// resolveSecrets replaces secret references such as
+// "aws-sm://lotto/prod#password" or "aws-ssm:///weekly-lotto/smtp-password"
+// with the values fetched from the backing secret store.
+func (c *Config) resolveSecrets(problems *[]string) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+
+	fields := []struct {
+		key   string
+		value *string
+	}{
+		{"LOTTO_USERNAME", &c.Credential.Username},
+		{"LOTTO_PASSWORD", &c.Credential.Password},
+		{"LOTTO_EMAIL_USERNAME", &c.Email.Username},
+		{"LOTTO_EMAIL_PASSWORD", &c.Email.Password},
+		{"LOTTO_SHEETS_CREDENTIALS", &c.Sheets.Credentials},
+		{"LOTTO_BACKUP_SECRET_ACCESS_KEY", &c.Backup.SecretAccessKey},
+		{"LOTTO_BACKUP_PASSWORD", &c.Backup.Password},
+		{"LOTTO_STORE_DSN", &c.Store.DSN},
+		{"LOTTO_STORE_ENCRYPTION_KEY", &c.Store.EncryptionKey},
+		{"LOTTO_SENTRY_DSN", &c.Sentry.DSN},
+		{"LOTTO_LOCK_URL", &c.Lock.URL},
+		{"LOTTO_LOCK_SECRET_ACCESS_KEY", &c.Lock.SecretAccessKey},
+		{"LOTTO_SERVE_TOKEN", &c.Serve.Token},
+		{"LOTTO_TELEGRAM_TOKEN", &c.Telegram.Token},
+		{"LOTTO_MQTT_PASSWORD", &c.MQTT.Password},
+	}
+	for i := range c.Accounts {
+		account := &c.Accounts[i]
+		fields = append(fields,
+			struct {
+				key   string
+				value *string
+			}{fmt.Sprintf("accounts[%s].username", account.Name), &account.Username},
+			struct {
+				key   string
+				value *string
+			}{fmt.Sprintf("accounts[%s].password", account.Name), &account.Password},
+		)
+	}
+
+	for i := range c.Serve.Tokens {
+		token := &c.Serve.Tokens[i]
+		fields = append(fields, struct {
+			key   string
+			value *string
+		}{fmt.Sprintf("serve.tokens[%s].token", token.Name), &token.Token})
+	}
+
+	for _, field := range fields {
+		if !secrets.IsReference(*field.value) {
+			continue
+		}
+
+		resolved, err := secrets.Resolve(ctx, *field.value)
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("%s 시크릿 참조를 해석하지 못했습니다: %v", field.key, err))
+			continue
+		}
+		*field.value = resolved
+	}
+}
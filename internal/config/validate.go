@@ -0,0 +1,532 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/generator"
+	"weekly-lotto/internal/store"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ValidationError aggregates every configuration problem found by Validate.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("설정 검증 실패 (%d건)", len(e.Problems)))
+	for _, problem := range e.Problems {
+		builder.WriteString("\n  - ")
+		builder.WriteString(problem)
+	}
+	return builder.String()
+}
+
+// Validate checks required fields, ranges and formats of the configuration.
+// It returns a *ValidationError listing every problem, or nil when valid.
+func (c *Config) Validate() error {
+	return c.validate(nil)
+}
+
+func (c *Config) validate(problems []string) error {
+	if len(c.Accounts) == 0 {
+		problems = append(problems, c.Credential.validate()...)
+	}
+	problems = append(problems, validateAccounts(c.Accounts)...)
+	problems = append(problems, c.Email.validate(len(c.Notifications.Routes) > 0)...)
+	problems = append(problems, c.Notifications.validate(c.LotteryAccounts())...)
+	problems = append(problems, c.Purchase.validate()...)
+	problems = append(problems, c.Budget.validate(c.Store)...)
+	problems = append(problems, c.Store.validate()...)
+	problems = append(problems, c.Schedule.validate(c.Backup)...)
+	problems = append(problems, c.Sheets.validate()...)
+	problems = append(problems, c.Backup.validate(c.Store)...)
+	problems = append(problems, c.Sentry.validate()...)
+	problems = append(problems, c.Tracing.validate()...)
+	problems = append(problems, c.Healthchecks.validate()...)
+	problems = append(problems, c.Pushgateway.validate()...)
+	problems = append(problems, c.Lock.validate()...)
+	problems = append(problems, c.Serve.validate()...)
+	problems = append(problems, c.Telegram.validate()...)
+	problems = append(problems, c.MQTT.validate()...)
+	problems = append(problems, c.Site.validate(c.Store)...)
+	problems = append(problems, c.Rotation.validate(c.Store)...)
+	if _, err := c.TLS.PinHashes(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
+
+func missing(key, envKey string) string {
+	return fmt.Sprintf("%s (%s) 값이 설정되지 않았습니다", key, envKey)
+}
+
+func (c CredentialConfig) validate() []string {
+	var problems []string
+	if c.Username == "" {
+		problems = append(problems, missing("credential.username", "LOTTO_USERNAME"))
+	}
+	if c.Password == "" {
+		problems = append(problems, missing("credential.password", "LOTTO_PASSWORD"))
+	}
+	return problems
+}
+
+func validateAccounts(accounts []AccountConfig) []string {
+	var problems []string
+	seen := make(map[string]struct{}, len(accounts))
+	for i, account := range accounts {
+		if account.Name == "" {
+			problems = append(problems, fmt.Sprintf("accounts[%d].name 값이 설정되지 않았습니다", i))
+		} else if _, dup := seen[account.Name]; dup {
+			problems = append(problems, fmt.Sprintf("accounts[%d].name 이 중복되었습니다: %q", i, account.Name))
+		}
+		seen[account.Name] = struct{}{}
+
+		if account.Username == "" {
+			problems = append(problems, fmt.Sprintf("accounts[%d].username 값이 설정되지 않았습니다", i))
+		}
+		if account.Password == "" {
+			problems = append(problems, fmt.Sprintf("accounts[%d].password 값이 설정되지 않았습니다", i))
+		}
+	}
+	return problems
+}
+
+func (c NotificationsConfig) validate(accounts []AccountConfig) []string {
+	var problems []string
+
+	known := make(map[string]struct{}, len(accounts))
+	for _, account := range accounts {
+		known[account.Name] = struct{}{}
+	}
+
+	for i, route := range c.Routes {
+		prefix := fmt.Sprintf("notifications.routes[%d]", i)
+
+		if route.Channel != ChannelEmail {
+			problems = append(problems, fmt.Sprintf("%s.channel 은 %q 만 지원합니다: %q", prefix, ChannelEmail, route.Channel))
+		}
+
+		for _, event := range route.Events {
+			if event != "*" && !containsString(NotificationEvents, event) {
+				problems = append(problems, fmt.Sprintf("%s.events 에 알 수 없는 이벤트가 있습니다: %q (%s)", prefix, event, strings.Join(NotificationEvents, ", ")))
+			}
+		}
+
+		for _, account := range route.Accounts {
+			if _, ok := known[account]; !ok && account != "*" {
+				problems = append(problems, fmt.Sprintf("%s.accounts 에 없는 계정이 있습니다: %q", prefix, account))
+			}
+		}
+
+		if len(route.To) == 0 {
+			problems = append(problems, fmt.Sprintf("%s.to 값이 설정되지 않았습니다", prefix))
+		}
+		for _, to := range route.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				problems = append(problems, fmt.Sprintf("%s.to 이메일 형식이 올바르지 않습니다: %q", prefix, to))
+			}
+		}
+	}
+
+	return problems
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (c EmailConfig) validate(routed bool) []string {
+	var problems []string
+
+	if c.From == "" {
+		problems = append(problems, missing("email.from", "LOTTO_EMAIL_FROM"))
+	} else if _, err := mail.ParseAddress(c.From); err != nil {
+		problems = append(problems, fmt.Sprintf("email.from 이메일 형식이 올바르지 않습니다: %q", c.From))
+	}
+
+	if len(c.To) == 0 && !routed {
+		problems = append(problems, missing("email.to", "LOTTO_EMAIL_TO"))
+	}
+	for _, to := range c.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			problems = append(problems, fmt.Sprintf("email.to 이메일 형식이 올바르지 않습니다: %q", to))
+		}
+	}
+
+	if c.SMTPHost == "" {
+		problems = append(problems, missing("email.smtpHost", "LOTTO_EMAIL_SMTP_HOST"))
+	}
+
+	if c.SMTPPort == 0 {
+		problems = append(problems, missing("email.smtpPort", "LOTTO_EMAIL_SMTP_PORT"))
+	} else if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+		problems = append(problems, fmt.Sprintf("email.smtpPort 범위가 올바르지 않습니다 (1~65535): %d", c.SMTPPort))
+	}
+
+	if c.Username == "" {
+		problems = append(problems, missing("email.username", "LOTTO_EMAIL_USERNAME"))
+	}
+	if c.Password == "" {
+		problems = append(problems, missing("email.password", "LOTTO_EMAIL_PASSWORD"))
+	}
+
+	return problems
+}
+
+func (c PurchaseConfig) validate() []string {
+	var problems []string
+
+	if len(c.Tickets) < 1 || len(c.Tickets) > MaxTicketsPerPurchase {
+		problems = append(problems, fmt.Sprintf("purchase.tickets (LOTTO_TICKET_COUNT) 는 1~%d장이어야 합니다: %d", MaxTicketsPerPurchase, len(c.Tickets)))
+	}
+
+	for i, ticket := range c.Tickets {
+		for _, problem := range ticket.validate() {
+			problems = append(problems, fmt.Sprintf("purchase.tickets[%d]: %s", i, problem))
+		}
+	}
+
+	return problems
+}
+
+func (t TicketConfig) validate() []string {
+	mode, err := domain.ParseLotto645Mode(t.Mode)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	if t.Strategy != "" {
+		if _, err := generator.Lookup(t.Strategy); err != nil {
+			return []string{err.Error()}
+		}
+		// 전략 사용 시 지정한 번호는 고정 번호로만 쓰이므로 범위/중복만 확인
+		if len(t.Numbers) >= domain.NumbersPerTicket {
+			return []string{fmt.Sprintf("전략을 사용할 때는 고정 번호를 %d개 미만으로 지정해야 합니다", domain.NumbersPerTicket)}
+		}
+		if len(t.Numbers) > 0 {
+			if err := domain.ValidateNumbers(domain.ModeSemiAuto, t.Numbers); err != nil {
+				return []string{err.Error()}
+			}
+		}
+		return nil
+	}
+
+	if err := domain.ValidateNumbers(mode, t.Numbers); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+func (c BudgetConfig) validate(store StoreConfig) []string {
+	var problems []string
+	if c.Weekly < 0 {
+		problems = append(problems, fmt.Sprintf("budget.weekly (LOTTO_BUDGET_WEEKLY) 는 0 이상이어야 합니다: %d", c.Weekly))
+	}
+	if c.Monthly < 0 {
+		problems = append(problems, fmt.Sprintf("budget.monthly (LOTTO_BUDGET_MONTHLY) 는 0 이상이어야 합니다: %d", c.Monthly))
+	}
+	if c.Enabled() && !store.Enabled() {
+		problems = append(problems, "예산 한도를 사용하려면 store.path (LOTTO_STORE_PATH) 또는 store.dsn (LOTTO_STORE_DSN) 이 필요합니다")
+	}
+	return problems
+}
+
+func (c StoreConfig) validate() []string {
+	var problems []string
+	if c.Driver != "" && !slices.Contains(store.Drivers(), c.Driver) {
+		problems = append(problems, fmt.Sprintf("store.driver (LOTTO_STORE_DRIVER) 는 %s 중 하나여야 합니다: %s", strings.Join(store.Drivers(), ", "), c.Driver))
+	}
+	if c.RetentionDays < 0 || (c.RetentionDays > 0 && c.RetentionDays < MinRetentionDays) {
+		problems = append(problems, fmt.Sprintf("store.retentionDays (LOTTO_STORE_RETENTION_DAYS) 는 0(전체 보관) 또는 %d 이상이어야 합니다: %d", MinRetentionDays, c.RetentionDays))
+	}
+	if store.IsRemote(c.Driver) && c.DSN == "" {
+		problems = append(problems, missing("store.dsn", "LOTTO_STORE_DSN")+fmt.Sprintf(" (%s 드라이버)", c.Driver))
+	}
+	if c.DSN != "" && !store.IsRemote(c.Driver) {
+		problems = append(problems, fmt.Sprintf("store.dsn (LOTTO_STORE_DSN) 은 데이터베이스 서버 드라이버(postgres 등)에서만 쓸 수 있습니다: %s", c.Driver))
+	}
+	if _, err := c.Key(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if c.EncryptionKey != "" && !c.Enabled() {
+		problems = append(problems, "저장소 암호화를 사용하려면 store.path (LOTTO_STORE_PATH) 또는 store.dsn (LOTTO_STORE_DSN) 이 필요합니다")
+	}
+	if c.ArchivePages && !c.Enabled() {
+		problems = append(problems, "페이지 보관을 사용하려면 store.path (LOTTO_STORE_PATH) 또는 store.dsn (LOTTO_STORE_DSN) 이 필요합니다")
+	}
+	return problems
+}
+
+func (s SheetsConfig) validate() []string {
+	if s.Enabled() && s.Credentials == "" {
+		return []string{missing("sheets.credentials", "LOTTO_SHEETS_CREDENTIALS")}
+	}
+	return nil
+}
+
+func (s SentryConfig) validate() []string {
+	if !s.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(s.DSN)
+	if err != nil || u.Host == "" || u.User.Username() == "" || strings.Trim(u.Path, "/") == "" ||
+		(u.Scheme != "http" && u.Scheme != "https") {
+		return []string{"sentry.dsn (LOTTO_SENTRY_DSN) 형식 오류: https://<키>@<호스트>/<프로젝트 ID> 형식이어야 합니다"}
+	}
+	return nil
+}
+
+func (s ServeConfig) validate() []string {
+	var problems []string
+	names := make(map[string]bool, len(s.Tokens))
+	for i, token := range s.Tokens {
+		prefix := fmt.Sprintf("serve.tokens[%d]", i)
+		if token.Name == "" {
+			problems = append(problems, fmt.Sprintf("%s.name 값이 설정되지 않았습니다", prefix))
+		} else if names[token.Name] {
+			problems = append(problems, fmt.Sprintf("%s.name 이 중복되었습니다: %s", prefix, token.Name))
+		}
+		names[token.Name] = true
+		if token.Token == "" {
+			problems = append(problems, fmt.Sprintf("%s.token 값이 설정되지 않았습니다", prefix))
+		}
+		if !containsString(ServeScopes, token.Scope) {
+			problems = append(problems, fmt.Sprintf("%s.scope 는 %s 중 하나여야 합니다: %q", prefix, strings.Join(ServeScopes, ", "), token.Scope))
+		}
+	}
+	return problems
+}
+
+func (t TelegramConfig) validate() []string {
+	if t.Enabled() && len(t.ChatIDs) == 0 {
+		return []string{missing("telegram.chatIds", "LOTTO_TELEGRAM_CHAT_IDS")}
+	}
+	return nil
+}
+
+func (m MQTTConfig) validate() []string {
+	if !m.Enabled() {
+		return nil
+	}
+	var problems []string
+	u, err := url.Parse(m.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "mqtt" && u.Scheme != "mqtts") {
+		problems = append(problems, "mqtt.url (LOTTO_MQTT_URL) 형식 오류: mqtt(s)://<호스트>[:<포트>] 형식이어야 합니다")
+	}
+	for _, prefix := range []struct{ value, key, envKey string }{
+		{m.TopicPrefix, "mqtt.topicPrefix", "LOTTO_MQTT_TOPIC_PREFIX"},
+		{m.DiscoveryPrefix, "mqtt.discoveryPrefix", "LOTTO_MQTT_DISCOVERY_PREFIX"},
+	} {
+		if strings.ContainsAny(prefix.value, "+#") {
+			problems = append(problems, fmt.Sprintf("%s (%s) 에는 +, #를 쓸 수 없습니다: %s", prefix.key, prefix.envKey, prefix.value))
+		}
+	}
+	return problems
+}
+
+func (t TracingConfig) validate() []string {
+	if !t.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(t.Endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return []string{"tracing.endpoint (LOTTO_TRACING_ENDPOINT) 형식 오류: http(s)://<호스트>:<포트> 형식이어야 합니다"}
+	}
+	return nil
+}
+
+func (h HealthchecksConfig) validate() []string {
+	var problems []string
+	for _, check := range []struct{ value, key, envKey string }{
+		{h.Buy, "healthchecks.buy", "LOTTO_HEALTHCHECKS_BUY"},
+		{h.Check, "healthchecks.check", "LOTTO_HEALTHCHECKS_CHECK"},
+		{h.Report, "healthchecks.report", "LOTTO_HEALTHCHECKS_REPORT"},
+	} {
+		if check.value == "" {
+			continue
+		}
+		u, err := url.Parse(check.value)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			problems = append(problems, fmt.Sprintf("%s (%s) 형식 오류: https://hc-ping.com/<UUID> 형식이어야 합니다", check.key, check.envKey))
+		}
+	}
+	return problems
+}
+
+func (p PushgatewayConfig) validate() []string {
+	if !p.Enabled() {
+		return nil
+	}
+	var problems []string
+	u, err := url.Parse(p.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		problems = append(problems, "pushgateway.url (LOTTO_PUSHGATEWAY_URL) 형식 오류: http(s)://<호스트>:<포트> 형식이어야 합니다")
+	}
+	if strings.Contains(p.Job, "/") {
+		problems = append(problems, fmt.Sprintf("pushgateway.job (LOTTO_PUSHGATEWAY_JOB) 에는 /를 쓸 수 없습니다: %s", p.Job))
+	}
+	return problems
+}
+
+func (l LockConfig) validate() []string {
+	var problems []string
+	if d, err := time.ParseDuration(l.TTL); err != nil || d <= 0 {
+		problems = append(problems, fmt.Sprintf("lock.ttl (LOTTO_LOCK_TTL) 는 양의 기간이어야 합니다 (예: 30m): %q", l.TTL))
+	}
+	if l.URL == "" {
+		return problems
+	}
+	u, err := url.Parse(l.URL)
+	if err != nil {
+		return append(problems, fmt.Sprintf("lock.url (LOTTO_LOCK_URL) 형식 오류: %v", err))
+	}
+	switch u.Scheme {
+	case "", "file":
+	case "redis", "rediss":
+		if u.Host == "" {
+			problems = append(problems, "lock.url (LOTTO_LOCK_URL) 형식 오류: redis://[:비밀번호@]<호스트>:<포트>[/<DB>] 형식이어야 합니다")
+		}
+	case "dynamodb":
+		if u.Host == "" || u.Query().Get("region") == "" {
+			problems = append(problems, "lock.url (LOTTO_LOCK_URL) 형식 오류: dynamodb://<테이블>?region=<리전> 형식이어야 합니다")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("lock.url (LOTTO_LOCK_URL) 의 스킴을 지원하지 않습니다: %s (file, redis, rediss, dynamodb)", u.Scheme))
+	}
+	return problems
+}
+
+func (s SiteConfig) validate(ledger StoreConfig) []string {
+	if s.Enabled() && !ledger.Enabled() {
+		return []string{"정적 HTML을 생성하려면 store.path (LOTTO_STORE_PATH) 또는 store.dsn (LOTTO_STORE_DSN) 이 필요합니다"}
+	}
+	return nil
+}
+
+func (r RotationConfig) validate(ledger StoreConfig) []string {
+	if r.MaxAgeDays < 0 {
+		return []string{fmt.Sprintf("rotation.maxAgeDays (LOTTO_ROTATION_MAX_AGE_DAYS) 는 0(사용 안 함) 이상이어야 합니다: %d", r.MaxAgeDays)}
+	}
+	if r.Enabled() && !ledger.Enabled() {
+		return []string{"비밀번호 교체 알림을 사용하려면 store.path (LOTTO_STORE_PATH) 또는 store.dsn (LOTTO_STORE_DSN) 이 필요합니다"}
+	}
+	return nil
+}
+
+func (b BackupConfig) validate(ledger StoreConfig) []string {
+	if !b.Enabled() {
+		return nil
+	}
+	var problems []string
+	if store.IsRemote(ledger.Driver) {
+		problems = append(problems, fmt.Sprintf("%s 저장소는 원격 백업을 지원하지 않습니다 - pg_dump 등 서버의 백업을 사용하세요", ledger.Driver))
+	} else if ledger.Path == "" {
+		problems = append(problems, "백업을 사용하려면 store.path (LOTTO_STORE_PATH) 가 필요합니다")
+	}
+	u, err := url.Parse(b.URL)
+	if err != nil || u.Host == "" {
+		return append(problems, fmt.Sprintf("backup.url (LOTTO_BACKUP_URL) 형식 오류: %s", b.URL))
+	}
+	switch u.Scheme {
+	case "s3", "gs":
+		if u.Scheme == "s3" && b.AccessKeyID == "" && b.SecretAccessKey == "" {
+			// AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY/AWS_SESSION_TOKEN으로 서명
+			break
+		}
+		if b.AccessKeyID == "" {
+			problems = append(problems, missing("backup.accessKeyId", "LOTTO_BACKUP_ACCESS_KEY_ID"))
+		}
+		if b.SecretAccessKey == "" {
+			problems = append(problems, missing("backup.secretAccessKey", "LOTTO_BACKUP_SECRET_ACCESS_KEY"))
+		}
+	case "http", "https":
+	default:
+		problems = append(problems, fmt.Sprintf("backup.url (LOTTO_BACKUP_URL) 는 s3://, gs://, http(s):// 중 하나여야 합니다: %s", b.URL))
+	}
+	if b.Endpoint != "" {
+		if u, err := url.Parse(b.Endpoint); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			problems = append(problems, fmt.Sprintf("backup.endpoint (LOTTO_BACKUP_ENDPOINT) 형식 오류: %s", b.Endpoint))
+		}
+	}
+	return problems
+}
+
+func (s ScheduleConfig) validate(backup BackupConfig) []string {
+	var problems []string
+	if _, err := cron.ParseStandard(s.Buy); err != nil {
+		problems = append(problems, fmt.Sprintf("schedule.buy (LOTTO_SCHEDULE_BUY) cron 형식 오류 %q: %v", s.Buy, err))
+	}
+	if _, err := cron.ParseStandard(s.Check); err != nil {
+		problems = append(problems, fmt.Sprintf("schedule.check (LOTTO_SCHEDULE_CHECK) cron 형식 오류 %q: %v", s.Check, err))
+	}
+	if s.Report != "" {
+		if _, err := cron.ParseStandard(s.Report); err != nil {
+			problems = append(problems, fmt.Sprintf("schedule.report (LOTTO_SCHEDULE_REPORT) cron 형식 오류 %q: %v", s.Report, err))
+		}
+	}
+	if s.Backup != "" {
+		if _, err := cron.ParseStandard(s.Backup); err != nil {
+			problems = append(problems, fmt.Sprintf("schedule.backup (LOTTO_SCHEDULE_BACKUP) cron 형식 오류 %q: %v", s.Backup, err))
+		}
+		if !backup.Enabled() {
+			problems = append(problems, "schedule.backup (LOTTO_SCHEDULE_BACKUP) 을 쓰려면 백업 위치(backup.url / LOTTO_BACKUP_URL)가 필요합니다")
+		}
+	}
+	if s.Retries < 0 {
+		problems = append(problems, fmt.Sprintf("schedule.retries (LOTTO_SCHEDULE_RETRIES) 는 0 이상이어야 합니다: %d", s.Retries))
+	}
+	if d, err := time.ParseDuration(s.RetryDelay); err != nil || d <= 0 {
+		problems = append(problems, fmt.Sprintf("schedule.retryDelay (LOTTO_SCHEDULE_RETRY_DELAY) 는 양의 기간이어야 합니다 (예: 5m): %q", s.RetryDelay))
+	}
+	if s.BuyJitter != "" {
+		if d, err := time.ParseDuration(s.BuyJitter); err != nil || d < 0 {
+			problems = append(problems, fmt.Sprintf("schedule.buyJitter (LOTTO_SCHEDULE_BUY_JITTER) 는 0 이상의 기간이어야 합니다 (예: 15m): %q", s.BuyJitter))
+		}
+	}
+	for _, action := range ScheduleActions {
+		problems = append(problems, s.Retry.For(action).validate(action)...)
+	}
+	return problems
+}
+
+func (p *RetryPolicy) validate(action string) []string {
+	var problems []string
+	key := "schedule.retry." + action
+	env := "LOTTO_SCHEDULE_" + strings.ToUpper(action)
+	if p.Retries != nil && *p.Retries < 0 {
+		problems = append(problems, fmt.Sprintf("%s.retries (%s_RETRIES) 는 0 이상이어야 합니다: %d", key, env, *p.Retries))
+	}
+	if p.RetryDelay != "" {
+		if d, err := time.ParseDuration(p.RetryDelay); err != nil || d <= 0 {
+			problems = append(problems, fmt.Sprintf("%s.retryDelay (%s_RETRY_DELAY) 는 양의 기간이어야 합니다 (예: 5m): %q", key, env, p.RetryDelay))
+		}
+	}
+	if p.Backoff != "" && !slices.Contains(Backoffs, p.Backoff) {
+		problems = append(problems, fmt.Sprintf("%s.backoff (%s_BACKOFF) 는 %s 중 하나여야 합니다: %q", key, env, strings.Join(Backoffs, ", "), p.Backoff))
+	}
+	if p.MaxRetryDelay != "" {
+		if d, err := time.ParseDuration(p.MaxRetryDelay); err != nil || d <= 0 {
+			problems = append(problems, fmt.Sprintf("%s.maxRetryDelay (%s_MAX_RETRY_DELAY) 는 양의 기간이어야 합니다 (예: 1h): %q", key, env, p.MaxRetryDelay))
+		}
+	}
+	return problems
+}
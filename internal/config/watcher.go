@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is how often Watcher checks the config file for changes.
+const DefaultWatchInterval = 10 * time.Second
+
+// Watcher keeps the latest valid configuration for long-running processes.
+// It polls the LOTTO_CONFIG file and swaps in a new configuration only when it
+// loads and validates; otherwise the previous configuration stays active.
+type Watcher struct {
+	path      string
+	interval  time.Duration
+	overrides *Overrides
+
+	mu      sync.RWMutex
+	current *Config
+	modTime time.Time
+
+	onReload func(old, updated *Config)
+	onError  func(err error)
+}
+
+// NewWatcher creates a watcher that starts from an already loaded config.
+// The same overrides are re-applied on every reload.
+func NewWatcher(initial *Config, overrides *Overrides, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	w := &Watcher{
+		path:      env{overrides: overrides}.get("LOTTO_CONFIG"),
+		interval:  interval,
+		overrides: overrides,
+		current:   initial,
+	}
+	if info, err := os.Stat(w.path); err == nil {
+		w.modTime = info.ModTime()
+	}
+	return w
+}
+
+// OnReload registers a callback invoked after a new configuration is applied.
+func (w *Watcher) OnReload(fn func(old, updated *Config)) {
+	w.onReload = fn
+}
+
+// OnError registers a callback invoked when a changed file is rejected.
+func (w *Watcher) OnError(fn func(err error)) {
+	w.onError = fn
+}
+
+// Current returns the active configuration.
+func (w *Watcher) Current() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Run polls the config file until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	if w.path == "" {
+		return
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(w.path)
+			if err != nil {
+				w.reportError(fmt.Errorf("설정 파일 확인 실패: %w", err))
+				continue
+			}
+
+			w.mu.RLock()
+			changed := !info.ModTime().Equal(w.modTime)
+			w.mu.RUnlock()
+			if !changed {
+				continue
+			}
+
+			w.mu.Lock()
+			w.modTime = info.ModTime()
+			w.mu.Unlock()
+
+			_ = w.Reload()
+		}
+	}
+}
+
+// Reload loads and validates the configuration immediately (e.g. on SIGHUP).
+// On failure the previous configuration is kept and the error is returned.
+func (w *Watcher) Reload() error {
+	updated, err := LoadWith(w.overrides)
+	if err != nil {
+		err = fmt.Errorf("새 설정을 적용하지 않고 이전 설정을 유지합니다: %w", err)
+		w.reportError(err)
+		return err
+	}
+
+	w.mu.Lock()
+	old := w.current
+	w.current = updated
+	w.mu.Unlock()
+
+	if w.onReload != nil {
+		w.onReload(old, updated)
+	}
+	return nil
+}
+
+func (w *Watcher) reportError(err error) {
+	if w.onError != nil {
+		w.onError(err)
+	}
+}
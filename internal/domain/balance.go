@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/domain/utils"
+)
+
+// BalanceSnapshot is a point-in-time view of an account's money and
+// purchases for the round on sale.
+type BalanceSnapshot struct {
+	Account        string    `json:"account"`
+	Deposit        int64     `json:"deposit"`
+	UnclaimedPrize int64     `json:"unclaimedPrize"`
+	Round          int       `json:"round"`
+	RoundTickets   int       `json:"roundTickets"`
+	CheckedAt      time.Time `json:"checkedAt"`
+}
+
+// ToString renders the snapshot for terminal output.
+func (s *BalanceSnapshot) ToString() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("💰 [%s] %s 기준\n", s.Account, s.CheckedAt.In(Seoul).Format("2006-01-02 15:04")))
+	builder.WriteString(fmt.Sprintf("- 예치금: %s원\n", utils.FormatAmount(s.Deposit)))
+	builder.WriteString(fmt.Sprintf("- %d회 구매: %d장\n", s.Round, s.RoundTickets))
+	builder.WriteString(fmt.Sprintf("- 미수령 당첨금: %s원\n", utils.FormatAmount(s.UnclaimedPrize)))
+	return builder.String()
+}
+
+// TopUpAlert reports a purchase skipped because the deposit of an account
+// cannot cover it.
+type TopUpAlert struct {
+	Account  string
+	Deposit  int64
+	Required int64
+	// VirtualAccount is the deposit account to transfer to, empty when it
+	// could not be read from the site.
+	VirtualAccount string
+	CheckedAt      time.Time
+}
+
+// Shortfall returns the amount missing for the purchase.
+func (a *TopUpAlert) Shortfall() int64 {
+	return a.Required - a.Deposit
+}
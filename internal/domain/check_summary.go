@@ -16,6 +16,9 @@ type CheckSummary struct {
 	BonusNumber    int
 	Prizes         map[WinningRank]*PrizeInfo
 	Tickets        []TicketResult
+	// Warnings say why the summary may be missing tickets, such as
+	// purchases whose details could not be read.
+	Warnings []string
 }
 
 // NewCheckSummary builds a summary initialized with winning info.
@@ -74,11 +77,30 @@ func (s *CheckSummary) ToString() string {
 	return builder.String()
 }
 
+// MaskedString renders the summary like ToString, showing of each ticket
+// only the numbers that were drawn, bonus included.
+func (s *CheckSummary) MaskedString() string {
+	drawn := append(append([]int(nil), s.WinningNumbers...), s.BonusNumber)
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("\n📋 [%d회] 당첨 확인 결과:\n", s.Round))
+	for _, ticket := range s.Tickets {
+		builder.WriteString(ticket.MaskedString(drawn))
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
 // EmailBody renders the summary as an email-friendly string.
 func (s *CheckSummary) EmailBody() string {
 	var builder strings.Builder
 	builder.WriteString(fmt.Sprintf("🎰 %d회 (%s 추첨)\n", s.Round, s.DrawDate.Format("2006-01-02")))
 	builder.WriteString(fmt.Sprintf("당첨 번호: %s + %d\n\n", utils.FormatNumbers(s.WinningNumbers), s.BonusNumber))
+	for _, warning := range s.Warnings {
+		builder.WriteString(fmt.Sprintf("⚠️ %s\n", warning))
+	}
+	if len(s.Warnings) > 0 {
+		builder.WriteString("\n")
+	}
 
 	for _, ticket := range s.Tickets {
 		status := "낙첨"
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// OnlinePayoutLimit is the largest prize the site credits to the deposit of
+// an online purchase on its own. Larger prizes must be claimed in person.
+const OnlinePayoutLimit int64 = 2_000_000
+
+// ClaimMethod describes how a winning online ticket is paid out.
+type ClaimMethod int
+
+const (
+	ClaimAutoDeposit ClaimMethod = iota // 예치금 자동 지급
+	ClaimBranch                         // NH농협은행 지점 방문
+	ClaimHeadOffice                     // NH농협은행 본점 방문
+)
+
+// String returns a Korean description of the claim method.
+func (m ClaimMethod) String() string {
+	switch m {
+	case ClaimAutoDeposit:
+		return "예치금 자동 지급"
+	case ClaimBranch:
+		return "NH농협은행 지점 방문 수령"
+	case ClaimHeadOffice:
+		return "NH농협은행 본점 방문 수령"
+	default:
+		return "알 수 없음"
+	}
+}
+
+// ClaimMethodFor returns how a prize of rank and amount bought online is paid.
+func ClaimMethodFor(rank WinningRank, prize int64) ClaimMethod {
+	switch {
+	case rank == Rank1:
+		return ClaimHeadOffice
+	case prize > OnlinePayoutLimit:
+		return ClaimBranch
+	default:
+		return ClaimAutoDeposit
+	}
+}
+
+// ClaimDeadline returns the last day a prize of round can be claimed:
+// one year from the payout start date (the day after the draw).
+func ClaimDeadline(round int) time.Time {
+	return DrawDate(round).AddDate(1, 0, 1)
+}
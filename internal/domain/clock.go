@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Seoul is the timezone the lottery operates in. Korea has no daylight
+// saving time, so a fixed +09:00 zone stands in on hosts without tzdata.
+var Seoul = func() *time.Location {
+	loc, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		return time.FixedZone("KST", 9*60*60)
+	}
+	return loc
+}()
+
+// Clock tells the current time. Round cutoffs, sales hours, purchase search
+// windows, report months and the pages rendered from them read it through
+// Now, so a test can run them at "Saturday 19:59 KST" with SetClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the real time.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// FixedClock stands still at its time.
+type FixedClock time.Time
+
+func (c FixedClock) Now() time.Time { return time.Time(c) }
+
+// OffsetClock is the real time shifted by its duration, so time passes as
+// usual from the moment it was set to.
+type OffsetClock time.Duration
+
+func (c OffsetClock) Now() time.Time { return time.Now().Add(time.Duration(c)) }
+
+// StartingAt returns a clock that reads t now and runs from there.
+func StartingAt(t time.Time) OffsetClock {
+	return OffsetClock(time.Until(t))
+}
+
+// clockHolder lets clocks of different types share an atomic pointer.
+type clockHolder struct{ Clock }
+
+var clock atomic.Pointer[clockHolder]
+
+func init() {
+	clock.Store(&clockHolder{SystemClock{}})
+}
+
+// SetClock makes Now read c and returns a function that puts the previous
+// clock back:
+//
+//	defer domain.SetClock(domain.FixedClock(saturdayEvening))()
+func SetClock(c Clock) (restore func()) {
+	previous := clock.Swap(&clockHolder{c})
+	return func() { clock.Store(previous) }
+}
+
+// Now returns the current time of the clock in KST. Dates derived from it
+// (round cutoffs, search ranges sent to the site, report months) are the
+// ones the lottery uses, even on hosts running in UTC such as CI runners.
+func Now() time.Time {
+	return clock.Load().Now().In(Seoul)
+}
+
+// clockLayouts are the forms ParseClockTime accepts besides RFC 3339.
+var clockLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// ParseClockTime parses a moment to set the clock to, as RFC 3339 or as a
+// KST date and time such as "2026-10-17 19:59".
+func ParseClockTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range clockLayouts {
+		if t, err := time.ParseInLocation(layout, s, Seoul); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("시각 형식이 올바르지 않습니다: %q (예: 2026-10-17 19:59, RFC 3339)", s)
+}
+
+// StartOfDay returns 00:00 KST of t's date in KST.
+func StartOfDay(t time.Time) time.Time {
+	t = t.In(Seoul)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, Seoul)
+}
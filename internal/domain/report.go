@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/domain/utils"
+)
+
+// Report summarizes spending and winnings over a month or a year.
+type Report struct {
+	Title string      `json:"title"`
+	From  time.Time   `json:"from"`
+	To    time.Time   `json:"to"` // exclusive
+	Total ReportRow   `json:"total"`
+	Rows  []ReportRow `json:"rows"` // per account (monthly) or per month (yearly)
+}
+
+// ReportRow aggregates the tickets of one account or month.
+type ReportRow struct {
+	Label          string `json:"label"`
+	Tickets        int    `json:"tickets"`
+	PendingTickets int    `json:"pendingTickets"`
+	Spent          int64  `json:"spent"`
+	DrawnSpent     int64  `json:"drawnSpent"`
+	Winnings       int64  `json:"winnings"`
+	Ranks          [5]int `json:"ranks"` // index 0 is 1등
+}
+
+// Add counts one ticket. drawn is false while the result is unknown.
+func (r *ReportRow) Add(amount int64, drawn bool, rank WinningRank, prize int64) {
+	r.Tickets++
+	r.Spent += amount
+	if !drawn {
+		r.PendingTickets++
+		return
+	}
+	r.DrawnSpent += amount
+	r.Winnings += prize
+	if rank != RankNone {
+		r.Ranks[rank.Number()-1]++
+	}
+}
+
+// Merge adds the counts of other to r.
+func (r *ReportRow) Merge(other ReportRow) {
+	r.Tickets += other.Tickets
+	r.PendingTickets += other.PendingTickets
+	r.Spent += other.Spent
+	r.DrawnSpent += other.DrawnSpent
+	r.Winnings += other.Winnings
+	for i := range r.Ranks {
+		r.Ranks[i] += other.Ranks[i]
+	}
+}
+
+// ROI returns the return on the drawn tickets in percent.
+func (r ReportRow) ROI() float64 {
+	if r.DrawnSpent == 0 {
+		return 0
+	}
+	return float64(r.Winnings-r.DrawnSpent) / float64(r.DrawnSpent) * 100
+}
+
+// RankSummary lists the winning ranks, e.g. "5등 2장, 4등 1장".
+func (r ReportRow) RankSummary() string {
+	var parts []string
+	for i := len(r.Ranks) - 1; i >= 0; i-- {
+		if r.Ranks[i] > 0 {
+			parts = append(parts, fmt.Sprintf("%d등 %d장", i+1, r.Ranks[i]))
+		}
+	}
+	if len(parts) == 0 {
+		return "당첨 없음"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ToString renders the report for terminal output.
+func (r *Report) ToString() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("📒 %s 로또 리포트 (%s ~ %s)\n", r.Title,
+		r.From.Format("2006-01-02"), r.To.AddDate(0, 0, -1).Format("2006-01-02")))
+	builder.WriteString(fmt.Sprintf("- 구매: %d장 (미추첨/확인 불가 %d장)\n", r.Total.Tickets, r.Total.PendingTickets))
+	builder.WriteString(fmt.Sprintf("- 지출: %s원\n", utils.FormatAmount(r.Total.Spent)))
+	builder.WriteString(fmt.Sprintf("- 당첨금: %s원\n", utils.FormatAmount(r.Total.Winnings)))
+	builder.WriteString(fmt.Sprintf("- 수익률: %.1f%%\n", r.Total.ROI()))
+	builder.WriteString(fmt.Sprintf("- 당첨: %s\n", r.Total.RankSummary()))
+	if len(r.Rows) > 1 {
+		builder.WriteString("\n")
+		for _, row := range r.Rows {
+			builder.WriteString(fmt.Sprintf("[%s] 구매 %d장 · 지출 %s원 · 당첨금 %s원 · 수익률 %.1f%%\n",
+				row.Label, row.Tickets, utils.FormatAmount(row.Spent), utils.FormatAmount(row.Winnings), row.ROI()))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// StaleCredential is a password used for longer than the rotation policy
+// allows.
+type StaleCredential struct {
+	// Label names the password for the reader, e.g. "동행복권 계정 default".
+	Label string
+	// Since is when the password was first seen in use.
+	Since time.Time
+}
+
+// Days returns the whole days the password has been in use at now.
+func (c StaleCredential) Days(now time.Time) int {
+	return int(now.Sub(c.Since).Hours() / 24)
+}
+
+// RotationReminder asks to rotate passwords older than MaxAgeDays.
+type RotationReminder struct {
+	MaxAgeDays  int
+	Credentials []StaleCredential
+	CheckedAt   time.Time
+}
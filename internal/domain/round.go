@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// firstDrawDate is the draw date of round 1 (2002-12-07, Saturday).
+var firstDrawDate = time.Date(2002, time.December, 7, 0, 0, 0, 0, Seoul)
+
+// DrawDate returns the (KST midnight) draw date of round. Draws are held
+// every Saturday, so rounds map onto weeks one-to-one.
+func DrawDate(round int) time.Time {
+	return firstDrawDate.AddDate(0, 0, 7*(round-1))
+}
+
+// RoundOn returns the round whose draw falls on or after t's date, i.e. the
+// round on sale at t.
+func RoundOn(t time.Time) int {
+	day := StartOfDay(t)
+	days := int(day.Sub(firstDrawDate).Hours()/24 + 0.5)
+	if days <= 0 {
+		return 1
+	}
+	return (days+6)/7 + 1
+}
+
+// LatestDrawRound returns the round drawn on t's date if it is a Saturday,
+// or else the round drawn on the Saturday before, whether or not its
+// results are published yet.
+func LatestDrawRound(t time.Time) int {
+	round := RoundOn(t)
+	if t.In(Seoul).Weekday() != time.Saturday {
+		round--
+	}
+	return round
+}
+
+// Online sales hours (KST): every day from 06:00 until midnight, except
+// that sales for a round end at 20:00 on its draw day.
+const (
+	salesOpenHour    = 6
+	drawDayCloseHour = 20
+)
+
+// drawTimeOfDay is when the draw is held (KST) on a draw day.
+const drawTimeOfDay = 20*time.Hour + 35*time.Minute
+
+// DrawTime returns when round is drawn.
+func DrawTime(round int) time.Time {
+	return DrawDate(round).Add(drawTimeOfDay)
+}
+
+// NextDraw returns the first round drawn after t and when it is drawn.
+func NextDraw(t time.Time) (int, time.Time) {
+	round := RoundOn(t)
+	if !t.Before(DrawTime(round)) {
+		round++
+	}
+	return round, DrawTime(round)
+}
+
+// SalesClose returns when online sales for round end.
+func SalesClose(round int) time.Time {
+	return DrawDate(round).Add(drawDayCloseHour * time.Hour)
+}
+
+// NextSalesOpen returns t if online sales are open at t, or else when they
+// open next.
+func NextSalesOpen(t time.Time) time.Time {
+	t = t.In(Seoul)
+	day := StartOfDay(t)
+	switch {
+	case t.Hour() < salesOpenHour:
+		return day.Add(salesOpenHour * time.Hour)
+	case t.Weekday() == time.Saturday && t.Hour() >= drawDayCloseHour:
+		return day.AddDate(0, 0, 1).Add(salesOpenHour * time.Hour)
+	default:
+		return t
+	}
+}
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // Lotto645Mode represents the ticket purchase mode.
 type Lotto645Mode int
 
@@ -23,6 +29,63 @@ func (m Lotto645Mode) String() string {
 	}
 }
 
+// ParseLotto645Mode converts a mode name into Lotto645Mode.
+// Both English ("auto", "semi-auto", "manual") and Korean names are accepted.
+func ParseLotto645Mode(s string) (Lotto645Mode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "auto", "자동":
+		return ModeAuto, nil
+	case "semi-auto", "semiauto", "반자동":
+		return ModeSemiAuto, nil
+	case "manual", "수동":
+		return ModeManual, nil
+	default:
+		return ModeAuto, fmt.Errorf("알 수 없는 모드입니다: %q (auto, semi-auto, manual)", s)
+	}
+}
+
+const (
+	MinNumber        = 1  // 선택 가능한 최소 번호
+	MaxNumber        = 45 // 선택 가능한 최대 번호
+	NumbersPerTicket = 6  // 한 게임당 번호 개수
+
+	TicketPrice int64 = 1000 // 한 게임 가격 (원)
+)
+
+// ValidateNumbers checks that numbers are unique, within 1~45 and that their
+// count matches the mode (auto: 0, semi-auto: 1~5, manual: 6).
+func ValidateNumbers(mode Lotto645Mode, numbers []int) error {
+	switch mode {
+	case ModeAuto:
+		if len(numbers) != 0 {
+			return fmt.Errorf("자동 모드는 번호를 지정할 수 없습니다")
+		}
+	case ModeSemiAuto:
+		if len(numbers) < 1 || len(numbers) >= NumbersPerTicket {
+			return fmt.Errorf("반자동 모드는 번호를 1~%d개 지정해야 합니다 (현재 %d개)", NumbersPerTicket-1, len(numbers))
+		}
+	case ModeManual:
+		if len(numbers) != NumbersPerTicket {
+			return fmt.Errorf("수동 모드는 번호를 %d개 지정해야 합니다 (현재 %d개)", NumbersPerTicket, len(numbers))
+		}
+	default:
+		return fmt.Errorf("올바르지 않은 모드입니다: %v", mode)
+	}
+
+	seen := make(map[int]struct{}, len(numbers))
+	for _, n := range numbers {
+		if n < MinNumber || n > MaxNumber {
+			return fmt.Errorf("번호는 %d~%d 사이여야 합니다: %d", MinNumber, MaxNumber, n)
+		}
+		if _, dup := seen[n]; dup {
+			return fmt.Errorf("중복된 번호가 있습니다: %d", n)
+		}
+		seen[n] = struct{}{}
+	}
+
+	return nil
+}
+
 // Lotto645Ticket represents a single lottery ticket.
 type Lotto645Ticket struct {
 	Numbers []int
@@ -45,3 +108,20 @@ func NewAutoTickets(count int) []*Lotto645Ticket {
 	}
 	return tickets
 }
+
+// NewTicket creates a ticket for the given mode after validating the numbers.
+// Numbers are copied and sorted so the purchase parameter is stable.
+func NewTicket(mode Lotto645Mode, numbers []int) (*Lotto645Ticket, error) {
+	if err := ValidateNumbers(mode, numbers); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]int, len(numbers))
+	copy(sorted, numbers)
+	sort.Ints(sorted)
+
+	return &Lotto645Ticket{
+		Numbers: sorted,
+		Mode:    mode,
+	}, nil
+}
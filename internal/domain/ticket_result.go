@@ -35,12 +35,22 @@ func (t TicketResult) Clone() TicketResult {
 
 // ToString returns a formatted description of the ticket result.
 func (t TicketResult) ToString() string {
+	return t.describe(utils.FormatNumbers(t.Numbers))
+}
+
+// MaskedString describes the ticket result like ToString, showing only the
+// numbers that were drawn.
+func (t TicketResult) MaskedString(drawn []int) string {
+	return t.describe(utils.FormatMaskedNumbers(t.Numbers, drawn))
+}
+
+func (t TicketResult) describe(numbers string) string {
 	if t.Rank != RankNone {
 		return fmt.Sprintf(
 			"   슬롯 %s (%s / %s): %s 🎉 (당첨금: %s원)",
 			t.Slot,
 			t.Mode,
-			utils.FormatNumbers(t.Numbers),
+			numbers,
 			t.Rank.String(),
 			utils.FormatAmount(t.Prize),
 		)
@@ -50,6 +60,6 @@ func (t TicketResult) ToString() string {
 		"   슬롯 %s (%s / %s): 낙첨",
 		t.Slot,
 		t.Mode,
-		utils.FormatNumbers(t.Numbers),
+		numbers,
 	)
 }
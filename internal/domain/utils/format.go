@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -13,6 +14,19 @@ func FormatNumbers(numbers []int) string {
 	return strings.Join(parts, ", ")
 }
 
+// FormatMaskedNumbers formats numbers like FormatNumbers but shows only
+// those in visible, replacing the others with "*".
+func FormatMaskedNumbers(numbers, visible []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = "*"
+		if slices.Contains(visible, n) {
+			parts[i] = strconv.Itoa(n)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 func FormatAmount(amount int64) string {
 	s := strconv.FormatInt(amount, 10)
 	n := len(s)
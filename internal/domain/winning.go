@@ -61,6 +61,14 @@ func (r WinningRank) String() string {
 	}
 }
 
+// Number returns the rank as 1~5, or 0 for RankNone.
+func (r WinningRank) Number() int {
+	if r == RankNone {
+		return 0
+	}
+	return int(Rank1-r) + 1
+}
+
 // CheckWinning compares purchased numbers with winning numbers.
 func CheckWinning(purchased []int, winning *WinningNumbers) WinningRank {
 	matchCount := countMatches(purchased, winning.Numbers)
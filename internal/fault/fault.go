@@ -0,0 +1,224 @@
+// Package fault injects failures at chosen points of a run (chaos mode), so
+// the retry, outbox, idempotency and failure-notification paths can be
+// exercised on purpose - typically against the mock site (mock-site).
+//
+// Faults are configured with a spec of comma-separated points, each failing
+// always, the first N times or at a rate:
+//
+//	login        every login fails
+//	buy:1        the first purchase is sent but its response is lost
+//	parse:30%    three in ten site pages come back unreadable
+//	notify:2     the first two notifications are not delivered
+//
+// Lottery faults plug into the clients as a lottery.Middleware and
+// notification faults wrap a notify.Transport.
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/notify"
+)
+
+// Point is a place a failure can be injected at.
+type Point string
+
+const (
+	// Login fails the login request before it is sent.
+	Login Point = "login"
+	// Buy sends the purchase request and then drops the response, like a
+	// connection lost after the site took the order.
+	Buy Point = "buy"
+	// Parse replaces a site page the client reads with an empty one, so
+	// parsing it fails. Login and purchase responses are left alone.
+	Parse Point = "parse"
+	// Notify fails the delivery of a notification.
+	Notify Point = "notify"
+)
+
+// Points lists every point in the order they are documented.
+var Points = []Point{Login, Buy, Parse, Notify}
+
+// ErrInjected wraps every injected failure.
+var ErrInjected = errors.New("주입된 장애")
+
+// rule is how often a point fails.
+type rule struct {
+	// remaining is how many more times the point fails; negative is always.
+	remaining int
+	// rate, when positive, is the probability that each call fails.
+	rate float64
+}
+
+var (
+	mu    sync.Mutex
+	rules map[Point]*rule
+	spec  string
+)
+
+// Configure replaces the configured faults with those of s (see the package
+// documentation). An empty s turns fault injection off.
+func Configure(s string) error {
+	parsed := make(map[Point]*rule)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, how, hasHow := strings.Cut(entry, ":")
+		point := Point(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(Points, point) {
+			return fmt.Errorf("알 수 없는 장애 지점입니다: %q (login, buy, parse, notify)", name)
+		}
+		r := &rule{remaining: -1}
+		how = strings.TrimSpace(how)
+		switch {
+		case !hasHow:
+		case strings.HasSuffix(how, "%"):
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(how, "%"), 64)
+			if err != nil || percent <= 0 || percent > 100 {
+				return fmt.Errorf("%s: 확률은 0%%보다 크고 100%% 이하여야 합니다: %q", point, how)
+			}
+			r.rate = percent / 100
+		default:
+			n, err := strconv.Atoi(how)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%s: 횟수는 1 이상의 정수여야 합니다: %q", point, how)
+			}
+			r.remaining = n
+		}
+		parsed[point] = r
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	rules, spec = parsed, ""
+	if len(parsed) > 0 {
+		spec = s
+	}
+	return nil
+}
+
+// Enabled reports whether any fault is configured.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return len(rules) > 0
+}
+
+// Spec returns the configured spec, or "" when fault injection is off.
+func Spec() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return spec
+}
+
+// Check returns an error wrapping ErrInjected when the call at point should
+// fail, counting it against the configured times.
+func Check(point Point) error {
+	mu.Lock()
+	r := rules[point]
+	fail := false
+	switch {
+	case r == nil:
+	case r.rate > 0:
+		fail = rand.Float64() < r.rate
+	case r.remaining < 0:
+		fail = true
+	case r.remaining > 0:
+		r.remaining--
+		fail = true
+	}
+	mu.Unlock()
+
+	if !fail {
+		return nil
+	}
+	logging.Warnf("💥 장애 주입: %s", point)
+	return fmt.Errorf("%w: %s", ErrInjected, point)
+}
+
+// Middleware injects the login, buy and parse faults into the requests of
+// lottery clients.
+func Middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case isLogin(req):
+			if err := Check(Login); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		case isBuy(req):
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			if err := Check(Buy); err != nil {
+				resp.Body.Close()
+				return nil, fmt.Errorf("%w (구매 요청은 전송됨, 응답 유실)", err)
+			}
+			return resp, nil
+		}
+
+		resp, err := next.RoundTrip(req)
+		if err != nil || isSessionStart(req) {
+			return resp, err
+		}
+		if err := Check(Parse); err != nil {
+			resp.Body.Close()
+			body := "<html><body></body></html>"
+			resp.Body = io.NopCloser(strings.NewReader(body))
+			resp.ContentLength = int64(len(body))
+			resp.Header.Del("Content-Length")
+		}
+		return resp, nil
+	})
+}
+
+func isLogin(req *http.Request) bool {
+	return req.Method == http.MethodPost && req.URL.Query().Get("method") == "login"
+}
+
+func isBuy(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/execBuy.do")
+}
+
+// isSessionStart reports whether req only opens a session; its page is not
+// read, so corrupting it would not fail anything.
+func isSessionStart(req *http.Request) bool {
+	return req.URL.Query().Has("wiselog") || strings.HasSuffix(req.URL.Path, "/index_check.html")
+}
+
+// Transport wraps next so that deliveries fail at the notify point.
+func Transport(next notify.Transport) notify.Transport {
+	return &transport{next: next}
+}
+
+type transport struct {
+	next notify.Transport
+}
+
+func (t *transport) Send(ctx context.Context, from string, to []string, message []byte) error {
+	if err := Check(Notify); err != nil {
+		return err
+	}
+	return t.next.Send(ctx, from, to, message)
+}
+
+func (t *transport) Verify(ctx context.Context) error {
+	return t.next.Verify(ctx)
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,8 @@
+// Package filelock takes exclusive advisory locks on open files, so
+// overlapping weekly-lotto processes on one host can exclude each other.
+package filelock
+
+import "errors"
+
+// ErrLocked is returned by TryLock when another process holds the lock.
+var ErrLocked = errors.New("locked")
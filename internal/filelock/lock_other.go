@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package filelock
+
+import "os"
+
+// TryLock always succeeds on platforms without a supported file lock;
+// overlapping runs are not protected there.
+func TryLock(file *os.File) error {
+	return nil
+}
+
+// Unlock releases a lock taken with TryLock.
+func Unlock(file *os.File) error {
+	return nil
+}
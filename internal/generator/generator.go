@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	mathrand "math/rand/v2"
+	"sort"
+	"strings"
+
+	"weekly-lotto/internal/domain"
+)
+
+// Strategy picks ticket numbers locally (without the site's auto selection).
+type Strategy interface {
+	// Name returns the identifier used in configuration.
+	Name() string
+	// Generate returns six sorted numbers that include every fixed number.
+	Generate(fixed []int) ([]int, error)
+}
+
+var strategies = map[string]func(rng Rand) Strategy{
+	"random":   func(rng Rand) Strategy { return randomStrategy{rng: rng} },
+	"balanced": func(rng Rand) Strategy { return balancedStrategy{rng: rng} },
+}
+
+// Rand is the source of randomness strategies draw numbers from.
+type Rand interface {
+	// IntN returns a uniform random int in [0, n).
+	IntN(n int) int
+}
+
+// Option configures the strategies returned by Lookup and NewTicket.
+type Option func(*options)
+
+type options struct {
+	rng Rand
+}
+
+// WithRand makes strategies draw from rng instead of crypto/rand.
+func WithRand(rng Rand) Option {
+	return func(o *options) { o.rng = rng }
+}
+
+// WithSeed makes strategies draw from a generator seeded with seed, so that
+// the same seed yields the same numbers. Every strategy given the returned
+// option continues one sequence. Meant for tests, simulations and demo
+// runs; real purchases keep the crypto/rand default.
+func WithSeed(seed uint64) Option {
+	return WithRand(mathrand.New(mathrand.NewPCG(seed, ^seed)))
+}
+
+// Lookup returns the strategy registered under name.
+func Lookup(name string, opts ...Option) (Strategy, error) {
+	newStrategy, ok := strategies[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 번호 생성 전략입니다: %q (%s)", name, strings.Join(Names(), ", "))
+	}
+	o := options{rng: cryptoRand{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return newStrategy(o.rng), nil
+}
+
+// Names lists every registered strategy name.
+func Names() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// randomStrategy draws uniformly from the remaining numbers.
+type randomStrategy struct {
+	rng Rand
+}
+
+func (randomStrategy) Name() string { return "random" }
+
+func (s randomStrategy) Generate(fixed []int) ([]int, error) {
+	if len(fixed) > domain.NumbersPerTicket {
+		return nil, fmt.Errorf("고정 번호가 %d개를 넘습니다", domain.NumbersPerTicket)
+	}
+
+	picked := make(map[int]struct{}, domain.NumbersPerTicket)
+	numbers := make([]int, 0, domain.NumbersPerTicket)
+	for _, n := range fixed {
+		if _, dup := picked[n]; dup {
+			continue
+		}
+		picked[n] = struct{}{}
+		numbers = append(numbers, n)
+	}
+
+	for len(numbers) < domain.NumbersPerTicket {
+		n := domain.MinNumber + s.rng.IntN(domain.MaxNumber-domain.MinNumber+1)
+		if _, dup := picked[n]; dup {
+			continue
+		}
+		picked[n] = struct{}{}
+		numbers = append(numbers, n)
+	}
+
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// balancedStrategy draws random numbers until the ticket has an even
+// odd/even split and an even low(1~22)/high(23~45) split.
+type balancedStrategy struct {
+	rng Rand
+}
+
+const balancedMaxAttempts = 10000
+
+func (balancedStrategy) Name() string { return "balanced" }
+
+func (s balancedStrategy) Generate(fixed []int) ([]int, error) {
+	for attempt := 0; attempt < balancedMaxAttempts; attempt++ {
+		numbers, err := randomStrategy{rng: s.rng}.Generate(fixed)
+		if err != nil {
+			return nil, err
+		}
+		if isBalanced(numbers) {
+			return numbers, nil
+		}
+	}
+	return nil, fmt.Errorf("고정 번호 %v 로는 균형 잡힌 조합을 만들 수 없습니다", fixed)
+}
+
+func isBalanced(numbers []int) bool {
+	odd, low := 0, 0
+	for _, n := range numbers {
+		if n%2 == 1 {
+			odd++
+		}
+		if n <= 22 {
+			low++
+		}
+	}
+	half := domain.NumbersPerTicket / 2
+	return odd == half && low == half
+}
+
+// cryptoRand is the default Rand, backed by crypto/rand.
+type cryptoRand struct{}
+
+// IntN returns a uniform random int in [0, n) using crypto/rand.
+func (cryptoRand) IntN(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(fmt.Sprintf("난수 생성 실패: %v", err))
+	}
+	return int(v.Int64())
+}
+
+// NewTicket builds a purchasable ticket from a basket entry. Without a strategy
+// the ticket is passed to the site as-is; with a strategy the missing numbers
+// are generated locally and the ticket is bought as a manual ticket.
+func NewTicket(mode domain.Lotto645Mode, fixed []int, strategyName string, opts ...Option) (*domain.Lotto645Ticket, error) {
+	if strategyName == "" {
+		return domain.NewTicket(mode, fixed)
+	}
+
+	strategy, err := Lookup(strategyName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers, err := strategy.Generate(fixed)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewTicket(domain.ModeManual, numbers)
+}
@@ -0,0 +1,61 @@
+// Package healthcheck pings healthchecks.io style check URLs, so a cron
+// monitor notices when a scheduled run fails, runs too long or never starts.
+//
+// A check URL is pinged at <url>/start when a run begins, at <url> when it
+// succeeds and at <url>/fail (with the error as the body) when it fails.
+// Self-hosted healthchecks and compatible monitors accept the same URLs.
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/sanitize"
+)
+
+// maxBody caps the failure message sent with a fail ping.
+const maxBody = 10 * 1024
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Start reports that the run monitored by url has begun.
+func Start(ctx context.Context, url string) error {
+	return ping(ctx, strings.TrimSuffix(url, "/")+"/start", "")
+}
+
+// Success reports that the run monitored by url has finished.
+func Success(ctx context.Context, url string) error {
+	return ping(ctx, url, "")
+}
+
+// Fail reports that the run monitored by url has failed with message.
+func Fail(ctx context.Context, url, message string) error {
+	message = sanitize.String(message)
+	if len(message) > maxBody {
+		message = message[:maxBody]
+	}
+	return ping(ctx, strings.TrimSuffix(url, "/")+"/fail", message)
+}
+
+func ping(ctx context.Context, url, body string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("응답 %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Package ical writes iCalendar (RFC 5545) feeds that phone and desktop
+// calendars can subscribe to.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line allowed before folding.
+const maxLineOctets = 75
+
+// Calendar is a feed of events.
+type Calendar struct {
+	// Name is shown by calendar apps for the subscription.
+	Name string
+	// RefreshInterval suggests how often subscribers fetch the feed again.
+	RefreshInterval time.Duration
+	Events          []Event
+}
+
+// Event is a single calendar entry.
+type Event struct {
+	// UID identifies the event across fetches, so updates replace it.
+	UID     string
+	Summary string
+	// Description is the event body; newlines are kept.
+	Description string
+	Start       time.Time
+	// End is the end of a timed event; AllDay events span the day of Start.
+	End    time.Time
+	AllDay bool
+	// Alarms remind that long before Start.
+	Alarms []time.Duration
+}
+
+// Marshal encodes the calendar, stamping events with now.
+func (c *Calendar) Marshal(now time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		fold(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//weekly-lotto//weekly-lotto//KO")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		line("REFRESH-INTERVAL;VALUE=DURATION", duration(c.RefreshInterval))
+		line("X-PUBLISHED-TTL", duration(c.RefreshInterval))
+	}
+
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", utc(now))
+		if event.AllDay {
+			line("DTSTART;VALUE=DATE", event.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE", event.Start.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			line("DTSTART", utc(event.Start))
+			line("DTEND", utc(event.End))
+		}
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		for _, before := range event.Alarms {
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("DESCRIPTION", escape(event.Summary))
+			line("TRIGGER", "-"+duration(before))
+			line("END", "VALARM")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// utc formats t as a UTC date-time.
+func utc(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// duration formats d as an RFC 5545 duration, e.g. P7D or PT30M.
+func duration(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if d > 0 || days == 0 {
+		b.WriteString("T")
+		if h := d / time.Hour; h > 0 {
+			fmt.Fprintf(&b, "%dH", h)
+		}
+		if m := d % time.Hour / time.Minute; m > 0 || d < time.Hour {
+			fmt.Fprintf(&b, "%dM", m)
+		}
+	}
+	return b.String()
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold writes a content line, folding it at maxLineOctets without
+// splitting a UTF-8 character.
+func fold(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// 이어지는 줄은 앞의 공백 한 칸을 포함해 75바이트
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,177 @@
+// Package logging provides the leveled log output shared by every command.
+//
+// Messages keep the emoji-prefixed Korean lines of the original log stream;
+// the level only decides whether a line is written. Output goes to stderr so
+// stdout stays reserved for command results, either as plain lines or, for
+// log collectors such as Loki or CloudWatch, as one JSON object per line.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"weekly-lotto/internal/sanitize"
+)
+
+// Levels accepted by --log-level, from most to least verbose.
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// Levels lists the accepted level names.
+func Levels() []string {
+	return []string{LevelDebug, LevelInfo, LevelWarn, LevelError}
+}
+
+// Formats accepted by --log-format.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats lists the accepted format names.
+func Formats() []string {
+	return []string{FormatText, FormatJSON}
+}
+
+var (
+	level  slog.LevelVar
+	logger = slog.New(newTextHandler(os.Stderr, &level))
+)
+
+// SetFormat switches the output format. It must be called before logging
+// starts, e.g. while parsing flags.
+func SetFormat(name string) error {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case FormatText:
+		logger = slog.New(newTextHandler(os.Stderr, &level))
+	case FormatJSON:
+		logger = slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level:       &level,
+			ReplaceAttr: jsonAttr,
+		}))
+	default:
+		return fmt.Errorf("알 수 없는 로그 형식입니다: %s (%s)", name, strings.Join(Formats(), ", "))
+	}
+	return nil
+}
+
+// jsonAttr writes levels in lower case, matching the --log-level names, and
+// times in RFC 3339 with the local offset.
+func jsonAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+	switch attr.Key {
+	case slog.LevelKey:
+		attr.Value = slog.StringValue(strings.ToLower(attr.Value.String()))
+	case slog.TimeKey:
+		attr.Value = slog.StringValue(attr.Value.Time().Format(time.RFC3339Nano))
+	}
+	return attr
+}
+
+// ParseLevel converts a level name into a slog level.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case LevelDebug:
+		return slog.LevelDebug, nil
+	case LevelInfo:
+		return slog.LevelInfo, nil
+	case LevelWarn, "warning":
+		return slog.LevelWarn, nil
+	case LevelError:
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("알 수 없는 로그 레벨입니다: %s (%s)", name, strings.Join(Levels(), ", "))
+}
+
+// SetLevel sets the minimum level that is written.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Debugf logs details that are only useful while debugging a run.
+func Debugf(format string, args ...any) {
+	logf(slog.LevelDebug, format, args...)
+}
+
+// Infof logs the progress of a normal run.
+func Infof(format string, args ...any) {
+	logf(slog.LevelInfo, format, args...)
+}
+
+// Warnf logs problems the run recovered from.
+func Warnf(format string, args ...any) {
+	logf(slog.LevelWarn, format, args...)
+}
+
+// Errorf logs failures.
+func Errorf(format string, args ...any) {
+	logf(slog.LevelError, format, args...)
+}
+
+func logf(l slog.Level, format string, args ...any) {
+	ctx := context.Background()
+	if !logger.Enabled(ctx, l) {
+		return
+	}
+	logger.Log(ctx, l, sanitize.String(fmt.Sprintf(format, args...)))
+}
+
+// textHandler writes records in the format of the standard log package
+// ("2006/01/02 15:04:05 message"), followed by any attributes as key=value.
+type textHandler struct {
+	mu    *sync.Mutex
+	w     io.Writer
+	level slog.Leveler
+	attrs []slog.Attr
+}
+
+func newTextHandler(w io.Writer, level slog.Leveler) *textHandler {
+	return &textHandler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+func (h *textHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= h.level.Level()
+}
+
+func (h *textHandler) Handle(_ context.Context, record slog.Record) error {
+	var builder strings.Builder
+	builder.WriteString(record.Time.Format("2006/01/02 15:04:05"))
+	builder.WriteByte(' ')
+	builder.WriteString(record.Message)
+	writeAttr := func(attr slog.Attr) bool {
+		builder.WriteString(fmt.Sprintf(" %s=%v", attr.Key, attr.Value))
+		return true
+	}
+	for _, attr := range h.attrs {
+		writeAttr(attr)
+	}
+	record.Attrs(writeAttr)
+	builder.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, builder.String())
+	return err
+}
+
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &clone
+}
+
+func (h *textHandler) WithGroup(string) slog.Handler {
+	return h
+}
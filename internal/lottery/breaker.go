@@ -0,0 +1,142 @@
+package lottery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"weekly-lotto/internal/logging"
+)
+
+// ErrCircuitOpen is returned without sending a request while the circuit
+// breaker is open. It wraps the failure that opened the breaker, so a
+// breaker opened by maintenance still matches ErrMaintenance.
+var ErrCircuitOpen = errors.New("동행복권 사이트 요청이 연달아 실패해 잠시 보내지 않습니다")
+
+// Defaults of the circuit breaker shared by every client.
+const (
+	DefaultBreakerThreshold = 3
+	DefaultBreakerCooldown  = time.Minute
+)
+
+// sharedBreaker sits in front of every client, so that one account or job
+// hitting maintenance or a block spares the others the same timeouts.
+var sharedBreaker = &breaker{threshold: DefaultBreakerThreshold, cooldown: DefaultBreakerCooldown}
+
+// SetCircuitBreaker opens the breaker after threshold failures in a row -
+// transport errors, 5xx, 403 and 429 responses and maintenance redirects -
+// and short-circuits requests for cooldown. The first request after the
+// cooldown goes through as a probe while the others keep failing fast:
+// success closes the breaker and another failure opens it again. A
+// threshold of 0 or less turns the breaker off.
+func SetCircuitBreaker(threshold int, cooldown time.Duration) {
+	sharedBreaker.mu.Lock()
+	defer sharedBreaker.mu.Unlock()
+	sharedBreaker.threshold = threshold
+	sharedBreaker.cooldown = cooldown
+	sharedBreaker.failures = 0
+	sharedBreaker.openUntil = time.Time{}
+	sharedBreaker.probing = false
+}
+
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	// cause is the latest failure, reported while the breaker is open.
+	cause error
+	// probing is set while the probe of a half-open breaker is in flight.
+	probing bool
+}
+
+// allow returns ErrCircuitOpen while the breaker is open. Once the cooldown
+// has passed it lets a single request through and reports it as the probe;
+// the caller must call endProbe when that request is done.
+func (b *breaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return false, nil
+	}
+	if wait := time.Until(b.openUntil); wait > 0 {
+		return false, fmt.Errorf("%w (%s 후 재개): %w", ErrCircuitOpen, wait.Round(time.Second), b.cause)
+	}
+	if b.probing {
+		return false, fmt.Errorf("%w (재개 확인 중): %w", ErrCircuitOpen, b.cause)
+	}
+	b.probing = true
+	return true, nil
+}
+
+// endProbe lets another request probe the half-open breaker, when the probe
+// did not close or reopen it (e.g. it was cancelled or redirected).
+func (b *breaker) endProbe() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// record counts a request that failed with cause, or resets the count when
+// cause is nil.
+func (b *breaker) record(cause error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.threshold <= 0 {
+		return
+	}
+	if cause == nil {
+		if b.failures >= b.threshold {
+			logging.Infof("✅ 동행복권 사이트 요청이 다시 성공해 차단을 해제합니다")
+		}
+		b.failures, b.cause = 0, nil
+		return
+	}
+	b.failures++
+	b.cause = cause
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		logging.Warnf("⚠️  동행복권 사이트 요청이 %d번 연달아 실패해 %s 동안 요청을 보내지 않습니다: %v", b.failures, b.cooldown, cause)
+	}
+}
+
+// breakerTransport short-circuits requests while the breaker is open and
+// reports the outcome of the others to it.
+type breakerTransport struct {
+	breaker *breaker
+	next    http.RoundTripper
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	probe, err := t.breaker.allow()
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	if probe {
+		defer t.breaker.endProbe()
+	}
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil:
+		// 호출한 쪽이 취소한 요청은 사이트 장애가 아님
+		if req.Context().Err() == nil {
+			t.breaker.record(err)
+		}
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		// 리다이렉트는 따라간 요청의 결과로 판단
+	case strings.HasSuffix(req.URL.Path, "/index_check.html"):
+		t.breaker.record(ErrMaintenance)
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests:
+		t.breaker.record(fmt.Errorf("HTTP %d %s", resp.StatusCode, http.StatusText(resp.StatusCode)))
+	default:
+		t.breaker.record(nil)
+	}
+	return resp, err
+}
@@ -0,0 +1,87 @@
+package lottery
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBreakerLetsOneProbeThroughWhileHalfOpen(t *testing.T) {
+	var hits atomic.Int32
+	var status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var block atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if block.Load() {
+			entered <- struct{}{}
+			<-release
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	const cooldown = 20 * time.Millisecond
+	rt := &breakerTransport{breaker: &breaker{threshold: 1, cooldown: cooldown}, next: http.DefaultTransport}
+	send := func() error {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := rt.RoundTrip(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// 한 번 실패하면 열림
+	if err := send(); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if err := send(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("request while open = %v, want ErrCircuitOpen", err)
+	}
+
+	// 대기 시간이 지나면 확인 요청 하나만 보내고 나머지는 바로 실패
+	time.Sleep(cooldown + 10*time.Millisecond)
+	block.Store(true)
+	probe := make(chan error, 1)
+	go func() { probe <- send() }()
+	<-entered
+	for i := range 5 {
+		if err := send(); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d during the probe = %v, want ErrCircuitOpen", i, err)
+		}
+	}
+	if got := hits.Load(); got != 2 {
+		t.Fatalf("site hits = %d, want 2 (the failure and the probe)", got)
+	}
+	block.Store(false)
+	close(release)
+	if err := <-probe; err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+
+	// 확인 요청이 실패하면 다시 열림
+	if err := send(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("request after a failed probe = %v, want ErrCircuitOpen", err)
+	}
+
+	// 확인 요청이 성공하면 닫힘
+	time.Sleep(cooldown + 10*time.Millisecond)
+	status.Store(http.StatusOK)
+	for i := range 3 {
+		if err := send(); err != nil {
+			t.Fatalf("request %d after recovery: %v", i, err)
+		}
+	}
+	if got := hits.Load(); got != 5 {
+		t.Fatalf("site hits = %d, want 5", got)
+	}
+}
@@ -0,0 +1,282 @@
+// Package cassette records the requests lottery clients send to the site
+// and the responses they get into a cassette file, and replays them later
+// without the network, so parser and client changes can be checked against
+// responses the real site gave.
+//
+// Both directions plug into the clients as a lottery.Middleware:
+//
+//	c, err := cassette.Load("testdata/check.json")
+//	lottery.Use(c.Replay)
+//
+// Recorded interactions are scrubbed before they are written: registered
+// secrets and sensitive fields are masked (see sanitize), order numbers and
+// barcodes of purchase lists are swapped for stand-ins and long digit runs
+// are masked, so cassettes can be committed.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/korean"
+
+	"weekly-lotto/internal/sanitize"
+)
+
+// charsetEUCKR marks bodies the site sent in EUC-KR; they are kept as UTF-8
+// in the cassette and encoded again on replay.
+const charsetEUCKR = "EUC-KR"
+
+// keptHeaders are the response headers kept in a cassette.
+var keptHeaders = []string{"Content-Type", "Location"}
+
+// Cassette is a list of interactions with the site.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+
+	mu   sync.Mutex
+	path string
+	// played counts the replayed interactions of each request key.
+	played map[string]int
+	// standIns maps order numbers and barcodes to their stand-ins.
+	standIns map[string]string
+}
+
+// Interaction is one request and the response the site gave.
+type Interaction struct {
+	Request    Request   `json:"request"`
+	Response   Response  `json:"response"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// Request is a scrubbed request. Replay matches requests on Method and URL;
+// Body is kept for reference.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a scrubbed response.
+type Response struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Charset is EUC-KR when the site sent Body in EUC-KR.
+	Charset string `json:"charset,omitempty"`
+	Body    string `json:"body"`
+}
+
+// Load reads the cassette at path.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("카세트 읽기 실패: %w", err)
+	}
+	c := &Cassette{path: path}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("카세트 %s 형식 오류: %w", path, err)
+	}
+	return c, nil
+}
+
+// Open returns the cassette at path for recording, continuing it when it
+// exists.
+func Open(path string) (*Cassette, error) {
+	c, err := Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Cassette{path: path}, nil
+	}
+	return c, err
+}
+
+// Record passes requests on to next and appends every interaction to the
+// cassette, saving it after each one. Transport failures are not recorded.
+func (c *Cassette) Record(next http.RoundTripper) http.RoundTripper {
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		var reqBody []byte
+		if req.GetBody != nil && req.ContentLength != 0 {
+			if body, err := req.GetBody(); err == nil {
+				reqBody, _ = io.ReadAll(body)
+				body.Close()
+			}
+		}
+
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := c.add(req, reqBody, resp, body); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	})
+}
+
+// Replay answers requests from the cassette without sending them. Requests
+// with the same method and URL get the recorded responses in order, the
+// last one again once they run out; a request that was never recorded
+// fails.
+func (c *Cassette) Replay(http.RoundTripper) http.RoundTripper {
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		key := requestKey(req.Method, req.URL.String())
+
+		c.mu.Lock()
+		var matches []Interaction
+		for _, interaction := range c.Interactions {
+			if requestKey(interaction.Request.Method, interaction.Request.URL) == key {
+				matches = append(matches, interaction)
+			}
+		}
+		if c.played == nil {
+			c.played = make(map[string]int)
+		}
+		n := c.played[key]
+		c.played[key]++
+		c.mu.Unlock()
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("카세트 %s 에 녹화되지 않은 요청입니다: %s", c.path, key)
+		}
+		recorded := matches[min(n, len(matches)-1)].Response
+		body := []byte(recorded.Body)
+		if recorded.Charset == charsetEUCKR {
+			encoded, err := korean.EUCKR.NewEncoder().Bytes(body)
+			if err != nil {
+				return nil, fmt.Errorf("카세트 응답 EUC-KR 변환 실패: %w", err)
+			}
+			body = encoded
+		}
+		header := make(http.Header)
+		for name, value := range recorded.Headers {
+			header.Set(name, value)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+			StatusCode:    recorded.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	})
+}
+
+// add scrubs an interaction, appends it and saves the cassette.
+func (c *Cassette) add(req *http.Request, reqBody []byte, resp *http.Response, body []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	charset := ""
+	if !utf8.Valid(body) {
+		if decoded, err := korean.EUCKR.NewDecoder().Bytes(body); err == nil {
+			body, charset = decoded, charsetEUCKR
+		}
+	}
+	headers := make(map[string]string)
+	for _, name := range keptHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = c.scrub(value)
+		}
+	}
+	c.Interactions = append(c.Interactions, Interaction{
+		Request:    Request{Method: req.Method, URL: c.scrub(req.URL.String()), Body: c.scrub(string(reqBody))},
+		Response:   Response{Status: resp.StatusCode, Headers: headers, Charset: charset, Body: c.scrub(string(body))},
+		RecordedAt: time.Now().UTC().Truncate(time.Second),
+	})
+	return c.save()
+}
+
+var (
+	// detailPop carries the order number, barcode and issue number of each
+	// purchase in the purchase list.
+	detailPop = regexp.MustCompile(`(detailPop\(\s*')([^']+)('\s*,\s*')([^']+)('\s*,\s*')([^']+)('\))`)
+	// longDigits are runs long enough to be order, ticket or account numbers.
+	longDigits = regexp.MustCompile(`\d{10,}`)
+)
+
+// scrub returns s safe to commit. Order numbers and barcodes in purchase
+// lists get stand-ins, the same for the same value, so the detail requests
+// made from a replayed list still find their recorded pages.
+func (c *Cassette) scrub(s string) string {
+	s = detailPop.ReplaceAllStringFunc(s, func(call string) string {
+		m := detailPop.FindStringSubmatch(call)
+		return m[1] + c.standIn("order", m[2]) + m[3] + c.standIn("barcode", m[4]) + m[5] + m[6] + m[7]
+	})
+	return mask(s)
+}
+
+// mask masks secrets, sensitive fields and long digit runs in s.
+func mask(s string) string {
+	return longDigits.ReplaceAllStringFunc(sanitize.String(s), func(digits string) string {
+		return strings.Repeat("0", len(digits))
+	})
+}
+
+// standIn returns the stand-in of value, a numbered kind. Stand-ins only
+// need to tell the purchases of one list apart.
+func (c *Cassette) standIn(kind, value string) string {
+	if c.standIns == nil {
+		c.standIns = make(map[string]string)
+	}
+	if standIn, ok := c.standIns[value]; ok {
+		return standIn
+	}
+	standIn := fmt.Sprintf("%s-%d", kind, len(c.standIns)+1)
+	c.standIns[value] = standIn
+	return standIn
+}
+
+// save writes the cassette to its path, replacing the file at once.
+func (c *Cassette) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("카세트 변환 실패: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), "."+filepath.Base(c.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("카세트 저장 실패: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(append(data, '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+	if err != nil {
+		return fmt.Errorf("카세트 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// requestKey is what replay matches requests on: the method and the URL
+// masked as when recorded. Replayed requests already carry stand-ins.
+func requestKey(method, rawURL string) string {
+	return method + " " + mask(rawURL)
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
@@ -0,0 +1,82 @@
+package lottery
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/lottery/cassette"
+)
+
+// replay answers the requests of clients created during the test from the
+// cassette at path.
+func replay(t *testing.T, path string) {
+	t.Helper()
+	c, err := cassette.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	middlewareMu.Lock()
+	saved := middlewares
+	middlewares = append(slices.Clip(middlewares), c.Replay)
+	middlewareMu.Unlock()
+	t.Cleanup(func() {
+		middlewareMu.Lock()
+		middlewares = saved
+		middlewareMu.Unlock()
+	})
+}
+
+// testdata/check.json was recorded with --record-http from `history --source
+// online` and `check`, so it holds the session, login, latest winning page,
+// purchase list and purchase details of one account.
+func TestReplayCheckCassette(t *testing.T) {
+	replay(t, "testdata/check.json")
+	ctx := context.Background()
+
+	client, err := NewClient(ctx, "replayed", "replayed")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	winning, err := client.GetWinningNumbers(ctx)
+	if err != nil {
+		t.Fatalf("GetWinningNumbers: %v", err)
+	}
+	if winning.Round != 1245 || !slices.Equal(winning.Numbers, []int{1, 15, 23, 31, 32, 42}) || winning.BonusNumber != 8 {
+		t.Errorf("winning = round %d %v + %d, want round 1245 [1 15 23 31 32 42] + 8", winning.Round, winning.Numbers, winning.BonusNumber)
+	}
+	if prize := winning.Prizes[domain.Rank3]; prize == nil || prize.WinnerCount != 2605 || prize.AmountPerWinner != 1_612_284 {
+		t.Errorf("3rd prize = %+v, want 2,605 winners of 1,612,284원", prize)
+	}
+
+	// 녹화된 목록을 그대로 재생하므로 조회 기간은 결과에 영향이 없음
+	histories, err := client.GetPurchases(ctx, time.Now().AddDate(0, -1, 0), time.Now())
+	if err != nil {
+		t.Fatalf("GetPurchases: %v", err)
+	}
+	if len(histories) != 2 {
+		t.Fatalf("read %d orders, want 2", len(histories))
+	}
+	latest := histories[0]
+	if latest.Round != 1245 || latest.OrderNo != "order-1" || len(latest.Tickets) != 5 {
+		t.Fatalf("latest order = round %d %s with %d tickets, want round 1245 order-1 with 5", latest.Round, latest.OrderNo, len(latest.Tickets))
+	}
+	first := latest.Tickets[0]
+	if first.Slot != "A" || first.Mode != "자동" || !slices.Equal(first.Numbers, []int{1, 5, 22, 31, 33, 42}) {
+		t.Errorf("first ticket = %+v, want A 자동 [1 5 22 31 33 42]", first)
+	}
+	if rank := domain.CheckWinning(first.Numbers, winning); rank != domain.Rank5 {
+		t.Errorf("first ticket rank = %v, want 5th", rank)
+	}
+	if histories[1].Round != 1244 || len(histories[1].Tickets) != 5 {
+		t.Errorf("older order = round %d with %d tickets, want round 1244 with 5", histories[1].Round, len(histories[1].Tickets))
+	}
+
+	// 녹화되지 않은 요청은 사이트로 보내지 않고 실패
+	if _, err := client.GetWinningNumbersByRound(ctx, 1); err == nil {
+		t.Error("GetWinningNumbersByRound of an unrecorded round succeeded")
+	}
+}
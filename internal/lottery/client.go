@@ -2,13 +2,18 @@ package lottery
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"weekly-lotto/internal/domain"
@@ -28,45 +33,121 @@ const (
 	lottoDetailURL    = "https://www.dhlottery.co.kr/myPage.do?method=lotto645Detail"
 )
 
-// Client handles HTTP communication with the lottery website.
+// ErrNoPurchases is returned when no purchases exist in the requested period.
+var ErrNoPurchases = parser.ErrNoPurchases
+
+// ErrLoginFailed is returned when the site rejects the credentials.
+var ErrLoginFailed = parser.ErrLoginFailed
+
+// ErrMaintenance is returned while the lottery site is under maintenance.
+var ErrMaintenance = errors.New("동행복권 사이트가 현재 시스템 점검중입니다")
+
+// ErrInsufficientBalance is returned when the deposit cannot cover a
+// purchase, which is then not attempted.
+var ErrInsufficientBalance = errors.New("예치금이 부족합니다")
+
+// ErrNotDrawn is returned when the winning numbers of a round are not published yet.
+var ErrNotDrawn = errors.New("당첨 번호가 아직 발표되지 않았습니다")
+
+// Kinds of pages passed to a Client's recorder.
+const (
+	PageBuy       = "buy"        // purchase confirmation (JSON)
+	PageWinning   = "winning"    // winning numbers page
+	PageBuyList   = "buy-list"   // purchase list of a period
+	PageBuyDetail = "buy-detail" // tickets of a single purchase
+)
+
+// Page is the raw response body of a key page, kept as fetched so parsing
+// can be audited and reproduced later. Round is the round the page is about,
+// or 0 when it could not be determined.
+type Page struct {
+	Kind      string
+	Round     int
+	URL       string
+	FetchedAt time.Time
+	Body      []byte
+}
+
+// Client handles HTTP communication with the lottery website. Its methods
+// send their requests with the context they are given, so each call is
+// cancelled and bounded by the deadline of its own context.
 type Client struct {
 	httpClient *http.Client
 	username   string
 	password   string
+	recorder   func(Page)
+	readOnly   atomic.Bool
+
+	// roundMu guards round, the round on sale read from the main page.
+	roundMu sync.Mutex
+	round   int
 }
 
 // NewClient creates a new lottery client and initializes session.
 // It automatically performs session initialization and login.
-func NewClient(username, password string) (*Client, error) {
+func NewClient(ctx context.Context, username, password string) (*Client, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, fmt.Errorf("쿠키 jar 생성 실패: %w", err)
 	}
 
 	client := &Client{
-		httpClient: &http.Client{
-			Jar: jar,
-		},
-		username: username,
-		password: password,
+		httpClient: &http.Client{Jar: jar},
+		username:   username,
+		password:   password,
 	}
+	client.httpClient.Transport = newTransport(&client.readOnly)
 
 	// 세션 초기화
-	if err := client.initSession(); err != nil {
+	if err := client.initSession(ctx); err != nil {
 		return nil, fmt.Errorf("세션 초기화 실패: %w", err)
 	}
 
 	// 로그인
-	if err := client.login(); err != nil {
+	if err := client.login(ctx); err != nil {
 		return nil, fmt.Errorf("로그인 실패: %w", err)
 	}
 
 	return client, nil
 }
 
+// NewGuestClient creates a client with an initialized session but without
+// logging in. It can only access public pages such as winning numbers.
+func NewGuestClient(ctx context.Context) (*Client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("쿠키 jar 생성 실패: %w", err)
+	}
+
+	client := &Client{httpClient: &http.Client{Jar: jar}}
+	client.httpClient.Transport = newTransport(&client.readOnly)
+
+	if err := client.initSession(ctx); err != nil {
+		return nil, fmt.Errorf("세션 초기화 실패: %w", err)
+	}
+
+	return client, nil
+}
+
+// SetRecorder passes every purchase confirmation, winning page and purchase
+// list the client fetches to record, whether or not it parses. Purchase
+// details are fetched concurrently, so record must be safe for concurrent
+// use. A nil record stops recording.
+func (c *Client) SetRecorder(record func(Page)) {
+	c.recorder = record
+}
+
+// record hands body to the recorder, if any.
+func (c *Client) record(kind string, round int, rawURL string, body []byte) {
+	if c.recorder == nil {
+		return
+	}
+	c.recorder(Page{Kind: kind, Round: round, URL: rawURL, FetchedAt: time.Now(), Body: body})
+}
+
 // initSession obtains JSESSIONID cookie.
-func (c *Client) initSession() error {
-	req, err := http.NewRequest("GET", defaultSessionURL, nil)
+func (c *Client) initSession(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", defaultSessionURL, nil)
 	if err != nil {
 		return err
 	}
@@ -79,9 +160,12 @@ func (c *Client) initSession() error {
 	}
 	defer resp.Body.Close()
 
+	// 본문을 끝까지 읽어야 연결이 재사용됨
+	io.Copy(io.Discard, resp.Body)
+
 	// 시스템 점검 페이지로 리다이렉트되었는지 확인
 	if resp.Request.URL.String() == systemCheckURL {
-		return fmt.Errorf("동행복권 사이트가 현재 시스템 점검중입니다")
+		return ErrMaintenance
 	}
 
 	// JSESSIONID 쿠키는 자동으로 jar에 저장됨
@@ -89,7 +173,7 @@ func (c *Client) initSession() error {
 }
 
 // login performs user authentication.
-func (c *Client) login() error {
+func (c *Client) login(ctx context.Context) error {
 	formData := url.Values{}
 	formData.Set("returnUrl", mainURL)
 	formData.Set("userId", c.username)
@@ -97,7 +181,7 @@ func (c *Client) login() error {
 	formData.Set("checkSave", "off")
 	formData.Set("newsEventYn", "")
 
-	req, err := http.NewRequest("POST", loginURL, bytes.NewBufferString(formData.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, bytes.NewBufferString(formData.Encode()))
 	if err != nil {
 		return err
 	}
@@ -115,9 +199,28 @@ func (c *Client) login() error {
 	return parser.ParseLoginResult(resp.Body)
 }
 
-// GetCurrentRound retrieves the next lottery round number.
-func (c *Client) GetCurrentRound() (int, error) {
-	req, err := http.NewRequest("GET", mainURL, nil)
+// GetCurrentRound retrieves the next lottery round number. It is read from
+// the main page once and reused until its sales close, so buying several
+// orders or previewing and then buying with one client does not fetch the
+// page again.
+func (c *Client) GetCurrentRound(ctx context.Context) (int, error) {
+	c.roundMu.Lock()
+	defer c.roundMu.Unlock()
+	if c.round > 0 && domain.Now().Before(domain.SalesClose(c.round)) {
+		return c.round, nil
+	}
+
+	round, err := c.fetchCurrentRound(ctx)
+	if err != nil {
+		return 0, err
+	}
+	c.round = round
+	return round, nil
+}
+
+// fetchCurrentRound reads the next lottery round number from the main page.
+func (c *Client) fetchCurrentRound(ctx context.Context) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", mainURL, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -133,6 +236,40 @@ func (c *Client) GetCurrentRound() (int, error) {
 	return parser.ParseCurrentRound(resp.Body)
 }
 
+// Balance holds the deposit and unclaimed prize amounts of the logged-in account.
+type Balance struct {
+	Deposit        int64
+	UnclaimedPrize int64
+	VirtualAccount string // empty when the my-page does not show one
+}
+
+// GetBalance retrieves the deposit balance and unclaimed prizes from the my-page.
+func (c *Client) GetBalance(ctx context.Context) (*Balance, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", balanceURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setDefaultHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	parsed, err := parser.ParseBalance(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Balance{
+		Deposit:        parsed.Deposit,
+		UnclaimedPrize: parsed.UnclaimedPrize,
+		VirtualAccount: parsed.VirtualAccount,
+	}, nil
+}
+
 // setDefaultHeaders sets common HTTP headers for requests.
 func (c *Client) setDefaultHeaders(req *http.Request) {
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.77 Safari/537.36")
@@ -160,15 +297,20 @@ type PurchaseHistory struct {
 }
 
 // BuyLotto645 purchases lottery tickets and returns the purchased numbers.
-func (c *Client) BuyLotto645(tickets []*domain.Lotto645Ticket) ([]PurchasedTicket, error) {
+// It fails with ErrReadOnly in read-only mode (SetReadOnly).
+func (c *Client) BuyLotto645(ctx context.Context, tickets []*domain.Lotto645Ticket) ([]PurchasedTicket, error) {
+	if c.ReadOnly() {
+		return nil, ErrReadOnly
+	}
+
 	// 1. Get ready_ip
-	readyIP, err := c.getReadySocket()
+	readyIP, err := c.getReadySocket(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("ready_ip 획득 실패: %w", err)
 	}
 
 	// 2. Get current round number
-	round, err := c.GetCurrentRound()
+	round, err := c.GetCurrentRound(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("회차 정보 조회 실패: %w", err)
 	}
@@ -183,12 +325,12 @@ func (c *Client) BuyLotto645(tickets []*domain.Lotto645Ticket) ([]PurchasedTicke
 	formData := url.Values{}
 	formData.Set("round", strconv.Itoa(round))
 	formData.Set("direct", readyIP)
-	formData.Set("nBuyAmount", strconv.Itoa(1000*len(tickets)))
+	formData.Set("nBuyAmount", strconv.FormatInt(domain.TicketPrice*int64(len(tickets)), 10))
 	formData.Set("param", param)
 	formData.Set("gameCnt", strconv.Itoa(len(tickets)))
 
 	// 5. Send purchase request
-	req, err := http.NewRequest("POST", buyLotto645URL, bytes.NewBufferString(formData.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", buyLotto645URL, bytes.NewBufferString(formData.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +345,12 @@ func (c *Client) BuyLotto645(tickets []*domain.Lotto645Ticket) ([]PurchasedTicke
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("응답 읽기 실패: %w", err)
+	}
+	c.record(PageBuy, round, buyLotto645URL, body)
+
 	// 6. Parse response
 	var result struct {
 		Result struct {
@@ -212,13 +360,13 @@ func (c *Client) BuyLotto645(tickets []*domain.Lotto645Ticket) ([]PurchasedTicke
 		} `json:"result"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("응답 파싱 실패: %w", err)
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, responseError(PageBuy, round, buyLotto645URL, body, fmt.Errorf("응답 파싱 실패: %w", err))
 	}
 
 	// 7. Check success
 	if result.Result.ResultCode != "100" {
-		return nil, fmt.Errorf("구매 실패: %s", result.Result.ResultMsg)
+		return nil, responseError(PageBuy, round, buyLotto645URL, body, fmt.Errorf("구매 실패: %s", result.Result.ResultMsg))
 	}
 
 	// 8. Parse purchased numbers
@@ -228,9 +376,57 @@ func (c *Client) BuyLotto645(tickets []*domain.Lotto645Ticket) ([]PurchasedTicke
 	return purchased, nil
 }
 
+// PurchasePreview describes a purchase request that was built but not sent.
+type PurchasePreview struct {
+	Round   int
+	Amount  int64
+	Deposit int64
+	Param   string
+	Tickets []PurchasedTicket
+}
+
+// PreviewLotto645 performs the round lookup, balance check and parameter
+// construction of BuyLotto645 without sending the purchase request.
+// Numbers of auto-selected slots are empty since the site picks them.
+func (c *Client) PreviewLotto645(ctx context.Context, tickets []*domain.Lotto645Ticket) (*PurchasePreview, error) {
+	round, err := c.GetCurrentRound(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("회차 정보 조회 실패: %w", err)
+	}
+
+	balance, err := c.GetBalance(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("예치금 조회 실패: %w", err)
+	}
+
+	param, err := c.makeBuyParam(tickets)
+	if err != nil {
+		return nil, fmt.Errorf("구매 파라미터 생성 실패: %w", err)
+	}
+
+	slotNames := []string{"A", "B", "C", "D", "E"}
+	preview := &PurchasePreview{
+		Round:   round,
+		Amount:  domain.TicketPrice * int64(len(tickets)),
+		Deposit: balance.Deposit,
+		Param:   param,
+		Tickets: make([]PurchasedTicket, 0, len(tickets)),
+	}
+	for i, ticket := range tickets {
+		preview.Tickets = append(preview.Tickets, PurchasedTicket{
+			Round:   round,
+			Slot:    slotNames[i],
+			Numbers: ticket.Numbers,
+			Mode:    ticket.Mode.String(),
+		})
+	}
+
+	return preview, nil
+}
+
 // getReadySocket retrieves the ready_ip for purchase.
-func (c *Client) getReadySocket() (string, error) {
-	req, err := http.NewRequest("POST", readySocketURL, nil)
+func (c *Client) getReadySocket(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", readySocketURL, nil)
 	if err != nil {
 		return "", err
 	}
@@ -307,8 +503,8 @@ func numbersToString(numbers []int) string {
 }
 
 // GetWinningNumbers retrieves the latest winning numbers.
-func (c *Client) GetWinningNumbers() (*domain.WinningNumbers, error) {
-	req, err := http.NewRequest("GET", winningURL, nil)
+func (c *Client) GetWinningNumbers(ctx context.Context) (*domain.WinningNumbers, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", winningURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -321,45 +517,159 @@ func (c *Client) GetWinningNumbers() (*domain.WinningNumbers, error) {
 	}
 	defer resp.Body.Close()
 
-	return parser.ParseWinningNumbers(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	winning, err := parser.ParseWinningNumbers(bytes.NewReader(body))
+	round := 0
+	if err == nil {
+		round = winning.Round
+	}
+	c.record(PageWinning, round, winningURL, body)
+	if err != nil {
+		return nil, responseError(PageWinning, round, winningURL, body, err)
+	}
+	return winning, nil
+}
+
+// GetWinningNumbersByRound retrieves the winning numbers of a specific round.
+func (c *Client) GetWinningNumbersByRound(ctx context.Context, round int) (*domain.WinningNumbers, error) {
+	parsedURL, err := url.Parse(winningURL)
+	if err != nil {
+		return nil, err
+	}
+
+	q := parsedURL.Query()
+	q.Set("drwNo", strconv.Itoa(round))
+	parsedURL.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", parsedURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setDefaultHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	winning, err := parser.ParseWinningNumbers(bytes.NewReader(body))
+	if err != nil {
+		c.record(PageWinning, round, parsedURL.String(), body)
+		return nil, responseError(PageWinning, round, parsedURL.String(), body, err)
+	}
+	// 추첨 전 회차 요청의 응답은 최신 회차 페이지이므로 그 회차로 기록
+	c.record(PageWinning, winning.Round, parsedURL.String(), body)
+
+	// 추첨 전 회차를 요청하면 사이트는 최신 회차를 보여줌
+	if winning.Round != round {
+		return nil, fmt.Errorf("%d회차: %w (최신: %d회)", round, ErrNotDrawn, winning.Round)
+	}
+
+	return winning, nil
 }
 
 // GetRecentPurchases retrieves purchase history within the given number of days.
-func (c *Client) GetRecentPurchases(days int) ([]PurchaseHistory, error) {
-	end := time.Now()
-	start := end.AddDate(0, 0, -days)
+func (c *Client) GetRecentPurchases(ctx context.Context, days int) ([]PurchaseHistory, error) {
+	end := domain.Now()
+	return c.GetPurchases(ctx, end.AddDate(0, 0, -days), end)
+}
 
-	summaries, err := c.fetchPurchaseSummaries(start, end)
+// purchaseDetailConcurrency bounds the purchase details fetched at once.
+const purchaseDetailConcurrency = 4
+
+// PartialPurchasesError is returned by GetPurchases, together with the
+// purchases that could be read, when the details of some purchases failed.
+type PartialPurchasesError struct {
+	// Total is the number of purchases found in the period.
+	Total int
+	// Failed holds the failures of the purchases left out, in order.
+	Failed []error
+}
+
+func (e *PartialPurchasesError) Error() string {
+	return fmt.Sprintf("구매 %d건 중 %d건의 상세 조회 실패: %v", e.Total, len(e.Failed), e.Failed[0])
+}
+
+func (e *PartialPurchasesError) Unwrap() []error { return e.Failed }
+
+// GetPurchases retrieves purchase history between start and end (inclusive dates).
+// The details of the purchases are fetched concurrently. When only some of
+// them fail, the others are returned with a *PartialPurchasesError; when all
+// of them fail, the first failure is returned alone.
+func (c *Client) GetPurchases(ctx context.Context, start, end time.Time) ([]PurchaseHistory, error) {
+	summaries, err := c.fetchPurchaseSummaries(ctx, start, end)
 	if err != nil {
 		return nil, fmt.Errorf("구매 내역 조회 실패: %w", err)
 	}
 
-	histories := make([]PurchaseHistory, 0, len(summaries))
-	for _, summary := range summaries {
-		round, tickets, err := c.fetchPurchaseTickets(summary)
+	histories := make([]PurchaseHistory, len(summaries))
+	errs := make([]error, len(summaries))
+	slots := make(chan struct{}, purchaseDetailConcurrency)
+	var wg sync.WaitGroup
+	for i, summary := range summaries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			histories[i], errs[i] = c.fetchPurchaseHistory(ctx, summary)
+		}()
+	}
+	wg.Wait()
+
+	if len(histories) == 0 {
+		return nil, ErrNoPurchases
+	}
+
+	var failed []error
+	read := make([]PurchaseHistory, 0, len(histories))
+	for i, err := range errs {
 		if err != nil {
-			return nil, fmt.Errorf("구매 상세 조회 실패 (orderNo: %v, err :%v)", summary.OrderNo, err)
+			failed = append(failed, err)
+			continue
 		}
+		read = append(read, histories[i])
+	}
+	switch {
+	case len(failed) == len(summaries):
+		return nil, failed[0]
+	case len(failed) > 0:
+		return read, &PartialPurchasesError{Total: len(summaries), Failed: failed}
+	}
 
-		if round == 0 {
-			return nil, fmt.Errorf("구매 상세 조회 - 회차 조회 실패 (orderNo: %v)")
-		}
+	return histories, nil
+}
 
-		histories = append(histories, PurchaseHistory{
-			Round:   round,
-			OrderNo: summary.OrderNo,
-			Tickets: tickets,
-		})
+// fetchPurchaseHistory fetches the tickets of the purchase of summary.
+func (c *Client) fetchPurchaseHistory(ctx context.Context, summary parser.PurchaseSummary) (PurchaseHistory, error) {
+	round, tickets, err := c.fetchPurchaseTickets(ctx, summary)
+	if err != nil {
+		return PurchaseHistory{}, fmt.Errorf("구매 상세 조회 실패 (orderNo: %v, err :%w)", summary.OrderNo, err)
 	}
 
-	if len(histories) == 0 {
-		return nil, fmt.Errorf("구매 내역을 찾을 수 없습니다")
+	if round == 0 {
+		return PurchaseHistory{}, fmt.Errorf("구매 상세 조회 - 회차 조회 실패 (orderNo: %v)", summary.OrderNo)
 	}
 
-	return histories, nil
+	return PurchaseHistory{
+		Round:   round,
+		OrderNo: summary.OrderNo,
+		Tickets: tickets,
+	}, nil
 }
 
-func (c *Client) fetchPurchaseSummaries(start, end time.Time) ([]parser.PurchaseSummary, error) {
+func (c *Client) fetchPurchaseSummaries(ctx context.Context, start, end time.Time) ([]parser.PurchaseSummary, error) {
+	// 사이트는 KST 날짜로 검색하므로 호스트 시간대와 무관하게 변환
+	start, end = start.In(domain.Seoul), end.In(domain.Seoul)
 	formData := url.Values{}
 	formData.Set("nowPage", "1")
 	formData.Set("searchStartDate", start.Format("20060102"))
@@ -370,7 +680,7 @@ func (c *Client) fetchPurchaseSummaries(start, end time.Time) ([]parser.Purchase
 	formData.Set("calendarEndDt", end.Format("2006-01-02"))
 	formData.Set("sortOrder", "DESC")
 
-	req, err := http.NewRequest("POST", lottoBuyListURL, strings.NewReader(formData.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", lottoBuyListURL, strings.NewReader(formData.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -384,10 +694,17 @@ func (c *Client) fetchPurchaseSummaries(start, end time.Time) ([]parser.Purchase
 	}
 	defer resp.Body.Close()
 
-	return parser.ParsePurchaseList(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// 목록은 기간 단위이므로 조회 마지막 날에 판매 중인 회차로 기록
+	c.record(PageBuyList, domain.RoundOn(end), lottoBuyListURL, body)
+	summaries, err := parser.ParsePurchaseList(bytes.NewReader(body))
+	return summaries, responseError(PageBuyList, domain.RoundOn(end), lottoBuyListURL, body, err)
 }
 
-func (c *Client) fetchPurchaseTickets(summary parser.PurchaseSummary) (int, []PurchasedTicket, error) {
+func (c *Client) fetchPurchaseTickets(ctx context.Context, summary parser.PurchaseSummary) (int, []PurchasedTicket, error) {
 	parsedURL, err := url.Parse(lottoDetailURL)
 	if err != nil {
 		return 0, nil, err
@@ -399,7 +716,7 @@ func (c *Client) fetchPurchaseTickets(summary parser.PurchaseSummary) (int, []Pu
 	q.Set("issueNo", summary.IssueNo)
 	parsedURL.RawQuery = q.Encode()
 
-	req, err := http.NewRequest("GET", parsedURL.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", parsedURL.String(), nil)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -412,10 +729,15 @@ func (c *Client) fetchPurchaseTickets(summary parser.PurchaseSummary) (int, []Pu
 	}
 	defer resp.Body.Close()
 
-	round, details, err := parser.ParsePurchaseDetail(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return 0, nil, err
 	}
+	round, details, err := parser.ParsePurchaseDetail(bytes.NewReader(body))
+	c.record(PageBuyDetail, round, parsedURL.String(), body)
+	if err != nil {
+		return 0, nil, responseError(PageBuyDetail, round, parsedURL.String(), body, err)
+	}
 
 	tickets := make([]PurchasedTicket, 0, len(details))
 	for _, detail := range details {
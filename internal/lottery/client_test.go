@@ -0,0 +1,226 @@
+package lottery
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/lottery/lotterytest"
+)
+
+const (
+	testUsername = "tester"
+	testPassword = "secret"
+)
+
+// newTestSite serves a lotterytest site with one member holding deposit and
+// sends the clients to it, with the circuit breaker off, until the test ends.
+func newTestSite(t *testing.T, deposit int64) *lotterytest.Site {
+	t.Helper()
+	site := lotterytest.NewSite(time.Now(), lotterytest.Account{Username: testUsername, Password: testPassword, Deposit: deposit})
+	server := httptest.NewServer(site)
+	if err := SetBaseURL(server.URL); err != nil {
+		t.Fatal(err)
+	}
+	SetCircuitBreaker(0, 0)
+	t.Cleanup(func() {
+		SetCircuitBreaker(DefaultBreakerThreshold, DefaultBreakerCooldown)
+		SetBaseURL("")
+		server.Close()
+	})
+	return site
+}
+
+func TestNewClientLogsIn(t *testing.T) {
+	newTestSite(t, 12_000)
+	ctx := context.Background()
+
+	client, err := NewClient(ctx, testUsername, testPassword)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	balance, err := client.GetBalance(ctx)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.Deposit != 12_000 {
+		t.Errorf("deposit = %d, want 12000", balance.Deposit)
+	}
+	if balance.VirtualAccount == "" {
+		t.Error("virtual account is empty")
+	}
+}
+
+func TestNewClientRejectsWrongPassword(t *testing.T) {
+	newTestSite(t, 0)
+
+	_, err := NewClient(context.Background(), testUsername, "wrong")
+	if !errors.Is(err, ErrLoginFailed) {
+		t.Fatalf("NewClient with a wrong password = %v, want ErrLoginFailed", err)
+	}
+}
+
+func TestNewClientDuringMaintenance(t *testing.T) {
+	site := newTestSite(t, 0)
+	site.SetMaintenance(true)
+
+	_, err := NewClient(context.Background(), testUsername, testPassword)
+	if !errors.Is(err, ErrMaintenance) {
+		t.Fatalf("NewClient during maintenance = %v, want ErrMaintenance", err)
+	}
+}
+
+func TestGuestClientCannotReadPrivatePages(t *testing.T) {
+	newTestSite(t, 0)
+	ctx := context.Background()
+
+	guest, err := NewGuestClient(ctx)
+	if err != nil {
+		t.Fatalf("NewGuestClient: %v", err)
+	}
+	if _, err := guest.GetBalance(ctx); err == nil {
+		t.Error("GetBalance of a guest succeeded on the login page")
+	}
+	if _, err := guest.GetRecentPurchases(ctx, 30); !errors.Is(err, ErrNoPurchases) {
+		t.Errorf("GetRecentPurchases of a guest = %v, want ErrNoPurchases", err)
+	}
+}
+
+func TestBuyLotto645RoundTrip(t *testing.T) {
+	newTestSite(t, 10_000)
+	ctx := context.Background()
+
+	client, err := NewClient(ctx, testUsername, testPassword)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	round, err := client.GetCurrentRound(ctx)
+	if err != nil {
+		t.Fatalf("GetCurrentRound: %v", err)
+	}
+
+	tickets := []*domain.Lotto645Ticket{
+		{Mode: domain.ModeAuto},
+		{Mode: domain.ModeManual, Numbers: []int{1, 2, 3, 4, 5, 6}},
+		{Mode: domain.ModeSemiAuto, Numbers: []int{7, 8}},
+	}
+	purchased, err := client.BuyLotto645(ctx, tickets)
+	if err != nil {
+		t.Fatalf("BuyLotto645: %v", err)
+	}
+	if len(purchased) != len(tickets) {
+		t.Fatalf("bought %d tickets, want %d", len(purchased), len(tickets))
+	}
+	wantModes := []string{"자동", "수동", "반자동"}
+	for i, ticket := range purchased {
+		if ticket.Round != round || ticket.Mode != wantModes[i] || len(ticket.Numbers) != 6 {
+			t.Errorf("ticket %d = %+v, want round %d, mode %s and 6 numbers", i, ticket, round, wantModes[i])
+		}
+	}
+	if !slices.Equal(purchased[1].Numbers, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("manual numbers = %v", purchased[1].Numbers)
+	}
+
+	balance, err := client.GetBalance(ctx)
+	if err != nil {
+		t.Fatalf("GetBalance: %v", err)
+	}
+	if balance.Deposit != 10_000-3*domain.TicketPrice {
+		t.Errorf("deposit after buying = %d, want %d", balance.Deposit, 10_000-3*domain.TicketPrice)
+	}
+
+	// 구매 내역에서 방금 산 주문을 그대로 읽어야 함 (모의 사이트는 추첨 다음 날
+	// 아침에 산 주문을 미리 만들어 두므로 조회 기간을 앞으로도 넉넉히 잡음)
+	now := time.Now()
+	histories, err := client.GetPurchases(ctx, now.AddDate(0, 0, -30), now.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("GetPurchases: %v", err)
+	}
+	index := slices.IndexFunc(histories, func(h PurchaseHistory) bool { return h.Round == round })
+	if index < 0 {
+		t.Fatalf("order of round %d missing from %+v", round, histories)
+	}
+	if !slices.EqualFunc(histories[index].Tickets, purchased, func(a, b PurchasedTicket) bool {
+		return a.Slot == b.Slot && a.Mode == b.Mode && slices.Equal(a.Numbers, b.Numbers)
+	}) {
+		t.Errorf("history tickets = %+v, want %+v", histories[index].Tickets, purchased)
+	}
+	if len(histories) != 3 {
+		t.Errorf("read %d orders, want the 2 seeded ones and the new one", len(histories))
+	}
+}
+
+func TestBuyLotto645Refused(t *testing.T) {
+	newTestSite(t, domain.TicketPrice)
+	ctx := context.Background()
+
+	client, err := NewClient(ctx, testUsername, testPassword)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	_, err = client.BuyLotto645(ctx, []*domain.Lotto645Ticket{{Mode: domain.ModeAuto}, {Mode: domain.ModeAuto}})
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) || responseErr.Kind != PageBuy {
+		t.Fatalf("BuyLotto645 beyond the deposit = %v, want a ResponseError of the buy page", err)
+	}
+}
+
+func TestReadOnlyClientRefusesPurchases(t *testing.T) {
+	newTestSite(t, 10_000)
+	ctx := context.Background()
+
+	client, err := NewClient(ctx, testUsername, testPassword)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client.SetReadOnly(true)
+	if _, err := client.BuyLotto645(ctx, []*domain.Lotto645Ticket{{Mode: domain.ModeAuto}}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("BuyLotto645 = %v, want ErrReadOnly", err)
+	}
+	// 구매 서버로 가는 요청은 BuyLotto645를 거치지 않아도 막힘
+	if _, err := client.getReadySocket(ctx); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("getReadySocket = %v, want ErrReadOnly", err)
+	}
+
+	// 다른 클라이언트는 영향을 받지 않음
+	other, err := NewClient(ctx, testUsername, testPassword)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := other.BuyLotto645(ctx, []*domain.Lotto645Ticket{{Mode: domain.ModeAuto}}); err != nil {
+		t.Fatalf("BuyLotto645 of another client: %v", err)
+	}
+}
+
+func TestGetWinningNumbersByRound(t *testing.T) {
+	site := newTestSite(t, 0)
+	ctx := context.Background()
+
+	guest, err := NewGuestClient(ctx)
+	if err != nil {
+		t.Fatalf("NewGuestClient: %v", err)
+	}
+	latest, err := guest.GetWinningNumbers(ctx)
+	if err != nil {
+		t.Fatalf("GetWinningNumbers: %v", err)
+	}
+	if len(latest.Numbers) != 6 || latest.Prizes[domain.Rank1] == nil {
+		t.Errorf("latest draw = %+v", latest)
+	}
+
+	if _, err := guest.GetWinningNumbersByRound(ctx, latest.Round+1); !errors.Is(err, ErrNotDrawn) {
+		t.Fatalf("GetWinningNumbersByRound of the round on sale = %v, want ErrNotDrawn", err)
+	}
+	drawn := site.Draw()
+	winning, err := guest.GetWinningNumbersByRound(ctx, drawn)
+	if err != nil {
+		t.Fatalf("GetWinningNumbersByRound after the draw: %v", err)
+	}
+	if winning.Round != drawn {
+		t.Errorf("round = %d, want %d", winning.Round, drawn)
+	}
+}
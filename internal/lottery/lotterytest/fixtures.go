@@ -0,0 +1,93 @@
+package lotterytest
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+
+	"weekly-lotto/internal/domain"
+)
+
+// Fixed prizes and typical figures of a draw, in KRW.
+const (
+	firstPrizePool   = 25_000_000_000
+	secondPrizePool  = 4_200_000_000
+	thirdPrizePool   = 4_200_000_000
+	fourthPrize      = 50_000
+	fifthPrize       = 5_000
+	fourthPrizeCount = 230_000
+	fifthPrizeCount  = 3_800_000
+)
+
+// draw returns the draw of round, generating it the first time. Numbers and
+// winner counts are derived from the round, so every mock agrees on them.
+func (s *Site) draw(round int) *domain.WinningNumbers {
+	if winning, ok := s.draws[round]; ok {
+		return winning
+	}
+	rng := rand.New(rand.NewPCG(uint64(round), 645))
+	picked := pick(rng, 7, nil)
+	numbers := slices.Sorted(slices.Values(picked[:6]))
+
+	prizes := make(map[domain.WinningRank]*domain.PrizeInfo)
+	add := func(rank domain.WinningRank, count int, perWinner int64) {
+		prizes[rank] = &domain.PrizeInfo{Rank: rank, TotalAmount: perWinner * int64(count), WinnerCount: count, AmountPerWinner: perWinner}
+	}
+	first, second, third := 5+rng.IntN(20), 50+rng.IntN(60), 2500+rng.IntN(1500)
+	add(domain.Rank1, first, firstPrizePool/int64(first))
+	add(domain.Rank2, second, secondPrizePool/int64(second))
+	add(domain.Rank3, third, thirdPrizePool/int64(third))
+	add(domain.Rank4, fourthPrizeCount+rng.IntN(40_000), fourthPrize)
+	add(domain.Rank5, fifthPrizeCount+rng.IntN(600_000), fifthPrize)
+
+	winning := &domain.WinningNumbers{
+		Round:       round,
+		DrawDate:    domain.DrawDate(round),
+		Numbers:     numbers,
+		BonusNumber: picked[6],
+		Prizes:      prizes,
+	}
+	s.draws[round] = winning
+	return winning
+}
+
+// seedTickets returns the auto-picked order an account starts with for the
+// draw of winning: slot A matches three numbers (5th prize), the others
+// match none.
+func seedTickets(winning *domain.WinningNumbers, username string) []ticket {
+	h := fnv.New64a()
+	h.Write([]byte(username))
+	rng := rand.New(rand.NewPCG(uint64(winning.Round), h.Sum64()))
+
+	drawn := append(slices.Clone(winning.Numbers), winning.BonusNumber)
+	tickets := make([]ticket, 0, maxTicketsPerRound)
+	for i, slot := range slotNames {
+		numbers := pick(rng, 6, drawn)
+		if i == 0 {
+			matched := slices.Clone(winning.Numbers)
+			rng.Shuffle(len(matched), func(a, b int) { matched[a], matched[b] = matched[b], matched[a] })
+			numbers = append(matched[:3], pick(rng, 3, drawn)...)
+		}
+		tickets = append(tickets, ticket{Slot: slot, Mode: modeAuto, Numbers: slices.Sorted(slices.Values(numbers))})
+	}
+	return tickets
+}
+
+// pick returns n distinct numbers from 1 to 45 other than those in exclude,
+// in the order drawn.
+func pick(rng *rand.Rand, n int, exclude []int) []int {
+	picked := make([]int, 0, n)
+	for len(picked) < n {
+		number := rng.IntN(45) + 1
+		if !slices.Contains(exclude, number) && !slices.Contains(picked, number) {
+			picked = append(picked, number)
+		}
+	}
+	return picked
+}
+
+// randomRNG returns a randomly seeded generator for the numbers of bought
+// tickets, which unlike the fixtures differ from run to run.
+func randomRNG() *rand.Rand {
+	return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+}
@@ -0,0 +1,217 @@
+package lotterytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/domain"
+)
+
+// Slot names and the selection modes as the site labels them.
+var slotNames = []string{"A", "B", "C", "D", "E"}
+
+const (
+	modeManual   = "수동"
+	modeSemiAuto = "반자동"
+	modeAuto     = "자동"
+)
+
+// modeCodes are the digits execBuy.do appends to each slot for its mode.
+var modeCodes = map[string]string{modeManual: "1", modeSemiAuto: "2", modeAuto: "3"}
+
+// serveWinning shows the draw of drwNo, or the latest draw when drwNo is
+// missing or not drawn yet.
+func (s *Site) serveWinning(w http.ResponseWriter, r *http.Request) {
+	round, err := strconv.Atoi(r.URL.Query().Get("drwNo"))
+	if err != nil || round < 1 || round > s.latest {
+		round = s.latest
+	}
+	writePage(w, winningPage, s.draw(round))
+}
+
+func (s *Site) serveMain(w http.ResponseWriter, session string) {
+	data := struct {
+		Round int
+		User  string
+	}{Round: s.latest, User: s.sessions[session]}
+	writePage(w, mainPage, data)
+}
+
+func (s *Site) serveLogin(w http.ResponseWriter, r *http.Request, session string) {
+	account := s.accounts[r.PostFormValue("userId")]
+	if account == nil || account.Password != r.PostFormValue("password") {
+		writePage(w, loginFailedPage, map[string]string{"Message": `아이디 또는 비밀번호를 잘못 입력하셨습니다.\n다시 확인해 주세요.`})
+		return
+	}
+	s.sessions[session] = account.Username
+	s.serveMain(w, session)
+}
+
+func (s *Site) serveMyPage(w http.ResponseWriter, session string) {
+	account := s.user(session)
+	if account == nil {
+		writePage(w, loginRequiredPage, nil)
+		return
+	}
+	writePage(w, myPage, account)
+}
+
+// buyResult is the JSON answer of execBuy.do.
+type buyResult struct {
+	ResultCode       string   `json:"resultCode"`
+	ResultMsg        string   `json:"resultMsg"`
+	BuyRound         string   `json:"buyRound,omitempty"`
+	ArrGameChoiceNum []string `json:"arrGameChoiceNum,omitempty"`
+	NBuyAmount       int64    `json:"nBuyAmount,omitempty"`
+	IssueDay         string   `json:"issueDay,omitempty"`
+	DrawDate         string   `json:"drawDate,omitempty"`
+	PayLimitDate     string   `json:"payLimitDate,omitempty"`
+	BarCode          string   `json:"barCode,omitempty"`
+}
+
+// serveBuy buys the slots of the param form value for the round on sale,
+// turning down what the site would: a missing login, a wrong round or
+// amount, malformed numbers, a short deposit or more than five tickets per
+// round.
+func (s *Site) serveBuy(w http.ResponseWriter, r *http.Request, session string) {
+	fail := func(format string, args ...any) {
+		writeJSON(w, map[string]buyResult{"result": {ResultCode: "-1", ResultMsg: fmt.Sprintf(format, args...)}})
+	}
+
+	account := s.user(session)
+	if account == nil {
+		fail("로그인 후 이용해 주세요.")
+		return
+	}
+	round := s.latest + 1
+	if r.PostFormValue("round") != strconv.Itoa(round) {
+		fail("구매 회차가 올바르지 않습니다. (판매 회차: %d회)", round)
+		return
+	}
+	var slots []struct {
+		GenType          string  `json:"genType"`
+		ArrGameChoiceNum *string `json:"arrGameChoiceNum"`
+		Alpabet          string  `json:"alpabet"`
+	}
+	if err := json.Unmarshal([]byte(r.PostFormValue("param")), &slots); err != nil || len(slots) == 0 {
+		fail("구매 정보가 올바르지 않습니다.")
+		return
+	}
+	amount := domain.TicketPrice * int64(len(slots))
+	if r.PostFormValue("gameCnt") != strconv.Itoa(len(slots)) || r.PostFormValue("nBuyAmount") != strconv.FormatInt(amount, 10) {
+		fail("구매 금액이 올바르지 않습니다.")
+		return
+	}
+
+	bought := 0
+	for _, p := range s.purchases {
+		if p.Username == account.Username && p.Round == round {
+			bought += len(p.Tickets)
+		}
+	}
+	if bought+len(slots) > maxTicketsPerRound {
+		fail("1회 구매한도(%d,000원)를 초과하였습니다. (이번 회차 구매: %d게임)", maxTicketsPerRound, bought)
+		return
+	}
+	if account.Deposit < amount {
+		fail("예치금이 부족합니다. (예치금: %d원)", account.Deposit)
+		return
+	}
+
+	tickets := make([]ticket, 0, len(slots))
+	for i, slot := range slots {
+		var fixed []int
+		if slot.ArrGameChoiceNum != nil {
+			for _, field := range strings.Split(*slot.ArrGameChoiceNum, ",") {
+				number, err := strconv.Atoi(strings.TrimSpace(field))
+				if err != nil || number < 1 || number > 45 || slices.Contains(fixed, number) {
+					fail("%s 슬롯의 번호가 올바르지 않습니다.", slotNames[i])
+					return
+				}
+				fixed = append(fixed, number)
+			}
+		}
+		var mode string
+		switch {
+		case slot.GenType == "0" && len(fixed) == 0:
+			mode = modeAuto
+		case slot.GenType == "1" && len(fixed) == 6:
+			mode = modeManual
+		case slot.GenType == "2" && len(fixed) > 0 && len(fixed) < 6:
+			mode = modeSemiAuto
+		default:
+			fail("%s 슬롯의 선택 방식이 올바르지 않습니다.", slotNames[i])
+			return
+		}
+		numbers := append(fixed, pick(randomRNG(), 6-len(fixed), fixed)...)
+		tickets = append(tickets, ticket{Slot: slotNames[i], Mode: mode, Numbers: slices.Sorted(slices.Values(numbers))})
+	}
+
+	account.Deposit -= amount
+	now := domain.Now()
+	p := s.addPurchase(account.Username, round, now, tickets)
+	result := buyResult{
+		ResultCode:   "100",
+		ResultMsg:    "SUCCESS",
+		BuyRound:     strconv.Itoa(round),
+		NBuyAmount:   amount,
+		IssueDay:     now.Format("2006/01/02 15:04:05"),
+		DrawDate:     domain.DrawDate(round).Format("2006/01/02"),
+		PayLimitDate: domain.ClaimDeadline(round).Format("2006/01/02"),
+		BarCode:      p.Barcode,
+	}
+	for _, t := range tickets {
+		numbers := make([]string, len(t.Numbers))
+		for i, n := range t.Numbers {
+			numbers[i] = fmt.Sprintf("%02d", n)
+		}
+		result.ArrGameChoiceNum = append(result.ArrGameChoiceNum, t.Slot+"|"+strings.Join(numbers, "|")+modeCodes[t.Mode])
+	}
+	writeJSON(w, map[string]buyResult{"result": result})
+}
+
+// serveBuyList lists the orders of the account placed between
+// searchStartDate and searchEndDate (YYYYMMDD, KST), newest first.
+func (s *Site) serveBuyList(w http.ResponseWriter, r *http.Request, session string) {
+	account := s.user(session)
+	if account == nil {
+		writePage(w, loginRequiredPage, nil)
+		return
+	}
+	start, err1 := time.ParseInLocation("20060102", r.PostFormValue("searchStartDate"), domain.Seoul)
+	end, err2 := time.ParseInLocation("20060102", r.PostFormValue("searchEndDate"), domain.Seoul)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "조회 기간이 올바르지 않습니다", http.StatusBadRequest)
+		return
+	}
+	end = end.AddDate(0, 0, 1)
+
+	var orders []*purchase
+	for _, p := range slices.Backward(s.purchases) {
+		if p.Username == account.Username && !p.At.Before(start) && p.At.Before(end) {
+			orders = append(orders, p)
+		}
+	}
+	writePage(w, buyListPage, orders)
+}
+
+func (s *Site) serveBuyDetail(w http.ResponseWriter, r *http.Request, session string) {
+	account := s.user(session)
+	if account == nil {
+		writePage(w, loginRequiredPage, nil)
+		return
+	}
+	q := r.URL.Query()
+	for _, p := range s.purchases {
+		if p.Username == account.Username && p.OrderNo == q.Get("orderNo") && p.Barcode == q.Get("barcode") && p.IssueNo == q.Get("issueNo") {
+			writePage(w, buyDetailPage, p)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
@@ -0,0 +1,103 @@
+package lotterytest
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"golang.org/x/text/encoding/korean"
+
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+)
+
+// The pages keep only the markup the parsers read, laid out as on the site.
+var pageFuncs = template.FuncMap{
+	"won":   utils.FormatAmount,
+	"count": func(n int) string { return utils.FormatAmount(int64(n)) },
+	"date": func(winning *domain.WinningNumbers) string {
+		return winning.DrawDate.Format("2006년 01월 02일")
+	},
+	"prizes": func(winning *domain.WinningNumbers) []*domain.PrizeInfo {
+		var prizes []*domain.PrizeInfo
+		for _, rank := range []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5} {
+			if prize, ok := winning.Prizes[rank]; ok {
+				prizes = append(prizes, prize)
+			}
+		}
+		return prizes
+	},
+}
+
+var (
+	maintenancePage = page(`<div class="check"><h2>시스템 점검 안내</h2><p>보다 나은 서비스를 위해 시스템 점검 중입니다.</p></div>`)
+
+	mainPage = page(`<div class="content"><h3>로또 6/45</h3>
+<p class="lotto_round"><strong id="lottoDrwNo">{{.Round}}</strong>회 당첨결과</p>
+{{if .User}}<p class="user"><strong>{{.User}}</strong>님 환영합니다</p>{{end}}</div>`)
+
+	loginFailedPage = page(`<div class="content"><form name="jform"><input type="text" name="userId"><input type="password" name="password"></form>
+<a href="javascript:;" class="btn_common lrg blu">로그인</a></div>
+<script>alert("{{.Message}}");</script>`)
+
+	loginRequiredPage = page(`<div class="content"><p>로그인 후 이용 가능합니다.</p>
+<a href="/user.do?method=login" class="btn_common lrg blu">로그인</a></div>`)
+
+	winningPage = page(`<div class="win_result">
+<h4><strong>{{.Round}}회</strong> 당첨결과</h4>
+<p class="desc">({{date .}} 추첨)</p>
+<div class="nums">
+<div class="num win"><strong>당첨번호</strong><p>{{range .Numbers}}<span class="ball_645 lrg">{{.}}</span>{{end}}</p></div>
+<div class="num bonus"><strong>보너스</strong><p><span class="ball_645 lrg">{{.BonusNumber}}</span></p></div>
+</div></div>
+<table class="tbl_data tbl_data_col">
+<thead><tr><th>순위</th><th>등위별 총 당첨금액</th><th>당첨게임 수</th><th>1게임당 당첨금액</th></tr></thead>
+<tbody>{{range prizes .}}
+<tr><td>{{.Rank}}</td><td class="tar"><strong>{{won .TotalAmount}}원</strong></td><td>{{count .WinnerCount}}</td><td class="tar">{{won .AmountPerWinner}}원</td></tr>{{end}}
+</tbody></table>`)
+
+	myPage = page(`<div class="content"><h3>마이페이지</h3>
+<div class="money"><p class="total_new"><strong>{{won .Deposit}}</strong>원</p></div>
+<table class="tbl_data"><tbody>
+<tr><th>미수령 당첨금</th><td>0원</td></tr>
+<tr><th>입금전용 가상계좌</th><td>모의은행 000-0000-0000-00</td></tr>
+</tbody></table></div>`)
+
+	buyListPage = page(`<table class="tbl_data tbl_data_col">
+<thead><tr><th>구입일자</th><th>복권명</th><th>회차</th><th>선택번호/복권번호</th><th>구입매수</th><th>당첨결과</th></tr></thead>
+<tbody>{{range .}}
+<tr><td>{{.At.Format "2006-01-02"}}</td><td>로또6/45</td><td>{{.Round}}</td>
+<td><a href="javascript:void(0);" onclick="detailPop('{{.OrderNo}}', '{{.Barcode}}', '{{.IssueNo}}'); return false;">{{.Barcode}}</a></td>
+<td>{{len .Tickets}}</td><td>-</td></tr>{{else}}
+<tr><td colspan="6" class="nodata">조회 결과가 없습니다.</td></tr>{{end}}
+</tbody></table>`)
+
+	buyDetailPage = page(`<div class="popup"><h3><strong>제 {{.Round}}회</strong> 로또 6/45</h3>
+<div class="selected"><ul>{{range .Tickets}}
+<li><strong><span>{{.Slot}}</span><span>{{.Mode}}</span></strong>
+<div class="nums">{{range .Numbers}}<span>{{.}}</span>{{end}}</div></li>{{end}}
+</ul></div></div>`)
+)
+
+// page parses body into a page template.
+func page(body string) *template.Template {
+	return template.Must(template.New("page").Funcs(pageFuncs).Parse(`<!DOCTYPE html>
+<html lang="ko"><head><meta charset="EUC-KR"><title>동행복권</title></head>
+<body>` + body + `</body></html>`))
+}
+
+// writePage renders t with data and writes it in EUC-KR like the site.
+func writePage(w http.ResponseWriter, t *template.Template, data any) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	encoded, err := korean.EUCKR.NewEncoder().Bytes(buf.Bytes())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=EUC-KR")
+	w.Write(encoded)
+}
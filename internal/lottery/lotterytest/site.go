@@ -0,0 +1,238 @@
+// Package lotterytest is a stand-in for the dhlottery site: it serves the
+// login, round, balance, buy, winning-number and purchase pages the lottery
+// client reads, backed by in-memory accounts and generated draws, so the
+// buy and check flows can run end to end without real credentials.
+//
+// Point the clients at it with lottery.SetBaseURL (LOTTO_DHLOTTERY_URL).
+// Besides the site's pages it serves a few endpoints under /_mock/ to
+// inspect and drive it: GET /_mock/state, POST /_mock/draw and
+// POST /_mock/maintenance?enabled=true|false.
+package lotterytest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"weekly-lotto/internal/domain"
+)
+
+// maxTicketsPerRound is the online purchase limit of an account per round
+// (5,000원).
+const maxTicketsPerRound = 5
+
+// sessionCookie is the session cookie of the site.
+const sessionCookie = "JSESSIONID"
+
+// Account is a member of the mock site.
+type Account struct {
+	Username string `json:"username"`
+	Password string `json:"-"`
+	// Deposit is the balance purchases are paid from, in KRW.
+	Deposit int64 `json:"deposit"`
+}
+
+// Site is the mock site. It is safe for concurrent use.
+type Site struct {
+	mu          sync.Mutex
+	accounts    map[string]*Account
+	sessions    map[string]string // JSESSIONID → 로그인한 아이디 (로그인 전이면 빈 값)
+	latest      int               // 최근 추첨 회차
+	draws       map[int]*domain.WinningNumbers
+	purchases   []*purchase
+	maintenance bool
+}
+
+// purchase is one order of up to five tickets.
+type purchase struct {
+	Username string    `json:"username"`
+	Round    int       `json:"round"`
+	OrderNo  string    `json:"orderNo"`
+	Barcode  string    `json:"barcode"`
+	IssueNo  string    `json:"issueNo"`
+	At       time.Time `json:"purchasedAt"`
+	Tickets  []ticket  `json:"tickets"`
+}
+
+// ticket is one slot of an order.
+type ticket struct {
+	Slot    string `json:"slot"`
+	Mode    string `json:"mode"` // 자동, 반자동, 수동
+	Numbers []int  `json:"numbers"`
+}
+
+// NewSite returns a site whose latest draw is the last one held before
+// now, with the accounts as members. Each account starts with an order for
+// each of the two latest draws, the latest one holding a 5th-prize ticket,
+// so checks find something to report right away.
+func NewSite(now time.Time, accounts ...Account) *Site {
+	next, _ := domain.NextDraw(now)
+	s := &Site{
+		accounts: make(map[string]*Account),
+		sessions: make(map[string]string),
+		latest:   next - 1,
+		draws:    make(map[int]*domain.WinningNumbers),
+	}
+	for _, account := range accounts {
+		s.accounts[account.Username] = &account
+		for _, round := range []int{s.latest - 1, s.latest} {
+			s.addPurchase(account.Username, round, domain.DrawDate(round).Add(10*time.Hour), seedTickets(s.draw(round), account.Username))
+		}
+	}
+	return s
+}
+
+// Draw holds the draw of the round on sale and returns it; purchases for
+// that round can be checked afterwards and sales move on to the next one.
+func (s *Site) Draw() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latest++
+	s.draw(s.latest)
+	return s.latest
+}
+
+// SetMaintenance sends every page to the maintenance notice while enabled.
+func (s *Site) SetMaintenance(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maintenance = enabled
+}
+
+// ServeHTTP serves the site's pages by path and method query parameter.
+func (s *Site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/_mock/") {
+		s.serveAdmin(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/index_check.html" {
+		writePage(w, maintenancePage, nil)
+		return
+	}
+	if s.maintenance {
+		http.Redirect(w, r, "/index_check.html", http.StatusFound)
+		return
+	}
+
+	session := s.session(w, r)
+	route := r.Method + " " + r.URL.Path
+	if method := r.URL.Query().Get("method"); method != "" {
+		route += "?" + method
+	}
+	switch route {
+	case "GET /gameResult.do?byWin":
+		s.serveWinning(w, r)
+	case "GET /common.do?main":
+		s.serveMain(w, session)
+	case "POST /userSsl.do?login":
+		s.serveLogin(w, r, session)
+	case "GET /userSsl.do?myPage":
+		s.serveMyPage(w, session)
+	case "POST /olotto/game/egovUserReadySocket.json":
+		writeJSON(w, map[string]string{"ready_ip": "127.0.0.1"})
+	case "POST /olotto/game/execBuy.do":
+		s.serveBuy(w, r, session)
+	case "POST /myPage.do?lottoBuyList":
+		s.serveBuyList(w, r, session)
+	case "GET /myPage.do?lotto645Detail":
+		s.serveBuyDetail(w, r, session)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// session returns the session id of the request, starting a session when
+// it has none.
+func (s *Site) session(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		if _, ok := s.sessions[cookie.Value]; ok {
+			return cookie.Value
+		}
+	}
+	id := randomHex(16)
+	s.sessions[id] = ""
+	// 사이트는 www, ol 하위 도메인에서도 같은 세션을 씀
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: id, Path: "/", Domain: "dhlottery.co.kr", HttpOnly: true})
+	return id
+}
+
+// user returns the account logged in with session, or nil.
+func (s *Site) user(session string) *Account {
+	return s.accounts[s.sessions[session]]
+}
+
+func (s *Site) serveAdmin(w http.ResponseWriter, r *http.Request) {
+	switch r.Method + " " + r.URL.Path {
+	case "GET /_mock/state":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		accounts := make([]*Account, 0, len(s.accounts))
+		for _, account := range s.accounts {
+			accounts = append(accounts, account)
+		}
+		slices.SortFunc(accounts, func(a, b *Account) int { return strings.Compare(a.Username, b.Username) })
+		writeJSON(w, map[string]any{
+			"latestRound": s.latest,
+			"onSale":      s.latest + 1,
+			"maintenance": s.maintenance,
+			"accounts":    accounts,
+			"purchases":   s.purchases,
+		})
+	case "POST /_mock/draw":
+		round := s.Draw()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		winning := s.draws[round]
+		writeJSON(w, map[string]any{"round": round, "numbers": winning.Numbers, "bonus": winning.BonusNumber})
+	case "POST /_mock/maintenance":
+		enabled := r.URL.Query().Get("enabled") != "false"
+		s.SetMaintenance(enabled)
+		writeJSON(w, map[string]bool{"maintenance": enabled})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// addPurchase records an order of tickets for round and returns it.
+func (s *Site) addPurchase(username string, round int, at time.Time, tickets []ticket) *purchase {
+	p := &purchase{
+		Username: username,
+		Round:    round,
+		OrderNo:  at.In(domain.Seoul).Format("20060102") + randomDigits(8),
+		Barcode:  randomDigits(20),
+		IssueNo:  randomDigits(4),
+		At:       at,
+		Tickets:  tickets,
+	}
+	s.purchases = append(s.purchases, p)
+	return p
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return strings.ToUpper(hex.EncodeToString(b))
+}
+
+func randomDigits(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	for i := range b {
+		b[i] = '0' + b[i]%10
+	}
+	return string(b)
+}
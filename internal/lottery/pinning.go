@@ -0,0 +1,50 @@
+package lottery
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrPinMismatch is returned when the site presents a certificate chain
+// without any of the pinned public keys, as a TLS-intercepting proxy does.
+var ErrPinMismatch = errors.New("동행복권 사이트 인증서의 공개키가 고정한 값과 다릅니다 - 네트워크에서 TLS를 가로채고 있을 수 있어 연결하지 않습니다")
+
+var pins atomic.Pointer[[][sha256.Size]byte]
+
+// SetPins pins the public keys the site may present: a connection to the
+// site is refused unless a certificate of its verified chain has a
+// SubjectPublicKeyInfo whose SHA-256 hash is one of hashes. Pinning an
+// intermediate or root key as well as the leaf lets the site renew its
+// certificate without breaking the clients. No hashes turn pinning off.
+// Requests sent to a base URL (SetBaseURL) are not pinned.
+func SetPins(hashes [][sha256.Size]byte) {
+	if len(hashes) == 0 {
+		pins.Store(nil)
+		return
+	}
+	pins.Store(&hashes)
+}
+
+// verifyPins is the VerifyConnection hook of siteTransport. It runs after
+// the usual chain verification, so only verified chains are matched.
+func verifyPins(cs tls.ConnectionState) error {
+	pinned := pins.Load()
+	if pinned == nil || (cs.ServerName != siteHost && !strings.HasSuffix(cs.ServerName, "."+siteHost)) {
+		return nil
+	}
+	for _, chain := range cs.VerifiedChains {
+		for _, cert := range chain {
+			hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			for _, pin := range *pinned {
+				if hash == pin {
+					return nil
+				}
+			}
+		}
+	}
+	return fmt.Errorf("%w (%s)", ErrPinMismatch, cs.ServerName)
+}
@@ -0,0 +1,45 @@
+package lottery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+)
+
+// ErrReadOnly is returned for purchase requests of a read-only client.
+var ErrReadOnly = errors.New("읽기 전용 모드에서는 구매할 수 없습니다 (readOnly)")
+
+// purchaseHost serves the purchase endpoints of the site (execBuy.do and
+// the ready socket), and nothing else the clients use.
+const purchaseHost = "ol." + siteHost
+
+// SetReadOnly turns read-only mode on or off for c. In read-only mode the
+// client's transport refuses any request to the purchase host before it is
+// sent, so credentials used on a dashboard or a shared machine cannot place
+// an order through any code path; winning numbers, purchase lists and the
+// balance can still be read. Other clients are not affected.
+func (c *Client) SetReadOnly(enabled bool) {
+	c.readOnly.Store(enabled)
+}
+
+// ReadOnly reports whether c is in read-only mode.
+func (c *Client) ReadOnly() bool {
+	return c.readOnly.Load()
+}
+
+// readOnlyTransport refuses purchase requests while readOnly is set.
+type readOnlyTransport struct {
+	readOnly *atomic.Bool
+	next     http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.readOnly.Load() && req.URL.Hostname() == purchaseHost {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("%w: %s", ErrReadOnly, req.URL.Path)
+	}
+	return t.next.RoundTrip(req)
+}
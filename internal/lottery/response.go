@@ -0,0 +1,58 @@
+package lottery
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"weekly-lotto/internal/sanitize"
+)
+
+// ResponseError is a failure to understand a page the site returned. It
+// keeps the page so error reports can show what the site actually sent;
+// Error() is that of the wrapped error.
+type ResponseError struct {
+	Kind  string // one of the Page* kinds
+	Round int    // 0 when unknown
+	URL   string
+	Body  []byte
+	Err   error
+}
+
+func (e *ResponseError) Error() string { return e.Err.Error() }
+func (e *ResponseError) Unwrap() error { return e.Err }
+
+// responseError wraps err with the page it came from. Expected outcomes
+// such as ErrNoPurchases are returned unchanged.
+func responseError(kind string, round int, rawURL string, body []byte, err error) error {
+	if err == nil || errors.Is(err, ErrNoPurchases) {
+		return err
+	}
+	return &ResponseError{Kind: kind, Round: round, URL: rawURL, Body: body, Err: err}
+}
+
+var (
+	snippetTags   = regexp.MustCompile(`(?s)<script.*?</script>|<style.*?</style>|<[^>]*>`)
+	snippetDigits = regexp.MustCompile(`\d{6,}`)
+)
+
+// Snippet returns at most max bytes of the body as text, with markup and
+// whitespace collapsed, sensitive fields (see sanitize) and long digit runs
+// (order numbers, barcodes, phone and account numbers) masked, so it can be
+// sent to an error tracker.
+func (e *ResponseError) Snippet(max int) string {
+	text := snippetTags.ReplaceAllString(string(sanitize.Page(e.Body)), " ")
+	text = strings.Join(strings.Fields(text), " ")
+	text = snippetDigits.ReplaceAllStringFunc(text, func(digits string) string {
+		return strings.Repeat("*", len(digits))
+	})
+	if len(text) <= max {
+		return text
+	}
+	cut := max
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "…"
+}
@@ -0,0 +1,97 @@
+package lottery
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/korean"
+
+	"weekly-lotto/internal/logging"
+	"weekly-lotto/internal/sanitize"
+)
+
+// traceBodyLimit caps the request and response bodies written by the HTTP
+// trace.
+const traceBodyLimit = 1024
+
+var traceHTTP atomic.Bool
+
+// SetTraceHTTP turns on logging of every request the clients send: method,
+// URL, status, timing and the start of both bodies, with credentials,
+// cookies and order numbers masked (see sanitize).
+func SetTraceHTTP(enabled bool) {
+	traceHTTP.Store(enabled)
+}
+
+// traceTransport logs requests while SetTraceHTTP is on.
+type traceTransport struct {
+	next http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !traceHTTP.Load() {
+		return t.next.RoundTrip(req)
+	}
+
+	logging.Infof("🔎 → %s %s", req.Method, sanitize.String(req.URL.String()))
+	if req.GetBody != nil && req.ContentLength != 0 {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, traceBodyLimit+1))
+			body.Close()
+			logging.Infof("🔎   요청 본문 (%s): %s", req.Header.Get("Content-Type"), traceBody(data))
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		logging.Infof("🔎 ← %s %s 실패 (%s): %v", req.Method, sanitize.String(req.URL.String()), elapsed, err)
+		return nil, err
+	}
+
+	detail := elapsed.String()
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		detail += ", " + contentType
+	}
+	logging.Infof("🔎 ← %s %s %s (%s)", req.Method, sanitize.String(req.URL.String()), resp.Status, detail)
+	if location := resp.Header.Get("Location"); location != "" {
+		logging.Infof("🔎   리다이렉트: %s", sanitize.String(location))
+	}
+
+	// 본문 앞부분만 읽고 나머지는 그대로 이어 붙여 호출한 쪽이 전부 읽을 수 있게 함
+	head, _ := io.ReadAll(io.LimitReader(resp.Body, traceBodyLimit+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if len(head) > 0 {
+		logging.Infof("🔎   응답 본문: %s", traceBody(head))
+	}
+	return resp, nil
+}
+
+// traceBody returns at most traceBodyLimit bytes of body as sanitized text
+// on one line. Pages the site serves in EUC-KR are converted to UTF-8.
+func traceBody(body []byte) string {
+	truncated := len(body) > traceBodyLimit
+	if truncated {
+		body = body[:traceBodyLimit]
+	}
+	if !utf8.Valid(body) {
+		if decoded, err := korean.EUCKR.NewDecoder().Bytes(body); err == nil {
+			body = decoded
+		}
+	}
+	text := strings.Join(strings.Fields(strings.ToValidUTF8(string(body), "")), " ")
+	text = sanitize.String(text)
+	if truncated {
+		text += " …"
+	}
+	return text
+}
@@ -0,0 +1,118 @@
+package lottery
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Middleware wraps the HTTP transport of lottery clients, so every request
+// a client sends - including redirects and retries of the session - passes
+// through it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+var (
+	middlewareMu sync.Mutex
+	middlewares  []Middleware
+)
+
+// Use adds m to the transport of clients created afterwards. The middleware
+// added first sees a request first and its response last.
+func Use(m Middleware) {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+	middlewares = append(middlewares, m)
+}
+
+// newTransport returns siteTransport wrapped in the registered
+// middlewares, a read-only guard that follows readOnly, the circuit breaker and the --trace-http
+// tracer, which sits closest to the network so its timings leave out the
+// middlewares. The guard sits above the breaker so refused purchases do not
+// count as site failures. Below the tracer, site requests are redirected to
+// the base URL while one is set.
+func newTransport(readOnly *atomic.Bool) http.RoundTripper {
+	middlewareMu.Lock()
+	defer middlewareMu.Unlock()
+
+	var rt http.RoundTripper = &readOnlyTransport{readOnly: readOnly, next: &breakerTransport{
+		breaker: sharedBreaker,
+		next:    &traceTransport{next: &rewriteTransport{next: siteTransport}},
+	}}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
+
+// siteTransport is the connection pool shared by every client. Backfills
+// and purchase lists send many requests to the same few hosts, so enough
+// idle connections are kept per host - and HTTP/2 is negotiated where the
+// server offers it - that they do not pay a TLS handshake per request.
+// Responses are requested gzip-compressed and decompressed transparently,
+// as Accept-Encoding is left to the transport. Site connections are also
+// checked against the pinned public keys (SetPins).
+var siteTransport = newSiteTransport()
+
+func newSiteTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConns = 32
+	t.MaxIdleConnsPerHost = 2 * purchaseDetailConcurrency
+	t.IdleConnTimeout = 90 * time.Second
+	t.TLSHandshakeTimeout = 10 * time.Second
+	t.TLSClientConfig = &tls.Config{VerifyConnection: verifyPins}
+	return t
+}
+
+// siteHost is the domain of the lottery site. Requests to it and its
+// subdomains are sent to the base URL set with SetBaseURL.
+const siteHost = "dhlottery.co.kr"
+
+var baseURL atomic.Pointer[url.URL]
+
+// SetBaseURL sends the requests of every client to base (scheme and host,
+// e.g. http://127.0.0.1:8645) instead of the real site, keeping the path
+// and query, so the flows can run against a mock such as lotterytest. The
+// clients still see the site's URLs, so redirects and cookies work as on
+// the real site. An empty base restores the real site.
+func SetBaseURL(base string) error {
+	if base == "" {
+		baseURL.Store(nil)
+		return nil
+	}
+	u, err := url.Parse(base)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("올바른 http(s) 주소가 아닙니다: %s", base)
+	}
+	baseURL.Store(u)
+	return nil
+}
+
+// rewriteTransport sends site requests to the base URL while one is set.
+type rewriteTransport struct {
+	next http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := baseURL.Load()
+	host := req.URL.Hostname()
+	if base == nil || (host != siteHost && !strings.HasSuffix(host, "."+siteHost)) {
+		return t.next.RoundTrip(req)
+	}
+
+	out := req.Clone(req.Context())
+	out.URL.Scheme = base.Scheme
+	out.URL.Host = base.Host
+	out.Host = ""
+	resp, err := t.next.RoundTrip(out)
+	if resp != nil {
+		// 점검 페이지 리다이렉트 판별과 쿠키 저장은 원래 주소 기준
+		resp.Request = req
+	}
+	return resp, err
+}
@@ -0,0 +1,329 @@
+// Package mcp is a minimal Model Context Protocol server: JSON-RPC 2.0
+// over newline-delimited stdio, offering tools and asking the user through
+// the client (see Elicit).
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// ProtocolVersions are the protocol revisions the server speaks, newest
+// first. A client asking for another one gets the newest.
+var ProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// maxMessageBytes bounds a single request line.
+const maxMessageBytes = 4 << 20
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is a function the client may call.
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// InputSchema is the JSON Schema of the arguments object.
+	InputSchema map[string]any   `json:"inputSchema"`
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+	// Call runs the tool with the raw arguments object and returns a value
+	// encoded as JSON for the client. An error is reported to the model as
+	// a failed tool result, not as a protocol error.
+	Call func(ctx context.Context, arguments json.RawMessage) (any, error) `json:"-"`
+}
+
+// ToolAnnotations are hints about what a tool does.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    bool   `json:"readOnlyHint"`
+	DestructiveHint bool   `json:"destructiveHint"`
+	IdempotentHint  bool   `json:"idempotentHint"`
+	OpenWorldHint   bool   `json:"openWorldHint"`
+}
+
+// Server answers the requests of one client.
+type Server struct {
+	Name         string
+	Version      string
+	Instructions string
+	Tools        []Tool
+
+	out   *json.Encoder
+	lines <-chan []byte
+	// readErr is why lines was closed, nil at the end of the input.
+	readErr error
+	// elicit is whether the client declared the elicitation capability.
+	elicit bool
+	// lastID numbers the requests sent to the client.
+	lastID int
+}
+
+// request is a message from the client: a request, a notification or,
+// with Result or Error, the response to a request of the server.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// outgoing is a request of the server to the client.
+type outgoing struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      string `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Serve reads requests from r and writes responses to w until r ends or
+// ctx is done. Requests are answered one at a time, in order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = json.NewEncoder(w)
+	lines := make(chan []byte)
+	s.lines = lines
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64<<10), maxMessageBytes)
+		for scanner.Scan() {
+			line := slices.Clone(scanner.Bytes())
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		s.readErr = scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				return s.readErr
+			}
+			if len(line) == 0 {
+				continue
+			}
+			if err := s.handle(ctx, line); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle answers one message, returning only errors writing the answer.
+func (s *Server) handle(ctx context.Context, line []byte) error {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return s.reply(json.RawMessage("null"), nil, &rpcError{codeParseError, "JSON 파싱 실패: " + err.Error()})
+	}
+	if req.ID == nil || (req.Method == "" && (req.Result != nil || req.Error != nil)) {
+		// 알림(notifications/initialized, notifications/cancelled 등)과 이미
+		// 포기한 요청의 응답에는 답하지 않음
+		return nil
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return s.reply(req.ID, nil, &rpcError{codeInvalidRequest, "JSON-RPC 2.0 요청이 아닙니다"})
+	}
+
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+			Capabilities    struct {
+				Elicitation json.RawMessage `json:"elicitation"`
+			} `json:"capabilities"`
+		}
+		json.Unmarshal(req.Params, &params)
+		version := ProtocolVersions[0]
+		if slices.Contains(ProtocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		s.elicit = params.Capabilities.Elicitation != nil
+		return s.reply(req.ID, map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": s.Name, "version": s.Version},
+			"instructions":    s.Instructions,
+		}, nil)
+	case "ping":
+		return s.reply(req.ID, map[string]any{}, nil)
+	case "tools/list":
+		return s.reply(req.ID, map[string]any{"tools": s.Tools}, nil)
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return s.reply(req.ID, nil, &rpcError{codeInvalidParams, err.Error()})
+		}
+		i := slices.IndexFunc(s.Tools, func(t Tool) bool { return t.Name == params.Name })
+		if i < 0 {
+			return s.reply(req.ID, nil, &rpcError{codeInvalidParams, "알 수 없는 도구입니다: " + params.Name})
+		}
+		if len(params.Arguments) == 0 || string(params.Arguments) == "null" {
+			params.Arguments = json.RawMessage("{}")
+		}
+		return s.reply(req.ID, call(context.WithValue(ctx, serverKey{}, s), s.Tools[i], params.Arguments), nil)
+	default:
+		return s.reply(req.ID, nil, &rpcError{codeMethodNotFound, "지원하지 않는 메서드입니다: " + req.Method})
+	}
+}
+
+// serverKey is the context key of the server running a tool call.
+type serverKey struct{}
+
+// ErrElicitationUnsupported is what Elicit fails with when the client did
+// not declare the elicitation capability.
+var ErrElicitationUnsupported = errors.New("클라이언트가 사용자 확인 요청(elicitation)을 지원하지 않습니다")
+
+// Elicitation is the answer of the user to Elicit.
+type Elicitation struct {
+	// Action is "accept", "decline" or "cancel"; Content is only set when
+	// the user accepted.
+	Action  string         `json:"action"`
+	Content map[string]any `json:"content,omitempty"`
+}
+
+// Accepted reports whether the user accepted and set the boolean field to
+// true.
+func (e *Elicitation) Accepted(field string) bool {
+	return e.Action == "accept" && e.Content[field] == true
+}
+
+// Elicit asks the user, through the client and without the model, to fill
+// in the flat object described by schema. ctx must be that of a tool call;
+// the answer is awaited until ctx ends.
+func Elicit(ctx context.Context, message string, schema map[string]any) (*Elicitation, error) {
+	s, _ := ctx.Value(serverKey{}).(*Server)
+	if s == nil || !s.elicit {
+		return nil, ErrElicitationUnsupported
+	}
+	result, err := s.request(ctx, "elicitation/create", map[string]any{"message": message, "requestedSchema": schema})
+	if err != nil {
+		return nil, err
+	}
+	var answer Elicitation
+	if err := json.Unmarshal(result, &answer); err != nil {
+		return nil, fmt.Errorf("사용자 확인 응답 형식 오류: %w", err)
+	}
+	return &answer, nil
+}
+
+// request sends a request to the client and waits for its response.
+// Messages arriving meanwhile are handled as usual, except tool calls,
+// which are refused while one is running.
+func (s *Server) request(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	s.lastID++
+	id := s.Name + "-" + strconv.Itoa(s.lastID)
+	if err := s.out.Encode(outgoing{JSONRPC: "2.0", ID: id, Method: method, Params: params}); err != nil {
+		return nil, fmt.Errorf("MCP 요청 쓰기 실패: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case line, ok := <-s.lines:
+			if !ok {
+				return nil, errors.New("응답을 받기 전에 클라이언트 연결이 끊어졌습니다")
+			}
+			if len(line) == 0 {
+				continue
+			}
+			var msg request
+			if json.Unmarshal(line, &msg) == nil && msg.Method == "" {
+				var answered string
+				if json.Unmarshal(msg.ID, &answered) == nil && answered == id {
+					if msg.Error != nil {
+						return nil, fmt.Errorf("클라이언트가 %s 요청을 거부했습니다: %s", method, msg.Error.Message)
+					}
+					return msg.Result, nil
+				}
+			}
+			if msg.Method == "tools/call" && msg.ID != nil {
+				if err := s.reply(msg.ID, nil, &rpcError{codeInvalidRequest, "다른 도구 호출을 처리하는 중입니다"}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := s.handle(ctx, line); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// call runs tool, turning its value or error into a tool result.
+func call(ctx context.Context, tool Tool, arguments json.RawMessage) toolResult {
+	value, err := tool.Call(ctx, arguments)
+	if err != nil {
+		return toolResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	text, ok := value.(string)
+	if !ok {
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return toolResult{Content: []content{{Type: "text", Text: fmt.Sprintf("결과 변환 실패: %v", err)}}, IsError: true}
+		}
+		text = string(data)
+	}
+	return toolResult{Content: []content{{Type: "text", Text: text}}}
+}
+
+func (s *Server) reply(id json.RawMessage, result any, rpcErr *rpcError) error {
+	if err := s.out.Encode(response{JSONRPC: "2.0", ID: id, Result: result, Error: rpcErr}); err != nil {
+		return fmt.Errorf("MCP 응답 쓰기 실패: %w", err)
+	}
+	return nil
+}
+
+// DecodeArguments decodes the arguments object of a tool call into v,
+// rejecting unknown fields so typos are reported to the model.
+func DecodeArguments(arguments json.RawMessage, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(arguments))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return errors.New("인자 형식 오류: " + err.Error())
+	}
+	return nil
+}
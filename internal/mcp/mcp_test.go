@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// session runs a server with tools over pipes and returns a function
+// writing a message to it and a decoder of what it writes back.
+func session(t *testing.T, tools ...Tool) (send func(string), out *json.Decoder) {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- (&Server{Name: "test", Version: "1", Tools: tools}).Serve(ctx, inR, outW)
+		outW.Close()
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve: %v", err)
+		}
+	})
+	return func(line string) {
+		if _, err := io.WriteString(inW, line+"\n"); err != nil {
+			t.Fatal(err)
+		}
+	}, json.NewDecoder(outR)
+}
+
+// message is any message of the server, decoded loosely.
+type message struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params struct {
+		Message string `json:"message"`
+	} `json:"params"`
+	Result struct {
+		Content []content `json:"content"`
+		IsError bool      `json:"isError"`
+	} `json:"result"`
+	Error *rpcError `json:"error"`
+}
+
+func read(t *testing.T, out *json.Decoder) message {
+	t.Helper()
+	var msg message
+	if err := out.Decode(&msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+// confirmTool asks the user and reports the answer.
+var confirmTool = Tool{
+	Name: "confirm",
+	Call: func(ctx context.Context, _ json.RawMessage) (any, error) {
+		answer, err := Elicit(ctx, "정말요?", map[string]any{"type": "object"})
+		if err != nil {
+			return nil, err
+		}
+		if answer.Accepted("ok") {
+			return "accepted", nil
+		}
+		return "refused: " + answer.Action, nil
+	},
+}
+
+func TestElicitAsksTheClient(t *testing.T) {
+	send, out := session(t, confirmTool)
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{"elicitation":{}}}}`)
+	read(t, out)
+
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"confirm"}}`)
+	ask := read(t, out)
+	if ask.Method != "elicitation/create" || ask.Params.Message != "정말요?" {
+		t.Fatalf("server sent %s %q, want an elicitation", ask.Method, ask.Params.Message)
+	}
+
+	// 응답을 기다리는 동안 온 요청도 처리하고, 다른 도구 호출은 거절함
+	send(`{"jsonrpc":"2.0","id":3,"method":"ping"}`)
+	if pong := read(t, out); string(pong.ID) != "3" || pong.Error != nil {
+		t.Fatalf("ping during the elicitation = %+v", pong)
+	}
+	send(`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"confirm"}}`)
+	if busy := read(t, out); string(busy.ID) != "4" || busy.Error == nil {
+		t.Fatalf("nested tool call = %+v, want an error", busy)
+	}
+
+	send(`{"jsonrpc":"2.0","id":` + string(ask.ID) + `,"result":{"action":"accept","content":{"ok":true}}}`)
+	result := read(t, out)
+	if string(result.ID) != "2" || len(result.Result.Content) != 1 || result.Result.Content[0].Text != "accepted" {
+		t.Fatalf("tool result = %+v, want accepted", result)
+	}
+
+	send(`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"confirm"}}`)
+	ask = read(t, out)
+	send(`{"jsonrpc":"2.0","id":` + string(ask.ID) + `,"result":{"action":"decline"}}`)
+	if result := read(t, out); result.Result.Content[0].Text != "refused: decline" {
+		t.Fatalf("declined tool result = %q", result.Result.Content[0].Text)
+	}
+}
+
+func TestElicitWithoutTheCapability(t *testing.T) {
+	send, out := session(t, confirmTool)
+	send(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{}}}`)
+	read(t, out)
+
+	send(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"confirm"}}`)
+	result := read(t, out)
+	if !result.Result.IsError || !strings.Contains(result.Result.Content[0].Text, ErrElicitationUnsupported.Error()) {
+		t.Fatalf("tool result = %+v, want ErrElicitationUnsupported", result)
+	}
+	if _, err := Elicit(context.Background(), "정말요?", nil); !errors.Is(err, ErrElicitationUnsupported) {
+		t.Errorf("Elicit outside a tool call = %v, want ErrElicitationUnsupported", err)
+	}
+}
@@ -0,0 +1,223 @@
+// Package mqtt publishes messages to an MQTT broker, speaking just enough of
+// MQTT 3.1.1 for that (CONNECT, PUBLISH at QoS 0, PINGREQ, DISCONNECT), so
+// no client library is needed.
+//
+// Every Publish opens its own connection: runs publish a handful of
+// messages at the end, so there is no session worth keeping.
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+// timeout bounds a whole Publish when ctx has no earlier deadline.
+const timeout = 10 * time.Second
+
+// keepAlive is the keep alive announced in CONNECT. Connections are closed
+// long before it matters.
+const keepAlive = 60
+
+// Packet types, already shifted into the upper nibble of the first byte.
+const (
+	packetConnect    = 0x10
+	packetConnack    = 0x20
+	packetPublish    = 0x30
+	packetPingreq    = 0xc0
+	packetPingresp   = 0xd0
+	packetDisconnect = 0xe0
+)
+
+// Message is one message to publish.
+type Message struct {
+	Topic   string
+	Payload []byte
+	// Retain keeps the message on the broker for later subscribers, as
+	// for state topics.
+	Retain bool
+}
+
+// connackErrors are the CONNACK return codes refusing a connection.
+var connackErrors = map[byte]string{
+	1: "지원하지 않는 프로토콜 버전",
+	2: "거부된 클라이언트 ID",
+	3: "브로커를 사용할 수 없음",
+	4: "사용자 이름 또는 비밀번호 오류",
+	5: "권한 없음",
+}
+
+// Publish connects to the broker at rawURL (mqtt://host[:port], mqtts://
+// for TLS) as clientID and publishes messages. It waits for the answer to a
+// ping after the last message, so they have all reached the broker when it
+// returns nil.
+func Publish(ctx context.Context, rawURL, username, password, clientID string, messages []Message) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("MQTT 주소 오류: %w", err)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		port := "1883"
+		if u.Scheme == "mqtts" {
+			port = "8883"
+		}
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	if u.Scheme == "mqtts" {
+		conn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("MQTT 브로커 연결 실패: %w", err)
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(timeout)
+	}
+	conn.SetDeadline(deadline)
+
+	if err := connect(conn, username, password, clientID); err != nil {
+		return err
+	}
+	for _, message := range messages {
+		if err := writePacket(conn, publishPacket(message)); err != nil {
+			return fmt.Errorf("MQTT %s 발행 실패: %w", message.Topic, err)
+		}
+	}
+
+	// 브로커는 패킷을 순서대로 처리하므로 핑 응답이 오면 앞선 메시지도 모두 받은 것
+	if err := writePacket(conn, []byte{packetPingreq, 0}); err != nil {
+		return fmt.Errorf("MQTT 발행 실패: %w", err)
+	}
+	if reply, err := readPacket(conn); err != nil {
+		return fmt.Errorf("MQTT 발행 확인 실패: %w", err)
+	} else if reply[0]&0xf0 != packetPingresp {
+		return fmt.Errorf("MQTT 발행 확인 실패: 예상하지 못한 응답 0x%02x", reply[0])
+	}
+	return writePacket(conn, []byte{packetDisconnect, 0})
+}
+
+// connect sends CONNECT with a clean session and reads the CONNACK.
+func connect(conn net.Conn, username, password, clientID string) error {
+	var body bytes.Buffer
+	writeString(&body, "MQTT")
+	body.WriteByte(4) // 프로토콜 레벨 3.1.1
+	flags := byte(0x02)
+	if username != "" {
+		flags |= 0x80
+	}
+	if password != "" {
+		flags |= 0x40
+	}
+	body.WriteByte(flags)
+	body.Write([]byte{keepAlive >> 8, keepAlive & 0xff})
+	writeString(&body, clientID)
+	if username != "" {
+		writeString(&body, username)
+	}
+	if password != "" {
+		writeString(&body, password)
+	}
+	if err := writePacket(conn, packet(packetConnect, body.Bytes())); err != nil {
+		return fmt.Errorf("MQTT 연결 실패: %w", err)
+	}
+
+	reply, err := readPacket(conn)
+	if err != nil {
+		return fmt.Errorf("MQTT 연결 응답 수신 실패: %w", err)
+	}
+	if reply[0]&0xf0 != packetConnack || len(reply) < 3 {
+		return fmt.Errorf("MQTT 연결 실패: 예상하지 못한 응답 0x%02x", reply[0])
+	}
+	if code := reply[2]; code != 0 {
+		reason, ok := connackErrors[code]
+		if !ok {
+			reason = fmt.Sprintf("응답 코드 %d", code)
+		}
+		return fmt.Errorf("MQTT 연결 거부: %s", reason)
+	}
+	return nil
+}
+
+func publishPacket(message Message) []byte {
+	header := byte(packetPublish)
+	if message.Retain {
+		header |= 0x01
+	}
+	var body bytes.Buffer
+	writeString(&body, message.Topic)
+	body.Write(message.Payload)
+	return packet(header, body.Bytes())
+}
+
+// packet prefixes body with the fixed header: the first byte and the
+// remaining length as a variable byte integer.
+func packet(header byte, body []byte) []byte {
+	out := []byte{header}
+	n := len(body)
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		out = append(out, digit)
+		if n == 0 {
+			break
+		}
+	}
+	return append(out, body...)
+}
+
+// readPacket reads one packet and returns it with the first byte but
+// without the remaining length.
+func readPacket(r io.Reader) ([]byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return nil, err
+	}
+	header := b[0]
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return nil, errors.New("잘못된 패킷 길이")
+		}
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		length += int(b[0]&0x7f) * multiplier
+		multiplier *= 128
+		if b[0]&0x80 == 0 {
+			break
+		}
+	}
+	out := make([]byte, 1+length)
+	out[0] = header
+	if _, err := io.ReadFull(r, out[1:]); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func writePacket(w io.Writer, packet []byte) error {
+	_, err := w.Write(packet)
+	return err
+}
+
+// writeString writes s as a length-prefixed UTF-8 string.
+func writeString(buf *bytes.Buffer, s string) {
+	buf.Write([]byte{byte(len(s) >> 8), byte(len(s))})
+	buf.WriteString(s)
+}
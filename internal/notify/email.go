@@ -2,27 +2,73 @@ package notify
 
 import (
 	"bytes"
-	"crypto/tls"
+	"context"
+	"errors"
 	"fmt"
 	"html/template"
-	"log"
-	"net/smtp"
+	"slices"
 	"strings"
+	"time"
 
+	"weekly-lotto/internal/budget"
 	"weekly-lotto/internal/config"
 	"weekly-lotto/internal/domain"
 	domainutils "weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/logging"
 	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/sanitize"
 )
 
-// EmailSender sends notifications via SMTP.
+// EmailSender renders notifications as emails, routes them to their
+// recipients and delivers them through a Transport, SMTP by default.
 type EmailSender struct {
-	cfg *config.EmailConfig
+	ctx       context.Context
+	cfg       *config.EmailConfig
+	router    *Router
+	transport Transport
+	account   string
+	tag       string
+	outbox    Outbox
 }
 
 // NewEmailSender creates a sender using the provided configuration.
-func NewEmailSender(cfg *config.EmailConfig) *EmailSender {
-	return &EmailSender{cfg: cfg}
+// Recipients are chosen per event by the notification routes.
+func NewEmailSender(cfg *config.EmailConfig, notifications *config.NotificationsConfig) *EmailSender {
+	return &EmailSender{
+		ctx:       context.Background(),
+		cfg:       cfg,
+		router:    NewRouter(notifications, cfg.To),
+		transport: NewSMTPTransport(cfg),
+	}
+}
+
+// WithContext returns a sender whose deliveries give up when ctx ends.
+func (s *EmailSender) WithContext(ctx context.Context) *EmailSender {
+	clone := *s
+	clone.ctx = ctx
+	return &clone
+}
+
+// WithTransport returns a sender that delivers through transport instead.
+func (s *EmailSender) WithTransport(transport Transport) *EmailSender {
+	clone := *s
+	clone.transport = transport
+	return &clone
+}
+
+// ForAccount returns a sender whose notifications are routed for account.
+func (s *EmailSender) ForAccount(account string) *EmailSender {
+	clone := *s
+	clone.account = account
+	return &clone
+}
+
+// WithTag returns a sender that prefixes every subject with tag, so that
+// test notifications cannot be mistaken for real ones.
+func (s *EmailSender) WithTag(tag string) *EmailSender {
+	clone := *s
+	clone.tag = tag
+	return &clone
 }
 
 // SendLotteryBuyMail notifies purchased ticket numbers.
@@ -31,16 +77,39 @@ func (s *EmailSender) SendLotteryBuyMail(tickets []lottery.PurchasedTicket) erro
 		return fmt.Errorf("구매한 티켓이 없습니다")
 	}
 
-	body, err := renderBuyEmail(tickets)
+	body, err := renderBuyEmail(tickets, false)
+	if err != nil {
+		return err
+	}
+	masked, err := renderBuyEmail(tickets, true)
 	if err != nil {
 		return err
 	}
 
 	round := tickets[0].Round
 	subject := fmt.Sprintf("[weekly-lotto] %d회 로또 %d장 구매 완료", round, len(tickets))
-	log.Println(subject)
+	logging.Infof("%s", subject)
+
+	return s.sendMasked(config.EventBuy, subject, body, masked, "text/html; charset=UTF-8")
+}
+
+// SendPurchasePreview notifies what a dry-run purchase would have bought.
+func (s *EmailSender) SendPurchasePreview(preview *lottery.PurchasePreview) error {
+	if preview == nil || len(preview.Tickets) == 0 {
+		return fmt.Errorf("미리보기할 티켓이 없습니다")
+	}
 
-	return s.send(subject, body, "text/html; charset=UTF-8")
+	body, err := renderBuyTemplate(preview.Tickets, true, false)
+	if err != nil {
+		return err
+	}
+	masked, err := renderBuyTemplate(preview.Tickets, true, true)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[weekly-lotto] 🧪 %d회 로또 %d장 구매 미리보기 (dry-run)", preview.Round, len(preview.Tickets))
+	return s.sendMasked(config.EventBuy, subject, body, masked, "text/html; charset=UTF-8")
 }
 
 // SendLotteryCheckResultMail notifies winning check results.
@@ -49,106 +118,251 @@ func (s *EmailSender) SendLotteryCheckResultMail(summary *domain.CheckSummary) e
 		return fmt.Errorf("check summary가 비어 있습니다")
 	}
 
-	body, err := renderCheckResultEmail(summary)
+	body, err := renderCheckResultEmail(summary, false)
+	if err != nil {
+		return err
+	}
+	masked, err := renderCheckResultEmail(summary, true)
 	if err != nil {
 		return err
 	}
 
 	subject := fmt.Sprintf("[weekly-lotto] %d회 당첨 결과", summary.Round)
-	return s.send(subject, body, "text/html; charset=UTF-8")
+	return s.sendMasked(config.EventCheck, subject, body, masked, "text/html; charset=UTF-8")
 }
 
 // SendFailureNotification sends error notification email.
 func (s *EmailSender) SendFailureNotification(operation string, errorMsg string) error {
-	body, err := renderFailureEmail(operation, errorMsg)
+	body, err := renderFailureEmail(operation, sanitize.String(errorMsg))
 	if err != nil {
 		return err
 	}
 
 	subject := fmt.Sprintf("[weekly-lotto] ❌ %s 실패", operation)
-	return s.send(subject, body, "text/html; charset=UTF-8")
+	return s.send(config.EventFailure, subject, body, "text/html; charset=UTF-8")
 }
 
-// send dispatches an email with the given subject and body.
-func (s *EmailSender) send(subject, body, contentType string) error {
-	if contentType == "" {
-		contentType = "text/plain; charset=UTF-8"
+// SendBudgetExceeded notifies that a purchase was refused by a spending cap.
+func (s *EmailSender) SendBudgetExceeded(exceeded *budget.ExceededError) error {
+	if exceeded == nil {
+		return fmt.Errorf("예산 초과 정보가 비어 있습니다")
 	}
-	headers := []string{
-		fmt.Sprintf("From: %s", s.cfg.From),
-		fmt.Sprintf("To: %s", strings.Join(s.cfg.To, ", ")),
-		fmt.Sprintf("Subject: %s", subject),
-		"MIME-Version: 1.0",
-		fmt.Sprintf("Content-Type: %s", contentType),
+
+	body, err := renderBudgetEmail(exceeded)
+	if err != nil {
+		return err
 	}
 
-	message := strings.Join(headers, "\r\n") + "\r\n\r\n" + body
-	addr := fmt.Sprintf("%s:%d", s.cfg.SMTPHost, s.cfg.SMTPPort)
+	subject := fmt.Sprintf("[weekly-lotto] ⚠️ %s 예산 한도 초과로 구매 건너뜀", exceeded.Period)
+	return s.send(config.EventBudget, subject, body, "text/html; charset=UTF-8")
+}
 
-	// AIDEV-NOTE: 포트 465 (implicit TLS) 지원
-	// 포트 465는 연결 시작부터 TLS가 필요하므로 직접 TLS 다이얼 후 SMTP 통신
-	// 포트 587 (STARTTLS)은 smtp.SendMail이 자동 처리
-	if s.cfg.SMTPPort == 465 {
-		tlsConfig := &tls.Config{
-			ServerName:         s.cfg.SMTPHost,
-			InsecureSkipVerify: false, // 프로덕션: 인증서 검증 필수
-			MinVersion:         tls.VersionTLS12,
-		}
-		conn, err := tls.Dial("tcp", addr, tlsConfig)
-		if err != nil {
-			return fmt.Errorf("TLS 연결 실패: %w", err)
-		}
-		defer conn.Close()
+// SendTopUpAlert notifies that a purchase was skipped because the deposit
+// could not cover it, with the account to transfer to.
+func (s *EmailSender) SendTopUpAlert(alert *domain.TopUpAlert) error {
+	if alert == nil {
+		return fmt.Errorf("예치금 부족 정보가 비어 있습니다")
+	}
 
-		client, err := smtp.NewClient(conn, s.cfg.SMTPHost)
-		if err != nil {
-			return fmt.Errorf("SMTP 클라이언트 생성 실패: %w", err)
-		}
-		defer client.Close()
+	body, err := renderTopUpEmail(alert)
+	if err != nil {
+		return err
+	}
 
-		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.SMTPHost)
-		if err = client.Auth(auth); err != nil {
-			return fmt.Errorf("인증 실패: %w", err)
-		}
+	subject := fmt.Sprintf("[weekly-lotto] 💸 예치금 부족으로 구매 건너뜀 (%s원 충전 필요)", domainutils.FormatAmount(alert.Shortfall()))
+	return s.send(config.EventTopUp, subject, body, "text/html; charset=UTF-8")
+}
 
-		if err = client.Mail(s.cfg.From); err != nil {
-			return fmt.Errorf("MAIL FROM 실패: %w", err)
-		}
-		for _, to := range s.cfg.To {
-			if err = client.Rcpt(to); err != nil {
-				return fmt.Errorf("RCPT TO 실패 (%s): %w", to, err)
+// SendReport sends a monthly or yearly spending report.
+func (s *EmailSender) SendReport(report *domain.Report) error {
+	if report == nil {
+		return fmt.Errorf("리포트가 비어 있습니다")
+	}
+
+	body, err := renderReportEmail(report)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[weekly-lotto] 📒 %s 로또 리포트", report.Title)
+	return s.send(config.EventReport, subject, body, "text/html; charset=UTF-8")
+}
+
+// SendBalanceSnapshot sends the deposit balance and purchase snapshot of an account.
+func (s *EmailSender) SendBalanceSnapshot(snapshot *domain.BalanceSnapshot) error {
+	if snapshot == nil {
+		return fmt.Errorf("잔액 정보가 비어 있습니다")
+	}
+
+	body, err := renderBalanceEmail(snapshot)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[weekly-lotto] 💰 예치금 %s원", domainutils.FormatAmount(snapshot.Deposit))
+	return s.send(config.EventBalance, subject, body, "text/html; charset=UTF-8")
+}
+
+// SendRotationReminder asks to rotate passwords used for longer than the
+// rotation policy allows.
+func (s *EmailSender) SendRotationReminder(reminder *domain.RotationReminder) error {
+	if reminder == nil || len(reminder.Credentials) == 0 {
+		return fmt.Errorf("교체할 비밀번호가 없습니다")
+	}
+
+	body, err := renderRotationEmail(reminder)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("[weekly-lotto] 🔑 비밀번호 %d개 교체 필요 (교체 주기 %d일)", len(reminder.Credentials), reminder.MaxAgeDays)
+	return s.send(config.EventRotation, subject, body, "text/html; charset=UTF-8")
+}
+
+// SendPurchaseConfirmation sends the code that confirms a purchase for
+// accounts asked for through an assistant, so only someone reading the email
+// can approve it. It goes, as one email, to the unmasked recipients of the
+// buy notifications of the accounts and is never queued: the code expires
+// long before a resend.
+func (s *EmailSender) SendPurchaseConfirmation(code string, accounts []string, round int, amount int64, expires time.Time) error {
+	var recipients []string
+	for _, account := range accounts {
+		owners, _ := s.router.Recipients(config.EventBuy, account)
+		for _, to := range owners {
+			if !slices.Contains(recipients, to) {
+				recipients = append(recipients, to)
 			}
 		}
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("%w: 구매 확인 코드를 받을 수신자가 없습니다", ErrDelivery)
+	}
+
+	body := fmt.Sprintf("도우미가 %d회 로또 %s원어치 구매를 요청했습니다.\n\n"+
+		"확인 코드: %s\n\n"+
+		"구매하려면 이 코드를 도우미에게 알려 주세요. 코드는 %s까지 한 번만 쓸 수 있습니다.\n"+
+		"직접 요청하지 않았다면 무시하세요. 코드를 알려 주지 않으면 아무것도 구매하지 않습니다.\n",
+		round, domainutils.FormatAmount(amount), code, expires.In(domain.Seoul).Format("15:04"))
+	subject := fmt.Sprintf("[weekly-lotto] 🔐 %d회 구매 확인 코드", round)
+	if err := s.transport.Send(s.ctx, s.cfg.From, recipients, s.compose(recipients, subject, body, "")); err != nil {
+		return fmt.Errorf("%w: %w", ErrDelivery, err)
+	}
+	return nil
+}
+
+// ErrDelivery wraps every failure to deliver a notification.
+var ErrDelivery = errors.New("알림 전송 실패")
+
+// send dispatches an email with the given subject and body to the recipients
+// routed for event. With an outbox, a failed email is queued instead.
+func (s *EmailSender) send(event, subject, body, contentType string) error {
+	return s.sendMasked(event, subject, body, "", contentType)
+}
+
+// sendMasked is send for a notification showing played numbers: masked is
+// the body for the recipients of masked routes. When there are such
+// recipients the full and the masked email are delivered, and queued, as
+// separate parts, so a failure of one is not sent again to the recipients
+// of the other.
+func (s *EmailSender) sendMasked(event, subject, body, masked, contentType string) error {
+	message := Message{Event: event, Account: s.account, Subject: subject, Body: body, ContentType: contentType}
+	if _, hidden := s.router.Recipients(event, s.account); masked == "" || len(hidden) == 0 {
+		return s.dispatch(message)
+	}
+	full, hidden := message, message
+	full.Part = PartFull
+	hidden.Part, hidden.Body, hidden.MaskedBody = PartMasked, "", masked
+	return errors.Join(s.dispatch(full), s.dispatch(hidden))
+}
 
-		w, err := client.Data()
-		if err != nil {
-			return fmt.Errorf("DATA 명령 실패: %w", err)
+// dispatch delivers message, queueing it in the outbox when that fails.
+func (s *EmailSender) dispatch(message Message) error {
+	if err := s.deliver(message); err != nil {
+		if s.enqueue(message, err) {
+			return nil
 		}
-		_, err = w.Write([]byte(message))
-		if err != nil {
-			return fmt.Errorf("메시지 쓰기 실패: %w", err)
+		return fmt.Errorf("%w: %w", ErrDelivery, err)
+	}
+	return nil
+}
+
+// deliver sends message to the recipients routed for its event (only those
+// of its Part, when set), as one email unless some recipients get the
+// masked body.
+func (s *EmailSender) deliver(message Message) error {
+	recipients, masked := s.router.Recipients(message.Event, s.account)
+	switch {
+	case message.Part == PartFull:
+		masked = nil
+	case message.Part == PartMasked:
+		recipients = nil
+	case message.MaskedBody == "":
+		recipients, masked = slices.Concat(recipients, masked), nil
+	}
+	if len(recipients) == 0 && len(masked) == 0 {
+		if message.Part == "" {
+			logging.Infof("ℹ️  [%s/%s] 알림 수신자가 없어 이메일을 보내지 않습니다", message.Event, s.account)
 		}
-		err = w.Close()
-		if err != nil {
-			return fmt.Errorf("메시지 종료 실패: %w", err)
+		return nil
+	}
+
+	if len(recipients) > 0 {
+		if err := s.transport.Send(s.ctx, s.cfg.From, recipients, s.compose(recipients, message.Subject, message.Body, message.ContentType)); err != nil {
+			return err
 		}
+	}
+	if len(masked) > 0 {
+		return s.transport.Send(s.ctx, s.cfg.From, masked, s.compose(masked, message.Subject, message.MaskedBody, message.ContentType))
+	}
+	return nil
+}
+
+// compose renders a notification to recipients as an email, returning the
+// message with headers.
+func (s *EmailSender) compose(recipients []string, subject, body, contentType string) []byte {
+	if s.account != "" && s.account != config.DefaultAccountName {
+		subject = fmt.Sprintf("%s (%s)", subject, s.account)
+	}
+	if s.tag != "" {
+		subject = s.tag + " " + subject
+	}
 
-		return client.Quit()
+	if contentType == "" {
+		contentType = "text/plain; charset=UTF-8"
 	}
+	headers := []string{
+		fmt.Sprintf("From: %s", s.cfg.From),
+		fmt.Sprintf("To: %s", strings.Join(recipients, ", ")),
+		fmt.Sprintf("Subject: %s", subject),
+		"MIME-Version: 1.0",
+		fmt.Sprintf("Content-Type: %s", contentType),
+	}
+
+	return []byte(strings.Join(headers, "\r\n") + "\r\n\r\n" + body)
+}
 
-	// 포트 587 (STARTTLS) 또는 포트 25는 기존 방식 사용
-	auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.SMTPHost)
-	return smtp.SendMail(addr, auth, s.cfg.From, s.cfg.To, []byte(message))
+// Verify checks that the transport could deliver notifications; for SMTP it
+// connects and authenticates without sending mail.
+func (s *EmailSender) Verify() error {
+	return s.transport.Verify(s.ctx)
 }
 
-func renderCheckResultEmail(summary *domain.CheckSummary) (string, error) {
+// renderCheckResultEmail renders a check result; masked shows only the drawn
+// numbers of the tickets.
+func renderCheckResultEmail(summary *domain.CheckSummary, masked bool) (string, error) {
+	summaryText := summary.ToString()
+	if masked {
+		summaryText = summary.MaskedString()
+	}
 	data := checkResultTemplateData{
 		Round:       summary.Round,
 		DrawDate:    summary.DrawDate.Format("2006-01-02"),
 		Numbers:     append([]int(nil), summary.WinningNumbers...),
 		BonusNumber: summary.BonusNumber,
 		HasWinner:   summary.HasWinner(),
-		SummaryText: strings.TrimSpace(summary.ToString()),
+		Warnings:    summary.Warnings,
+		Masked:      masked,
+		SummaryText: strings.TrimSpace(summaryText),
 	}
 
 	if len(summary.Prizes) > 0 {
@@ -186,6 +400,8 @@ type checkResultTemplateData struct {
 	Numbers     []int
 	BonusNumber int
 	HasWinner   bool
+	Warnings    []string
+	Masked      bool
 	Prizes      []checkResultTemplatePrize
 	SummaryText string
 }
@@ -290,6 +506,14 @@ const checkResultTemplateHTML = `<!DOCTYPE html>
       font-weight: 600;
       margin-bottom: 12px;
     }
+    .status-warn {
+      padding: 10px 12px;
+      border-radius: 10px;
+      background: #fffbeb;
+      color: #92400e;
+      font-size: 13px;
+      margin-bottom: 12px;
+    }
 
     /* 당첨금 테이블 */
     .section-title {
@@ -386,6 +610,11 @@ const checkResultTemplateHTML = `<!DOCTYPE html>
           😢 아쉽게도 이번 회차에서는 당첨되지 않았습니다.
         </div>
       {{end}}
+      {{range .Warnings}}
+        <div class="status-warn">
+          ⚠️ {{.}}
+        </div>
+      {{end}}
 
       <!-- 당첨금 정보 -->
       {{if .Prizes}}
@@ -415,6 +644,11 @@ const checkResultTemplateHTML = `<!DOCTYPE html>
       <div class="summary-box">
         {{.SummaryText}}
       </div>
+      {{if .Masked}}
+        <div style="margin-top: 8px; font-size: 12px; color: #6b7280;">
+          🔒 구매 번호는 추첨된 번호만 표시하고 나머지는 *로 가렸습니다.
+        </div>
+      {{end}}
 
       <!-- 푸터 -->
       <div class="footer">
@@ -426,7 +660,13 @@ const checkResultTemplateHTML = `<!DOCTYPE html>
 </body>
 </html>`
 
-func renderBuyEmail(tickets []lottery.PurchasedTicket) (string, error) {
+func renderBuyEmail(tickets []lottery.PurchasedTicket, masked bool) (string, error) {
+	return renderBuyTemplate(tickets, false, masked)
+}
+
+// renderBuyTemplate renders purchased tickets, or a dry-run preview of them;
+// masked leaves out their numbers.
+func renderBuyTemplate(tickets []lottery.PurchasedTicket, preview, masked bool) (string, error) {
 	if len(tickets) == 0 {
 		return "", fmt.Errorf("구매한 티켓이 없습니다")
 	}
@@ -446,6 +686,8 @@ func renderBuyEmail(tickets []lottery.PurchasedTicket) (string, error) {
 		Round:       round,
 		TicketCount: len(tickets),
 		Tickets:     ticketList,
+		Preview:     preview,
+		Masked:      masked,
 	}
 
 	var buf bytes.Buffer
@@ -466,6 +708,8 @@ type buyTemplateData struct {
 	Round       int
 	TicketCount int
 	Tickets     []buyTemplateTicket
+	Preview     bool
+	Masked      bool
 }
 
 var buyTemplate = template.Must(template.New("lotto-buy").Parse(buyTemplateHTML))
@@ -474,7 +718,7 @@ const buyTemplateHTML = `<!DOCTYPE html>
 <html lang="ko">
 <head>
   <meta charset="UTF-8" />
-  <title>로또 {{.Round}}회 구매 완료</title>
+  <title>로또 {{.Round}}회 {{if .Preview}}구매 미리보기{{else}}구매 완료{{end}}</title>
   <style>
     /* 기본 레이아웃 */
     body {
@@ -614,15 +858,25 @@ const buyTemplateHTML = `<!DOCTYPE html>
     <div class="container">
       <!-- 헤더 -->
       <div class="header">
+        {{if .Preview}}
+        <div class="badge">🧪 로또 구매 미리보기 (dry-run)</div>
+        <h1>{{.Round}}회 구매 미리보기</h1>
+        <div class="sub">총 {{.TicketCount}}장 구매 예정</div>
+        {{else}}
         <div class="badge">🎰 로또 자동 구매 완료</div>
         <h1>{{.Round}}회 구매 완료</h1>
         <div class="sub">총 {{.TicketCount}}장 구매</div>
+        {{end}}
       </div>
 
       <!-- 요약 -->
       <div class="summary">
         <div class="summary-text">
+          {{if .Preview}}
+          🧪 실제 구매는 하지 않았습니다. 아래 내용으로 {{.Round}}회 로또 {{.TicketCount}}장이 구매될 예정입니다
+          {{else}}
           ✅ {{.Round}}회 로또 {{.TicketCount}}장 구매가 완료되었습니다
+          {{end}}
         </div>
       </div>
 
@@ -635,8 +889,14 @@ const buyTemplateHTML = `<!DOCTYPE html>
               <span class="mode-badge">{{.Mode}}</span>
             </div>
             <div class="ticket-numbers">
-              {{range .Numbers}}
-                <span class="ball">{{.}}</span>
+              {{if $.Masked}}
+                <span class="mode-badge">🔒 번호 비공개</span>
+              {{else}}
+                {{range .Numbers}}
+                  <span class="ball">{{.}}</span>
+                {{else}}
+                  <span class="mode-badge">구매 시 자동 선택</span>
+                {{end}}
               {{end}}
             </div>
           </div>
@@ -824,3 +1084,794 @@ const failureTemplateHTML = `<!DOCTYPE html>
   </div>
 </body>
 </html>`
+
+func renderBudgetEmail(exceeded *budget.ExceededError) (string, error) {
+	remaining := exceeded.Cap - exceeded.Spent
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	data := budgetTemplateData{
+		Period:    exceeded.Period,
+		From:      exceeded.From.Format("2006-01-02"),
+		To:        exceeded.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		Cap:       fmt.Sprintf("%s원", domainutils.FormatAmount(exceeded.Cap)),
+		Spent:     fmt.Sprintf("%s원", domainutils.FormatAmount(exceeded.Spent)),
+		Remaining: fmt.Sprintf("%s원", domainutils.FormatAmount(remaining)),
+		Amount:    fmt.Sprintf("%s원", domainutils.FormatAmount(exceeded.Amount)),
+	}
+
+	var buf bytes.Buffer
+	if err := budgetTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("예산 초과 템플릿 렌더링 실패: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+type budgetTemplateData struct {
+	Period    string
+	From      string
+	To        string
+	Cap       string
+	Spent     string
+	Remaining string
+	Amount    string
+}
+
+var budgetTemplate = template.Must(template.New("lotto-budget").Parse(budgetTemplateHTML))
+
+const budgetTemplateHTML = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="UTF-8" />
+  <title>로또 {{.Period}} 예산 한도 초과</title>
+  <style>
+    /* 기본 레이아웃 */
+    body {
+      margin: 0;
+      padding: 0;
+      background-color: #f4f4f5;
+      font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Noto Sans KR",
+        "Apple SD Gothic Neo", sans-serif;
+    }
+    .wrapper {
+      width: 100%;
+      padding: 24px 0;
+    }
+    .container {
+      max-width: 600px;
+      margin: 0 auto;
+      background-color: #ffffff;
+      border-radius: 12px;
+      padding: 24px 24px 32px;
+      box-shadow: 0 4px 16px rgba(15, 23, 42, 0.08);
+    }
+
+    /* 헤더 */
+    .header {
+      text-align: center;
+      margin-bottom: 24px;
+    }
+    .badge {
+      display: inline-block;
+      padding: 4px 12px;
+      border-radius: 999px;
+      background: #fef3c7;
+      color: #92400e;
+      font-size: 12px;
+      font-weight: 600;
+      letter-spacing: 0.03em;
+    }
+    h1 {
+      font-size: 22px;
+      margin: 12px 0 4px;
+      color: #111827;
+    }
+    .sub {
+      font-size: 13px;
+      color: #6b7280;
+    }
+
+    /* 예산 테이블 */
+    .budget-table {
+      width: 100%;
+      border-collapse: collapse;
+      margin: 20px 0;
+      font-size: 13px;
+    }
+    .budget-table td {
+      padding: 8px 10px;
+      border-bottom: 1px solid #e5e7eb;
+      text-align: right;
+    }
+    .budget-table td:first-child {
+      text-align: left;
+      color: #6b7280;
+    }
+
+    /* 푸터 */
+    .footer {
+      margin-top: 24px;
+      font-size: 11px;
+      color: #9ca3af;
+      text-align: center;
+      line-height: 1.5;
+    }
+  </style>
+</head>
+<body>
+  <div class="wrapper">
+    <div class="container">
+      <!-- 헤더 -->
+      <div class="header">
+        <div class="badge">⚠️ 예산 한도 초과</div>
+        <h1>{{.Period}} 예산 한도로 구매를 건너뛰었습니다</h1>
+        <div class="sub">{{.From}} ~ {{.To}} 기준</div>
+      </div>
+
+      <!-- 예산 정보 -->
+      <table class="budget-table" role="presentation">
+        <tr><td>{{.Period}} 한도</td><td>{{.Cap}}</td></tr>
+        <tr><td>이미 사용한 금액</td><td>{{.Spent}}</td></tr>
+        <tr><td>남은 금액</td><td>{{.Remaining}}</td></tr>
+        <tr><td>이번 구매 예정 금액</td><td>{{.Amount}}</td></tr>
+      </table>
+
+      <!-- 푸터 -->
+      <div class="footer">
+        이 메일은 로또 자동 구매 기능에 의해 발송되었습니다.<br />
+        예산 한도는 설정 파일의 budget 항목에서 변경할 수 있습니다.
+      </div>
+    </div>
+  </div>
+</body>
+</html>`
+
+func renderTopUpEmail(alert *domain.TopUpAlert) (string, error) {
+	data := topUpTemplateData{
+		Account:        alert.Account,
+		CheckedAt:      alert.CheckedAt.In(domain.Seoul).Format("2006-01-02 15:04"),
+		Deposit:        fmt.Sprintf("%s원", domainutils.FormatAmount(alert.Deposit)),
+		Required:       fmt.Sprintf("%s원", domainutils.FormatAmount(alert.Required)),
+		Shortfall:      fmt.Sprintf("%s원", domainutils.FormatAmount(alert.Shortfall())),
+		VirtualAccount: alert.VirtualAccount,
+	}
+
+	var buf bytes.Buffer
+	if err := topUpTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("예치금 부족 템플릿 렌더링 실패: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+type topUpTemplateData struct {
+	Account        string
+	CheckedAt      string
+	Deposit        string
+	Required       string
+	Shortfall      string
+	VirtualAccount string
+}
+
+var topUpTemplate = template.Must(template.New("lotto-topup").Parse(topUpTemplateHTML))
+
+const topUpTemplateHTML = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="UTF-8" />
+  <title>로또 예치금 부족</title>
+  <style>
+    /* 기본 레이아웃 */
+    body {
+      margin: 0;
+      padding: 0;
+      background-color: #f4f4f5;
+      font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Noto Sans KR",
+        "Apple SD Gothic Neo", sans-serif;
+    }
+    .wrapper {
+      width: 100%;
+      padding: 24px 0;
+    }
+    .container {
+      max-width: 600px;
+      margin: 0 auto;
+      background-color: #ffffff;
+      border-radius: 12px;
+      padding: 24px 24px 32px;
+      box-shadow: 0 4px 16px rgba(15, 23, 42, 0.08);
+    }
+
+    /* 헤더 */
+    .header {
+      text-align: center;
+      margin-bottom: 24px;
+    }
+    .badge {
+      display: inline-block;
+      padding: 4px 12px;
+      border-radius: 999px;
+      background: #fee2e2;
+      color: #991b1b;
+      font-size: 12px;
+      font-weight: 600;
+      letter-spacing: 0.03em;
+    }
+    h1 {
+      font-size: 22px;
+      margin: 12px 0 4px;
+      color: #111827;
+    }
+    .sub {
+      font-size: 13px;
+      color: #6b7280;
+    }
+
+    /* 예치금 테이블 */
+    .topup-table {
+      width: 100%;
+      border-collapse: collapse;
+      margin: 20px 0;
+      font-size: 13px;
+    }
+    .topup-table td {
+      padding: 8px 10px;
+      border-bottom: 1px solid #e5e7eb;
+      text-align: right;
+    }
+    .topup-table td:first-child {
+      text-align: left;
+      color: #6b7280;
+    }
+
+    /* 입금 계좌 */
+    .account-box {
+      padding: 12px 16px;
+      border-radius: 8px;
+      background: #f9fafb;
+      font-size: 14px;
+      text-align: center;
+      color: #111827;
+    }
+
+    /* 푸터 */
+    .footer {
+      margin-top: 24px;
+      font-size: 11px;
+      color: #9ca3af;
+      text-align: center;
+      line-height: 1.5;
+    }
+  </style>
+</head>
+<body>
+  <div class="wrapper">
+    <div class="container">
+      <!-- 헤더 -->
+      <div class="header">
+        <div class="badge">💸 예치금 부족</div>
+        <h1>[{{.Account}}] 예치금이 부족해 구매를 건너뛰었습니다</h1>
+        <div class="sub">{{.CheckedAt}} 기준</div>
+      </div>
+
+      <!-- 예치금 정보 -->
+      <table class="topup-table" role="presentation">
+        <tr><td>현재 예치금</td><td>{{.Deposit}}</td></tr>
+        <tr><td>이번 구매 예정 금액</td><td>{{.Required}}</td></tr>
+        <tr><td>충전 필요 금액</td><td>{{.Shortfall}}</td></tr>
+      </table>
+
+      <!-- 입금 계좌 -->
+      <div class="account-box">
+        {{if .VirtualAccount}}입금전용 가상계좌: <strong>{{.VirtualAccount}}</strong>{{else}}동행복권 마이페이지의 예치금 충전 메뉴에서 입금 계좌를 확인하세요.{{end}}
+      </div>
+
+      <!-- 푸터 -->
+      <div class="footer">
+        이 메일은 로또 자동 구매 기능에 의해 발송되었습니다.<br />
+        충전 후 다음 예약 구매부터 다시 구매합니다.
+      </div>
+    </div>
+  </div>
+</body>
+</html>`
+
+func renderRotationEmail(reminder *domain.RotationReminder) (string, error) {
+	data := rotationTemplateData{
+		MaxAgeDays: reminder.MaxAgeDays,
+		CheckedAt:  reminder.CheckedAt.In(domain.Seoul).Format("2006-01-02 15:04"),
+	}
+	for _, credential := range reminder.Credentials {
+		data.Credentials = append(data.Credentials, rotationTemplateCredential{
+			Label: credential.Label,
+			Since: credential.Since.In(domain.Seoul).Format("2006-01-02"),
+			Days:  credential.Days(reminder.CheckedAt),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := rotationTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("비밀번호 교체 알림 템플릿 렌더링 실패: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+type rotationTemplateCredential struct {
+	Label string
+	Since string
+	Days  int
+}
+
+type rotationTemplateData struct {
+	MaxAgeDays  int
+	CheckedAt   string
+	Credentials []rotationTemplateCredential
+}
+
+var rotationTemplate = template.Must(template.New("lotto-rotation").Parse(rotationTemplateHTML))
+
+const rotationTemplateHTML = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="UTF-8" />
+  <title>비밀번호 교체 알림</title>
+  <style>
+    /* 기본 레이아웃 */
+    body {
+      margin: 0;
+      padding: 0;
+      background-color: #f4f4f5;
+      font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Noto Sans KR",
+        "Apple SD Gothic Neo", sans-serif;
+    }
+    .wrapper {
+      width: 100%;
+      padding: 24px 0;
+    }
+    .container {
+      max-width: 600px;
+      margin: 0 auto;
+      background-color: #ffffff;
+      border-radius: 12px;
+      padding: 24px 24px 32px;
+      box-shadow: 0 4px 16px rgba(15, 23, 42, 0.08);
+    }
+
+    /* 헤더 */
+    .header {
+      text-align: center;
+      margin-bottom: 24px;
+    }
+    .badge {
+      display: inline-block;
+      padding: 4px 12px;
+      border-radius: 999px;
+      background: #fef3c7;
+      color: #92400e;
+      font-size: 12px;
+      font-weight: 600;
+      letter-spacing: 0.03em;
+    }
+    h1 {
+      font-size: 22px;
+      margin: 12px 0 4px;
+      color: #111827;
+    }
+    .sub {
+      font-size: 13px;
+      color: #6b7280;
+    }
+
+    /* 비밀번호 테이블 */
+    .rotation-table {
+      width: 100%;
+      border-collapse: collapse;
+      margin: 20px 0;
+      font-size: 13px;
+    }
+    .rotation-table th,
+    .rotation-table td {
+      padding: 8px 10px;
+      border-bottom: 1px solid #e5e7eb;
+      text-align: right;
+    }
+    .rotation-table th {
+      color: #6b7280;
+      font-weight: 600;
+    }
+    .rotation-table th:first-child,
+    .rotation-table td:first-child {
+      text-align: left;
+    }
+
+    /* 안내 */
+    .notice-box {
+      margin: 20px 0;
+      padding: 16px;
+      background: #fffbeb;
+      border-radius: 8px;
+      border-left: 4px solid #f59e0b;
+    }
+    .notice-title {
+      font-size: 14px;
+      font-weight: 600;
+      color: #92400e;
+      margin-bottom: 8px;
+    }
+    .notice-text {
+      font-size: 13px;
+      color: #78350f;
+      line-height: 1.6;
+    }
+
+    /* 푸터 */
+    .footer {
+      margin-top: 24px;
+      font-size: 11px;
+      color: #9ca3af;
+      text-align: center;
+      line-height: 1.5;
+    }
+  </style>
+</head>
+<body>
+  <div class="wrapper">
+    <div class="container">
+      <!-- 헤더 -->
+      <div class="header">
+        <div class="badge">🔑 비밀번호 교체</div>
+        <h1>{{.MaxAgeDays}}일 넘게 쓴 비밀번호가 있습니다</h1>
+        <div class="sub">{{.CheckedAt}} 기준</div>
+      </div>
+
+      <!-- 비밀번호 목록 -->
+      <table class="rotation-table" role="presentation">
+        <thead>
+          <tr><th>비밀번호</th><th>사용 시작</th><th>사용 기간</th></tr>
+        </thead>
+        <tbody>
+          {{range .Credentials}}
+            <tr><td>{{.Label}}</td><td>{{.Since}}</td><td>{{.Days}}일</td></tr>
+          {{end}}
+        </tbody>
+      </table>
+
+      <!-- 안내 -->
+      <div class="notice-box">
+        <div class="notice-title">⚠️ 교체 방법</div>
+        <div class="notice-text">
+          동행복권 사이트 또는 메일 서비스에서 비밀번호를 바꾼 뒤 설정(또는 시크릿)의 값을 함께 바꾸고,
+          <strong>weekly-lotto doctor</strong>로 새 비밀번호의 로그인과 SMTP 인증을 확인하세요.
+          새 비밀번호가 확인되면 사용 기간을 다시 셉니다.
+        </div>
+      </div>
+
+      <!-- 푸터 -->
+      <div class="footer">
+        이 메일은 로또 자동 구매 기능에 의해 발송되었습니다.<br />
+        비밀번호를 바꾸기 전까지 매주 다시 알려 드립니다.
+      </div>
+    </div>
+  </div>
+</body>
+</html>`
+
+func renderBalanceEmail(snapshot *domain.BalanceSnapshot) (string, error) {
+	data := balanceTemplateData{
+		Account:        snapshot.Account,
+		CheckedAt:      snapshot.CheckedAt.In(domain.Seoul).Format("2006-01-02 15:04"),
+		Deposit:        fmt.Sprintf("%s원", domainutils.FormatAmount(snapshot.Deposit)),
+		Round:          snapshot.Round,
+		RoundTickets:   snapshot.RoundTickets,
+		UnclaimedPrize: fmt.Sprintf("%s원", domainutils.FormatAmount(snapshot.UnclaimedPrize)),
+	}
+
+	var buf bytes.Buffer
+	if err := balanceTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("잔액 템플릿 렌더링 실패: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+type balanceTemplateData struct {
+	Account        string
+	CheckedAt      string
+	Deposit        string
+	Round          int
+	RoundTickets   int
+	UnclaimedPrize string
+}
+
+var balanceTemplate = template.Must(template.New("lotto-balance").Parse(balanceTemplateHTML))
+
+const balanceTemplateHTML = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="UTF-8" />
+  <title>로또 계정 잔액</title>
+  <style>
+    /* 기본 레이아웃 */
+    body {
+      margin: 0;
+      padding: 0;
+      background-color: #f4f4f5;
+      font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Noto Sans KR",
+        "Apple SD Gothic Neo", sans-serif;
+    }
+    .wrapper {
+      width: 100%;
+      padding: 24px 0;
+    }
+    .container {
+      max-width: 600px;
+      margin: 0 auto;
+      background-color: #ffffff;
+      border-radius: 12px;
+      padding: 24px 24px 32px;
+      box-shadow: 0 4px 16px rgba(15, 23, 42, 0.08);
+    }
+
+    /* 헤더 */
+    .header {
+      text-align: center;
+      margin-bottom: 24px;
+    }
+    .badge {
+      display: inline-block;
+      padding: 4px 12px;
+      border-radius: 999px;
+      background: #dcfce7;
+      color: #166534;
+      font-size: 12px;
+      font-weight: 600;
+      letter-spacing: 0.03em;
+    }
+    h1 {
+      font-size: 22px;
+      margin: 12px 0 4px;
+      color: #111827;
+    }
+    .sub {
+      font-size: 13px;
+      color: #6b7280;
+    }
+
+    /* 잔액 테이블 */
+    .balance-table {
+      width: 100%;
+      border-collapse: collapse;
+      margin: 20px 0;
+      font-size: 13px;
+    }
+    .balance-table td {
+      padding: 8px 10px;
+      border-bottom: 1px solid #e5e7eb;
+      text-align: right;
+    }
+    .balance-table td:first-child {
+      text-align: left;
+      color: #6b7280;
+    }
+
+    /* 푸터 */
+    .footer {
+      margin-top: 24px;
+      font-size: 11px;
+      color: #9ca3af;
+      text-align: center;
+      line-height: 1.5;
+    }
+  </style>
+</head>
+<body>
+  <div class="wrapper">
+    <div class="container">
+      <!-- 헤더 -->
+      <div class="header">
+        <div class="badge">💰 계정 잔액</div>
+        <h1>예치금 {{.Deposit}}</h1>
+        <div class="sub">{{.Account}} · {{.CheckedAt}} 기준</div>
+      </div>
+
+      <!-- 잔액 정보 -->
+      <table class="balance-table" role="presentation">
+        <tr><td>예치금</td><td>{{.Deposit}}</td></tr>
+        <tr><td>{{.Round}}회 구매</td><td>{{.RoundTickets}}장</td></tr>
+        <tr><td>미수령 당첨금</td><td>{{.UnclaimedPrize}}</td></tr>
+      </table>
+
+      <!-- 푸터 -->
+      <div class="footer">
+        이 메일은 로또 자동화 시스템에 의해 발송되었습니다.<br />
+        본 메일은 발신 전용이며 회신이 되지 않습니다.
+      </div>
+    </div>
+  </div>
+</body>
+</html>`
+
+func renderReportEmail(report *domain.Report) (string, error) {
+	data := reportTemplateData{
+		Title:  report.Title,
+		Period: fmt.Sprintf("%s ~ %s", report.From.Format("2006-01-02"), report.To.AddDate(0, 0, -1).Format("2006-01-02")),
+		Total:  newReportRowData(report.Total),
+	}
+	if len(report.Rows) > 1 {
+		for _, row := range report.Rows {
+			data.Rows = append(data.Rows, newReportRowData(row))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("리포트 템플릿 렌더링 실패: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func newReportRowData(row domain.ReportRow) reportRowData {
+	return reportRowData{
+		Label:    row.Label,
+		Tickets:  row.Tickets,
+		Pending:  row.PendingTickets,
+		Spent:    fmt.Sprintf("%s원", domainutils.FormatAmount(row.Spent)),
+		Winnings: fmt.Sprintf("%s원", domainutils.FormatAmount(row.Winnings)),
+		ROI:      fmt.Sprintf("%.1f%%", row.ROI()),
+		Ranks:    row.RankSummary(),
+	}
+}
+
+type reportTemplateData struct {
+	Title  string
+	Period string
+	Total  reportRowData
+	Rows   []reportRowData
+}
+
+type reportRowData struct {
+	Label    string
+	Tickets  int
+	Pending  int
+	Spent    string
+	Winnings string
+	ROI      string
+	Ranks    string
+}
+
+var reportTemplate = template.Must(template.New("lotto-report").Parse(reportTemplateHTML))
+
+const reportTemplateHTML = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="UTF-8" />
+  <title>로또 리포트</title>
+  <style>
+    /* 기본 레이아웃 */
+    body {
+      margin: 0;
+      padding: 0;
+      background-color: #f4f4f5;
+      font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Noto Sans KR",
+        "Apple SD Gothic Neo", sans-serif;
+    }
+    .wrapper {
+      width: 100%;
+      padding: 24px 0;
+    }
+    .container {
+      max-width: 600px;
+      margin: 0 auto;
+      background-color: #ffffff;
+      border-radius: 12px;
+      padding: 24px 24px 32px;
+      box-shadow: 0 4px 16px rgba(15, 23, 42, 0.08);
+    }
+
+    /* 헤더 */
+    .header {
+      text-align: center;
+      margin-bottom: 24px;
+    }
+    .badge {
+      display: inline-block;
+      padding: 4px 12px;
+      border-radius: 999px;
+      background: #e0e7ff;
+      color: #3730a3;
+      font-size: 12px;
+      font-weight: 600;
+      letter-spacing: 0.03em;
+    }
+    h1 {
+      font-size: 22px;
+      margin: 12px 0 4px;
+      color: #111827;
+    }
+    .sub {
+      font-size: 13px;
+      color: #6b7280;
+    }
+
+    /* 요약/상세 테이블 */
+    .report-table {
+      width: 100%;
+      border-collapse: collapse;
+      margin: 20px 0;
+      font-size: 13px;
+    }
+    .report-table th {
+      padding: 8px 10px;
+      background-color: #f9fafb;
+      color: #374151;
+      font-weight: 600;
+      text-align: right;
+      border-bottom: 1px solid #e5e7eb;
+    }
+    .report-table td {
+      padding: 8px 10px;
+      border-bottom: 1px solid #e5e7eb;
+      text-align: right;
+    }
+    .report-table th:first-child,
+    .report-table td:first-child {
+      text-align: left;
+      color: #6b7280;
+    }
+
+    /* 푸터 */
+    .footer {
+      margin-top: 24px;
+      font-size: 11px;
+      color: #9ca3af;
+      text-align: center;
+      line-height: 1.5;
+    }
+  </style>
+</head>
+<body>
+  <div class="wrapper">
+    <div class="container">
+      <!-- 헤더 -->
+      <div class="header">
+        <div class="badge">📒 로또 리포트</div>
+        <h1>{{.Title}} 수익률 {{.Total.ROI}}</h1>
+        <div class="sub">{{.Period}}</div>
+      </div>
+
+      <!-- 요약 -->
+      <table class="report-table" role="presentation">
+        <tr><td>구매</td><td>{{.Total.Tickets}}장{{if .Total.Pending}} (미추첨 {{.Total.Pending}}장){{end}}</td></tr>
+        <tr><td>지출</td><td>{{.Total.Spent}}</td></tr>
+        <tr><td>당첨금</td><td>{{.Total.Winnings}}</td></tr>
+        <tr><td>수익률</td><td>{{.Total.ROI}}</td></tr>
+        <tr><td>당첨</td><td>{{.Total.Ranks}}</td></tr>
+      </table>
+
+      {{if .Rows}}
+      <!-- 상세 -->
+      <table class="report-table">
+        <thead>
+          <tr><th>구분</th><th>구매</th><th>지출</th><th>당첨금</th><th>수익률</th></tr>
+        </thead>
+        <tbody>
+          {{range .Rows}}
+          <tr><td>{{.Label}}</td><td>{{.Tickets}}장</td><td>{{.Spent}}</td><td>{{.Winnings}}</td><td>{{.ROI}}</td></tr>
+          {{end}}
+        </tbody>
+      </table>
+      {{end}}
+
+      <!-- 푸터 -->
+      <div class="footer">
+        이 메일은 로또 자동화 시스템에 의해 발송되었습니다.<br />
+        본 메일은 발신 전용이며 회신이 되지 않습니다.
+      </div>
+    </div>
+  </div>
+</body>
+</html>`
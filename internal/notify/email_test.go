@@ -0,0 +1,216 @@
+package notify_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/lottery"
+	"weekly-lotto/internal/notify"
+	"weekly-lotto/internal/notify/notifytest"
+)
+
+var tickets = []lottery.PurchasedTicket{
+	{Round: 1245, Slot: "A", Mode: "자동", Numbers: []int{1, 5, 22, 31, 33, 42}},
+	{Round: 1245, Slot: "B", Mode: "수동", Numbers: []int{3, 9, 17, 26, 38, 44}},
+}
+
+// newSender returns a sender for routes that delivers to a fake transport.
+func newSender(routes ...config.RouteConfig) (*notify.EmailSender, *notifytest.Transport) {
+	transport := &notifytest.Transport{}
+	email := &config.EmailConfig{From: "lotto@example.com", To: []string{"fallback@example.com"}}
+	sender := notify.NewEmailSender(email, &config.NotificationsConfig{Routes: routes}).WithTransport(transport)
+	return sender, transport
+}
+
+func TestRoutesNotificationsPerAccount(t *testing.T) {
+	sender, transport := newSender(
+		config.RouteConfig{Accounts: []string{"alice"}, To: []string{"alice@example.com"}},
+		config.RouteConfig{Accounts: []string{"bob"}, To: []string{"bob@example.com"}},
+		config.RouteConfig{Events: []string{config.EventFailure}, Accounts: []string{"*"}, To: []string{"ops@example.com"}},
+	)
+
+	tests := []struct {
+		account string
+		send    func(*notify.EmailSender) error
+		to      []string
+		subject string
+	}{
+		{"alice", func(s *notify.EmailSender) error { return s.SendLotteryBuyMail(tickets) }, []string{"alice@example.com"}, "(alice)"},
+		{"bob", func(s *notify.EmailSender) error { return s.SendLotteryBuyMail(tickets) }, []string{"bob@example.com"}, "(bob)"},
+		{"bob", func(s *notify.EmailSender) error { return s.SendFailureNotification("구매", "boom") },
+			[]string{"bob@example.com", "ops@example.com"}, "(bob)"},
+		// 로그인 전 실패처럼 계정을 모르면 와일드카드 경로만 받음
+		{"", func(s *notify.EmailSender) error { return s.SendFailureNotification("로그인", "boom") },
+			[]string{"ops@example.com"}, "로그인 실패"},
+	}
+	for _, tt := range tests {
+		transport.Reset()
+		if err := tt.send(sender.ForAccount(tt.account)); err != nil {
+			t.Fatalf("send for %q: %v", tt.account, err)
+		}
+		mails := transport.Mails()
+		if len(mails) != 1 {
+			t.Fatalf("sent %d emails for %q, want 1", len(mails), tt.account)
+		}
+		if !slices.Equal(mails[0].To, tt.to) {
+			t.Errorf("email for %q went to %v, want %v", tt.account, mails[0].To, tt.to)
+		}
+		if !strings.HasSuffix(mails[0].Subject, tt.subject) {
+			t.Errorf("subject for %q = %q, want it to end with %q", tt.account, mails[0].Subject, tt.subject)
+		}
+	}
+}
+
+func TestMaskedRoutesDoNotSeeNumbers(t *testing.T) {
+	sender, transport := newSender(
+		config.RouteConfig{To: []string{"owner@example.com"}},
+		config.RouteConfig{Events: []string{config.EventBuy, config.EventFailure}, To: []string{"family@example.com"}, Mask: true},
+	)
+
+	if err := sender.SendLotteryBuyMail(tickets); err != nil {
+		t.Fatal(err)
+	}
+	mails := transport.Mails()
+	if len(mails) != 2 {
+		t.Fatalf("sent %d emails, want one full and one masked", len(mails))
+	}
+	full, masked := mails[0], mails[1]
+	if !slices.Equal(full.To, []string{"owner@example.com"}) || !slices.Equal(masked.To, []string{"family@example.com"}) {
+		t.Fatalf("emails went to %v and %v, want owner then family", full.To, masked.To)
+	}
+	if !strings.Contains(full.Body, `class="ball">22<`) {
+		t.Error("full email does not show the numbers")
+	}
+	if strings.Contains(masked.Body, `class="ball"`) || !strings.Contains(masked.Body, "번호 비공개") {
+		t.Error("masked email shows the numbers")
+	}
+	if masked.Subject != full.Subject {
+		t.Errorf("masked subject = %q, want %q", masked.Subject, full.Subject)
+	}
+
+	// 번호가 없는 알림은 가릴 것이 없으므로 한 통으로 보냄
+	transport.Reset()
+	if err := sender.SendFailureNotification("구매", "boom"); err != nil {
+		t.Fatal(err)
+	}
+	if mails := transport.Mails(); len(mails) != 1 || len(mails[0].To) != 2 {
+		t.Errorf("failure notification went out as %d emails, want one to both", len(mails))
+	}
+}
+
+func TestOutboxKeepsUndeliveredNotifications(t *testing.T) {
+	sender, transport := newSender(config.RouteConfig{To: []string{"alice@example.com"}})
+	outbox := &notifytest.Outbox{Limit: 1}
+	down := sender.WithTransport(&notifytest.Transport{Err: errors.New("smtp down")}).WithOutbox(outbox).ForAccount("alice")
+
+	if err := down.SendLotteryBuyMail(tickets); err != nil {
+		t.Fatalf("SendLotteryBuyMail with an outbox = %v, want it queued", err)
+	}
+	queued := outbox.Messages()
+	if len(queued) != 1 {
+		t.Fatalf("queued %d messages, want 1", len(queued))
+	}
+	if queued[0].Event != config.EventBuy || queued[0].Account != "alice" || queued[0].Queued.IsZero() {
+		t.Errorf("queued %s message of %q at %s, want a buy message of alice", queued[0].Event, queued[0].Account, queued[0].Queued)
+	}
+
+	// 보관함이 가득 차면 실패를 그대로 돌려줌
+	if err := down.SendFailureNotification("구매", "boom"); !errors.Is(err, notify.ErrDelivery) {
+		t.Errorf("send to a full outbox = %v, want ErrDelivery", err)
+	}
+
+	if err := sender.Resend(queued[0]); err != nil {
+		t.Fatalf("Resend: %v", err)
+	}
+	mail := transport.Last()
+	if !slices.Equal(mail.To, []string{"alice@example.com"}) || !strings.HasSuffix(mail.Subject, "(alice)") {
+		t.Errorf("resent email = %v %q, want alice's", mail.To, mail.Subject)
+	}
+	if err := down.Resend(queued[0]); !errors.Is(err, notify.ErrDelivery) {
+		t.Errorf("Resend through a failing transport = %v, want ErrDelivery", err)
+	}
+	if len(outbox.Messages()) != 1 {
+		t.Error("Resend queued the message again")
+	}
+}
+
+// refusing fails every email to one recipient and delivers the others.
+type refusing struct {
+	*notifytest.Transport
+	to string
+}
+
+func (r refusing) Send(ctx context.Context, from string, to []string, message []byte) error {
+	if slices.Contains(to, r.to) {
+		return errors.New("mailbox unavailable")
+	}
+	return r.Transport.Send(ctx, from, to, message)
+}
+
+func TestFailedMaskedPartIsQueuedAlone(t *testing.T) {
+	sender, transport := newSender(
+		config.RouteConfig{To: []string{"owner@example.com"}},
+		config.RouteConfig{To: []string{"family@example.com"}, Mask: true},
+	)
+	outbox := &notifytest.Outbox{}
+
+	err := sender.WithTransport(refusing{transport, "family@example.com"}).WithOutbox(outbox).SendLotteryBuyMail(tickets)
+	if err != nil {
+		t.Fatalf("SendLotteryBuyMail with an outbox = %v, want the failed part queued", err)
+	}
+	if mails := transport.Mails(); len(mails) != 1 || !slices.Equal(mails[0].To, []string{"owner@example.com"}) {
+		t.Fatalf("sent %d emails, want only the owner's", len(mails))
+	}
+	queued := outbox.Messages()
+	if len(queued) != 1 || queued[0].Part != notify.PartMasked {
+		t.Fatalf("queued %d messages, want only the masked part", len(queued))
+	}
+	if strings.Contains(queued[0].Body+queued[0].MaskedBody, `class="ball"`) {
+		t.Error("queued masked part holds the numbers")
+	}
+
+	// 다시 보낼 때는 가린 본문만 가족에게 감
+	transport.Reset()
+	if err := sender.Resend(queued[0]); err != nil {
+		t.Fatalf("Resend: %v", err)
+	}
+	mails := transport.Mails()
+	if len(mails) != 1 || !slices.Equal(mails[0].To, []string{"family@example.com"}) {
+		t.Fatalf("resent %d emails, want only the family's", len(mails))
+	}
+	if !strings.Contains(mails[0].Body, "번호 비공개") {
+		t.Error("resent email is not the masked one")
+	}
+}
+
+func TestPurchaseConfirmationGoesToOwnersOnly(t *testing.T) {
+	sender, transport := newSender(
+		config.RouteConfig{Accounts: []string{"alice"}, To: []string{"alice@example.com"}},
+		config.RouteConfig{Accounts: []string{"bob"}, To: []string{"bob@example.com", "alice@example.com"}},
+		config.RouteConfig{To: []string{"family@example.com"}, Mask: true},
+	)
+	expires := time.Date(2026, 10, 17, 10, 5, 0, 0, domain.Seoul)
+
+	if err := sender.SendPurchaseConfirmation("042917", []string{"alice", "bob"}, 1245, 4000, expires); err != nil {
+		t.Fatal(err)
+	}
+	mails := transport.Mails()
+	if len(mails) != 1 || !slices.Equal(mails[0].To, []string{"alice@example.com", "bob@example.com"}) {
+		t.Fatalf("confirmation went out as %d emails, want one to alice and bob", len(mails))
+	}
+	if !strings.Contains(mails[0].Body, "042917") || !strings.Contains(mails[0].Body, "10:05") {
+		t.Errorf("confirmation body = %q, want the code and its expiry", mails[0].Body)
+	}
+
+	// 가린 경로만 있으면 코드를 받을 사람이 없음
+	masked, _ := newSender(config.RouteConfig{To: []string{"family@example.com"}, Mask: true})
+	if err := masked.SendPurchaseConfirmation("042917", []string{"alice"}, 1245, 1000, expires); !errors.Is(err, notify.ErrDelivery) {
+		t.Errorf("confirmation without an owner = %v, want ErrDelivery", err)
+	}
+}
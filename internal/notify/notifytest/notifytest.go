@@ -0,0 +1,138 @@
+// Package notifytest provides fakes for the notify package, so the content
+// and routing of notifications can be checked without an SMTP server:
+//
+//	transport := &notifytest.Transport{}
+//	sender := notify.NewEmailSender(&cfg.Email, &cfg.Notifications).WithTransport(transport)
+//	sender.SendLotteryBuyMail(tickets)
+//	mail := transport.Last() // mail.To, mail.Subject, mail.Body
+package notifytest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"sync"
+
+	"weekly-lotto/internal/notify"
+)
+
+// Mail is an email handed to a Transport, with its headers parsed.
+type Mail struct {
+	From        string
+	To          []string
+	Subject     string
+	ContentType string
+	Body        string
+	// Raw is the message as the sender composed it.
+	Raw []byte
+}
+
+// Transport is a notify.Transport that keeps the emails it is given instead
+// of sending them.
+type Transport struct {
+	// Err, when set, fails every Send and Verify without keeping the email.
+	Err error
+
+	mu    sync.Mutex
+	mails []Mail
+}
+
+var _ notify.Transport = (*Transport)(nil)
+
+// Send implements notify.Transport.
+func (t *Transport) Send(ctx context.Context, from string, to []string, message []byte) error {
+	if t.Err != nil {
+		return t.Err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	parsed, err := mail.ReadMessage(bytes.NewReader(message))
+	if err != nil {
+		return fmt.Errorf("메시지 형식 오류: %w", err)
+	}
+	body, err := io.ReadAll(parsed.Body)
+	if err != nil {
+		return fmt.Errorf("메시지 본문 읽기 실패: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mails = append(t.mails, Mail{
+		From:        from,
+		To:          append([]string(nil), to...),
+		Subject:     parsed.Header.Get("Subject"),
+		ContentType: parsed.Header.Get("Content-Type"),
+		Body:        string(body),
+		Raw:         append([]byte(nil), message...),
+	})
+	return nil
+}
+
+// Verify implements notify.Transport.
+func (t *Transport) Verify(ctx context.Context) error {
+	if t.Err != nil {
+		return t.Err
+	}
+	return ctx.Err()
+}
+
+// Mails returns the emails sent so far, oldest first.
+func (t *Transport) Mails() []Mail {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Mail(nil), t.mails...)
+}
+
+// Last returns the latest email, or the zero Mail when none was sent.
+func (t *Transport) Last() Mail {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.mails) == 0 {
+		return Mail{}
+	}
+	return t.mails[len(t.mails)-1]
+}
+
+// Reset forgets the emails sent so far.
+func (t *Transport) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mails = nil
+}
+
+// ErrOutboxFull is what a full Outbox fails Enqueue with.
+var ErrOutboxFull = errors.New("보관함이 가득 찼습니다")
+
+// Outbox is a notify.Outbox that keeps queued messages in memory.
+type Outbox struct {
+	// Limit, when positive, is how many messages fit before Enqueue fails
+	// with ErrOutboxFull.
+	Limit int
+
+	mu       sync.Mutex
+	messages []notify.Message
+}
+
+var _ notify.Outbox = (*Outbox)(nil)
+
+// Enqueue implements notify.Outbox.
+func (o *Outbox) Enqueue(message notify.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.Limit > 0 && len(o.messages) >= o.Limit {
+		return ErrOutboxFull
+	}
+	o.messages = append(o.messages, message)
+	return nil
+}
+
+// Messages returns the queued messages, oldest first.
+func (o *Outbox) Messages() []notify.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]notify.Message(nil), o.messages...)
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"weekly-lotto/internal/logging"
+)
+
+// Message is a rendered notification that could not be delivered yet.
+type Message struct {
+	Event   string `json:"event"`
+	Account string `json:"account,omitempty"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	// MaskedBody is sent instead of Body to the recipients of masked
+	// routes; it is empty when the notification has no numbers to hide.
+	MaskedBody string `json:"maskedBody,omitempty"`
+	// Part, when set, limits the message to the recipients of unmasked
+	// (PartFull) or masked (PartMasked) routes: the other part is delivered
+	// or queued on its own.
+	Part        string    `json:"part,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	Queued      time.Time `json:"queued"`
+}
+
+// Message parts of a notification with a masked body.
+const (
+	PartFull   = "full"
+	PartMasked = "masked"
+)
+
+// Outbox keeps notifications that failed to be delivered, so they can be
+// sent again later with Resend.
+type Outbox interface {
+	Enqueue(message Message) error
+}
+
+// WithOutbox returns a sender that queues notifications it fails to deliver
+// in outbox instead of returning an error. Notifications that cannot be
+// queued either still fail.
+func (s *EmailSender) WithOutbox(outbox Outbox) *EmailSender {
+	clone := *s
+	clone.outbox = outbox
+	return &clone
+}
+
+// Resend delivers a queued message again. A failure is returned rather than
+// queued once more, so the caller decides whether to keep the message.
+func (s *EmailSender) Resend(message Message) error {
+	sender := s.ForAccount(message.Account)
+	if err := sender.deliver(message); err != nil {
+		return fmt.Errorf("%w: %w", ErrDelivery, err)
+	}
+	return nil
+}
+
+// enqueue queues a message whose delivery failed with cause, reporting
+// whether it was queued.
+func (s *EmailSender) enqueue(message Message, cause error) bool {
+	if s.outbox == nil {
+		return false
+	}
+	message.Queued = time.Now()
+	if err := s.outbox.Enqueue(message); err != nil {
+		logging.Errorf("❌ [%s/%s] 전송하지 못한 알림을 보관하지 못했습니다: %v", message.Event, s.account, err)
+		return false
+	}
+	logging.Warnf("⚠️  [%s/%s] 알림 전송 실패 - 나중에 다시 보냅니다: %v", message.Event, s.account, cause)
+	return true
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"weekly-lotto/internal/config"
+)
+
+// Router decides who receives a notification for an (event, account) pair.
+type Router struct {
+	routes   []config.RouteConfig
+	fallback []string
+}
+
+// NewRouter builds a router from the notification routes. When no routes are
+// configured every event goes to the fallback recipients.
+func NewRouter(notifications *config.NotificationsConfig, fallback []string) *Router {
+	var routes []config.RouteConfig
+	if notifications != nil {
+		routes = notifications.Routes
+	}
+	return &Router{routes: routes, fallback: fallback}
+}
+
+// Recipients returns the deduplicated recipients for event on account,
+// split by whether they get the full notification or a masked one without
+// the played numbers. A recipient of any masked route is masked.
+// An empty account (e.g. a failure before login) only matches wildcard routes.
+func (r *Router) Recipients(event, account string) (recipients, masked []string) {
+	if len(r.routes) == 0 {
+		return r.fallback, nil
+	}
+
+	hidden := make(map[string]bool)
+	var order []string
+	for _, route := range r.routes {
+		if !matches(route.Events, event) || !matchesAccount(route.Accounts, account) {
+			continue
+		}
+		for _, to := range route.To {
+			if _, seen := hidden[to]; !seen {
+				order = append(order, to)
+			}
+			hidden[to] = hidden[to] || route.Mask
+		}
+	}
+	for _, to := range order {
+		if hidden[to] {
+			masked = append(masked, to)
+		} else {
+			recipients = append(recipients, to)
+		}
+	}
+	return recipients, masked
+}
+
+func matches(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAccount(patterns []string, account string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if pattern == "*" || (account != "" && pattern == account) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"weekly-lotto/internal/config"
+)
+
+// Transport delivers composed emails. EmailSender renders and routes
+// notifications and hands the result to its transport, so the content and
+// recipients can be checked with a fake transport (see notifytest).
+type Transport interface {
+	// Send delivers message, a complete email with headers, from from to
+	// every address in to, giving up when ctx ends.
+	Send(ctx context.Context, from string, to []string, message []byte) error
+	// Verify checks that the transport could deliver, without sending mail.
+	Verify(ctx context.Context) error
+}
+
+// smtpTimeout bounds a whole SMTP session, so a wedged server cannot hold a
+// notification - or the run waiting for it - for longer.
+const smtpTimeout = time.Minute
+
+// SMTPTransport delivers emails through the configured SMTP server.
+type SMTPTransport struct {
+	cfg *config.EmailConfig
+}
+
+// NewSMTPTransport creates a transport for the SMTP server of cfg.
+func NewSMTPTransport(cfg *config.EmailConfig) *SMTPTransport {
+	return &SMTPTransport{cfg: cfg}
+}
+
+// Send implements Transport.
+func (t *SMTPTransport) Send(ctx context.Context, from string, to []string, message []byte) (err error) {
+	defer func() { err = ended(ctx, err) }()
+	client, err := t.dial(ctx, false)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err = client.Mail(from); err != nil {
+		return fmt.Errorf("MAIL FROM 실패: %w", err)
+	}
+	for _, rcpt := range to {
+		if err = client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("RCPT TO 실패 (%s): %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA 명령 실패: %w", err)
+	}
+	_, err = w.Write(message)
+	if err != nil {
+		return fmt.Errorf("메시지 쓰기 실패: %w", err)
+	}
+	err = w.Close()
+	if err != nil {
+		return fmt.Errorf("메시지 종료 실패: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// Verify connects and authenticates to the SMTP server without sending mail.
+func (t *SMTPTransport) Verify(ctx context.Context) (err error) {
+	defer func() { err = ended(ctx, err) }()
+	client, err := t.dial(ctx, true)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.Quit()
+}
+
+// dial opens an SMTP session that ends with ctx or after smtpTimeout.
+// Port 465 uses implicit TLS; other ports upgrade with STARTTLS when the
+// server offers it. The session authenticates on port 465, when
+// requireAuth is set or when the server offers AUTH.
+func (t *SMTPTransport) dial(ctx context.Context, requireAuth bool) (*smtp.Client, error) {
+	addr := fmt.Sprintf("%s:%d", t.cfg.SMTPHost, t.cfg.SMTPPort)
+	tlsConfig := &tls.Config{
+		ServerName:         t.cfg.SMTPHost,
+		InsecureSkipVerify: false, // 프로덕션: 인증서 검증 필수
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+
+	// AIDEV-NOTE: 포트 465 (implicit TLS) 지원
+	// 포트 465는 연결 시작부터 TLS가 필요하므로 직접 TLS 다이얼 후 SMTP 통신
+	var raw net.Conn
+	var err error
+	if t.cfg.SMTPPort == 465 {
+		raw, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("TLS 연결 실패: %w", err)
+		}
+	} else {
+		raw, err = dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("SMTP 연결 실패: %w", err)
+		}
+	}
+	raw.SetDeadline(deadline)
+	conn := &ctxConn{Conn: raw, stop: context.AfterFunc(ctx, func() { raw.Close() })}
+
+	client, err := smtp.NewClient(conn, t.cfg.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("SMTP 클라이언트 생성 실패: %w", err)
+	}
+	if t.cfg.SMTPPort != 465 {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(tlsConfig); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("STARTTLS 실패: %w", err)
+			}
+		}
+	}
+
+	if ok, _ := client.Extension("AUTH"); ok || requireAuth || t.cfg.SMTPPort == 465 {
+		auth := smtp.PlainAuth("", t.cfg.Username, t.cfg.Password, t.cfg.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("인증 실패: %w", err)
+		}
+	}
+	return client, nil
+}
+
+// ended prefixes err with why ctx ended, when it did; the connection errors
+// of a cancelled session only say it was closed.
+func ended(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %w", context.Cause(ctx), err)
+}
+
+// ctxConn is a connection closed when its context ends, so a cancelled
+// send does not wait on a wedged server.
+type ctxConn struct {
+	net.Conn
+	stop func() bool
+}
+
+func (c *ctxConn) Close() error {
+	c.stop()
+	return c.Conn.Close()
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"weekly-lotto/internal/domain"
+)
+
+// archiveDatePattern matches the draw date of the Excel download: "2025.12.06".
+var archiveDatePattern = regexp.MustCompile(`^(\d{4})\.(\d{1,2})\.(\d{1,2})$`)
+
+// ParseDrawArchive extracts every draw from the "엑셀 다운로드" file of the
+// lottery site's 회차별 당첨번호 page. The file is an EUC-KR HTML table saved
+// with an .xls extension, one row per round:
+//
+//	년도 | 회차 | 추첨일 | 1등 당첨자수 | 1등 당첨금액 | ... | 5등 당첨금액 | 번호1~6 | 보너스
+//
+// The year cell spans all rounds of a year, so columns are read from the end
+// of each row. Header rows and rows that do not look like a draw are skipped.
+func ParseDrawArchive(r io.Reader) ([]*domain.WinningNumbers, error) {
+	doc, err := goquery.NewDocumentFromReader(wrapEucKRReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("HTML 파싱 실패: %w", err)
+	}
+
+	var draws []*domain.WinningNumbers
+	var rowErr error
+	doc.Find("tr").EachWithBreak(func(i int, tr *goquery.Selection) bool {
+		var cells []string
+		tr.Find("td").Each(func(_ int, td *goquery.Selection) {
+			cells = append(cells, strings.TrimSpace(td.Text()))
+		})
+		// 회차, 추첨일, 1~5등 (당첨자수, 당첨금액), 번호 6개, 보너스
+		if len(cells) < 19 {
+			return true
+		}
+		cells = cells[len(cells)-19:]
+
+		round, err := strconv.Atoi(cells[0])
+		if err != nil {
+			return true
+		}
+		draw, err := parseArchiveRow(round, cells[1:])
+		if err != nil {
+			rowErr = fmt.Errorf("%d회 파싱 실패: %w", round, err)
+			return false
+		}
+		draws = append(draws, draw)
+		return true
+	})
+	if rowErr != nil {
+		return nil, rowErr
+	}
+	if len(draws) == 0 {
+		return nil, fmt.Errorf("당첨 번호 행을 찾을 수 없습니다")
+	}
+	return draws, nil
+}
+
+// parseArchiveRow parses the cells following the round number.
+func parseArchiveRow(round int, cells []string) (*domain.WinningNumbers, error) {
+	matches := archiveDatePattern.FindStringSubmatch(cells[0])
+	if matches == nil {
+		return nil, fmt.Errorf("추첨일 형식이 올바르지 않습니다: %s", cells[0])
+	}
+	year, _ := strconv.Atoi(matches[1])
+	month, _ := strconv.Atoi(matches[2])
+	day, _ := strconv.Atoi(matches[3])
+
+	draw := &domain.WinningNumbers{
+		Round:    round,
+		DrawDate: time.Date(year, time.Month(month), day, 0, 0, 0, 0, domain.Seoul),
+		Prizes:   make(map[domain.WinningRank]*domain.PrizeInfo),
+	}
+
+	ranks := []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5}
+	for i, rank := range ranks {
+		winners := parseDigit(cells[1+2*i])
+		amount := parseAmount(cells[2+2*i])
+		draw.Prizes[rank] = &domain.PrizeInfo{
+			Rank:            rank,
+			TotalAmount:     amount * int64(winners),
+			WinnerCount:     winners,
+			AmountPerWinner: amount,
+		}
+	}
+
+	for _, cell := range cells[11:17] {
+		n, err := strconv.Atoi(cell)
+		if err != nil {
+			return nil, fmt.Errorf("당첨번호 형식이 올바르지 않습니다: %q", cell)
+		}
+		draw.Numbers = append(draw.Numbers, n)
+	}
+	bonus, err := strconv.Atoi(cells[17])
+	if err != nil {
+		return nil, fmt.Errorf("보너스 번호 형식이 올바르지 않습니다: %q", cells[17])
+	}
+	draw.BonusNumber = bonus
+	return draw, nil
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Balance holds the money figures shown on the my-page.
+type Balance struct {
+	Deposit        int64
+	UnclaimedPrize int64
+	// VirtualAccount is the dedicated deposit account (bank and number) as
+	// shown on the page, or empty when the page does not list one.
+	VirtualAccount string
+}
+
+// ParseBalance extracts the deposit balance and unclaimed prize amount from
+// the my-page HTML.
+// HTML structure:
+//
+//	<p class="total_new"><strong>12,000</strong>원</p>
+//	...
+//	<th>미수령 당첨금</th><td>5,000원</td>
+//	<th>입금전용 가상계좌</th><td>농협 790-1234-5678-90</td>
+func ParseBalance(r io.Reader) (*Balance, error) {
+	doc, err := goquery.NewDocumentFromReader(wrapEucKRReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("HTML 파싱 실패: %w", err)
+	}
+
+	depositText := strings.TrimSpace(doc.Find("p.total_new strong").First().Text())
+	if depositText == "" {
+		return nil, fmt.Errorf("예치금 정보를 찾을 수 없습니다")
+	}
+
+	balance := &Balance{Deposit: int64(parseDigit(depositText))}
+
+	// 미수령 당첨금 항목은 당첨 내역이 있을 때만 표시됨
+	doc.Find("th, dt").EachWithBreak(func(_ int, label *goquery.Selection) bool {
+		if !strings.Contains(label.Text(), "미수령") {
+			return true
+		}
+		balance.UnclaimedPrize = int64(parseDigit(label.Next().Text()))
+		return false
+	})
+	doc.Find("th, dt").EachWithBreak(func(_ int, label *goquery.Selection) bool {
+		if !strings.Contains(label.Text(), "가상계좌") {
+			return true
+		}
+		balance.VirtualAccount = strings.Join(strings.Fields(label.Next().Text()), " ")
+		return false
+	})
+
+	return balance, nil
+}
@@ -1,14 +1,63 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+// ErrLoginFailed is returned when the site rejects the credentials.
+var ErrLoginFailed = errors.New("로그인에 실패했습니다. 아이디 또는 비밀번호를 확인해주세요")
+
+// LoginFailure classifies why the site rejected a login.
+type LoginFailure int
+
+const (
+	LoginWrongCredentials LoginFailure = iota // 아이디/비밀번호 불일치
+	LoginLocked                               // 로그인 실패 횟수 초과 등으로 잠김
+	LoginDormant                              // 장기 미사용 휴면 계정
+)
+
+// String returns a Korean description of the failure.
+func (f LoginFailure) String() string {
+	switch f {
+	case LoginLocked:
+		return "계정 잠김"
+	case LoginDormant:
+		return "휴면 계정"
+	default:
+		return "아이디 또는 비밀번호 불일치"
+	}
+}
+
+// LoginError is a rejected login. It matches ErrLoginFailed with errors.Is.
+type LoginError struct {
+	Reason  LoginFailure
+	Message string // 사이트가 보여준 안내 문구 (없으면 빈 값)
+}
+
+func (e *LoginError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("로그인 실패 (%s): %s", e.Reason, e.Message)
+	}
+	if e.Reason == LoginWrongCredentials {
+		return ErrLoginFailed.Error()
+	}
+	return fmt.Sprintf("로그인 실패: %s", e.Reason)
+}
+
+func (e *LoginError) Is(target error) bool {
+	return target == ErrLoginFailed
+}
+
+var alertPattern = regexp.MustCompile(`alert\(\s*["']([^"']+)["']`)
+
 // ParseLoginResult checks if login was successful.
-// Returns error if login failed (i.e., HTML contains <a class="btn_common">).
+// Returns a *LoginError if login failed (i.e., HTML contains <a class="btn_common">).
 func ParseLoginResult(r io.Reader) error {
 	doc, err := goquery.NewDocumentFromReader(wrapEucKRReader(r))
 	if err != nil {
@@ -16,9 +65,22 @@ func ParseLoginResult(r io.Reader) error {
 	}
 
 	// 로그인 실패 시 "btn_common" 클래스의 <a> 태그가 존재
-	if doc.Find("a.btn_common").Length() > 0 {
-		return fmt.Errorf("로그인에 실패했습니다. 아이디 또는 비밀번호를 확인해주세요")
+	if doc.Find("a.btn_common").Length() == 0 {
+		return nil
+	}
+
+	loginErr := &LoginError{Reason: LoginWrongCredentials}
+	if match := alertPattern.FindStringSubmatch(doc.Find("script").Text()); match != nil {
+		loginErr.Message = strings.TrimSpace(strings.ReplaceAll(match[1], `\n`, " "))
 	}
 
-	return nil
+	// 안내 문구는 alert 또는 본문에 표시되므로 둘 다 확인
+	text := loginErr.Message + " " + doc.Find("body").Text()
+	switch {
+	case strings.Contains(text, "휴면"):
+		loginErr.Reason = LoginDormant
+	case strings.Contains(text, "잠금") || strings.Contains(text, "잠김") || strings.Contains(text, "로그인이 제한"):
+		loginErr.Reason = LoginLocked
+	}
+	return loginErr
 }
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
@@ -10,6 +11,9 @@ import (
 	"github.com/PuerkitoBio/goquery"
 )
 
+// ErrNoPurchases is returned when the purchase list has no entries.
+var ErrNoPurchases = errors.New("구매 내역을 찾을 수 없습니다")
+
 var detailPopRegex = regexp.MustCompile(`detailPop\('([^']+)'\s*,\s*'([^']+)'\s*,\s*'([^']+)'\)`)
 
 // PurchaseSummary holds identifiers required to fetch purchase details.
@@ -35,7 +39,7 @@ func ParsePurchaseList(r io.Reader) ([]PurchaseSummary, error) {
 
 	matches := detailPopRegex.FindAllStringSubmatch(string(body), -1)
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("구매 내역 링크를 찾을 수 없습니다")
+		return nil, ErrNoPurchases
 	}
 
 	seen := make(map[string]struct{})
@@ -57,7 +61,7 @@ func ParsePurchaseList(r io.Reader) ([]PurchaseSummary, error) {
 	}
 
 	if len(summaries) == 0 {
-		return nil, fmt.Errorf("구매 내역을 찾을 수 없습니다")
+		return nil, ErrNoPurchases
 	}
 
 	return summaries, nil
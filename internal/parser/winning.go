@@ -88,9 +88,7 @@ func parseDrawDate(s string) (time.Time, error) {
 	month, _ := strconv.Atoi(matches[2])
 	day, _ := strconv.Atoi(matches[3])
 
-	// Asia/Seoul timezone
-	loc, _ := time.LoadLocation("Asia/Seoul")
-	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc), nil
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, domain.Seoul), nil
 }
 
 // parsePrizeInfo extracts prize information for each rank from the table.
@@ -0,0 +1,87 @@
+// Package pushgateway pushes metrics of a finished batch run to a
+// Prometheus Pushgateway, where Prometheus scrapes them after the process
+// has exited.
+//
+// Each push replaces the whole group <job>/operation/<operation>, so the
+// gateway always holds the latest buy and the latest check run side by side.
+package pushgateway
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Metric is one gauge sample.
+type Metric struct {
+	Name   string
+	Help   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Push replaces the metrics of the operation group of job on the gateway at
+// baseURL with metrics.
+func Push(ctx context.Context, baseURL, job, operation string, metrics []Metric) error {
+	target := fmt.Sprintf("%s/metrics/job/%s/operation/%s", strings.TrimSuffix(baseURL, "/"), url.PathEscape(job), url.PathEscape(operation))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(Encode(metrics)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Pushgateway 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("Pushgateway 전송 실패: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Encode writes metrics in the Prometheus text exposition format. Samples
+// of the same name are grouped under one HELP and TYPE line, in the order
+// the names first appear.
+func Encode(metrics []Metric) []byte {
+	var names []string
+	samples := make(map[string][]Metric)
+	for _, m := range metrics {
+		if _, ok := samples[m.Name]; !ok {
+			names = append(names, m.Name)
+		}
+		samples[m.Name] = append(samples[m.Name], m)
+	}
+
+	var buf bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s gauge\n", name, samples[name][0].Help, name)
+		for _, m := range samples[name] {
+			buf.WriteString(name)
+			if len(m.Labels) > 0 {
+				keys := make([]string, 0, len(m.Labels))
+				for key := range m.Labels {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				pairs := make([]string, 0, len(keys))
+				for _, key := range keys {
+					pairs = append(pairs, fmt.Sprintf("%s=%q", key, m.Labels[key]))
+				}
+				buf.WriteString("{" + strings.Join(pairs, ",") + "}")
+			}
+			fmt.Fprintf(&buf, " %g\n", m.Value)
+		}
+	}
+	return buf.Bytes()
+}
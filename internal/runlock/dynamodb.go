@@ -0,0 +1,138 @@
+package runlock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/sigv4"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// dynamoDBLocker takes locks by conditionally putting an item keyed by the
+// lock name into a table whose partition key is the string attribute
+// lockKey. The item's expires attribute (epoch seconds) can also be set as
+// the table's TTL attribute so stale locks are cleaned up.
+type dynamoDBLocker struct {
+	table    string
+	endpoint string
+	region   string
+	creds    sigv4.Credentials
+	ttl      time.Duration
+}
+
+type dynamoDBLock struct {
+	locker *dynamoDBLocker
+	key    string
+	token  string
+}
+
+func newDynamoDB(u *url.URL, cfg config.LockConfig) (*dynamoDBLocker, error) {
+	query := u.Query()
+	l := &dynamoDBLocker{
+		table:    u.Host,
+		region:   query.Get("region"),
+		endpoint: query.Get("endpoint"),
+		creds:    sigv4.Credentials{AccessKeyID: cfg.AccessKeyID, SecretAccessKey: cfg.SecretAccessKey},
+		ttl:      cfg.Expiry(),
+	}
+	if l.creds.AccessKeyID == "" && l.creds.SecretAccessKey == "" {
+		l.creds = sigv4.FromEnv()
+	}
+	if !l.creds.Complete() {
+		return nil, fmt.Errorf("DynamoDB 잠금에는 lock.accessKeyId/secretAccessKey 또는 AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY가 필요합니다")
+	}
+	if l.endpoint == "" {
+		l.endpoint = fmt.Sprintf("https://dynamodb.%s.amazonaws.com", l.region)
+	}
+	return l, nil
+}
+
+func (l *dynamoDBLocker) TryLock(ctx context.Context, key string) (Lock, error) {
+	token := newToken()
+	now := time.Now()
+	err := l.call(ctx, "PutItem", map[string]any{
+		"TableName": l.table,
+		"Item": map[string]any{
+			"lockKey": map[string]string{"S": key},
+			"owner":   map[string]string{"S": token},
+			"expires": map[string]string{"N": strconv.FormatInt(now.Add(l.ttl).Unix(), 10)},
+		},
+		// 만료된 잠금은 다시 잡을 수 있음
+		"ConditionExpression":       "attribute_not_exists(lockKey) OR expires < :now",
+		"ExpressionAttributeValues": map[string]any{":now": map[string]string{"N": strconv.FormatInt(now.Unix(), 10)}},
+	})
+	if errors.Is(err, errConditionFailed) {
+		return nil, ErrHeld
+	}
+	if err != nil {
+		return nil, fmt.Errorf("DynamoDB 잠금 실패: %w", err)
+	}
+	return &dynamoDBLock{locker: l, key: key, token: token}, nil
+}
+
+func (l *dynamoDBLock) Unlock(ctx context.Context) error {
+	err := l.locker.call(ctx, "DeleteItem", map[string]any{
+		"TableName":                 l.locker.table,
+		"Key":                       map[string]any{"lockKey": map[string]string{"S": l.key}},
+		"ConditionExpression":       "#owner = :token",
+		"ExpressionAttributeNames":  map[string]string{"#owner": "owner"},
+		"ExpressionAttributeValues": map[string]any{":token": map[string]string{"S": l.token}},
+	})
+	if err != nil && !errors.Is(err, errConditionFailed) {
+		return fmt.Errorf("DynamoDB 잠금 해제 실패: %w", err)
+	}
+	return nil
+}
+
+// errConditionFailed is returned by call when a condition expression did
+// not hold.
+var errConditionFailed = errors.New("ConditionalCheckFailedException")
+
+// call performs a SigV4-signed DynamoDB JSON request.
+func (l *dynamoDBLocker) call(ctx context.Context, operation string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", "DynamoDB_20120810."+operation)
+	sigv4.Sign(req, body, l.creds, l.region, "dynamodb", time.Now())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var awsErr struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(respBody, &awsErr)
+	if strings.HasSuffix(awsErr.Type, "#ConditionalCheckFailedException") {
+		return errConditionFailed
+	}
+	return fmt.Errorf("AWS 응답 오류 (status: %d, type: %s): %s", resp.StatusCode, awsErr.Type, awsErr.Message)
+}
@@ -0,0 +1,72 @@
+package runlock
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"weekly-lotto/internal/filelock"
+)
+
+// fileLocker locks <dir>/weekly-lotto-<key>.lock. The operating system
+// releases the lock when the process exits, so it needs no TTL.
+type fileLocker struct {
+	dir string
+}
+
+type fileLock struct {
+	file *os.File
+}
+
+func (l fileLocker) TryLock(ctx context.Context, key string) (Lock, error) {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return nil, fmt.Errorf("잠금 디렉터리 생성 실패: %w", err)
+	}
+	path := filepath.Join(l.dir, "weekly-lotto-"+fileName(key)+".lock")
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("잠금 파일 열기 실패: %w", err)
+	}
+	if err := filelock.TryLock(file); err != nil {
+		file.Close()
+		if errors.Is(err, filelock.ErrLocked) {
+			return nil, ErrHeld
+		}
+		return nil, fmt.Errorf("%s 잠금 실패: %w", path, err)
+	}
+	return fileLock{file: file}, nil
+}
+
+// Unlock releases the lock. The file is left in place, as removing it would
+// let two processes lock different files of the same name.
+func (l fileLock) Unlock(ctx context.Context) error {
+	err := filelock.Unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// fileName replaces characters that are not safe in a file name, adding a
+// hash of key when it does so that e.g. two Korean account names do not end
+// up on the same file.
+func fileName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	if name == key {
+		return name
+	}
+	sum := sha256.Sum256([]byte(key))
+	return name + "-" + hex.EncodeToString(sum[:4])
+}
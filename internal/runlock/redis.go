@@ -0,0 +1,181 @@
+package runlock
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisTimeout bounds one Redis round trip when ctx has no earlier deadline.
+const redisTimeout = 10 * time.Second
+
+// redisKeyPrefix namespaces lock keys in a shared database.
+const redisKeyPrefix = "weekly-lotto:lock:"
+
+// unlockScript deletes the key only while it still holds our token.
+const unlockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+
+// redisLocker takes locks with SET NX PX, speaking just enough of the RESP
+// protocol for that, so no client library is needed.
+type redisLocker struct {
+	addr     string
+	tls      bool
+	username string
+	password string
+	db       string
+	ttl      time.Duration
+}
+
+type redisLock struct {
+	locker *redisLocker
+	key    string
+	token  string
+}
+
+func newRedis(u *url.URL, ttl time.Duration) *redisLocker {
+	l := &redisLocker{
+		addr: u.Host,
+		tls:  u.Scheme == "rediss",
+		db:   strings.Trim(u.Path, "/"),
+		ttl:  ttl,
+	}
+	if u.Port() == "" {
+		l.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		l.username = u.User.Username()
+		l.password, _ = u.User.Password()
+	}
+	return l
+}
+
+func (l *redisLocker) TryLock(ctx context.Context, key string) (Lock, error) {
+	key = redisKeyPrefix + key
+	token := newToken()
+	reply, err := l.do(ctx, "SET", key, token, "NX", "PX", strconv.FormatInt(l.ttl.Milliseconds(), 10))
+	if err != nil {
+		return nil, fmt.Errorf("Redis 잠금 실패: %w", err)
+	}
+	if reply == nil {
+		return nil, ErrHeld
+	}
+	return &redisLock{locker: l, key: key, token: token}, nil
+}
+
+func (l *redisLock) Unlock(ctx context.Context) error {
+	if _, err := l.locker.do(ctx, "EVAL", unlockScript, "1", l.key, l.token); err != nil {
+		return fmt.Errorf("Redis 잠금 해제 실패: %w", err)
+	}
+	return nil
+}
+
+// do runs command on a new connection, after authenticating and selecting
+// the database. A nil reply (e.g. SET NX on an existing key) is returned
+// as nil.
+func (l *redisLocker) do(ctx context.Context, command ...string) (any, error) {
+	dialer := &net.Dialer{Timeout: redisTimeout}
+	var conn net.Conn
+	var err error
+	if l.tls {
+		conn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", l.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", l.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(redisTimeout)
+	}
+	conn.SetDeadline(deadline)
+
+	var commands [][]string
+	if l.password != "" {
+		if l.username != "" {
+			commands = append(commands, []string{"AUTH", l.username, l.password})
+		} else {
+			commands = append(commands, []string{"AUTH", l.password})
+		}
+	}
+	if l.db != "" && l.db != "0" {
+		commands = append(commands, []string{"SELECT", l.db})
+	}
+	commands = append(commands, command)
+
+	r := bufio.NewReader(conn)
+	var reply any
+	for _, args := range commands {
+		if err := writeCommand(conn, args); err != nil {
+			return nil, err
+		}
+		if reply, err = readReply(r); err != nil {
+			return nil, fmt.Errorf("%s: %w", args[0], err)
+		}
+	}
+	return reply, nil
+}
+
+// writeCommand sends args as a RESP array of bulk strings.
+func writeCommand(w io.Writer, args []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// readReply reads one RESP reply. Arrays are read but not returned, as no
+// command used here answers with one.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("빈 응답")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, errors.New(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		for range n {
+			if _, err := readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return "", nil
+	default:
+		return nil, fmt.Errorf("알 수 없는 응답: %q", line)
+	}
+}
@@ -0,0 +1,69 @@
+// Package runlock keeps two runs from doing the same job at once, e.g. a
+// scheduled buy and a manual one for the same account and round.
+//
+// Locks are taken without waiting: a run that finds the lock held skips the
+// job instead. The default lock is a file in a local directory and only
+// covers runs on one host; Redis and DynamoDB locks cover runners on several
+// hosts and expire after a TTL, so a run that dies holding one does not
+// block the next week.
+package runlock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"weekly-lotto/internal/config"
+)
+
+// ErrHeld is returned by TryLock when another run holds the lock.
+var ErrHeld = errors.New("다른 실행이 잠금을 잡고 있습니다")
+
+// Locker takes named locks.
+type Locker interface {
+	// TryLock takes the lock named key, or returns ErrHeld when another run
+	// holds it.
+	TryLock(ctx context.Context, key string) (Lock, error)
+}
+
+// Lock is a held lock.
+type Lock interface {
+	// Unlock releases the lock. A lock that has already expired and been
+	// taken by another run is left alone.
+	Unlock(ctx context.Context) error
+}
+
+// New returns the Locker configured by cfg, which must be validated.
+func New(cfg config.LockConfig) (Locker, error) {
+	if cfg.URL == "" {
+		return fileLocker{dir: os.TempDir()}, nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("잠금 URL 형식 오류: %w", err)
+	}
+	switch u.Scheme {
+	case "":
+		return fileLocker{dir: cfg.URL}, nil
+	case "file":
+		return fileLocker{dir: u.Path}, nil
+	case "redis", "rediss":
+		return newRedis(u, cfg.Expiry()), nil
+	case "dynamodb":
+		return newDynamoDB(u, cfg)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 잠금 URL입니다: %s", u.Redacted())
+	}
+}
+
+// newToken returns a random value identifying one lock holder, so a run
+// only releases a lock it still owns.
+func newToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
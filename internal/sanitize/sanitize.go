@@ -0,0 +1,105 @@
+// Package sanitize scrubs credentials and purchase identifiers from text
+// before it leaves the process: log lines, error messages sent to
+// notifications and error trackers, and raw pages exported for debugging.
+//
+// Two things are masked: the configured secret values themselves (lottery
+// and SMTP usernames and passwords, keys), registered with AddSecrets once
+// the configuration is loaded, and well-known sensitive fields wherever they
+// appear - cookies, login form fields and order numbers/barcodes in query
+// strings, "key: value" messages, JSON and HTML form inputs.
+package sanitize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask replaces every scrubbed value.
+const Mask = "********"
+
+// minSecretLength keeps very short values (e.g. a one-letter username) from
+// masking unrelated text.
+const minSecretLength = 4
+
+var (
+	mu       sync.RWMutex
+	secrets  []string
+	replacer *strings.Replacer
+)
+
+// AddSecrets registers values that must never appear in emitted text.
+// Empty and very short values are ignored.
+func AddSecrets(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	seen := make(map[string]bool, len(secrets))
+	for _, secret := range secrets {
+		seen[secret] = true
+	}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) < minSecretLength || seen[value] {
+			continue
+		}
+		seen[value] = true
+		secrets = append(secrets, value)
+	}
+
+	// 긴 값부터 바꿔야 다른 값에 포함된 짧은 값이 일부만 가려지지 않음
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	pairs := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, Mask)
+	}
+	replacer = strings.NewReplacer(pairs...)
+}
+
+// sensitiveFields are the form, query and JSON field names whose values are
+// masked.
+const sensitiveFields = `userId|user_id|userPw|user_pw|password|passwd|pwd|orderNo|order_no|barcode|barCode|ticketNo|ticket_no`
+
+var patterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	// Cookie / Set-Cookie headers
+	{regexp.MustCompile(`(?i)\b((?:set-)?cookie:\s*)[^\r\n]+`), "${1}" + Mask},
+	// session cookies (JSESSIONID, DHJSESSIONID, WMONID, ...)
+	{regexp.MustCompile(`(?i)\b((?:[A-Z]*SESSIONID|WMONID)=)[^;\s&"']+`), "${1}" + Mask},
+	// JSON: "orderNo": "..." / "orderNo": 123
+	{regexp.MustCompile(`(?i)("(?:` + sensitiveFields + `)"\s*:\s*)("[^"]*"|[0-9]+)`), `${1}"` + Mask + `"`},
+	// HTML form inputs: name="orderNo" ... value="..."
+	{regexp.MustCompile(`(?i)(name=["']?(?:` + sensitiveFields + `)["']?[^>]*?\bvalue=["']?)[^"'\s>]*`), "${1}" + Mask},
+	// query strings, form bodies and messages: orderNo=..., orderNo: ...
+	{regexp.MustCompile(`(?i)\b((?:` + sensitiveFields + `)\s*[:=]\s*)[^&\s"',;)<]+`), "${1}" + Mask},
+}
+
+// String returns s with registered secrets and sensitive fields masked.
+func String(s string) string {
+	mu.RLock()
+	r := replacer
+	mu.RUnlock()
+	if r != nil {
+		s = r.Replace(s)
+	}
+	for _, p := range patterns {
+		s = p.re.ReplaceAllString(s, p.repl)
+	}
+	return s
+}
+
+// Error returns the sanitized message of err, or "" for a nil error.
+func Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	return String(err.Error())
+}
+
+// Page returns a copy of a raw page body that is safe to share.
+func Page(body []byte) []byte {
+	return []byte(String(string(body)))
+}
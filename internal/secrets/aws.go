@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/sigv4"
+)
+
+// loadAWSCredentials reads the standard AWS environment variables.
+func loadAWSCredentials() (sigv4.Credentials, error) {
+	creds := sigv4.FromEnv()
+	if !creds.Complete() {
+		return creds, fmt.Errorf("AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY 환경 변수가 설정되지 않았습니다")
+	}
+	return creds, nil
+}
+
+// awsRegion prefers the region embedded in an ARN and falls back to env.
+func awsRegion(ref string) (string, error) {
+	if strings.HasPrefix(ref, "arn:") {
+		parts := strings.SplitN(ref, ":", 6)
+		if len(parts) == 6 && parts[3] != "" {
+			return parts[3], nil
+		}
+	}
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		return region, nil
+	}
+	if region := os.Getenv("AWS_DEFAULT_REGION"); region != "" {
+		return region, nil
+	}
+	return "", fmt.Errorf("AWS 리전을 알 수 없습니다 (ARN 또는 AWS_REGION 필요)")
+}
+
+// resolveSecretsManager reads a SecretString from AWS Secrets Manager.
+func resolveSecretsManager(ctx context.Context, ref string) (string, error) {
+	var result struct {
+		SecretString string `json:"SecretString"`
+	}
+	payload := map[string]interface{}{"SecretId": ref}
+	if err := callAWSJSON(ctx, "secretsmanager", "secretsmanager.GetSecretValue", ref, payload, &result); err != nil {
+		return "", err
+	}
+	if result.SecretString == "" {
+		return "", fmt.Errorf("SecretString이 비어 있습니다 (바이너리 시크릿은 지원하지 않습니다)")
+	}
+	return result.SecretString, nil
+}
+
+// resolveSSMParameter reads a (SecureString) parameter from SSM Parameter Store.
+func resolveSSMParameter(ctx context.Context, ref string) (string, error) {
+	var result struct {
+		Parameter struct {
+			Value string `json:"Value"`
+		} `json:"Parameter"`
+	}
+	payload := map[string]interface{}{"Name": ref, "WithDecryption": true}
+	if err := callAWSJSON(ctx, "ssm", "AmazonSSM.GetParameter", ref, payload, &result); err != nil {
+		return "", err
+	}
+	return result.Parameter.Value, nil
+}
+
+// callAWSJSON performs a SigV4-signed AWS JSON 1.1 protocol request.
+func callAWSJSON(ctx context.Context, service, target, ref string, payload, out interface{}) error {
+	creds, err := loadAWSCredentials()
+	if err != nil {
+		return err
+	}
+
+	region, err := awsRegion(ref)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	host := fmt.Sprintf("%s.%s.amazonaws.com", service, region)
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://"+host+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", target)
+
+	sigv4.Sign(req, body, creds, region, service, time.Now())
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var awsErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &awsErr)
+		return fmt.Errorf("AWS 응답 오류 (status: %d, type: %s): %s", resp.StatusCode, awsErr.Type, awsErr.Message)
+	}
+
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,51 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// resolveKeychain reads a password from the operating system's keychain:
+// the login keychain on macOS (security) and the Secret Service on Linux
+// (secret-tool, e.g. GNOME Keyring or KWallet).
+//
+//	keychain://weekly-lotto/store-key   (service "weekly-lotto", account "store-key")
+func resolveKeychain(ctx context.Context, ref string) (string, error) {
+	service, account, ok := strings.Cut(ref, "/")
+	if !ok || service == "" || account == "" {
+		return "", fmt.Errorf("keychain 참조는 keychain://<서비스>/<계정> 형식이어야 합니다: %s", ref)
+	}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux", "freebsd", "openbsd":
+		cmd = exec.CommandContext(ctx, "secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("%s 에서는 keychain 참조를 지원하지 않습니다", runtime.GOOS)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s 명령을 찾을 수 없습니다", cmd.Args[0])
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	secret := strings.TrimRight(string(out), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("keychain에 %s/%s 항목이 없습니다", service, account)
+	}
+	return secret, nil
+}
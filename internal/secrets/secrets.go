@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Resolver fetches a secret value for a reference (without its scheme prefix).
+type Resolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// ResolverFunc adapts a plain function to the Resolver interface.
+type ResolverFunc func(ctx context.Context, ref string) (string, error)
+
+// Resolve calls f(ctx, ref).
+func (f ResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var resolvers = map[string]Resolver{
+	"aws-sm":   ResolverFunc(resolveSecretsManager),
+	"aws-ssm":  ResolverFunc(resolveSSMParameter),
+	"vault":    ResolverFunc(resolveVault),
+	"keychain": ResolverFunc(resolveKeychain),
+}
+
+// Register adds a resolver for the given scheme (e.g. "vault" for "vault://...").
+func Register(scheme string, resolver Resolver) {
+	resolvers[scheme] = resolver
+}
+
+// Schemes lists every registered reference scheme.
+func Schemes() []string {
+	schemes := make([]string, 0, len(resolvers))
+	for scheme := range resolvers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+// IsReference reports whether value points to a secret backend
+// (e.g. "aws-sm://lotto/prod#password") rather than being a literal secret.
+func IsReference(value string) bool {
+	scheme, _, ok := splitReference(value)
+	if !ok {
+		return false
+	}
+	_, registered := resolvers[scheme]
+	return registered
+}
+
+// Resolve returns the secret a reference points to.
+// Literal values (without a registered scheme) are returned unchanged.
+//
+// A trailing "#key" selects a field when the stored secret is a JSON object:
+//
+//	aws-sm://arn:aws:secretsmanager:ap-northeast-2:123456789012:secret:lotto#password
+//	aws-ssm:///weekly-lotto/smtp-password
+func Resolve(ctx context.Context, value string) (string, error) {
+	scheme, ref, ok := splitReference(value)
+	if !ok {
+		return value, nil
+	}
+
+	resolver, registered := resolvers[scheme]
+	if !registered {
+		return value, nil
+	}
+
+	ref, key := splitKey(ref)
+	secret, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("%s 시크릿 조회 실패 (%s): %w", scheme, ref, err)
+	}
+
+	if key == "" {
+		return secret, nil
+	}
+	return extractJSONField(secret, key)
+}
+
+func splitReference(value string) (scheme, ref string, ok bool) {
+	idx := strings.Index(value, "://")
+	if idx <= 0 {
+		return "", "", false
+	}
+	return value[:idx], value[idx+len("://"):], true
+}
+
+func splitKey(ref string) (string, string) {
+	idx := strings.LastIndex(ref, "#")
+	if idx < 0 {
+		return ref, ""
+	}
+	return ref[:idx], ref[idx+1:]
+}
+
+func extractJSONField(secret, key string) (string, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("시크릿이 JSON 객체가 아니어서 %q 키를 읽을 수 없습니다: %w", key, err)
+	}
+
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("시크릿에 %q 키가 없습니다", key)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,151 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// vaultClient talks to the HashiCorp Vault HTTP API using the standard
+// VAULT_ADDR / VAULT_TOKEN / VAULT_NAMESPACE environment variables.
+// AppRole login (VAULT_ROLE_ID + VAULT_SECRET_ID) is used when no token is set.
+type vaultClient struct {
+	mu    sync.Mutex
+	token string
+}
+
+var defaultVault = &vaultClient{}
+
+// resolveVault reads a secret from Vault and returns its data as a JSON object,
+// so a "#key" suffix selects the field:
+//
+//	vault://secret/data/weekly-lotto#password   (KV v2)
+//	vault://kv/weekly-lotto#password            (KV v1)
+func resolveVault(ctx context.Context, ref string) (string, error) {
+	return defaultVault.read(ctx, ref)
+}
+
+func (v *vaultClient) read(ctx context.Context, path string) (string, error) {
+	token, err := v.loginToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := vaultRequest(ctx, "GET", "/v1/"+strings.TrimPrefix(path, "/"), token, nil, &result); err != nil {
+		return "", err
+	}
+
+	data := result.Data
+	// KV v2 응답은 data.data 안에 실제 값이 들어 있음
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMeta := data["metadata"]; hasMeta {
+			data = inner
+		}
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// loginToken returns VAULT_TOKEN or performs (and caches) an AppRole login.
+func (v *vaultClient) loginToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.token != "" {
+		return v.token, nil
+	}
+
+	roleID := os.Getenv("VAULT_ROLE_ID")
+	secretID := os.Getenv("VAULT_SECRET_ID")
+	if roleID == "" || secretID == "" {
+		return "", fmt.Errorf("VAULT_TOKEN 또는 VAULT_ROLE_ID/VAULT_SECRET_ID 환경 변수가 필요합니다")
+	}
+
+	mount := os.Getenv("VAULT_APPROLE_MOUNT")
+	if mount == "" {
+		mount = "approle"
+	}
+
+	var result struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	payload := map[string]string{"role_id": roleID, "secret_id": secretID}
+	if err := vaultRequest(ctx, "POST", "/v1/auth/"+mount+"/login", "", payload, &result); err != nil {
+		return "", fmt.Errorf("AppRole 로그인 실패: %w", err)
+	}
+	if result.Auth.ClientToken == "" {
+		return "", fmt.Errorf("AppRole 로그인 응답에 토큰이 없습니다")
+	}
+
+	v.token = result.Auth.ClientToken
+	return v.token, nil
+}
+
+func vaultRequest(ctx context.Context, method, path, token string, payload, out interface{}) error {
+	addr := strings.TrimRight(os.Getenv("VAULT_ADDR"), "/")
+	if addr == "" {
+		return fmt.Errorf("VAULT_ADDR 환경 변수가 설정되지 않았습니다")
+	}
+
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, addr+path, body)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(respBody, &vaultErr)
+		return fmt.Errorf("Vault 응답 오류 (status: %d): %s", resp.StatusCode, strings.Join(vaultErr.Errors, "; "))
+	}
+
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,158 @@
+// Package sentry reports failed runs to Sentry, or a compatible service
+// such as GlitchTip, through the envelope HTTP API, so no SDK is needed.
+//
+// Events are sent synchronously when a run fails, before the failure email,
+// so failures are aggregated even when the email itself cannot be sent.
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/sanitize"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Client sends events to one Sentry project.
+type Client struct {
+	dsn         string
+	endpoint    string
+	key         string
+	environment string
+	release     string
+}
+
+// New returns a Client for cfg, or nil when Sentry is not configured.
+// release is the running version (e.g. "v1.2.3").
+func New(cfg config.SentryConfig, release string) (*Client, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	u, err := url.Parse(cfg.DSN)
+	if err != nil || u.Host == "" || u.User.Username() == "" {
+		return nil, fmt.Errorf("Sentry DSN 형식 오류")
+	}
+	prefix, project := path.Split(strings.TrimSuffix(u.Path, "/"))
+	if project == "" {
+		return nil, fmt.Errorf("Sentry DSN에 프로젝트 ID가 없습니다")
+	}
+
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join(prefix, "api", project, "envelope") + "/"}
+	return &Client{
+		dsn:         cfg.DSN,
+		endpoint:    endpoint.String(),
+		key:         u.User.Username(),
+		environment: cfg.Environment,
+		release:     release,
+	}, nil
+}
+
+// Event is a single failure with the context needed to triage it.
+type Event struct {
+	Operation string // e.g. "buy", "로또 구매"
+	Account   string
+	Round     int
+	Err       error
+	// Extra holds additional context such as a sanitized response snippet.
+	Extra map[string]string
+}
+
+// event is the Sentry event payload.
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Logger      string            `json:"logger"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+	Exception   struct {
+		Values []exception `json:"values"`
+	} `json:"exception"`
+}
+
+type exception struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Capture sends e and returns the ID of the created event.
+func (c *Client) Capture(ctx context.Context, e Event) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+
+	payload := event{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   now.Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       "error",
+		Logger:      "weekly-lotto",
+		Release:     "weekly-lotto@" + c.release,
+		Environment: c.environment,
+		Transaction: e.Operation,
+		Tags:        map[string]string{"operation": e.Operation},
+	}
+	payload.ServerName, _ = os.Hostname()
+	if e.Account != "" {
+		payload.Tags["account"] = e.Account
+	}
+	if e.Round > 0 {
+		payload.Tags["round"] = fmt.Sprint(e.Round)
+	}
+	payload.Exception.Values = []exception{{Type: e.Operation, Value: sanitize.Error(e.Err)}}
+	if len(e.Extra) > 0 {
+		payload.Extra = make(map[string]string, len(e.Extra))
+		for key, value := range e.Extra {
+			payload.Extra[key] = sanitize.String(value)
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	var envelope bytes.Buffer
+	header, _ := json.Marshal(map[string]string{"event_id": payload.EventID, "sent_at": now.Format(time.RFC3339), "dsn": c.dsn})
+	envelope.Write(header)
+	fmt.Fprintf(&envelope, "\n{\"type\":\"event\",\"length\":%d}\n", len(body))
+	envelope.Write(body)
+	envelope.WriteByte('\n')
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, &envelope)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=weekly-lotto/%s, sentry_key=%s", c.release, c.key))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("Sentry 응답 %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return payload.EventID, nil
+}
@@ -0,0 +1,333 @@
+// Package sheets appends purchases and check results to a Google Sheet.
+//
+// It authenticates as a Google Cloud service account (the JSON key file
+// downloaded from the console) with the OAuth 2.0 JWT bearer flow and talks
+// to the Sheets API v4 over plain HTTP, so no Google client library is needed.
+// The spreadsheet must be shared with the service account's client_email.
+package sheets
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/domain/utils"
+	"weekly-lotto/internal/store"
+)
+
+const (
+	sheetsScope  = "https://www.googleapis.com/auth/spreadsheets"
+	sheetsAPI    = "https://sheets.googleapis.com/v4/spreadsheets/"
+	defaultToken = "https://oauth2.googleapis.com/token"
+)
+
+// Column headers written to an empty sheet before the first row.
+var (
+	purchaseHeader = []any{"구매 시각", "계정", "회차", "슬롯", "모드", "번호", "금액"}
+	resultHeader   = []any{"확인 시각", "계정", "회차", "추첨일", "당첨 번호", "보너스", "슬롯", "모드", "번호", "결과", "당첨금"}
+)
+
+// Sheet appends rows to the configured spreadsheet. A nil *Sheet is valid
+// and records nothing, so callers do not need to check whether the
+// integration is enabled.
+type Sheet struct {
+	cfg        config.SheetsConfig
+	key        *serviceAccountKey
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expiry  time.Time
+	headers map[string]bool
+}
+
+// serviceAccountKey is the part of a service account JSON key that is used.
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+
+	signer *rsa.PrivateKey
+}
+
+// New returns a Sheet for cfg, or nil when no spreadsheet is configured.
+func New(cfg config.SheetsConfig) (*Sheet, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	key, err := loadKey(cfg.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &Sheet{
+		cfg:        cfg,
+		key:        key,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		headers:    make(map[string]bool),
+	}, nil
+}
+
+// loadKey reads a service account key given as JSON or as a file path.
+func loadKey(credentials string) (*serviceAccountKey, error) {
+	data := []byte(credentials)
+	if !strings.HasPrefix(strings.TrimSpace(credentials), "{") {
+		var err error
+		if data, err = os.ReadFile(credentials); err != nil {
+			return nil, fmt.Errorf("서비스 계정 키 파일 읽기 실패: %w", err)
+		}
+	}
+
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("서비스 계정 키 파싱 실패: %w", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("서비스 계정 키에 client_email 또는 private_key가 없습니다")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultToken
+	}
+
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("서비스 계정 private_key 형식 오류")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("서비스 계정 private_key 파싱 실패: %w", err)
+	}
+	signer, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("서비스 계정 private_key가 RSA 키가 아닙니다")
+	}
+	key.signer = signer
+	return &key, nil
+}
+
+// RecordPurchases appends one row per purchased ticket.
+func (s *Sheet) RecordPurchases(ctx context.Context, purchases []store.Purchase) error {
+	if s == nil || len(purchases) == 0 {
+		return nil
+	}
+	rows := make([][]any, 0, len(purchases))
+	for _, p := range purchases {
+		rows = append(rows, []any{
+			p.PurchasedAt.In(domain.Seoul).Format("2006-01-02 15:04:05"),
+			p.Account, p.Round, p.Slot, p.Mode, utils.FormatNumbers(p.Numbers), p.Amount,
+		})
+	}
+	return s.append(ctx, s.cfg.PurchaseSheet, purchaseHeader, rows)
+}
+
+// RecordResults appends one row per checked ticket of account.
+func (s *Sheet) RecordResults(ctx context.Context, account string, summary *domain.CheckSummary, at time.Time) error {
+	if s == nil || len(summary.Tickets) == 0 {
+		return nil
+	}
+	rows := make([][]any, 0, len(summary.Tickets))
+	for _, ticket := range summary.Tickets {
+		rows = append(rows, []any{
+			at.In(domain.Seoul).Format("2006-01-02 15:04:05"),
+			account, summary.Round, summary.DrawDate.Format("2006-01-02"),
+			utils.FormatNumbers(summary.WinningNumbers), summary.BonusNumber,
+			ticket.Slot, ticket.Mode, utils.FormatNumbers(ticket.Numbers),
+			ticket.Rank.String(), ticket.Prize,
+		})
+	}
+	return s.append(ctx, s.cfg.ResultSheet, resultHeader, rows)
+}
+
+// append adds rows below the existing data of sheet, writing header first
+// when the sheet is still empty.
+func (s *Sheet) append(ctx context.Context, sheet string, header []any, rows [][]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.headers[sheet] {
+		empty, err := s.empty(ctx, sheet)
+		if err != nil {
+			return err
+		}
+		if empty {
+			rows = append([][]any{header}, rows...)
+		}
+		s.headers[sheet] = true
+	}
+
+	body, err := json.Marshal(map[string]any{"values": rows})
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s:append?valueInputOption=USER_ENTERED&insertDataOption=INSERT_ROWS", s.rangeURL(sheet+"!A1"))
+	if err := s.call(ctx, "POST", endpoint, body, nil); err != nil {
+		return fmt.Errorf("Google Sheets '%s' 행 추가 실패: %w", sheet, err)
+	}
+	return nil
+}
+
+// empty reports whether the first cell of sheet is blank.
+func (s *Sheet) empty(ctx context.Context, sheet string) (bool, error) {
+	var result struct {
+		Values [][]any `json:"values"`
+	}
+	if err := s.call(ctx, "GET", s.rangeURL(sheet+"!A1:A1"), nil, &result); err != nil {
+		return false, fmt.Errorf("Google Sheets '%s' 조회 실패: %w", sheet, err)
+	}
+	return len(result.Values) == 0, nil
+}
+
+// rangeURL is the values endpoint of an A1 range. Sheet names are quoted
+// since they usually contain Korean or spaces.
+func (s *Sheet) rangeURL(a1 string) string {
+	sheet, cell, _ := strings.Cut(a1, "!")
+	quoted := "'" + strings.ReplaceAll(sheet, "'", "''") + "'!" + cell
+	return sheetsAPI + url.PathEscape(s.cfg.SpreadsheetID) + "/values/" + url.PathEscape(quoted)
+}
+
+func (s *Sheet) call(ctx context.Context, method, endpoint string, body []byte, out any) error {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return apiError(resp.StatusCode, data)
+	}
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// apiError extracts the message of a Google API error response.
+func apiError(status int, body []byte) error {
+	var payload struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Description string `json:"error_description"`
+	}
+	json.Unmarshal(body, &payload)
+	message := payload.Error.Message
+	if message == "" {
+		message = payload.Description
+	}
+	if message == "" {
+		message = strings.TrimSpace(string(body))
+	}
+	return fmt.Errorf("HTTP %d: %s", status, message)
+}
+
+// accessToken returns a cached OAuth token, exchanging a freshly signed JWT
+// when it is about to expire.
+func (s *Sheet) accessToken(ctx context.Context) (string, error) {
+	if s.token != "" && time.Until(s.expiry) > time.Minute {
+		return s.token, nil
+	}
+
+	assertion, err := s.key.signJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Google 인증 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Google 인증 실패: %w", apiError(resp.StatusCode, data))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", fmt.Errorf("Google 인증 응답 파싱 실패: %w", err)
+	}
+	s.token = token.AccessToken
+	s.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// signJWT builds the RS256-signed assertion of the JWT bearer flow.
+func (k *serviceAccountKey) signJWT(now time.Time) (string, error) {
+	encode := func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return base64.RawURLEncoding.EncodeToString(data), nil
+	}
+
+	header, err := encode(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := encode(map[string]any{
+		"iss":   k.ClientEmail,
+		"scope": sheetsScope,
+		"aud":   k.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + claims
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(nil, k.signer, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("JWT 서명 실패: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
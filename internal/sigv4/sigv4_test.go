@@ -0,0 +1,47 @@
+package sigv4
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	exampleCredentials = Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	exampleTime        = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+)
+
+// get-vanilla of the AWS Signature Version 4 test suite.
+func TestSignGetVanilla(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	Sign(req, nil, exampleCredentials, "us-east-1", "service", exampleTime)
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %s\nwant %s", got, want)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date = %s", got)
+	}
+}
+
+func TestSignSessionTokenAndS3Payload(t *testing.T) {
+	creds := exampleCredentials
+	creds.SessionToken = "session-token"
+	req, _ := http.NewRequest(http.MethodPut, "https://bucket.s3.us-east-1.amazonaws.com/backups/a.gz", nil)
+	req.Header.Set("Content-Type", "application/gzip")
+	Sign(req, []byte("backup"), creds, "us-east-1", "s3", exampleTime)
+
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "session-token" {
+		t.Errorf("X-Amz-Security-Token = %q, want the session token", got)
+	}
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		t.Error("S3 request has no X-Amz-Content-Sha256")
+	}
+	want := "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date;x-amz-security-token,"
+	if got := req.Header.Get("Authorization"); !strings.Contains(got, want) {
+		t.Errorf("Authorization = %s, want it to contain %s", got, want)
+	}
+}
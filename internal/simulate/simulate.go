@@ -0,0 +1,104 @@
+// Package simulate estimates the outcome of a ticket strategy by playing it
+// against past draws (backtesting) or randomly generated draws (Monte Carlo).
+package simulate
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+
+	"weekly-lotto/internal/domain"
+	"weekly-lotto/internal/generator"
+)
+
+// EstimatedPrizes are per-winner amounts used for generated draws. Ranks 1~3
+// are pari-mutuel, so these are long-run averages; ranks 4 and 5 are fixed.
+var EstimatedPrizes = map[domain.WinningRank]int64{
+	domain.Rank1: 2_000_000_000,
+	domain.Rank2: 55_000_000,
+	domain.Rank3: 1_500_000,
+	domain.Rank4: 50_000,
+	domain.Rank5: 5_000,
+}
+
+// Result is the aggregate outcome of a simulation.
+type Result struct {
+	Strategy string
+	Rounds   int
+	Tickets  int
+	Cost     int64
+	Winnings int64
+	Ranks    map[domain.WinningRank]int
+}
+
+// ROI returns the return on investment in percent.
+func (r *Result) ROI() float64 {
+	if r.Cost == 0 {
+		return 0
+	}
+	return float64(r.Winnings-r.Cost) / float64(r.Cost) * 100
+}
+
+// Run plays ticketsPerRound tickets generated by strategy against every draw.
+func Run(strategy generator.Strategy, ticketsPerRound int, draws []*domain.WinningNumbers) (*Result, error) {
+	if ticketsPerRound < 1 {
+		return nil, fmt.Errorf("회차당 티켓 수는 1 이상이어야 합니다: %d", ticketsPerRound)
+	}
+
+	result := &Result{
+		Strategy: strategy.Name(),
+		Rounds:   len(draws),
+		Ranks:    make(map[domain.WinningRank]int),
+	}
+
+	for _, draw := range draws {
+		for i := 0; i < ticketsPerRound; i++ {
+			numbers, err := strategy.Generate(nil)
+			if err != nil {
+				return nil, fmt.Errorf("%d회 번호 생성 실패: %w", draw.Round, err)
+			}
+
+			result.Tickets++
+			result.Cost += domain.TicketPrice
+
+			rank := domain.CheckWinning(numbers, draw)
+			result.Ranks[rank]++
+			if info, ok := draw.Prizes[rank]; ok && rank != domain.RankNone {
+				result.Winnings += info.AmountPerWinner
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// RandomDraws generates n draws with EstimatedPrizes using rng.
+func RandomDraws(n int, rng *rand.Rand) []*domain.WinningNumbers {
+	prizes := make(map[domain.WinningRank]*domain.PrizeInfo, len(EstimatedPrizes))
+	for rank, amount := range EstimatedPrizes {
+		prizes[rank] = &domain.PrizeInfo{Rank: rank, AmountPerWinner: amount}
+	}
+
+	draws := make([]*domain.WinningNumbers, n)
+	pool := make([]int, domain.MaxNumber-domain.MinNumber+1)
+	for i := range draws {
+		for j := range pool {
+			pool[j] = domain.MinNumber + j
+		}
+		// 앞쪽 7개만 섞으면 충분 (당첨 번호 6개 + 보너스 1개)
+		for j := 0; j <= domain.NumbersPerTicket; j++ {
+			k := j + rng.IntN(len(pool)-j)
+			pool[j], pool[k] = pool[k], pool[j]
+		}
+
+		numbers := append([]int(nil), pool[:domain.NumbersPerTicket]...)
+		sort.Ints(numbers)
+		draws[i] = &domain.WinningNumbers{
+			Round:       i + 1,
+			Numbers:     numbers,
+			BonusNumber: pool[domain.NumbersPerTicket],
+			Prizes:      prizes,
+		}
+	}
+	return draws
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Page is a raw copy of a scraped page kept for auditing and for reproducing
+// parser failures. Body is uncompressed here; drivers keep it gzip-compressed.
+type Page struct {
+	Account   string
+	Kind      string
+	Round     int
+	URL       string
+	FetchedAt time.Time
+	Body      []byte
+}
+
+// PageFilter narrows a page query. Zero values leave a bound open.
+type PageFilter struct {
+	Account   string
+	Kind      string
+	FromRound int
+	ToRound   int
+}
+
+// matches reports whether p falls within every bound of f.
+func (f PageFilter) matches(p Page) bool {
+	return (f.Account == "" || p.Account == f.Account) &&
+		(f.Kind == "" || p.Kind == f.Kind) &&
+		(f.FromRound <= 0 || p.Round >= f.FromRound) &&
+		(f.ToRound <= 0 || p.Round <= f.ToRound)
+}
+
+// compressPage gzips a page body for storage.
+func compressPage(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressPage reverses compressPage.
+func decompressPage(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("페이지 압축 해제 실패: %w", err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("페이지 압축 해제 실패: %w", err)
+	}
+	return body, nil
+}
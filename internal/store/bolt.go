@@ -0,0 +1,460 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"weekly-lotto/internal/domain"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets of a BoltStore. Values are the same records a JSONStore writes
+// per line, so both files hold interchangeable data.
+var (
+	boltPurchases   = []byte("purchases")   // key: insertion sequence
+	boltDraws       = []byte("draws")       // key: round
+	boltMonths      = []byte("months")      // key: "2006-01"
+	boltPages       = []byte("pages")       // key: insertion sequence
+	boltCredentials = []byte("credentials") // key: name
+)
+
+func init() {
+	Register(DriverBolt, Driver{
+		Open:       func(path string, key []byte) (Store, error) { return OpenBolt(path, key) },
+		Extensions: []string{".bolt", ".bbolt"},
+	})
+}
+
+// BoltStore is a Store kept in a bbolt key/value file. It is pure Go, so it
+// works in builds without cgo where SQLite is unavailable.
+type BoltStore struct {
+	db     *bolt.DB
+	sealer *sealer
+}
+
+// OpenBolt opens (and creates if needed) the bbolt database at path. Only
+// one process can hold it open at a time; others wait up to five seconds.
+// A non-empty key encrypts private fields (see sealer).
+func OpenBolt(path string, key []byte) (*BoltStore, error) {
+	sealer, err := newSealer(key)
+	if err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("bbolt 저장소 열기 실패: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltPurchases, boltDraws, boltMonths, boltPages, boltCredentials} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("bbolt 버킷 생성 실패: %w", err)
+	}
+
+	return &BoltStore{db: db, sealer: sealer}, nil
+}
+
+// encode marshals record, sealing its private fields.
+func (s *BoltStore) encode(record jsonRecord) ([]byte, error) {
+	return json.Marshal(s.sealer.sealRecord(record))
+}
+
+// decode reverses encode.
+func (s *BoltStore) decode(value []byte) (jsonRecord, error) {
+	var record jsonRecord
+	if err := json.Unmarshal(value, &record); err != nil {
+		return jsonRecord{}, err
+	}
+	return s.sealer.openRecord(record)
+}
+
+// boltKey encodes n so keys sort numerically.
+func boltKey(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, n)
+}
+
+// SavePurchases records purchased tickets in a single transaction, updating
+// the tickets already recorded under the same round, order number and slot.
+func (s *BoltStore) SavePurchases(ctx context.Context, purchases []Purchase) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltPurchases)
+		var keys [][]byte
+		var rows []Purchase
+		err := bucket.ForEach(func(key, value []byte) error {
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, append([]byte(nil), key...))
+			rows = append(rows, record.purchase())
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		var changed []Purchase
+		for _, p := range purchases {
+			i := matchPurchase(rows, p)
+			if i >= 0 {
+				merged, ok := mergePurchase(rows[i], p)
+				if !ok {
+					continue
+				}
+				p = merged
+				rows[i] = merged
+			} else {
+				seq, err := bucket.NextSequence()
+				if err != nil {
+					return err
+				}
+				i = len(rows)
+				keys = append(keys, boltKey(seq))
+				rows = append(rows, p)
+			}
+			value, err := s.encode(purchaseRecord(p))
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(keys[i], value); err != nil {
+				return err
+			}
+			changed = append(changed, p)
+		}
+		return discardBoltMonths(tx, purchaseMonths(changed))
+	})
+	if err != nil {
+		return fmt.Errorf("구매 기록 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// Purchases returns the recorded purchases matching filter, oldest first.
+func (s *BoltStore) Purchases(ctx context.Context, filter PurchaseFilter) ([]Purchase, error) {
+	var purchases []Purchase
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltPurchases).ForEach(func(_, value []byte) error {
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			if p := record.purchase(); filter.matches(p) {
+				purchases = append(purchases, p)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("구매 기록 조회 실패: %w", err)
+	}
+	sort.SliceStable(purchases, func(i, j int) bool {
+		return purchases[i].PurchasedAt.Before(purchases[j].PurchasedAt)
+	})
+	return purchases, nil
+}
+
+// SaveDraws records published winning numbers, replacing existing rounds.
+func (s *BoltStore) SaveDraws(ctx context.Context, draws []*domain.WinningNumbers) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltDraws)
+		for _, draw := range draws {
+			value, err := s.encode(drawRecord(draw))
+			if err != nil {
+				return fmt.Errorf("%d회 당첨금 인코딩 실패: %w", draw.Round, err)
+			}
+			if err := bucket.Put(boltKey(uint64(draw.Round)), value); err != nil {
+				return err
+			}
+		}
+		return discardBoltMonths(tx, drawMonths(draws))
+	})
+	if err != nil {
+		return fmt.Errorf("당첨 번호 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// Draws returns the recorded draws in [fromRound, toRound], oldest first.
+func (s *BoltStore) Draws(ctx context.Context, fromRound, toRound int) ([]*domain.WinningNumbers, error) {
+	var draws []*domain.WinningNumbers
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltDraws).Cursor()
+		for key, value := cursor.Seek(boltKey(uint64(max(fromRound, 0)))); key != nil; key, value = cursor.Next() {
+			if toRound > 0 && binary.BigEndian.Uint64(key) > uint64(toRound) {
+				break
+			}
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			draws = append(draws, record.draw())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+	return draws, nil
+}
+
+// discardBoltMonths drops the materialized aggregates of months.
+func discardBoltMonths(tx *bolt.Tx, months map[string]bool) error {
+	bucket := tx.Bucket(boltMonths)
+	for month := range months {
+		if err := bucket.Delete([]byte(month)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveMonthly materializes the totals of month.
+func (s *BoltStore) SaveMonthly(ctx context.Context, month time.Time, totals []MonthlyTotal) error {
+	key := monthKey(month)
+	if totals == nil {
+		totals = []MonthlyTotal{}
+	}
+	value, err := s.encode(monthRecord(MonthlySummary{Month: month, Totals: totals}))
+	if err != nil {
+		return err
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltMonths).Put([]byte(key), value)
+	})
+	if err != nil {
+		return fmt.Errorf("%s 월별 집계 저장 실패: %w", key, err)
+	}
+	return nil
+}
+
+// Monthly returns the materialized months starting in [from, to), oldest first.
+func (s *BoltStore) Monthly(ctx context.Context, from, to time.Time) ([]MonthlySummary, error) {
+	var months []MonthlySummary
+	toKey := monthKey(to)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltMonths).Cursor()
+		for key, value := cursor.Seek([]byte(monthKey(from))); key != nil && string(key) < toKey; key, value = cursor.Next() {
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			month, err := record.month()
+			if err != nil {
+				return err
+			}
+			months = append(months, month)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("월별 집계 조회 실패: %w", err)
+	}
+	return months, nil
+}
+
+// Spent returns the total amount account spent in [from, to).
+func (s *BoltStore) Spent(ctx context.Context, account string, from, to time.Time) (int64, error) {
+	purchases, err := s.Purchases(ctx, PurchaseFilter{Account: account, From: from, To: to})
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, p := range purchases {
+		total += p.Amount
+	}
+	return total, nil
+}
+
+// PrunePurchases deletes purchases made before before. bbolt reuses the
+// freed pages for later writes but never shrinks the file itself.
+func (s *BoltStore) PrunePurchases(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltPurchases)
+		var keys [][]byte
+		err := bucket.ForEach(func(key, value []byte) error {
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			if record.purchase().PurchasedAt.Before(before) {
+				keys = append(keys, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		deleted = int64(len(keys))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("구매 기록 삭제 실패: %w", err)
+	}
+	return deleted, nil
+}
+
+// SavePages archives gzip-compressed pages in a single transaction.
+func (s *BoltStore) SavePages(ctx context.Context, pages []Page) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltPages)
+		for _, p := range pages {
+			body, err := compressPage(p.Body)
+			if err != nil {
+				return err
+			}
+			p.Body = body
+			seq, err := bucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			value, err := s.encode(pageRecord(p))
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(boltKey(seq), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("페이지 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// Pages returns the archived pages matching filter, oldest first.
+func (s *BoltStore) Pages(ctx context.Context, filter PageFilter) ([]Page, error) {
+	var pages []Page
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltPages).ForEach(func(_, value []byte) error {
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			p := record.page()
+			if !filter.matches(p) {
+				return nil
+			}
+			body, err := decompressPage(p.Body)
+			if err != nil {
+				return err
+			}
+			p.Body = body
+			pages = append(pages, p)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("페이지 조회 실패: %w", err)
+	}
+	sort.SliceStable(pages, func(i, j int) bool {
+		return pages[i].FetchedAt.Before(pages[j].FetchedAt)
+	})
+	return pages, nil
+}
+
+// PrunePages deletes pages fetched before before.
+func (s *BoltStore) PrunePages(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltPages)
+		var keys [][]byte
+		err := bucket.ForEach(func(key, value []byte) error {
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			if record.page().FetchedAt.Before(before) {
+				keys = append(keys, append([]byte(nil), key...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		deleted = int64(len(keys))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("페이지 삭제 실패: %w", err)
+	}
+	return deleted, nil
+}
+
+// SaveCredentials records credentials, replacing those of the same name.
+func (s *BoltStore) SaveCredentials(ctx context.Context, credentials []Credential) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltCredentials)
+		for _, c := range credentials {
+			value, err := s.encode(credentialRecord(c))
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(c.Name), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("자격 증명 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// Credentials returns the tracked credentials ordered by name.
+func (s *BoltStore) Credentials(ctx context.Context) ([]Credential, error) {
+	var credentials []Credential
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltCredentials).ForEach(func(key, value []byte) error {
+			record, err := s.decode(value)
+			if err != nil {
+				return err
+			}
+			credentials = append(credentials, record.credential())
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("자격 증명 기록 조회 실패: %w", err)
+	}
+	return credentials, nil
+}
+
+// Snapshot writes a copy of the database from a read transaction.
+func (s *BoltStore) Snapshot(ctx context.Context, w io.Writer) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+}
+
+// Close closes the database and releases its file lock.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
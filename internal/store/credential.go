@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Credential tracks how long a password - the login of a lottery account or
+// the SMTP password - has been in use, so its rotation can be reminded of.
+// The secret itself is never stored, only a salted hash that tells whether
+// it changed (see Fingerprint).
+type Credential struct {
+	Name        string // e.g. "lottery:default", "smtp"
+	Fingerprint string
+	Since       time.Time // when the current secret was first seen
+	RemindedAt  time.Time // last rotation reminder; zero when none was sent
+}
+
+// CredentialStore keeps the credentials being tracked.
+type CredentialStore interface {
+	// Credentials returns the tracked credentials ordered by name.
+	Credentials(ctx context.Context) ([]Credential, error)
+	// SaveCredentials records credentials, replacing those of the same name.
+	SaveCredentials(ctx context.Context, credentials []Credential) error
+}
+
+// fingerprintIterations makes guessing a secret from its fingerprint slow,
+// should a store copy leak.
+const fingerprintIterations = 100_000
+
+// Fingerprint returns a salted PBKDF2-SHA256 hash of secret:
+// "pbkdf2-sha256$<iterations>$<salt>$<hash>".
+func Fingerprint(secret string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return fingerprint(secret, salt, fingerprintIterations)
+}
+
+func fingerprint(secret string, salt []byte, iterations int) (string, error) {
+	hash, err := pbkdf2.Key(sha256.New, secret, salt, iterations, sha256.Size)
+	if err != nil {
+		return "", err
+	}
+	encoding := base64.RawStdEncoding
+	return fmt.Sprintf("pbkdf2-sha256$%d$%s$%s", iterations, encoding.EncodeToString(salt), encoding.EncodeToString(hash)), nil
+}
+
+// Matches reports whether secret is the one c was fingerprinted from.
+func (c Credential) Matches(secret string) bool {
+	parts := strings.Split(c.Fingerprint, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2-sha256" {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := fingerprint(secret, salt, iterations)
+	return err == nil && subtle.ConstantTimeCompare([]byte(want), []byte(c.Fingerprint)) == 1
+}
@@ -0,0 +1,191 @@
+package store
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/domain"
+)
+
+// Column schemas of the CSV exports. Columns are only ever appended so
+// spreadsheets and scripts built on an export keep working.
+var (
+	PurchaseColumns = []string{"account", "round", "order_no", "slot", "mode", "numbers", "amount", "purchased_at"}
+	ResultColumns   = append(append([]string{}, PurchaseColumns...), "result", "rank", "prize")
+	DrawColumns     = []string{"round", "draw_date", "numbers", "bonus",
+		"rank1_winners", "rank1_prize", "rank2_winners", "rank2_prize", "rank3_winners", "rank3_prize",
+		"rank4_winners", "rank4_prize", "rank5_winners", "rank5_prize"}
+)
+
+// CSV files written by ExportCSV.
+const (
+	PurchasesFile = "purchases.csv"
+	ResultsFile   = "results.csv"
+	DrawsFile     = "draws.csv"
+)
+
+// Result is a purchase together with its outcome.
+type Result struct {
+	Purchase
+	Status string // 등수 이름, "미추첨" 또는 "확인 불가"
+	Rank   int    // 1~5, 0 for a losing or undrawn ticket
+	Prize  int64
+}
+
+// Results resolves purchases matching filter against the draws kept in s,
+// without contacting the lottery site. Purchases whose draw has not been
+// stored are reported as "미추첨" before the draw date and "확인 불가" after.
+func Results(ctx context.Context, s Store, filter PurchaseFilter) ([]Result, error) {
+	purchases, err := s.Purchases(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.Draws(ctx, filter.FromRound, filter.ToRound)
+	if err != nil {
+		return nil, err
+	}
+	draws := make(map[int]*domain.WinningNumbers, len(stored))
+	for _, draw := range stored {
+		draws[draw.Round] = draw
+	}
+
+	now := domain.Now()
+	results := make([]Result, 0, len(purchases))
+	for _, p := range purchases {
+		result := Result{Purchase: p, Status: "미추첨"}
+		if draw, ok := draws[p.Round]; ok {
+			rank := domain.CheckWinning(p.Numbers, draw)
+			result.Status = rank.String()
+			result.Rank = rank.Number()
+			if info, ok := draw.Prizes[rank]; ok && rank != domain.RankNone {
+				result.Prize = info.AmountPerWinner
+			}
+		} else if !domain.DrawDate(p.Round).After(now) {
+			result.Status = "확인 불가"
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// PurchaseRecord is the CSV row of p in PurchaseColumns order.
+func PurchaseRecord(p Purchase) []string {
+	return []string{
+		p.Account, strconv.Itoa(p.Round), p.OrderNo, p.Slot, p.Mode,
+		CSVNumbers(p.Numbers), strconv.FormatInt(p.Amount, 10), CSVTime(p.PurchasedAt),
+	}
+}
+
+// ResultRecord is the CSV row of r in ResultColumns order.
+func ResultRecord(r Result) []string {
+	return append(PurchaseRecord(r.Purchase), r.Status, strconv.Itoa(r.Rank), strconv.FormatInt(r.Prize, 10))
+}
+
+// DrawRecord is the CSV row of draw in DrawColumns order. Missing prize
+// information is left blank.
+func DrawRecord(draw *domain.WinningNumbers) []string {
+	record := []string{
+		strconv.Itoa(draw.Round), draw.DrawDate.In(domain.Seoul).Format("2006-01-02"),
+		CSVNumbers(draw.Numbers), strconv.Itoa(draw.BonusNumber),
+	}
+	for _, rank := range []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5} {
+		info, ok := draw.Prizes[rank]
+		if !ok || info == nil {
+			record = append(record, "", "")
+			continue
+		}
+		record = append(record, strconv.Itoa(info.WinnerCount), strconv.FormatInt(info.AmountPerWinner, 10))
+	}
+	return record
+}
+
+// CSVNumbers joins numbers with spaces so spreadsheets keep them in one cell.
+func CSVNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, " ")
+}
+
+// CSVTime formats t in KST; the zero time is left blank.
+func CSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(domain.Seoul).Format("2006-01-02 15:04:05")
+}
+
+// WriteCSV writes columns as the header followed by records.
+func WriteCSV(w io.Writer, columns []string, records [][]string) error {
+	writer := csv.NewWriter(w)
+	writer.Write(columns)
+	writer.WriteAll(records)
+	return writer.Error()
+}
+
+// ExportCSV writes the purchases matching filter, their results and every
+// stored draw to PurchasesFile, ResultsFile and DrawsFile in dir, creating
+// dir if needed. It returns the number of rows written per file.
+func ExportCSV(ctx context.Context, s Store, dir string, filter PurchaseFilter) (map[string]int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("내보내기 디렉터리 생성 실패: %w", err)
+	}
+
+	results, err := Results(ctx, s, filter)
+	if err != nil {
+		return nil, err
+	}
+	draws, err := s.Draws(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseRecords := make([][]string, 0, len(results))
+	resultRecords := make([][]string, 0, len(results))
+	for _, result := range results {
+		purchaseRecords = append(purchaseRecords, PurchaseRecord(result.Purchase))
+		resultRecords = append(resultRecords, ResultRecord(result))
+	}
+	drawRecords := make([][]string, 0, len(draws))
+	for _, draw := range draws {
+		drawRecords = append(drawRecords, DrawRecord(draw))
+	}
+
+	files := []struct {
+		name    string
+		columns []string
+		records [][]string
+	}{
+		{PurchasesFile, PurchaseColumns, purchaseRecords},
+		{ResultsFile, ResultColumns, resultRecords},
+		{DrawsFile, DrawColumns, drawRecords},
+	}
+	counts := make(map[string]int, len(files))
+	for _, file := range files {
+		if err := writeCSVFile(filepath.Join(dir, file.name), file.columns, file.records); err != nil {
+			return nil, err
+		}
+		counts[file.name] = len(file.records)
+	}
+	return counts, nil
+}
+
+func writeCSVFile(path string, columns []string, records [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("파일 생성 실패: %w", err)
+	}
+	if err := WriteCSV(file, columns, records); err != nil {
+		file.Close()
+		return fmt.Errorf("%s 쓰기 실패: %w", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Store drivers selectable with store.driver.
+const (
+	DriverSQLite   = "sqlite"
+	DriverJSON     = "json"
+	DriverBolt     = "bolt"
+	DriverPostgres = "postgres"
+)
+
+// defaultDriver opens locations no registered driver claims.
+const defaultDriver = DriverSQLite
+
+// Driver is a store backend that can be selected with store.driver.
+type Driver struct {
+	// Open opens (and creates if needed) the store at location. A non-empty
+	// key, KeySize bytes long, encrypts private fields (see sealer).
+	Open func(location string, key []byte) (Store, error)
+	// Extensions are the file extensions (".json") and Schemes the URL
+	// prefixes ("postgres://") DriverFor maps to this driver.
+	Extensions []string
+	Schemes    []string
+	// Remote drivers connect to a database server: they are configured with
+	// store.dsn instead of store.path and cannot be snapshotted.
+	Remote bool
+}
+
+var drivers = map[string]Driver{}
+
+// Register makes a store driver available under name. Drivers register
+// themselves from an init function, so adding a backend takes no changes
+// outside its own file.
+func Register(name string, driver Driver) {
+	if driver.Open == nil {
+		panic("store: Register driver " + name + " without Open")
+	}
+	drivers[name] = driver
+}
+
+// Drivers lists the registered store drivers.
+func Drivers() []string {
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsRemote reports whether driver connects to a database server.
+func IsRemote(driver string) bool {
+	return drivers[driver].Remote
+}
+
+// DriverFor infers the driver of location from the URL schemes and file
+// extensions drivers register, falling back to SQLite: postgres:// URLs use
+// PostgreSQL, .json, .jsonl and .ndjson files the JSON store, .bolt and
+// .bbolt files bbolt.
+func DriverFor(location string) string {
+	ext := strings.ToLower(filepath.Ext(location))
+	for _, name := range Drivers() {
+		driver := drivers[name]
+		for _, scheme := range driver.Schemes {
+			if strings.HasPrefix(location, scheme) {
+				return name
+			}
+		}
+		for _, e := range driver.Extensions {
+			if ext == e {
+				return name
+			}
+		}
+	}
+	return defaultDriver
+}
+
+// Open opens the store at location (a file path, or the DSN for a remote
+// driver) with driver, inferring the driver from location when it is
+// empty. A non-empty key, KeySize bytes long, encrypts ticket numbers,
+// order numbers and archived pages.
+func Open(driver, location string, key []byte) (Store, error) {
+	if driver == "" {
+		driver = DriverFor(location)
+	}
+	d, ok := drivers[driver]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 저장소 드라이버입니다: %s", driver)
+	}
+	return d.Open(location, key)
+}
@@ -0,0 +1,247 @@
+package store
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/domain"
+)
+
+// maxImportErrors bounds how many invalid draws are reported by ImportDraws.
+const maxImportErrors = 10
+
+// ImportResult counts what ImportDraws did with each draw.
+type ImportResult struct {
+	Inserted  int // rounds that were not stored yet
+	Updated   int // stored rounds whose numbers or prizes differed
+	Unchanged int // stored rounds identical to the imported draw
+}
+
+// ImportDraws validates draws and upserts them into s. Rounds that are
+// already stored with the same content are left alone, so importing the same
+// data again is a no-op. Nothing is written when any draw is invalid or a
+// round appears twice with different content.
+func ImportDraws(ctx context.Context, s Store, draws []*domain.WinningNumbers) (*ImportResult, error) {
+	result := &ImportResult{}
+	if len(draws) == 0 {
+		return result, nil
+	}
+
+	var errs []error
+	byRound := make(map[int]*domain.WinningNumbers, len(draws))
+	fromRound, toRound := draws[0].Round, draws[0].Round
+	for _, draw := range draws {
+		if err := ValidateDraw(draw); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if seen, ok := byRound[draw.Round]; ok && !sameDraw(seen, draw) {
+			errs = append(errs, fmt.Errorf("%d회가 서로 다른 내용으로 중복되어 있습니다", draw.Round))
+			continue
+		}
+		byRound[draw.Round] = draw
+		fromRound, toRound = min(fromRound, draw.Round), max(toRound, draw.Round)
+	}
+	if len(errs) > 0 {
+		if len(errs) > maxImportErrors {
+			errs = append(errs[:maxImportErrors], fmt.Errorf("외 %d건", len(errs)-maxImportErrors))
+		}
+		return nil, fmt.Errorf("당첨 번호 검증 실패: %w", errors.Join(errs...))
+	}
+
+	stored, err := s.Draws(ctx, fromRound, toRound)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[int]*domain.WinningNumbers, len(stored))
+	for _, draw := range stored {
+		existing[draw.Round] = draw
+	}
+
+	var changed []*domain.WinningNumbers
+	for round := fromRound; round <= toRound; round++ {
+		draw, ok := byRound[round]
+		if !ok {
+			continue
+		}
+		old, ok := existing[round]
+		switch {
+		case !ok:
+			result.Inserted++
+		case sameDraw(old, draw):
+			result.Unchanged++
+			continue
+		default:
+			result.Updated++
+		}
+		changed = append(changed, draw)
+	}
+
+	if len(changed) > 0 {
+		if err := s.SaveDraws(ctx, changed); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ValidateDraw checks that draw is a plausible 6/45 result: six distinct
+// numbers and a bonus number in range, drawn on the Saturday of its round.
+func ValidateDraw(draw *domain.WinningNumbers) error {
+	if draw.Round < 1 {
+		return fmt.Errorf("회차가 올바르지 않습니다: %d", draw.Round)
+	}
+	if len(draw.Numbers) != domain.NumbersPerTicket {
+		return fmt.Errorf("%d회: 당첨번호가 %d개가 아닙니다 (%d개)", draw.Round, domain.NumbersPerTicket, len(draw.Numbers))
+	}
+
+	seen := make(map[int]bool, len(draw.Numbers)+1)
+	for _, n := range append(slices.Clone(draw.Numbers), draw.BonusNumber) {
+		if n < domain.MinNumber || n > domain.MaxNumber {
+			return fmt.Errorf("%d회: 번호가 범위(%d~%d)를 벗어났습니다: %d", draw.Round, domain.MinNumber, domain.MaxNumber, n)
+		}
+		if seen[n] {
+			return fmt.Errorf("%d회: 번호가 중복되었습니다: %d", draw.Round, n)
+		}
+		seen[n] = true
+	}
+
+	want := domain.DrawDate(draw.Round)
+	if got := draw.DrawDate.In(domain.Seoul); got.Year() != want.Year() || got.YearDay() != want.YearDay() {
+		return fmt.Errorf("%d회: 추첨일이 %s이 아닙니다: %s", draw.Round, want.Format("2006-01-02"), got.Format("2006-01-02"))
+	}
+
+	for rank, info := range draw.Prizes {
+		if info == nil {
+			continue
+		}
+		if info.WinnerCount < 0 || info.AmountPerWinner < 0 {
+			return fmt.Errorf("%d회: %s 당첨 정보가 올바르지 않습니다", draw.Round, rank.String())
+		}
+	}
+	return nil
+}
+
+// ReadDrawsCSV reads draws written in the DrawColumns layout, e.g. the draws.csv
+// of ExportCSV. Columns are matched by header name, so extra or reordered
+// columns are fine; blank prize columns are left out.
+func ReadDrawsCSV(r io.Reader) ([]*domain.WinningNumbers, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("CSV 헤더 읽기 실패: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, name := range DrawColumns[:4] {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("CSV에 %s 열이 없습니다", name)
+		}
+	}
+
+	var draws []*domain.WinningNumbers
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV 읽기 실패: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		draw, err := parseDrawRecord(record, index)
+		if err != nil {
+			return nil, fmt.Errorf("%d행: %w", line, err)
+		}
+		draws = append(draws, draw)
+	}
+	return draws, nil
+}
+
+func parseDrawRecord(record []string, index map[string]int) (*domain.WinningNumbers, error) {
+	field := func(name string) string {
+		if i, ok := index[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	round, err := strconv.Atoi(field("round"))
+	if err != nil {
+		return nil, fmt.Errorf("회차 형식 오류: %w", err)
+	}
+	drawDate, err := time.ParseInLocation("2006-01-02", field("draw_date"), domain.Seoul)
+	if err != nil {
+		return nil, fmt.Errorf("추첨일 형식 오류: %w", err)
+	}
+	var numbers []int
+	for _, part := range strings.Fields(field("numbers")) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("당첨번호 형식 오류: %w", err)
+		}
+		numbers = append(numbers, n)
+	}
+	slices.Sort(numbers)
+	bonus, err := strconv.Atoi(field("bonus"))
+	if err != nil {
+		return nil, fmt.Errorf("보너스 번호 형식 오류: %w", err)
+	}
+
+	draw := &domain.WinningNumbers{
+		Round:       round,
+		DrawDate:    drawDate,
+		Numbers:     numbers,
+		BonusNumber: bonus,
+		Prizes:      make(map[domain.WinningRank]*domain.PrizeInfo),
+	}
+	for i, rank := range []domain.WinningRank{domain.Rank1, domain.Rank2, domain.Rank3, domain.Rank4, domain.Rank5} {
+		winners, prize := field(fmt.Sprintf("rank%d_winners", i+1)), field(fmt.Sprintf("rank%d_prize", i+1))
+		if winners == "" || prize == "" {
+			continue
+		}
+		count, err := strconv.Atoi(winners)
+		if err != nil {
+			return nil, fmt.Errorf("%s 당첨자 수 형식 오류: %w", rank.String(), err)
+		}
+		amount, err := strconv.ParseInt(prize, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s 당첨금 형식 오류: %w", rank.String(), err)
+		}
+		draw.Prizes[rank] = &domain.PrizeInfo{
+			Rank:            rank,
+			TotalAmount:     amount * int64(count),
+			WinnerCount:     count,
+			AmountPerWinner: amount,
+		}
+	}
+	return draw, nil
+}
+
+// sameDraw reports whether a and b record the same result. Total prize
+// amounts are ignored since some sources only carry the per-winner amount.
+func sameDraw(a, b *domain.WinningNumbers) bool {
+	if a.Round != b.Round || a.BonusNumber != b.BonusNumber || !slices.Equal(a.Numbers, b.Numbers) ||
+		!a.DrawDate.Equal(b.DrawDate) || len(a.Prizes) != len(b.Prizes) {
+		return false
+	}
+	for rank, info := range a.Prizes {
+		other, ok := b.Prizes[rank]
+		if !ok || (info == nil) != (other == nil) {
+			return false
+		}
+		if info != nil && (info.WinnerCount != other.WinnerCount || info.AmountPerWinner != other.AmountPerWinner) {
+			return false
+		}
+	}
+	return true
+}
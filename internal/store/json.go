@@ -0,0 +1,830 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"weekly-lotto/internal/domain"
+)
+
+func init() {
+	Register(DriverJSON, Driver{
+		Open:       func(path string, key []byte) (Store, error) { return OpenJSON(path, key) },
+		Extensions: []string{".json", ".jsonl", ".ndjson"},
+	})
+}
+
+// JSONStore is a Store kept in a single NDJSON file: one purchase, draw,
+// materialized month, archived page or tracked credential per line, so the file diffs cleanly when committed to a private repository
+// or synced with a service such as Dropbox. The whole file is loaded into
+// memory on open; it is meant for a single user's ledger. Writes take an
+// advisory lock on a ".lock" file next to it and reload the file when
+// another process changed it, so overlapping runs never drop each other's
+// records.
+type JSONStore struct {
+	mu        sync.Mutex
+	path      string
+	purchases []Purchase
+	draws     map[int]*domain.WinningNumbers
+	months    map[string]MonthlySummary
+	pages     []Page // bodies gzip-compressed as on disk
+	// credentials is keyed by name.
+	credentials map[string]Credential
+	sealer      *sealer
+	loaded      os.FileInfo // file state when last read or written; nil if absent
+}
+
+// Record kinds of a JSONStore line.
+const (
+	jsonKindPurchase   = "purchase"
+	jsonKindDraw       = "draw"
+	jsonKindMonth      = "month"
+	jsonKindPage       = "page"
+	jsonKindCredential = "credential"
+)
+
+// jsonRecord is a single line of a JSONStore file.
+type jsonRecord struct {
+	Kind        string     `json:"kind"`
+	Account     string     `json:"account,omitempty"`
+	Round       int        `json:"round,omitempty"`
+	OrderNo     string     `json:"orderNo,omitempty"`
+	Slot        string     `json:"slot,omitempty"`
+	Mode        string     `json:"mode,omitempty"`
+	Numbers     []int      `json:"numbers,omitempty"`
+	Amount      int64      `json:"amount,omitempty"`
+	PurchasedAt *time.Time `json:"purchasedAt,omitempty"`
+
+	DrawDate *time.Time                               `json:"drawDate,omitempty"`
+	Bonus    int                                      `json:"bonus,omitempty"`
+	Prizes   map[domain.WinningRank]*domain.PrizeInfo `json:"prizes,omitempty"`
+
+	Month  string         `json:"month,omitempty"`
+	Totals []MonthlyTotal `json:"totals,omitempty"`
+
+	Page      string     `json:"page,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	FetchedAt *time.Time `json:"fetchedAt,omitempty"`
+	Body      []byte     `json:"body,omitempty"` // gzip, base64 in JSON
+
+	Name        string     `json:"name,omitempty"`
+	Fingerprint string     `json:"fingerprint,omitempty"`
+	Since       *time.Time `json:"since,omitempty"`
+	RemindedAt  *time.Time `json:"remindedAt,omitempty"`
+
+	// Sealed holds the encrypted Numbers of a purchase when the store is
+	// encrypted (see sealer).
+	Sealed string `json:"sealed,omitempty"`
+}
+
+// OpenJSON opens (and creates if needed) the NDJSON store at path. A
+// non-empty key encrypts private fields (see sealer).
+func OpenJSON(path string, key []byte) (*JSONStore, error) {
+	sealer, err := newSealer(key)
+	if err != nil {
+		return nil, err
+	}
+	s := &JSONStore{path: path, draws: make(map[int]*domain.WinningNumbers), months: make(map[string]MonthlySummary), credentials: make(map[string]Credential), sealer: sealer}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func purchaseRecord(p Purchase) jsonRecord {
+	purchasedAt := p.PurchasedAt.UTC()
+	return jsonRecord{
+		Kind:        jsonKindPurchase,
+		Account:     p.Account,
+		Round:       p.Round,
+		OrderNo:     p.OrderNo,
+		Slot:        p.Slot,
+		Mode:        p.Mode,
+		Numbers:     p.Numbers,
+		Amount:      p.Amount,
+		PurchasedAt: &purchasedAt,
+	}
+}
+
+func drawRecord(draw *domain.WinningNumbers) jsonRecord {
+	drawDate := draw.DrawDate.UTC()
+	return jsonRecord{
+		Kind:     jsonKindDraw,
+		Round:    draw.Round,
+		Numbers:  draw.Numbers,
+		DrawDate: &drawDate,
+		Bonus:    draw.BonusNumber,
+		Prizes:   draw.Prizes,
+	}
+}
+
+func monthRecord(month MonthlySummary) jsonRecord {
+	return jsonRecord{Kind: jsonKindMonth, Month: monthKey(month.Month), Totals: month.Totals}
+}
+
+// pageRecord encodes p, whose body is already compressed.
+func pageRecord(p Page) jsonRecord {
+	fetchedAt := p.FetchedAt.UTC()
+	return jsonRecord{
+		Kind:      jsonKindPage,
+		Account:   p.Account,
+		Page:      p.Kind,
+		Round:     p.Round,
+		URL:       p.URL,
+		FetchedAt: &fetchedAt,
+		Body:      p.Body,
+	}
+}
+
+func credentialRecord(c Credential) jsonRecord {
+	since := c.Since.UTC()
+	record := jsonRecord{Kind: jsonKindCredential, Name: c.Name, Fingerprint: c.Fingerprint, Since: &since}
+	if !c.RemindedAt.IsZero() {
+		remindedAt := c.RemindedAt.UTC()
+		record.RemindedAt = &remindedAt
+	}
+	return record
+}
+
+func (r jsonRecord) purchase() Purchase {
+	p := Purchase{
+		Account: r.Account,
+		Round:   r.Round,
+		OrderNo: r.OrderNo,
+		Slot:    r.Slot,
+		Mode:    r.Mode,
+		Numbers: r.Numbers,
+		Amount:  r.Amount,
+	}
+	if r.PurchasedAt != nil {
+		p.PurchasedAt = *r.PurchasedAt
+	}
+	return p
+}
+
+func (r jsonRecord) draw() *domain.WinningNumbers {
+	draw := &domain.WinningNumbers{
+		Round:       r.Round,
+		Numbers:     r.Numbers,
+		BonusNumber: r.Bonus,
+		Prizes:      r.Prizes,
+	}
+	if r.DrawDate != nil {
+		draw.DrawDate = r.DrawDate.In(domain.Seoul)
+	}
+	return draw
+}
+
+func (r jsonRecord) credential() Credential {
+	c := Credential{Name: r.Name, Fingerprint: r.Fingerprint}
+	if r.Since != nil {
+		c.Since = *r.Since
+	}
+	if r.RemindedAt != nil {
+		c.RemindedAt = *r.RemindedAt
+	}
+	return c
+}
+
+// page returns the page of r with its body still compressed.
+func (r jsonRecord) page() Page {
+	p := Page{Account: r.Account, Kind: r.Page, Round: r.Round, URL: r.URL, Body: r.Body}
+	if r.FetchedAt != nil {
+		p.FetchedAt = *r.FetchedAt
+	}
+	return p
+}
+
+// sealRecord seals the order number, numbers and page body of r.
+func (c *sealer) sealRecord(r jsonRecord) jsonRecord {
+	if c == nil {
+		return r
+	}
+	switch r.Kind {
+	case jsonKindPurchase:
+		r.OrderNo = c.seal(r.OrderNo)
+		r.Sealed, r.Numbers = c.sealNumbers(r.Numbers), nil
+	case jsonKindPage:
+		r.Body = c.sealBytes(r.Body)
+	}
+	return r
+}
+
+// openRecord reverses sealRecord. Records written before encryption was
+// enabled are returned unchanged.
+func (c *sealer) openRecord(r jsonRecord) (jsonRecord, error) {
+	var err error
+	if r.OrderNo, err = c.open(r.OrderNo); err != nil {
+		return r, err
+	}
+	if r.Sealed != "" {
+		if r.Numbers, err = c.openNumbers(r.Sealed); err != nil {
+			return r, err
+		}
+		r.Sealed = ""
+	}
+	if r.Kind == jsonKindPage {
+		if r.Body, err = c.openBytes(r.Body); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+func (r jsonRecord) month() (MonthlySummary, error) {
+	month, err := time.ParseInLocation("2006-01", r.Month, domain.Seoul)
+	if err != nil {
+		return MonthlySummary{}, err
+	}
+	totals := r.Totals
+	if totals == nil {
+		totals = []MonthlyTotal{}
+	}
+	return MonthlySummary{Month: month, Totals: totals}, nil
+}
+
+// load replaces the in-memory records with the contents of the file.
+func (s *JSONStore) load() error {
+	s.purchases, s.pages = nil, nil
+	s.draws = make(map[int]*domain.WinningNumbers)
+	s.months = make(map[string]MonthlySummary)
+	s.credentials = make(map[string]Credential)
+	s.loaded = nil
+
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("JSON 저장소 열기 실패: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("JSON 저장소 열기 실패: %w", err)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("JSON 저장소 열기 실패: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	// 보관된 페이지는 한 줄이 길 수 있음
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record jsonRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("JSON 저장소 %s:%d 읽기 실패: %w", s.path, line, err)
+		}
+		if record, err = s.sealer.openRecord(record); err != nil {
+			return fmt.Errorf("JSON 저장소 %s:%d 읽기 실패: %w", s.path, line, err)
+		}
+		switch record.Kind {
+		case jsonKindPurchase:
+			s.purchases = append(s.purchases, record.purchase())
+		case jsonKindDraw:
+			draw := record.draw()
+			s.draws[draw.Round] = draw
+		case jsonKindMonth:
+			month, err := record.month()
+			if err != nil {
+				return fmt.Errorf("JSON 저장소 %s:%d 읽기 실패: %w", s.path, line, err)
+			}
+			s.months[record.Month] = month
+		case jsonKindPage:
+			s.pages = append(s.pages, record.page())
+		case jsonKindCredential:
+			s.credentials[record.Name] = record.credential()
+		default:
+			return fmt.Errorf("JSON 저장소 %s:%d 알 수 없는 기록 종류: %q", s.path, line, record.Kind)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("JSON 저장소 읽기 실패: %w", err)
+	}
+	s.loaded = info
+	return nil
+}
+
+// lock takes the cross-process lock of the file for a write. When another
+// process changed the file since it was read, it is loaded again first so
+// the write does not drop that process's records. Callers hold s.mu.
+func (s *JSONStore) lock() (*fileLock, error) {
+	l, err := lockFile(s.path + ".lock")
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		l.Unlock()
+		return nil, err
+	}
+	if changed := (info == nil) != (s.loaded == nil) ||
+		info != nil && (info.Size() != s.loaded.Size() || !info.ModTime().Equal(s.loaded.ModTime())); changed {
+		if err := s.load(); err != nil {
+			l.Unlock()
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// written records the state of the file after a write, so the next lock
+// does not reload it needlessly.
+func (s *JSONStore) written() {
+	if info, err := os.Stat(s.path); err == nil {
+		s.loaded = info
+	}
+}
+
+// SavePurchases records purchased tickets, updating the tickets already
+// recorded under the same round, order number and slot. New tickets are
+// appended to the file; when a ticket is updated or falls in a materialized
+// month, the file is rewritten instead.
+func (s *JSONStore) SavePurchases(ctx context.Context, purchases []Purchase) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	previous := s.purchases
+	all := slices.Clone(previous)
+	var changed []Purchase
+	updated := false
+	for _, p := range purchases {
+		if i := matchPurchase(all, p); i >= 0 {
+			merged, ok := mergePurchase(all[i], p)
+			if !ok {
+				continue
+			}
+			all[i] = merged
+			changed = append(changed, merged)
+			updated = updated || i < len(previous)
+			continue
+		}
+		all = append(all, p)
+		changed = append(changed, p)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if discarded := s.discardMonths(purchaseMonths(changed)); updated || len(discarded) > 0 {
+		s.purchases = all
+		if err := s.rewrite(); err != nil {
+			s.purchases = previous
+			s.restoreMonths(discarded)
+			return fmt.Errorf("구매 기록 저장 실패: %w", err)
+		}
+		return nil
+	}
+
+	added := all[len(previous):]
+	records := make([]jsonRecord, len(added))
+	for i, p := range added {
+		records[i] = purchaseRecord(p)
+	}
+	if err := s.append(records); err != nil {
+		return fmt.Errorf("구매 기록 저장 실패: %w", err)
+	}
+
+	s.purchases = all
+	return nil
+}
+
+// append writes records to the end of the file.
+func (s *JSONStore) append(records []jsonRecord) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(s.sealer.sealRecord(record)); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	s.written()
+	return nil
+}
+
+// Purchases returns the recorded purchases matching filter, oldest first.
+func (s *JSONStore) Purchases(ctx context.Context, filter PurchaseFilter) ([]Purchase, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purchases []Purchase
+	for _, p := range s.purchases {
+		if filter.matches(p) {
+			purchases = append(purchases, p)
+		}
+	}
+	sort.SliceStable(purchases, func(i, j int) bool {
+		return purchases[i].PurchasedAt.Before(purchases[j].PurchasedAt)
+	})
+	return purchases, nil
+}
+
+// matches reports whether p falls within every bound of f.
+func (f PurchaseFilter) matches(p Purchase) bool {
+	return (f.Account == "" || p.Account == f.Account) &&
+		(f.FromRound <= 0 || p.Round >= f.FromRound) &&
+		(f.ToRound <= 0 || p.Round <= f.ToRound) &&
+		(f.From.IsZero() || !p.PurchasedAt.Before(f.From)) &&
+		(f.To.IsZero() || p.PurchasedAt.Before(f.To))
+}
+
+// SaveDraws records published winning numbers, replacing existing rounds,
+// and rewrites the file.
+func (s *JSONStore) SaveDraws(ctx context.Context, draws []*domain.WinningNumbers) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	previous := make(map[int]*domain.WinningNumbers, len(draws))
+	for _, draw := range draws {
+		previous[draw.Round] = s.draws[draw.Round]
+		s.draws[draw.Round] = draw
+	}
+	discarded := s.discardMonths(drawMonths(draws))
+	if err := s.rewrite(); err != nil {
+		s.restoreMonths(discarded)
+		for round, draw := range previous {
+			if draw == nil {
+				delete(s.draws, round)
+			} else {
+				s.draws[round] = draw
+			}
+		}
+		return fmt.Errorf("당첨 번호 저장 실패: %w", err)
+	}
+	return nil
+}
+
+// discardMonths drops the materialized aggregates of months and returns them.
+func (s *JSONStore) discardMonths(months map[string]bool) []MonthlySummary {
+	var discarded []MonthlySummary
+	for key := range months {
+		if month, ok := s.months[key]; ok {
+			discarded = append(discarded, month)
+			delete(s.months, key)
+		}
+	}
+	return discarded
+}
+
+func (s *JSONStore) restoreMonths(months []MonthlySummary) {
+	for _, month := range months {
+		s.months[monthKey(month.Month)] = month
+	}
+}
+
+// Draws returns the recorded draws in [fromRound, toRound], oldest first.
+func (s *JSONStore) Draws(ctx context.Context, fromRound, toRound int) ([]*domain.WinningNumbers, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var draws []*domain.WinningNumbers
+	for round, draw := range s.draws {
+		if (fromRound <= 0 || round >= fromRound) && (toRound <= 0 || round <= toRound) {
+			draws = append(draws, draw)
+		}
+	}
+	sort.Slice(draws, func(i, j int) bool { return draws[i].Round < draws[j].Round })
+	return draws, nil
+}
+
+// SaveMonthly materializes the totals of month and rewrites the file.
+func (s *JSONStore) SaveMonthly(ctx context.Context, month time.Time, totals []MonthlyTotal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	key := monthKey(month)
+	previous, existed := s.months[key]
+	if totals == nil {
+		totals = []MonthlyTotal{}
+	}
+	s.months[key] = MonthlySummary{Month: MonthStart(month), Totals: totals}
+	if err := s.rewrite(); err != nil {
+		if existed {
+			s.months[key] = previous
+		} else {
+			delete(s.months, key)
+		}
+		return fmt.Errorf("%s 월별 집계 저장 실패: %w", key, err)
+	}
+	return nil
+}
+
+// Monthly returns the materialized months starting in [from, to), oldest first.
+func (s *JSONStore) Monthly(ctx context.Context, from, to time.Time) ([]MonthlySummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fromKey, toKey := monthKey(from), monthKey(to)
+	var months []MonthlySummary
+	for key, month := range s.months {
+		if key >= fromKey && key < toKey {
+			months = append(months, month)
+		}
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Month.Before(months[j].Month) })
+	return months, nil
+}
+
+// Spent returns the total amount account spent in [from, to).
+func (s *JSONStore) Spent(ctx context.Context, account string, from, to time.Time) (int64, error) {
+	purchases, err := s.Purchases(ctx, PurchaseFilter{Account: account, From: from, To: to})
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, p := range purchases {
+		total += p.Amount
+	}
+	return total, nil
+}
+
+// PrunePurchases deletes purchases made before before and rewrites the file.
+func (s *JSONStore) PrunePurchases(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, err := s.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer l.Unlock()
+
+	all := s.purchases
+	kept := make([]Purchase, 0, len(all))
+	for _, p := range all {
+		if !p.PurchasedAt.Before(before) {
+			kept = append(kept, p)
+		}
+	}
+	deleted := int64(len(all) - len(kept))
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	s.purchases = kept
+	if err := s.rewrite(); err != nil {
+		s.purchases = all
+		return 0, fmt.Errorf("구매 기록 삭제 실패: %w", err)
+	}
+	return deleted, nil
+}
+
+// SavePages appends gzip-compressed pages to the file.
+func (s *JSONStore) SavePages(ctx context.Context, pages []Page) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	stored := make([]Page, len(pages))
+	records := make([]jsonRecord, len(pages))
+	for i, p := range pages {
+		body, err := compressPage(p.Body)
+		if err != nil {
+			return fmt.Errorf("페이지 압축 실패: %w", err)
+		}
+		p.Body = body
+		stored[i] = p
+		records[i] = pageRecord(p)
+	}
+	if err := s.append(records); err != nil {
+		return fmt.Errorf("페이지 저장 실패: %w", err)
+	}
+
+	s.pages = append(s.pages, stored...)
+	return nil
+}
+
+// Pages returns the archived pages matching filter, oldest first.
+func (s *JSONStore) Pages(ctx context.Context, filter PageFilter) ([]Page, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pages []Page
+	for _, p := range s.pages {
+		if !filter.matches(p) {
+			continue
+		}
+		body, err := decompressPage(p.Body)
+		if err != nil {
+			return nil, err
+		}
+		p.Body = body
+		pages = append(pages, p)
+	}
+	sort.SliceStable(pages, func(i, j int) bool {
+		return pages[i].FetchedAt.Before(pages[j].FetchedAt)
+	})
+	return pages, nil
+}
+
+// PrunePages deletes pages fetched before before and rewrites the file.
+func (s *JSONStore) PrunePages(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, err := s.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer l.Unlock()
+
+	all := s.pages
+	kept := make([]Page, 0, len(all))
+	for _, p := range all {
+		if !p.FetchedAt.Before(before) {
+			kept = append(kept, p)
+		}
+	}
+	deleted := int64(len(all) - len(kept))
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	s.pages = kept
+	if err := s.rewrite(); err != nil {
+		s.pages = all
+		return 0, fmt.Errorf("페이지 삭제 실패: %w", err)
+	}
+	return deleted, nil
+}
+
+// SaveCredentials records credentials, replacing those of the same name,
+// and rewrites the file.
+func (s *JSONStore) SaveCredentials(ctx context.Context, credentials []Credential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	previous := maps.Clone(s.credentials)
+	for _, c := range credentials {
+		s.credentials[c.Name] = c
+	}
+	if err := s.rewrite(); err != nil {
+		s.credentials = previous
+		return fmt.Errorf("자격 증명 기록 실패: %w", err)
+	}
+	return nil
+}
+
+// Credentials returns the tracked credentials ordered by name.
+func (s *JSONStore) Credentials(ctx context.Context) ([]Credential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedCredentials(), nil
+}
+
+func (s *JSONStore) sortedCredentials() []Credential {
+	credentials := slices.Collect(maps.Values(s.credentials))
+	sort.Slice(credentials, func(i, j int) bool { return credentials[i].Name < credentials[j].Name })
+	return credentials
+}
+
+// encode serializes the in-memory records: draws by round, materialized
+// months, credentials by name, then purchases and archived pages in
+// insertion order.
+func (s *JSONStore) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+
+	rounds := make([]int, 0, len(s.draws))
+	for round := range s.draws {
+		rounds = append(rounds, round)
+	}
+	sort.Ints(rounds)
+	for _, round := range rounds {
+		if err := encoder.Encode(s.sealer.sealRecord(drawRecord(s.draws[round]))); err != nil {
+			return nil, err
+		}
+	}
+	keys := make([]string, 0, len(s.months))
+	for key := range s.months {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := encoder.Encode(s.sealer.sealRecord(monthRecord(s.months[key]))); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range s.sortedCredentials() {
+		if err := encoder.Encode(s.sealer.sealRecord(credentialRecord(c))); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range s.purchases {
+		if err := encoder.Encode(s.sealer.sealRecord(purchaseRecord(p))); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range s.pages {
+		if err := encoder.Encode(s.sealer.sealRecord(pageRecord(p))); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// rewrite replaces the file with the encoded in-memory records. The file is
+// swapped atomically so a crash never leaves a half-written ledger.
+func (s *JSONStore) rewrite() error {
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	s.written()
+	return nil
+}
+
+// Snapshot writes the current contents of the file to w.
+func (s *JSONStore) Snapshot(ctx context.Context, w io.Writer) error {
+	s.mu.Lock()
+	data, err := s.encode()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Close releases nothing; every write is already on disk.
+func (s *JSONStore) Close() error {
+	return nil
+}
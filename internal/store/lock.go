@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"weekly-lotto/internal/filelock"
+)
+
+// lockTimeout bounds how long a write waits for another process holding the
+// lock, e.g. a manual check overlapping the scheduled buy.
+const lockTimeout = 30 * time.Second
+
+const lockRetryInterval = 50 * time.Millisecond
+
+// fileLock is an exclusive advisory lock held on a sidecar file next to a
+// store file. The lock file is left in place after unlocking; removing it
+// would let two processes lock different files of the same name.
+type fileLock struct {
+	file *os.File
+}
+
+// lockFile locks path (creating it if needed), waiting up to lockTimeout
+// for other processes to release it.
+func lockFile(path string) (*fileLock, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("잠금 파일 열기 실패: %w", err)
+	}
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		err := filelock.TryLock(file)
+		if err == nil {
+			return &fileLock{file: file}, nil
+		}
+		if !errors.Is(err, filelock.ErrLocked) {
+			file.Close()
+			return nil, fmt.Errorf("%s 잠금 실패: %w", path, err)
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, fmt.Errorf("다른 weekly-lotto 실행이 저장소를 %s 넘게 사용 중입니다 (%s)", lockTimeout, path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// Unlock releases the lock.
+func (l *fileLock) Unlock() error {
+	err := filelock.Unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
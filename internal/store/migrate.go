@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationFiles holds the schema changes of the SQL drivers, one directory
+// per dialect. Files are named NNNN_description.sql and applied in order of
+// their version number; a released file must never be edited, only followed
+// by a new one.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// migration is a single versioned schema change.
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrationDialect holds the statements that differ between SQL drivers.
+type migrationDialect struct {
+	dir         string
+	createTable string
+	insert      string
+	// lock serializes migrators of the same database for the rest of the
+	// transaction; empty when the driver's transactions already do.
+	lock string
+}
+
+var sqliteMigrations = migrationDialect{
+	dir: "migrations/sqlite",
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT      NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`,
+	insert: `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+}
+
+var postgresMigrations = migrationDialect{
+	dir: "migrations/postgres",
+	createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT        NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`,
+	insert: `INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+	// 키 값은 임의의 고정 값 (weekly-lotto 전용)
+	lock: `SELECT pg_advisory_xact_lock(7290113)`,
+}
+
+// loadMigrations reads the migrations of dialect sorted by version.
+func loadMigrations(dialect migrationDialect) ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, dialect.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migration
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 || path.Ext(name) != ".sql" {
+			return nil, fmt.Errorf("마이그레이션 파일 이름 오류: %s", name)
+		}
+		data, err := migrationFiles.ReadFile(path.Join(dialect.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration{version: version, name: strings.TrimSuffix(name, ".sql"), sql: string(data)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].version == migrations[i-1].version {
+			return nil, fmt.Errorf("마이그레이션 버전 중복: %s, %s", migrations[i-1].name, migrations[i].name)
+		}
+	}
+	return migrations, nil
+}
+
+// migrate brings db up to the latest schema of dialect, applying the pending
+// migrations in one transaction so a failed upgrade leaves the database as
+// it was. It refuses a database migrated by a newer release, whose schema
+// this one may not understand.
+func migrate(ctx context.Context, db *sql.DB, dialect migrationDialect) error {
+	migrations, err := loadMigrations(dialect)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if dialect.lock != "" {
+		if _, err := tx.ExecContext(ctx, dialect.lock); err != nil {
+			return fmt.Errorf("마이그레이션 잠금 실패: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, dialect.createTable); err != nil {
+		return err
+	}
+
+	var current int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+	if latest := migrations[len(migrations)-1].version; current > latest {
+		return fmt.Errorf("저장소 스키마 버전(%d)이 이 버전이 아는 최신 버전(%d)보다 높습니다 - weekly-lotto를 업데이트하세요", current, latest)
+	}
+
+	now := time.Now()
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+			return fmt.Errorf("마이그레이션 %s 실패: %w", m.name, err)
+		}
+		if _, err := tx.ExecContext(ctx, dialect.insert, m.version, m.name, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
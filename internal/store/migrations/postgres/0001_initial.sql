@@ -0,0 +1,46 @@
+-- Tables of the first release. IF NOT EXISTS lets databases created before
+-- migrations existed adopt this version as is.
+CREATE TABLE IF NOT EXISTS purchases (
+	id           BIGSERIAL PRIMARY KEY,
+	account      TEXT    NOT NULL,
+	round        INTEGER NOT NULL,
+	order_no     TEXT    NOT NULL DEFAULT '',
+	slot         TEXT    NOT NULL,
+	mode         TEXT    NOT NULL,
+	numbers      TEXT    NOT NULL,
+	amount       BIGINT  NOT NULL,
+	purchased_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_purchases_account_time ON purchases (account, purchased_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_purchases_order ON purchases (round, order_no, slot) WHERE order_no <> '';
+CREATE TABLE IF NOT EXISTS draws (
+	round     INTEGER PRIMARY KEY,
+	draw_date TIMESTAMPTZ NOT NULL,
+	numbers   TEXT    NOT NULL,
+	bonus     INTEGER NOT NULL,
+	prizes    TEXT    NOT NULL
+);
+CREATE TABLE IF NOT EXISTS months (
+	month      TEXT PRIMARY KEY,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+CREATE TABLE IF NOT EXISTS monthly_totals (
+	month     TEXT    NOT NULL REFERENCES months (month) ON DELETE CASCADE,
+	account   TEXT    NOT NULL,
+	tickets   INTEGER NOT NULL,
+	spent     BIGINT  NOT NULL,
+	winnings  BIGINT  NOT NULL,
+	ranks     TEXT    NOT NULL,
+	best_rank INTEGER NOT NULL,
+	PRIMARY KEY (month, account)
+);
+CREATE TABLE IF NOT EXISTS pages (
+	id         BIGSERIAL PRIMARY KEY,
+	account    TEXT    NOT NULL,
+	kind       TEXT    NOT NULL,
+	round      INTEGER NOT NULL,
+	url        TEXT    NOT NULL,
+	fetched_at TIMESTAMPTZ NOT NULL,
+	body       BYTEA   NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_pages_round ON pages (round, kind);
@@ -0,0 +1,8 @@
+-- How long each password has been in use, for rotation reminders. Only a
+-- salted hash of the password is kept.
+CREATE TABLE credentials (
+	name        TEXT        PRIMARY KEY,
+	fingerprint TEXT        NOT NULL,
+	since       TIMESTAMPTZ NOT NULL,
+	reminded_at TIMESTAMPTZ
+);
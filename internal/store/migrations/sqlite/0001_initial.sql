@@ -0,0 +1,46 @@
+-- Tables of the first release. IF NOT EXISTS lets databases created before
+-- migrations existed adopt this version as is.
+CREATE TABLE IF NOT EXISTS purchases (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	account      TEXT    NOT NULL,
+	round        INTEGER NOT NULL,
+	order_no     TEXT    NOT NULL DEFAULT '',
+	slot         TEXT    NOT NULL,
+	mode         TEXT    NOT NULL,
+	numbers      TEXT    NOT NULL,
+	amount       INTEGER NOT NULL,
+	purchased_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_purchases_account_time ON purchases (account, purchased_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_purchases_order ON purchases (round, order_no, slot) WHERE order_no <> '';
+CREATE TABLE IF NOT EXISTS draws (
+	round     INTEGER PRIMARY KEY,
+	draw_date TIMESTAMP NOT NULL,
+	numbers   TEXT    NOT NULL,
+	bonus     INTEGER NOT NULL,
+	prizes    TEXT    NOT NULL
+);
+CREATE TABLE IF NOT EXISTS months (
+	month      TEXT PRIMARY KEY,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE TABLE IF NOT EXISTS monthly_totals (
+	month     TEXT    NOT NULL REFERENCES months (month) ON DELETE CASCADE,
+	account   TEXT    NOT NULL,
+	tickets   INTEGER NOT NULL,
+	spent     INTEGER NOT NULL,
+	winnings  INTEGER NOT NULL,
+	ranks     TEXT    NOT NULL,
+	best_rank INTEGER NOT NULL,
+	PRIMARY KEY (month, account)
+);
+CREATE TABLE IF NOT EXISTS pages (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	account    TEXT    NOT NULL,
+	kind       TEXT    NOT NULL,
+	round      INTEGER NOT NULL,
+	url        TEXT    NOT NULL,
+	fetched_at TIMESTAMP NOT NULL,
+	body       BLOB    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_pages_round ON pages (round, kind);
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"time"
+
+	"weekly-lotto/internal/domain"
+)
+
+// MonthlyTotal aggregates one account's tickets bought in a month. Only
+// settled months are materialized, so every ticket in it has been drawn.
+type MonthlyTotal struct {
+	Account  string `json:"account"`
+	Tickets  int    `json:"tickets"`
+	Spent    int64  `json:"spent"`
+	Winnings int64  `json:"winnings"`
+	Ranks    [5]int `json:"ranks"` // index 0 is 1등
+}
+
+// BestRank returns the best rank won in the month (1~5), or 0 if nothing won.
+func (t MonthlyTotal) BestRank() int {
+	for i, count := range t.Ranks {
+		if count > 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// MonthlySummary is a materialized month: the totals of every account that
+// bought tickets in it. Totals is empty for a month without purchases.
+type MonthlySummary struct {
+	Month  time.Time // first day of the month, KST
+	Totals []MonthlyTotal
+}
+
+// MonthStart returns the first day of t's month in KST.
+func MonthStart(t time.Time) time.Time {
+	t = t.In(domain.Seoul)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, domain.Seoul)
+}
+
+// monthKey identifies a month in the stores: "2026-09".
+func monthKey(t time.Time) string {
+	return t.In(domain.Seoul).Format("2006-01")
+}
+
+// purchaseMonths returns the keys of the months purchases were made in.
+func purchaseMonths(purchases []Purchase) map[string]bool {
+	months := make(map[string]bool)
+	for _, p := range purchases {
+		months[monthKey(p.PurchasedAt)] = true
+	}
+	return months
+}
+
+// drawMonths returns the keys of the months in which tickets for draws could
+// have been bought: a round is on sale during the week before its draw.
+func drawMonths(draws []*domain.WinningNumbers) map[string]bool {
+	months := make(map[string]bool)
+	for _, draw := range draws {
+		drawDate := domain.DrawDate(draw.Round)
+		months[monthKey(drawDate)] = true
+		months[monthKey(drawDate.AddDate(0, 0, -7))] = true
+	}
+	return months
+}
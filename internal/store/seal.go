@@ -0,0 +1,174 @@
+package store
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the length of a store encryption key.
+const KeySize = 32
+
+// Prefixes that mark a sealed value. Values without them are plaintext
+// written before encryption was enabled and are read as is.
+var sealedMagic = []byte("wlenc1\x00")
+
+const sealedPrefix = "enc1:"
+
+// ErrEncrypted is returned when reading sealed data without a key.
+var ErrEncrypted = errors.New("암호화된 저장소입니다 - store.encryptionKey (LOTTO_STORE_ENCRYPTION_KEY) 가 필요합니다")
+
+// sealer encrypts the ticket numbers, order numbers and archived pages a
+// driver writes. It uses AES-256-GCM with a nonce derived from the
+// plaintext (HMAC-SHA256), so the same value always seals the same way and
+// drivers can still look rows up by their sealed numbers; only equality of
+// values is revealed. A nil sealer leaves values in plaintext.
+type sealer struct {
+	aead cipher.AEAD
+	mac  []byte
+}
+
+// newSealer returns a sealer for key, or nil when key is empty.
+func newSealer(key []byte) (*sealer, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("저장소 암호화 키는 %d바이트여야 합니다: %d바이트", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(deriveKey(key, "encrypt"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &sealer{aead: aead, mac: deriveKey(key, "nonce")}, nil
+}
+
+// deriveKey derives an independent subkey of key for purpose.
+func deriveKey(key []byte, purpose string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte("weekly-lotto store " + purpose))
+	return h.Sum(nil)
+}
+
+// sealBytes encrypts data. Empty data stays empty.
+func (c *sealer) sealBytes(data []byte) []byte {
+	if c == nil || len(data) == 0 {
+		return data
+	}
+	h := hmac.New(sha256.New, c.mac)
+	h.Write(data)
+	nonce := h.Sum(nil)[:c.aead.NonceSize()]
+
+	out := append(append([]byte(nil), sealedMagic...), nonce...)
+	return c.aead.Seal(out, nonce, data, sealedMagic)
+}
+
+// openBytes reverses sealBytes, passing plaintext through unchanged.
+func (c *sealer) openBytes(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, sealedMagic) {
+		return data, nil
+	}
+	if c == nil {
+		return nil, ErrEncrypted
+	}
+	data = data[len(sealedMagic):]
+	if len(data) < c.aead.NonceSize() {
+		return nil, errors.New("암호화된 값이 손상되었습니다")
+	}
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, sealedMagic)
+	if err != nil {
+		return nil, errors.New("저장소 암호화 키가 맞지 않거나 값이 손상되었습니다")
+	}
+	return plain, nil
+}
+
+// seal encrypts a text column. Empty strings stay empty so "no order
+// number" is still recognizable.
+func (c *sealer) seal(s string) string {
+	if c == nil || s == "" {
+		return s
+	}
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(c.sealBytes([]byte(s)))
+}
+
+// open reverses seal, passing plaintext through unchanged.
+func (c *sealer) open(s string) (string, error) {
+	encoded, ok := strings.CutPrefix(s, sealedPrefix)
+	if !ok {
+		return s, nil
+	}
+	if c == nil {
+		return "", ErrEncrypted
+	}
+	data, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || !bytes.HasPrefix(data, sealedMagic) {
+		return "", errors.New("암호화된 값이 손상되었습니다")
+	}
+	plain, err := c.openBytes(data)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// stale reports whether a stored text column still holds plaintext that c
+// would seal: the row was written before encryption was enabled and is
+// sealed the next time it is saved.
+func (c *sealer) stale(stored string) bool {
+	return c != nil && stored != "" && !strings.HasPrefix(stored, sealedPrefix)
+}
+
+// sealNumbers encodes and seals ticket numbers for a text column.
+func (c *sealer) sealNumbers(numbers []int) string {
+	return c.seal(encodeNumbers(numbers))
+}
+
+// openNumbers reverses sealNumbers.
+func (c *sealer) openNumbers(s string) ([]int, error) {
+	plain, err := c.open(s)
+	if err != nil {
+		return nil, err
+	}
+	return decodeNumbers(plain)
+}
+
+// openPurchase opens the sealed order number of p in place and sets its
+// numbers from the stored numbers column.
+func (c *sealer) openPurchase(p *Purchase, numbers string) error {
+	orderNo, err := c.open(p.OrderNo)
+	if err != nil {
+		return err
+	}
+	p.OrderNo = orderNo
+	p.Numbers, err = c.openNumbers(numbers)
+	return err
+}
+
+// sealPage compresses, then seals a page body for storage.
+func (c *sealer) sealPage(body []byte) ([]byte, error) {
+	compressed, err := compressPage(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.sealBytes(compressed), nil
+}
+
+// openPage reverses sealPage.
+func (c *sealer) openPage(data []byte) ([]byte, error) {
+	compressed, err := c.openBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return decompressPage(compressed)
+}
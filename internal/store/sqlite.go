@@ -0,0 +1,553 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"weekly-lotto/internal/domain"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func init() {
+	Register(DriverSQLite, Driver{
+		Open: func(path string, key []byte) (Store, error) { return OpenSQLite(path, key) },
+	})
+}
+
+// SQLiteStore is the default Store backed by a local SQLite file.
+type SQLiteStore struct {
+	db     *sql.DB
+	sealer *sealer
+}
+
+// OpenSQLite opens (and creates if needed) the SQLite database at path and
+// applies any pending migrations. Transactions take the write lock up front
+// (_txlock=immediate) so two processes opening an old database wait for
+// each other instead of failing halfway through an upgrade. A non-empty key
+// encrypts private fields (see sealer).
+func OpenSQLite(path string, key []byte) (*SQLiteStore, error) {
+	sealer, err := newSealer(key)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", path+"?_busy_timeout=5000&_journal_mode=WAL&_foreign_keys=on&_txlock=immediate")
+	if err != nil {
+		return nil, fmt.Errorf("SQLite 열기 실패: %w", err)
+	}
+
+	if err := migrate(context.Background(), db, sqliteMigrations); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("SQLite 스키마 마이그레이션 실패: %w", err)
+	}
+
+	return &SQLiteStore{db: db, sealer: sealer}, nil
+}
+
+// SavePurchases records purchased tickets in a single transaction, updating
+// the tickets already recorded under the same round, order number and slot.
+func (s *SQLiteStore) SavePurchases(ctx context.Context, purchases []Purchase) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var changed []Purchase
+	for _, p := range purchases {
+		ok, err := s.upsertPurchase(ctx, tx, p)
+		if err != nil {
+			return fmt.Errorf("구매 기록 저장 실패: %w", err)
+		}
+		if ok {
+			changed = append(changed, p)
+		}
+	}
+	if err := discardMonths(ctx, tx, purchaseMonths(changed)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// upsertPurchase inserts p or updates the row it matches (see matchPurchase)
+// and reports whether anything changed. Rows are matched on both the sealed
+// and the plaintext values, so rows written before encryption was enabled
+// are found, and sealed, rather than recorded again.
+func (s *SQLiteStore) upsertPurchase(ctx context.Context, tx *sql.Tx, p Purchase) (bool, error) {
+	if p.OrderNo != "" {
+		id, row, stale, err := s.findPurchase(ctx, tx, `round = ? AND order_no IN (?, ?) AND slot = ?`,
+			p.Round, s.sealer.seal(p.OrderNo), p.OrderNo, p.Slot)
+		if err != nil {
+			return false, err
+		}
+		if id == 0 {
+			id, row, stale, err = s.findPurchase(ctx, tx, `order_no = '' AND account = ? AND round = ? AND slot = ? AND numbers IN (?, ?)`,
+				p.Account, p.Round, p.Slot, s.sealer.sealNumbers(p.Numbers), encodeNumbers(p.Numbers))
+			if err != nil {
+				return false, err
+			}
+		}
+		if id != 0 {
+			merged, changed := mergePurchase(row, p)
+			if !changed && !stale {
+				return false, nil
+			}
+			_, err := tx.ExecContext(ctx, `
+				UPDATE purchases SET account = ?, order_no = ?, mode = ?, numbers = ?, amount = ?
+				WHERE id = ?`,
+				merged.Account, s.sealer.seal(merged.OrderNo), merged.Mode, s.sealer.sealNumbers(merged.Numbers), merged.Amount, id,
+			)
+			return changed && err == nil, err
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO purchases (account, round, order_no, slot, mode, numbers, amount, purchased_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		p.Account, p.Round, s.sealer.seal(p.OrderNo), p.Slot, p.Mode,
+		s.sealer.sealNumbers(p.Numbers), p.Amount, p.PurchasedAt.UTC(),
+	)
+	return err == nil, err
+}
+
+// findPurchase returns the id and contents of the first row matching where,
+// or a zero id when there is none, and whether the row is stale (see
+// sealer.stale).
+func (s *SQLiteStore) findPurchase(ctx context.Context, tx *sql.Tx, where string, args ...any) (int64, Purchase, bool, error) {
+	var id int64
+	var p Purchase
+	var numbers string
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, account, round, order_no, slot, mode, numbers, amount, purchased_at
+		FROM purchases WHERE `+where+` ORDER BY id LIMIT 1`, args...,
+	).Scan(&id, &p.Account, &p.Round, &p.OrderNo, &p.Slot, &p.Mode, &numbers, &p.Amount, &p.PurchasedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, Purchase{}, false, nil
+	}
+	if err != nil {
+		return 0, Purchase{}, false, err
+	}
+	stale := s.sealer.stale(numbers)
+	err = s.sealer.openPurchase(&p, numbers)
+	return id, p, stale, err
+}
+
+// Purchases returns the recorded purchases matching filter, oldest first.
+func (s *SQLiteStore) Purchases(ctx context.Context, filter PurchaseFilter) ([]Purchase, error) {
+	query := `
+		SELECT account, round, order_no, slot, mode, numbers, amount, purchased_at
+		FROM purchases WHERE 1 = 1`
+	var args []any
+	if filter.Account != "" {
+		query += " AND account = ?"
+		args = append(args, filter.Account)
+	}
+	if filter.FromRound > 0 {
+		query += " AND round >= ?"
+		args = append(args, filter.FromRound)
+	}
+	if filter.ToRound > 0 {
+		query += " AND round <= ?"
+		args = append(args, filter.ToRound)
+	}
+	if !filter.From.IsZero() {
+		query += " AND purchased_at >= ?"
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		query += " AND purchased_at < ?"
+		args = append(args, filter.To.UTC())
+	}
+	query += " ORDER BY purchased_at, id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("구매 기록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var purchases []Purchase
+	for rows.Next() {
+		var p Purchase
+		var numbers string
+		if err := rows.Scan(&p.Account, &p.Round, &p.OrderNo, &p.Slot, &p.Mode, &numbers, &p.Amount, &p.PurchasedAt); err != nil {
+			return nil, fmt.Errorf("구매 기록 읽기 실패: %w", err)
+		}
+		if err := s.sealer.openPurchase(&p, numbers); err != nil {
+			return nil, err
+		}
+		purchases = append(purchases, p)
+	}
+	return purchases, rows.Err()
+}
+
+// SaveDraws records published winning numbers in a single transaction.
+// Prize information is stored as JSON keyed by rank.
+func (s *SQLiteStore) SaveDraws(ctx context.Context, draws []*domain.WinningNumbers) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT OR REPLACE INTO draws (round, draw_date, numbers, bonus, prizes)
+		VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, draw := range draws {
+		prizes, err := json.Marshal(draw.Prizes)
+		if err != nil {
+			return fmt.Errorf("%d회 당첨금 인코딩 실패: %w", draw.Round, err)
+		}
+		if _, err := stmt.ExecContext(ctx,
+			draw.Round, draw.DrawDate.UTC(), encodeNumbers(draw.Numbers), draw.BonusNumber, string(prizes),
+		); err != nil {
+			return fmt.Errorf("%d회 당첨 번호 저장 실패: %w", draw.Round, err)
+		}
+	}
+	if err := discardMonths(ctx, tx, drawMonths(draws)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// discardMonths drops the materialized aggregates of months.
+func discardMonths(ctx context.Context, tx *sql.Tx, months map[string]bool) error {
+	for month := range months {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM months WHERE month = ?`, month); err != nil {
+			return fmt.Errorf("월별 집계 삭제 실패: %w", err)
+		}
+	}
+	return nil
+}
+
+// SaveMonthly materializes the totals of month in a single transaction.
+func (s *SQLiteStore) SaveMonthly(ctx context.Context, month time.Time, totals []MonthlyTotal) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	key := monthKey(month)
+	if err := discardMonths(ctx, tx, map[string]bool{key: true}); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO months (month, updated_at) VALUES (?, ?)`, key, time.Now().UTC()); err != nil {
+		return fmt.Errorf("%s 월별 집계 저장 실패: %w", key, err)
+	}
+	for _, total := range totals {
+		ranks, err := json.Marshal(total.Ranks)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO monthly_totals (month, account, tickets, spent, winnings, ranks, best_rank)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			key, total.Account, total.Tickets, total.Spent, total.Winnings, string(ranks), total.BestRank(),
+		); err != nil {
+			return fmt.Errorf("%s 월별 집계 저장 실패: %w", key, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Monthly returns the materialized months starting in [from, to).
+func (s *SQLiteStore) Monthly(ctx context.Context, from, to time.Time) ([]MonthlySummary, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT m.month, t.account, t.tickets, t.spent, t.winnings, t.ranks
+		FROM months m LEFT JOIN monthly_totals t ON t.month = m.month
+		WHERE m.month >= ? AND m.month < ?
+		ORDER BY m.month, t.account`,
+		monthKey(from), monthKey(to),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("월별 집계 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var months []MonthlySummary
+	for rows.Next() {
+		var key string
+		var account, ranks sql.NullString
+		var total MonthlyTotal
+		var tickets, spent, winnings sql.NullInt64
+		if err := rows.Scan(&key, &account, &tickets, &spent, &winnings, &ranks); err != nil {
+			return nil, fmt.Errorf("월별 집계 읽기 실패: %w", err)
+		}
+		if len(months) == 0 || monthKey(months[len(months)-1].Month) != key {
+			month, err := time.ParseInLocation("2006-01", key, domain.Seoul)
+			if err != nil {
+				return nil, fmt.Errorf("월별 집계 읽기 실패: %w", err)
+			}
+			months = append(months, MonthlySummary{Month: month, Totals: []MonthlyTotal{}})
+		}
+		if !account.Valid {
+			continue
+		}
+		total.Account = account.String
+		total.Tickets, total.Spent, total.Winnings = int(tickets.Int64), spent.Int64, winnings.Int64
+		if err := json.Unmarshal([]byte(ranks.String), &total.Ranks); err != nil {
+			return nil, fmt.Errorf("%s 월별 집계 읽기 실패: %w", key, err)
+		}
+		last := &months[len(months)-1]
+		last.Totals = append(last.Totals, total)
+	}
+	return months, rows.Err()
+}
+
+// SaveCredentials records credentials in a single transaction, replacing
+// those of the same name.
+func (s *SQLiteStore) SaveCredentials(ctx context.Context, credentials []Credential) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range credentials {
+		var remindedAt sql.NullTime
+		if !c.RemindedAt.IsZero() {
+			remindedAt = sql.NullTime{Time: c.RemindedAt.UTC(), Valid: true}
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO credentials (name, fingerprint, since, reminded_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (name) DO UPDATE SET fingerprint = excluded.fingerprint, since = excluded.since, reminded_at = excluded.reminded_at`,
+			c.Name, c.Fingerprint, c.Since.UTC(), remindedAt,
+		); err != nil {
+			return fmt.Errorf("%s 자격 증명 기록 실패: %w", c.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Credentials returns the tracked credentials ordered by name.
+func (s *SQLiteStore) Credentials(ctx context.Context) ([]Credential, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT name, fingerprint, since, reminded_at FROM credentials ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("자격 증명 기록 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var credentials []Credential
+	for rows.Next() {
+		var c Credential
+		var remindedAt sql.NullTime
+		if err := rows.Scan(&c.Name, &c.Fingerprint, &c.Since, &remindedAt); err != nil {
+			return nil, fmt.Errorf("자격 증명 기록 읽기 실패: %w", err)
+		}
+		if remindedAt.Valid {
+			c.RemindedAt = remindedAt.Time
+		}
+		credentials = append(credentials, c)
+	}
+	return credentials, rows.Err()
+}
+
+// Draws returns the recorded draws in [fromRound, toRound], oldest first.
+func (s *SQLiteStore) Draws(ctx context.Context, fromRound, toRound int) ([]*domain.WinningNumbers, error) {
+	query := "SELECT round, draw_date, numbers, bonus, prizes FROM draws WHERE 1 = 1"
+	var args []any
+	if fromRound > 0 {
+		query += " AND round >= ?"
+		args = append(args, fromRound)
+	}
+	if toRound > 0 {
+		query += " AND round <= ?"
+		args = append(args, toRound)
+	}
+	query += " ORDER BY round"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var draws []*domain.WinningNumbers
+	for rows.Next() {
+		draw := &domain.WinningNumbers{}
+		var numbers, prizes string
+		if err := rows.Scan(&draw.Round, &draw.DrawDate, &numbers, &draw.BonusNumber, &prizes); err != nil {
+			return nil, fmt.Errorf("당첨 번호 읽기 실패: %w", err)
+		}
+		if draw.Numbers, err = decodeNumbers(numbers); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(prizes), &draw.Prizes); err != nil {
+			return nil, fmt.Errorf("%d회 당첨금 읽기 실패: %w", draw.Round, err)
+		}
+		draw.DrawDate = draw.DrawDate.In(domain.Seoul)
+		draws = append(draws, draw)
+	}
+	return draws, rows.Err()
+}
+
+// Spent returns the total amount account spent in [from, to).
+func (s *SQLiteStore) Spent(ctx context.Context, account string, from, to time.Time) (int64, error) {
+	var total sql.NullInt64
+	err := s.db.QueryRowContext(ctx, `
+		SELECT SUM(amount) FROM purchases
+		WHERE account = ? AND purchased_at >= ? AND purchased_at < ?`,
+		account, from.UTC(), to.UTC(),
+	).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("지출 합계 조회 실패: %w", err)
+	}
+	return total.Int64, nil
+}
+
+// PrunePurchases deletes purchases made before before, then vacuums the
+// database file so it actually shrinks.
+func (s *SQLiteStore) PrunePurchases(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM purchases WHERE purchased_at < ?`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("구매 기록 삭제 실패: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+	if _, err := s.db.ExecContext(ctx, `VACUUM`); err != nil {
+		return deleted, fmt.Errorf("저장소 정리(VACUUM) 실패: %w", err)
+	}
+	return deleted, nil
+}
+
+// SavePages archives pages, gzip-compressed, in a single transaction.
+func (s *SQLiteStore) SavePages(ctx context.Context, pages []Page) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO pages (account, kind, round, url, fetched_at, body)
+		VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, p := range pages {
+		body, err := s.sealer.sealPage(p.Body)
+		if err != nil {
+			return fmt.Errorf("페이지 압축 실패: %w", err)
+		}
+		if _, err := stmt.ExecContext(ctx, p.Account, p.Kind, p.Round, p.URL, p.FetchedAt.UTC(), body); err != nil {
+			return fmt.Errorf("페이지 저장 실패: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Pages returns the archived pages matching filter, oldest first.
+func (s *SQLiteStore) Pages(ctx context.Context, filter PageFilter) ([]Page, error) {
+	query := "SELECT account, kind, round, url, fetched_at, body FROM pages WHERE 1 = 1"
+	var args []any
+	if filter.Account != "" {
+		query += " AND account = ?"
+		args = append(args, filter.Account)
+	}
+	if filter.Kind != "" {
+		query += " AND kind = ?"
+		args = append(args, filter.Kind)
+	}
+	if filter.FromRound > 0 {
+		query += " AND round >= ?"
+		args = append(args, filter.FromRound)
+	}
+	if filter.ToRound > 0 {
+		query += " AND round <= ?"
+		args = append(args, filter.ToRound)
+	}
+	query += " ORDER BY fetched_at, id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("페이지 조회 실패: %w", err)
+	}
+	defer rows.Close()
+
+	var pages []Page
+	for rows.Next() {
+		var p Page
+		var body []byte
+		if err := rows.Scan(&p.Account, &p.Kind, &p.Round, &p.URL, &p.FetchedAt, &body); err != nil {
+			return nil, fmt.Errorf("페이지 읽기 실패: %w", err)
+		}
+		if p.Body, err = s.sealer.openPage(body); err != nil {
+			return nil, err
+		}
+		pages = append(pages, p)
+	}
+	return pages, rows.Err()
+}
+
+// PrunePages deletes pages fetched before before, then vacuums the database
+// file so it actually shrinks.
+func (s *SQLiteStore) PrunePages(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM pages WHERE fetched_at < ?`, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("페이지 삭제 실패: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+	if _, err := s.db.ExecContext(ctx, `VACUUM`); err != nil {
+		return deleted, fmt.Errorf("저장소 정리(VACUUM) 실패: %w", err)
+	}
+	return deleted, nil
+}
+
+// Snapshot writes a copy of the database made with VACUUM INTO, which
+// includes changes still in the WAL and is safe while other connections
+// write.
+func (s *SQLiteStore) Snapshot(ctx context.Context, w io.Writer) error {
+	dir, err := os.MkdirTemp("", "weekly-lotto-snapshot-*")
+	if err != nil {
+		return fmt.Errorf("스냅샷 임시 디렉터리 생성 실패: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "snapshot.db")
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return fmt.Errorf("SQLite 스냅샷 생성 실패: %w", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
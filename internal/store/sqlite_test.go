@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Syncing a ledger written before store.encryptionKey was set must find the
+// plaintext rows, seal them and leave no duplicates behind.
+func TestSQLiteResyncSealsPlaintextPurchases(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "ledger.db")
+	at := time.Date(2026, 10, 10, 10, 0, 0, 0, time.UTC)
+	bought := []Purchase{
+		// buy가 기록한 주문 번호 없는 티켓과 동기화로 들어온 티켓
+		{Account: "tester", Round: 1245, Slot: "A", Mode: "자동", Numbers: []int{1, 5, 22, 31, 33, 42}, Amount: 1000, PurchasedAt: at},
+		{Account: "tester", Round: 1245, OrderNo: "order-1", Slot: "B", Mode: "수동", Numbers: []int{1, 2, 3, 4, 5, 6}, Amount: 1000, PurchasedAt: at},
+	}
+
+	plain, err := OpenSQLite(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.SavePurchases(ctx, bought); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := OpenSQLite(path, []byte(strings.Repeat("k", 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sealed.Close()
+	synced := []Purchase{bought[0], bought[1]}
+	synced[0].OrderNo = "order-1"
+	if err := sealed.SavePurchases(ctx, synced); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := sealed.Purchases(ctx, PurchaseFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(bought) {
+		t.Fatalf("ledger holds %d purchases after the re-sync, want %d: %+v", len(got), len(bought), got)
+	}
+	for _, p := range got {
+		if p.OrderNo != "order-1" {
+			t.Errorf("slot %s order = %q, want order-1", p.Slot, p.OrderNo)
+		}
+	}
+
+	rows, err := sealed.db.QueryContext(ctx, `SELECT order_no, numbers FROM purchases`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var orderNo, numbers string
+		if err := rows.Scan(&orderNo, &numbers); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(orderNo, sealedPrefix) || !strings.HasPrefix(numbers, sealedPrefix) {
+			t.Errorf("stored row %q %q is still in plaintext", orderNo, numbers)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
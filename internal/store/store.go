@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"weekly-lotto/internal/domain"
+)
+
+// Purchase is a single purchased ticket recorded in the ledger.
+type Purchase struct {
+	Account     string
+	Round       int
+	OrderNo     string
+	Slot        string
+	Mode        string
+	Numbers     []int
+	Amount      int64
+	PurchasedAt time.Time
+}
+
+// PurchaseFilter narrows a purchase query. Zero values leave a bound open.
+type PurchaseFilter struct {
+	Account   string
+	FromRound int
+	ToRound   int
+	From      time.Time // inclusive
+	To        time.Time // exclusive
+}
+
+// Store persists purchases so spending can be tracked across runs. Drivers
+// implement it and register themselves with Register.
+type Store interface {
+	Ledger
+	DrawStore
+	MonthlyStore
+	PageArchive
+	CredentialStore
+	// Snapshot writes a consistent copy of the whole store file to w, in the
+	// driver's own format, so it can be restored by replacing the file.
+	// Stores on a database server return ErrSnapshotUnsupported.
+	Snapshot(ctx context.Context, w io.Writer) error
+	// Close releases the underlying resources.
+	Close() error
+}
+
+// Ledger records purchased tickets.
+type Ledger interface {
+	// SavePurchases records purchased tickets. A ticket with an order number
+	// updates the ticket already recorded under the same round, order number
+	// and slot (or the matching ticket recorded at purchase time without one)
+	// instead of adding a row, so syncing the same purchases again is a no-op.
+	SavePurchases(ctx context.Context, purchases []Purchase) error
+	// Purchases returns the recorded purchases matching filter, oldest first.
+	Purchases(ctx context.Context, filter PurchaseFilter) ([]Purchase, error)
+	// Spent returns the total amount account spent in [from, to).
+	Spent(ctx context.Context, account string, from, to time.Time) (int64, error)
+	// PrunePurchases deletes purchases made before before and reclaims the
+	// freed space. It returns the number of deleted records.
+	// Materialized monthly aggregates are kept.
+	PrunePurchases(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DrawStore records published winning numbers.
+type DrawStore interface {
+	// SaveDraws records published winning numbers, replacing existing rounds.
+	SaveDraws(ctx context.Context, draws []*domain.WinningNumbers) error
+	// Draws returns the recorded draws in [fromRound, toRound], oldest first.
+	// A zero bound is left open.
+	Draws(ctx context.Context, fromRound, toRound int) ([]*domain.WinningNumbers, error)
+}
+
+// MonthlyStore keeps the materialized totals of settled months.
+type MonthlyStore interface {
+	// SaveMonthly materializes the totals of a settled month, replacing any
+	// earlier aggregates of it. Saving purchases or changed draws that fall in
+	// a materialized month discards its aggregates again.
+	SaveMonthly(ctx context.Context, month time.Time, totals []MonthlyTotal) error
+	// Monthly returns the materialized months starting in [from, to), oldest
+	// first. Months that were never materialized are left out.
+	Monthly(ctx context.Context, from, to time.Time) ([]MonthlySummary, error)
+}
+
+// PageArchive keeps raw copies of scraped pages.
+type PageArchive interface {
+	// SavePages archives raw copies of scraped pages.
+	SavePages(ctx context.Context, pages []Page) error
+	// Pages returns the archived pages matching filter, oldest first.
+	Pages(ctx context.Context, filter PageFilter) ([]Page, error)
+	// PrunePages deletes pages fetched before before and reclaims the freed
+	// space. It returns the number of deleted pages.
+	PrunePages(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ErrSnapshotUnsupported is returned by Snapshot for stores that live on a
+// database server rather than in a local file.
+var ErrSnapshotUnsupported = errors.New("파일 저장소가 아니므로 스냅샷을 지원하지 않습니다")
+
+// encodeNumbers stores numbers as a comma-separated string ("1,2,3,4,5,6").
+func encodeNumbers(numbers []int) string {
+	parts := make([]string, len(numbers))
+	for i, n := range numbers {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
+
+// decodeNumbers parses a string written by encodeNumbers.
+func decodeNumbers(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("번호 형식 오류 %q: %w", s, err)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
@@ -0,0 +1,37 @@
+package store
+
+import "slices"
+
+// matchPurchase returns the index of the row in existing that p updates, or
+// -1 when p is a new ticket. A ticket with an order number matches the row
+// with the same round, order number and slot; failing that, it adopts a row
+// recorded at purchase time, before the order number was known, with the
+// same account, round, slot and numbers. Tickets without an order number
+// never match, so repeated purchases of a round are all kept.
+func matchPurchase(existing []Purchase, p Purchase) int {
+	if p.OrderNo == "" {
+		return -1
+	}
+	for i, row := range existing {
+		if row.Round == p.Round && row.OrderNo == p.OrderNo && row.Slot == p.Slot {
+			return i
+		}
+	}
+	for i, row := range existing {
+		if row.OrderNo == "" && row.Account == p.Account && row.Round == p.Round &&
+			row.Slot == p.Slot && slices.Equal(row.Numbers, p.Numbers) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergePurchase returns row updated with p and whether anything changed.
+// The original purchase time is kept, since synced tickets only carry an
+// estimate of it.
+func mergePurchase(row, p Purchase) (Purchase, bool) {
+	p.PurchasedAt = row.PurchasedAt
+	changed := row.Account != p.Account || row.OrderNo != p.OrderNo || row.Mode != p.Mode ||
+		row.Amount != p.Amount || !slices.Equal(row.Numbers, p.Numbers)
+	return p, changed
+}
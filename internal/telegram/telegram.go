@@ -0,0 +1,146 @@
+// Package telegram is a minimal client of the Telegram Bot API: long
+// polling for updates, sending and editing text messages with inline
+// keyboards and answering button presses.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultBaseURL is the Bot API endpoint; methods are called at
+// <base>/bot<token>/<method>.
+const defaultBaseURL = "https://api.telegram.org"
+
+// Bot calls the Bot API with a bot token.
+type Bot struct {
+	token   string
+	baseURL string
+	client  *http.Client
+}
+
+// New returns a client for the bot with token.
+func New(token string) *Bot {
+	return &Bot{token: token, baseURL: defaultBaseURL, client: &http.Client{}}
+}
+
+// Update is an incoming message or button press.
+type Update struct {
+	UpdateID      int64          `json:"update_id"`
+	Message       *Message       `json:"message,omitempty"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+}
+
+// Message is a chat message.
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	Chat      Chat   `json:"chat"`
+	From      *User  `json:"from,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+// Chat is the conversation a message belongs to.
+type Chat struct {
+	ID int64 `json:"id"`
+}
+
+// User is the sender of a message or button press.
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username,omitempty"`
+}
+
+// CallbackQuery is a press of an inline keyboard button.
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	From    User     `json:"from"`
+	Message *Message `json:"message,omitempty"`
+	Data    string   `json:"data,omitempty"`
+}
+
+// Button is an inline keyboard button that sends Data back when pressed.
+type Button struct {
+	Text string `json:"text"`
+	Data string `json:"callback_data"`
+}
+
+// Keyboard is an inline keyboard, one slice of buttons per row.
+type Keyboard [][]Button
+
+// Updates long-polls for updates after offset, waiting up to timeout for
+// one to arrive.
+func (b *Bot) Updates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	var updates []Update
+	err := b.call(ctx, "getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message", "callback_query"},
+	}, &updates)
+	return updates, err
+}
+
+// Send sends text to chat, with keyboard under it when not nil.
+func (b *Bot) Send(ctx context.Context, chatID int64, text string, keyboard Keyboard) (*Message, error) {
+	params := map[string]any{"chat_id": chatID, "text": text}
+	if keyboard != nil {
+		params["reply_markup"] = map[string]any{"inline_keyboard": keyboard}
+	}
+	var message Message
+	if err := b.call(ctx, "sendMessage", params, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// Edit replaces the text of a sent message, removing its keyboard.
+func (b *Bot) Edit(ctx context.Context, chatID, messageID int64, text string) error {
+	return b.call(ctx, "editMessageText", map[string]any{"chat_id": chatID, "message_id": messageID, "text": text}, nil)
+}
+
+// Answer acknowledges a button press, showing text briefly when not empty.
+func (b *Bot) Answer(ctx context.Context, queryID, text string) error {
+	return b.call(ctx, "answerCallbackQuery", map[string]any{"callback_query_id": queryID, "text": text}, nil)
+}
+
+// response is the envelope of every Bot API response.
+type response struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	Description string          `json:"description"`
+}
+
+func (b *Bot) call(ctx context.Context, method string, params map[string]any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/bot%s/%s", b.baseURL, b.token, method), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		// 오류 메시지의 URL에 토큰이 들어 있으므로 가림
+		return fmt.Errorf("텔레그램 %s 요청 실패: %s", method, strings.ReplaceAll(err.Error(), b.token, "***"))
+	}
+	defer resp.Body.Close()
+
+	var envelope response
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("텔레그램 %s 응답 해석 실패 (%s): %w", method, resp.Status, err)
+	}
+	if !envelope.OK {
+		return fmt.Errorf("텔레그램 %s 실패 (%s): %s", method, resp.Status, envelope.Description)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(envelope.Result, result)
+}
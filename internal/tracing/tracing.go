@@ -0,0 +1,284 @@
+// Package tracing records OpenTelemetry spans of a run and exports them to
+// an OTLP/HTTP collector as JSON, so no SDK is needed.
+//
+// Spans are only recorded after Configure; until then Start returns a nil
+// *Span whose methods do nothing, so call sites need no checks. Finished
+// spans are buffered and sent by Flush at the end of a run (or of each
+// scheduled job).
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"weekly-lotto/internal/config"
+	"weekly-lotto/internal/sanitize"
+)
+
+// serviceName is reported as the service.name resource attribute.
+const serviceName = "weekly-lotto"
+
+// maxBuffered caps the spans kept between flushes, so a collector that is
+// down does not grow a long-running daemon without bound.
+const maxBuffered = 2048
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+var (
+	mu       sync.Mutex
+	exporter *otlpExporter
+	finished []*Span
+)
+
+type otlpExporter struct {
+	url     string
+	headers map[string]string
+	version string
+}
+
+// Configure enables tracing with cfg, or disables it when no endpoint is
+// configured. version is reported as service.version.
+func Configure(cfg config.TracingConfig, version string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	exporter = nil
+	finished = nil
+	if cfg.Enabled() {
+		exporter = &otlpExporter{
+			url:     strings.TrimSuffix(cfg.Endpoint, "/") + "/v1/traces",
+			headers: cfg.Headers,
+			version: version,
+		}
+	}
+}
+
+// Enabled reports whether spans are being recorded.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return exporter != nil
+}
+
+// Attr is a span attribute.
+type Attr struct {
+	Key   string
+	Value any // string, int or bool
+}
+
+// String returns a string attribute.
+func String(key, value string) Attr { return Attr{Key: key, Value: value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attr { return Attr{Key: key, Value: value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attr { return Attr{Key: key, Value: value} }
+
+// Span is one timed step of a run.
+type Span struct {
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	start    time.Time
+	end      time.Time
+	attrs    []Attr
+	err      error
+}
+
+type spanKey struct{}
+
+// Start begins a span named name as a child of the span in ctx, and returns
+// a context carrying the new span.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	span := &Span{spanID: randomID(8), name: name, start: time.Now(), attrs: attrs}
+	if parent, ok := ctx.Value(spanKey{}).(*Span); ok {
+		span.traceID = parent.traceID
+		span.parentID = parent.spanID
+	} else {
+		span.traceID = randomID(16)
+	}
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SetAttr adds attributes to the span, e.g. the round once it is known.
+func (s *Span) SetAttr(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// End finishes the span, marking it failed when err is not nil.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	s.err = err
+
+	mu.Lock()
+	defer mu.Unlock()
+	if exporter == nil || len(finished) >= maxBuffered {
+		return
+	}
+	finished = append(finished, s)
+}
+
+// Flush exports the spans finished since the last flush.
+func Flush(ctx context.Context) error {
+	mu.Lock()
+	exp, spans := exporter, finished
+	finished = nil
+	mu.Unlock()
+
+	if exp == nil || len(spans) == 0 {
+		return nil
+	}
+	return exp.export(ctx, spans)
+}
+
+func (e *otlpExporter) export(ctx context.Context, spans []*Span) error {
+	body, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("트레이스 전송 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("트레이스 전송 실패: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// OTLP/JSON encoding of an ExportTraceServiceRequest. IDs are hex strings
+// and 64-bit integers are decimal strings, as the OTLP JSON mapping requires.
+type (
+	exportRequest struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}
+	resourceSpans struct {
+		Resource   resource     `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+	resource struct {
+		Attributes []keyValue `json:"attributes"`
+	}
+	scopeSpans struct {
+		Scope scope      `json:"scope"`
+		Spans []spanJSON `json:"spans"`
+	}
+	scope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	spanJSON struct {
+		TraceID           string     `json:"traceId"`
+		SpanID            string     `json:"spanId"`
+		ParentSpanID      string     `json:"parentSpanId,omitempty"`
+		Name              string     `json:"name"`
+		Kind              int        `json:"kind"`
+		StartTimeUnixNano string     `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+		Attributes        []keyValue `json:"attributes,omitempty"`
+		Status            status     `json:"status"`
+	}
+	status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	keyValue struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	}
+)
+
+// Span kind and status codes from the OTLP protocol.
+const (
+	spanKindInternal = 1
+	statusOK         = 1
+	statusError      = 2
+)
+
+func (e *otlpExporter) request(spans []*Span) exportRequest {
+	encoded := make([]spanJSON, 0, len(spans))
+	for _, s := range spans {
+		span := spanJSON{
+			TraceID:           s.traceID,
+			SpanID:            s.spanID,
+			ParentSpanID:      s.parentID,
+			Name:              s.name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        keyValues(s.attrs),
+			Status:            status{Code: statusOK},
+		}
+		if s.err != nil {
+			span.Status = status{Code: statusError, Message: sanitize.Error(s.err)}
+		}
+		encoded = append(encoded, span)
+	}
+
+	return exportRequest{ResourceSpans: []resourceSpans{{
+		Resource: resource{Attributes: keyValues([]Attr{
+			String("service.name", serviceName),
+			String("service.version", e.version),
+		})},
+		ScopeSpans: []scopeSpans{{
+			Scope: scope{Name: serviceName, Version: e.version},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+func keyValues(attrs []Attr) []keyValue {
+	values := make([]keyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		var value map[string]any
+		switch v := attr.Value.(type) {
+		case int:
+			value = map[string]any{"intValue": strconv.Itoa(v)}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		values = append(values, keyValue{Key: attr.Key, Value: value})
+	}
+	return values
+}
+
+// randomID returns n random bytes as lowercase hex.
+func randomID(n int) string {
+	id := make([]byte, n)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,331 @@
+// Package update replaces the running binary with a GitHub release.
+//
+// A release carries one binary per platform named by AssetName, a
+// checksums.txt in sha256sum format and checksums.txt.sig: an ed25519
+// signature of checksums.txt. Download checks both; only DownloadUnsigned,
+// for an explicit opt-out, trusts checksums.txt without a signature.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repository is the GitHub repository releases are published to.
+const Repository = "rlaxowns7916/weekly-lottoery"
+
+const (
+	checksumsAsset = "checksums.txt"
+	signatureAsset = checksumsAsset + ".sig"
+
+	// maxAssetSize bounds downloads so a bad release cannot fill the disk.
+	maxAssetSize = 100 << 20
+)
+
+// ErrNoAsset is returned when a release has no binary for this platform.
+var ErrNoAsset = errors.New("현재 플랫폼용 배포 파일이 없습니다")
+
+// ErrNoPublicKey is returned by Download without a key to verify the
+// release signature with.
+var ErrNoPublicKey = errors.New("서명을 검증할 공개 키가 없습니다")
+
+var httpClient = &http.Client{Timeout: 2 * time.Minute}
+
+// Release is a published GitHub release.
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// AssetName is the binary name of the current platform
+// (e.g. "weekly-lotto_linux_amd64").
+func AssetName() string {
+	name := fmt.Sprintf("weekly-lotto_%s_%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Latest returns the latest release of repo, or the release tagged tag when
+// tag is not empty.
+func Latest(ctx context.Context, repo, tag string) (*Release, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", repo)
+	if tag != "" {
+		url = fmt.Sprintf("https://api.github.com/repos/%s/releases/tags/%s", repo, tag)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("릴리스 조회 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("릴리스를 찾을 수 없습니다: %s %s", repo, tag)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("릴리스 조회 실패: HTTP %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("릴리스 응답 파싱 실패: %w", err)
+	}
+	return &release, nil
+}
+
+// asset returns the attached file called name.
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Newer reports whether the release is newer than current. A current
+// version that is not a release (e.g. "dev") is always older.
+func (r *Release) Newer(current string) bool {
+	latest, ok := parseVersion(r.Tag)
+	if !ok {
+		return false
+	}
+	installed, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	for i := range latest {
+		if latest[i] != installed[i] {
+			return latest[i] > installed[i]
+		}
+	}
+	return false
+}
+
+// parseVersion reads "v1.2.3" (pre-release suffixes are ignored).
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+	v, _, _ = strings.Cut(strings.TrimPrefix(v, "v"), "-")
+	fields := strings.Split(v, ".")
+	if len(fields) != 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// ParsePublicKey reads an ed25519 public key given either as a PEM block
+// ("-----BEGIN PUBLIC KEY-----", as written by openssl) or as the base64
+// encoding of the raw 32-byte key.
+func ParsePublicKey(value string) (ed25519.PublicKey, error) {
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("공개 키 파싱 실패: %w", err)
+		}
+		if edKey, ok := key.(ed25519.PublicKey); ok {
+			return edKey, nil
+		}
+		return nil, fmt.Errorf("ed25519 공개 키가 아닙니다")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("공개 키는 PEM 또는 32바이트 base64 형식이어야 합니다")
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// Download fetches the binary of the current platform from release and
+// verifies it against checksums.txt, and checksums.txt against its
+// signature with publicKey, which is required.
+func Download(ctx context.Context, release *Release, publicKey ed25519.PublicKey) ([]byte, error) {
+	if publicKey == nil {
+		return nil, ErrNoPublicKey
+	}
+	return download(ctx, release, publicKey)
+}
+
+// DownloadUnsigned is Download without the signature check: checksums.txt
+// only guards against a corrupted download, not against a tampered release.
+func DownloadUnsigned(ctx context.Context, release *Release) ([]byte, error) {
+	return download(ctx, release, nil)
+}
+
+// download is Download, skipping the signature check when publicKey is nil.
+func download(ctx context.Context, release *Release, publicKey ed25519.PublicKey) ([]byte, error) {
+	binaryAsset, ok := release.asset(AssetName())
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrNoAsset, AssetName(), release.Tag)
+	}
+	checksumAsset, ok := release.asset(checksumsAsset)
+	if !ok {
+		return nil, fmt.Errorf("릴리스에 %s가 없어 무결성을 확인할 수 없습니다", checksumsAsset)
+	}
+
+	checksums, err := fetch(ctx, checksumAsset.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if publicKey != nil {
+		signatureFile, ok := release.asset(signatureAsset)
+		if !ok {
+			return nil, fmt.Errorf("릴리스에 서명 파일 %s가 없습니다", signatureAsset)
+		}
+		signature, err := fetch(ctx, signatureFile.URL)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySignature(publicKey, checksums, signature); err != nil {
+			return nil, err
+		}
+	}
+
+	expected, err := findChecksum(checksums, binaryAsset.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	binary, err := fetch(ctx, binaryAsset.URL)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(binary)
+	if hex.EncodeToString(sum[:]) != expected {
+		return nil, fmt.Errorf("%s 체크섬 불일치 (다운로드가 손상되었거나 변조되었습니다)", binaryAsset.Name)
+	}
+	return binary, nil
+}
+
+// verifySignature accepts a raw 64-byte signature or its base64 encoding.
+func verifySignature(publicKey ed25519.PublicKey, message, signature []byte) error {
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err != nil {
+			return fmt.Errorf("서명 형식 오류: %w", err)
+		}
+		signature = decoded
+	}
+	if !ed25519.Verify(publicKey, message, signature) {
+		return fmt.Errorf("%s 서명 검증 실패", checksumsAsset)
+	}
+	return nil
+}
+
+// findChecksum looks up name in sha256sum output ("<hex>  <name>").
+func findChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s에 %s 체크섬이 없습니다", checksumsAsset, name)
+}
+
+func fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("다운로드 실패: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("다운로드 실패: %s HTTP %d", url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("다운로드 실패: %w", err)
+	}
+	if len(body) > maxAssetSize {
+		return nil, fmt.Errorf("다운로드 파일이 너무 큽니다: %s", url)
+	}
+	return body, nil
+}
+
+// Replace atomically swaps the executable at path for binary. The new file
+// is written next to path so the final rename never crosses filesystems.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return fmt.Errorf("새 실행 파일 생성 실패 (쓰기 권한 확인): %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("새 실행 파일 쓰기 실패: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	// Windows는 실행 중인 파일을 덮어쓸 수 없지만 이름은 바꿀 수 있음
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("기존 실행 파일 이동 실패: %w", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("실행 파일 교체 실패: %w", err)
+	}
+	return nil
+}
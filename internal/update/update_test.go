@@ -0,0 +1,88 @@
+package update
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newRelease serves a release of binary whose checksums.txt is signed with
+// key, or unsigned when key is nil.
+func newRelease(t *testing.T, binary []byte, key ed25519.PrivateKey) *Release {
+	t.Helper()
+	sum := sha256.Sum256(binary)
+	files := map[string][]byte{
+		AssetName():    binary,
+		checksumsAsset: []byte(hex.EncodeToString(sum[:]) + "  " + AssetName() + "\n"),
+	}
+	if key != nil {
+		files[signatureAsset] = ed25519.Sign(key, files[checksumsAsset])
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := files[r.URL.Path[1:]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(file)
+	}))
+	t.Cleanup(server.Close)
+
+	release := &Release{Tag: "v1.2.3"}
+	for name := range files {
+		release.Assets = append(release.Assets, Asset{Name: name, URL: server.URL + "/" + name})
+	}
+	return release
+}
+
+func TestDownloadRequiresAValidSignature(t *testing.T) {
+	ctx := context.Background()
+	binary := []byte("new binary")
+	public, private, _ := ed25519.GenerateKey(nil)
+	other, _, _ := ed25519.GenerateKey(nil)
+	signed := newRelease(t, binary, private)
+
+	got, err := Download(ctx, signed, public)
+	if err != nil {
+		t.Fatalf("Download of a signed release: %v", err)
+	}
+	if !bytes.Equal(got, binary) {
+		t.Errorf("downloaded %q, want %q", got, binary)
+	}
+	if _, err := Download(ctx, signed, other); err == nil {
+		t.Error("Download with another key succeeded")
+	}
+	if _, err := Download(ctx, signed, nil); !errors.Is(err, ErrNoPublicKey) {
+		t.Errorf("Download without a key = %v, want ErrNoPublicKey", err)
+	}
+	if _, err := Download(ctx, newRelease(t, binary, nil), public); err == nil {
+		t.Error("Download of an unsigned release succeeded")
+	}
+}
+
+func TestDownloadUnsignedStillChecksTheChecksum(t *testing.T) {
+	ctx := context.Background()
+	release := newRelease(t, []byte("new binary"), nil)
+
+	if _, err := DownloadUnsigned(ctx, release); err != nil {
+		t.Fatalf("DownloadUnsigned: %v", err)
+	}
+
+	// 다른 릴리스의 체크섬과 맞지 않는 실행 파일은 거부
+	tampered := newRelease(t, []byte("tampered"), nil)
+	checksums, _ := release.asset(checksumsAsset)
+	for i, asset := range tampered.Assets {
+		if asset.Name == checksumsAsset {
+			tampered.Assets[i] = checksums
+		}
+	}
+	if _, err := DownloadUnsigned(ctx, tampered); err == nil {
+		t.Error("DownloadUnsigned of a binary not matching checksums.txt succeeded")
+	}
+}